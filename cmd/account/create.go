@@ -297,6 +297,8 @@ func WaitForAccountReady(ctx context.Context, token, accountID string) error {
 	defer ticker.Stop()
 	for {
 		select {
+		case <-ctx.Done():
+			return ctx.Err()
 		case <-timeout:
 			fmt.Fprintf(os.Stderr, "\n⚠️  Warning: Account did not become READY after 10 minutes. Please check account status with 'omnistrate-ctl account describe %s'\n", accountID)
 			return fmt.Errorf("account %s did not become READY after 10 minutes", accountID)
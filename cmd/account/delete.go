@@ -1,6 +1,10 @@
 package account
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
@@ -12,13 +16,17 @@ import (
 
 const (
 	deleteExample = `# Delete account with name or id
-omnistrate-ctl account delete [account-name or account-id]`
+omnistrate-ctl account delete [account-name or account-id]
+
+# Delete account even if instances are still deployed in it
+omnistrate-ctl account delete [account-name or account-id] --force`
 )
 
 var deleteCmd = &cobra.Command{
-	Use:          "delete [account-name or account-id] [flags]",
-	Short:        "Delete a Cloud Provider Account",
-	Long:         "This command helps you delete a cloud provider account.",
+	Use:   "delete [account-name or account-id] [flags]",
+	Short: "Delete a Cloud Provider Account",
+	Long: `This command helps you delete a cloud provider account.
+The account is not deleted while instances are still deployed in it: the blocking instances are listed so you can delete them first, or pass --force to delete the account anyway.`,
 	Example:      deleteExample,
 	RunE:         runDelete,
 	SilenceUsage: true,
@@ -26,6 +34,7 @@ var deleteCmd = &cobra.Command{
 
 func init() {
 	deleteCmd.Args = cobra.MaximumNArgs(1) // Require at most 1 argument
+	deleteCmd.Flags().Bool("force", false, "Delete the account even if instances are still deployed in it")
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
@@ -43,6 +52,11 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		utils.PrintError(err)
 		return err
 	}
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
 
 	// Validate input args
 	err = validateDeleteArguments(args)
@@ -76,6 +90,19 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Check for instances still deployed in the account
+	if !force {
+		account, err := dataaccess.DescribeAccount(cmd.Context(), token, id)
+		if err != nil {
+			utils.HandleSpinnerError(spinner, sm, err)
+			return err
+		}
+		if err = checkAccountHasNoInstances(id, account.ByoaInstanceIDs); err != nil {
+			utils.HandleSpinnerError(spinner, sm, err)
+			return err
+		}
+	}
+
 	// Delete account
 	err = dataaccess.DeleteAccount(cmd.Context(), token, id)
 	if err != nil {
@@ -97,3 +124,16 @@ func validateDeleteArguments(args []string) error {
 
 	return nil
 }
+
+// checkAccountHasNoInstances returns an error listing the instances that block the deletion of the account
+func checkAccountHasNoInstances(accountID string, instanceIDs []string) error {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+
+	sorted := append([]string(nil), instanceIDs...)
+	sort.Strings(sorted)
+	return utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf(
+		"account %s still has %d instance(s) deployed in it:\n  - %s\nDelete them first with 'omnistrate-ctl instance delete', or pass --force to delete the account anyway",
+		accountID, len(sorted), strings.Join(sorted, "\n  - ")))
+}
@@ -0,0 +1,26 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAccountHasNoInstances(t *testing.T) {
+	require.NoError(t, checkAccountHasNoInstances("ac-123", nil))
+
+	err := checkAccountHasNoInstances("ac-123", []string{"instance-b", "instance-a"})
+	require.Error(t, err)
+	assert.Equal(t, utils.ExitCodeValidation, utils.ExitCodeFor(err))
+	assert.Contains(t, err.Error(), "account ac-123 still has 2 instance(s)")
+	assert.Contains(t, err.Error(), "  - instance-a\n  - instance-b")
+	assert.Contains(t, err.Error(), "--force")
+}
+
+func TestDeleteCommandHasForceFlag(t *testing.T) {
+	flag := deleteCmd.Flags().Lookup("force")
+	require.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
@@ -16,14 +16,18 @@ import (
 
 const (
 	listExample = `# List accounts
-omnistrate-ctl account list`
+omnistrate-ctl account list
+
+# Export the AWS accounts as CSV
+omnistrate-ctl account list -f="cloud_provider:AWS" --output csv > accounts.csv`
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list [flags]",
 	Short: "List Cloud Provider Accounts",
 	Long: `This command helps you list Cloud Provider Accounts.
-You can filter for specific accounts by using the filter flag.`,
+You can filter for specific accounts by using the filter flag.
+Use --output csv to print a header row and one comma-separated row per account, with the table columns.`,
 	Example:      listExample,
 	RunE:         runList,
 	SilenceUsage: true,
@@ -54,10 +58,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Initialize spinner if output is not JSON
+	// Initialize spinner if output is not JSON or CSV
 	var sm utils.SpinnerManager
 	var spinner *utils.Spinner
-	if output != "json" {
+	if output != "json" && output != common.OutputTypeCsv {
 		sm = utils.NewSpinnerManager()
 		msg := "Retrieving accounts..."
 		spinner = sm.AddSpinner(msg)
@@ -106,8 +110,8 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Ask user to verify account if output is not JSON
-	if output != "json" {
+	// Ask user to verify account if output is not JSON or CSV
+	if output != "json" && output != common.OutputTypeCsv {
 		dataaccess.AskVerifyAccountIfAny(cmd.Context())
 	}
 
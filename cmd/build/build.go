@@ -1,12 +1,15 @@
 package build
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
 
@@ -37,6 +40,9 @@ omnistrate-ctl build --product-name "My Service"
 # Build service with compose spec in dev environment
 omnistrate-ctl build --file omnistrate-compose.yaml --product-name "My Service"
 
+# Build service with compose spec and capture the service, environment and plan IDs as JSON, e.g. in CI
+omnistrate-ctl build --file omnistrate-compose.yaml --product-name "My Service" --output json
+
 # Build service with compose spec in prod environment
 omnistrate-ctl build --file omnistrate-compose.yaml --product-name "My Service" --environment prod --environment-type prod
 
@@ -83,7 +89,11 @@ Below info served as service plan identifiers:
   - service plan name (the name field of x-omnistrate-service-plan tag in compose spec file, required)
 If the identifiers match an existing service plan, it will update that plan. Otherwise, it'll create a new service plan. 
 
-This command has an interactive mode. In this mode, you can choose to promote the service plan to production by interacting with the prompts.`
+With --output=json, the result is a JSON object with the service, environment and service plan IDs, whether the service was newly created (is_new_service), and the resources of the plan that the spec does not define (undefined_resources), so automation can use the IDs in later steps.
+
+This command has an interactive mode. In this mode, you can choose to promote the service plan to production by interacting with the prompts.
+
+Spec files can embed other files with {{ $file:path }}, resolved relative to the file that contains the reference. The older ${{ file:path }} form still works but is deprecated.`
 )
 
 // BuildCmd represents the build command
@@ -112,6 +122,7 @@ func init() {
 	BuildCmd.Flags().StringP("release-description", "", "", "Used together with --release or --release-as-preferred flag. Provide a description for the release version")
 	BuildCmd.Flags().BoolP("force-create-service-plan-version", "", false, "Force create a new service plan version on release.")
 	BuildCmd.Flags().BoolP("interactive", "i", false, "Interactive mode")
+	BuildCmd.Flags().Bool("strict", false, "Fail with a non-zero exit code when the service plan has resources that the spec does not define, e.g. to catch spec drift in CI")
 
 	// Deprecated flags
 	BuildCmd.Flags().StringP("name", "n", "", "Name of the service. A service can have multiple service plans. The build command will build a new or existing service plan inside the specified service. Deprecated: use --product-name instead")
@@ -281,6 +292,10 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	strict, err := cmd.Flags().GetBool("strict")
+	if err != nil {
+		return err
+	}
 	forceCreateServicePlanVersion, err := cmd.Flags().GetBool("force-create-service-plan-version")
 	if err != nil {
 		return err
@@ -652,6 +667,17 @@ func runBuild(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Whether the build creates the service is only reported in the JSON output
+	var isNewService bool
+	if output == "json" {
+		var exists bool
+		if exists, err = serviceExists(cmd.Context(), token, name); err != nil {
+			utils.PrintError(err)
+			return err
+		}
+		isNewService = !exists
+	}
+
 	var undefinedResources map[string]string
 	var isNewVersionCreated bool
 	if buildProgress != nil {
@@ -714,17 +740,25 @@ func runBuild(cmd *cobra.Command, args []string) error {
 			servicePlanDetails.ReleaseDescription = *versionDetails.Name
 		}
 		servicePlanDetails.VersionSetStatus = versionDetails.Status
-	}
-
-	if err = utils.PrintTextTableJsonOutput(output, servicePlanDetails); err != nil {
-		return err
+		servicePlanDetails.Preferred = versionDetails.Status == "Preferred"
+		servicePlanDetails.ReleasedAt = versionDetails.ReleasedAt
 	}
 
 	// Return early if output is json
 	if output == "json" {
+		if err = utils.PrintTextTableJsonOutput(output, newServiceBuildResult(servicePlanDetails, EnvironmentID, isNewService, undefinedResources)); err != nil {
+			return err
+		}
+		if strict {
+			return UndefinedResourcesError(undefinedResources)
+		}
 		return nil
 	}
 
+	if err = utils.PrintTextTableJsonOutput(output, servicePlanDetails); err != nil {
+		return err
+	}
+
 	// Print warning if there are any undefined resources
 	if len(undefinedResources) > 0 {
 		utils.PrintWarning("The following resources appear in the service plan but were not defined in the spec:")
@@ -732,6 +766,11 @@ func runBuild(cmd *cobra.Command, args []string) error {
 			utils.PrintWarning(fmt.Sprintf("  %s: %s", resourceName, resourceID))
 		}
 		utils.PrintWarning("These resources were not processed during the build. If you no longer need them, please deprecate and remove them from the service plan manually in UI or using the API.")
+		if strict {
+			err = UndefinedResourcesError(undefinedResources)
+			utils.PrintError(err)
+			return err
+		}
 	}
 
 	utils.PrintURL("Check the service plan result at", fmt.Sprintf("https://%s/product-tier?serviceId=%s&environmentId=%s", config.GetRootDomain(), ServiceID, EnvironmentID))
@@ -956,9 +995,11 @@ func BuildService(ctx context.Context, fileData []byte, token, name, specType st
 
 		// Convert config volumes to configs
 		var modified bool
-		if project, modified, err = convertVolumesToConfigs(project); err != nil {
+		var conversion configConversionReport
+		if project, modified, conversion, err = convertVolumesToConfigs(project, config.GetMaxConfigFileSize()); err != nil {
 			return "", "", "", make(map[string]string), false, err
 		}
+		conversion.print()
 
 		// Convert the project back to YAML, in case it was modified
 		if modified {
@@ -1032,6 +1073,57 @@ func BuildService(ctx context.Context, fileData []byte, token, name, specType st
 	}
 }
 
+// UndefinedResourcesError returns the error of a strict build when the service plan has resources that the spec
+// does not define, usually a mistake in the spec, or nil when there are none
+func UndefinedResourcesError(undefinedResources map[string]string) error {
+	if len(undefinedResources) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(undefinedResources))
+	for name := range undefinedResources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resources := make([]string, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, fmt.Sprintf("%s (%s)", name, undefinedResources[name]))
+	}
+	return utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf(
+		"strict mode: the service plan has %d resource(s) that the spec does not define: %s. Define them in the spec, or deprecate and remove them from the service plan",
+		len(resources), strings.Join(resources, ", ")))
+}
+
+// serviceExists reports whether a service with the given name exists
+func serviceExists(ctx context.Context, token, name string) (bool, error) {
+	services, err := dataaccess.ListServices(ctx, token)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list services")
+	}
+	for _, service := range services.Services {
+		if service.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newServiceBuildResult returns the JSON output of build, with the undefined resources sorted by name
+func newServiceBuildResult(details model.ServicePlanVersion, environmentID string, isNewService bool, undefinedResources map[string]string) model.ServiceBuildResult {
+	result := model.ServiceBuildResult{
+		ServicePlanVersion: details,
+		EnvironmentID:      environmentID,
+		IsNewService:       isNewService,
+		UndefinedResources: make([]model.UndefinedResource, 0, len(undefinedResources)),
+	}
+	for name, id := range undefinedResources {
+		result.UndefinedResources = append(result.UndefinedResources, model.UndefinedResource{Name: name, ID: id})
+	}
+	sort.Slice(result.UndefinedResources, func(i, j int) bool {
+		return result.UndefinedResources[i].Name < result.UndefinedResources[j].Name
+	})
+	return result
+}
+
 func checkIfSaaSPortalReady(serviceEnvironment *openapiclient.DescribeServiceEnvironmentResult) bool {
 	if serviceEnvironment.SaasPortalUrl != nil && serviceEnvironment.SaasPortalStatus != nil && *serviceEnvironment.SaasPortalStatus == "RUNNING" {
 		return true
@@ -1057,9 +1149,63 @@ func isValidSpecType(s string) bool {
 	return false
 }
 
+// configConversionReport lists the mounted files that were converted to configs and the ones that were skipped.
+type configConversionReport struct {
+	Converted []string
+	Skipped   []skippedConfigFile
+}
+
+type skippedConfigFile struct {
+	Path   string
+	Reason string
+}
+
+// print reports the conversion on stderr so it does not interfere with the command output.
+func (r configConversionReport) print() {
+	if len(r.Converted) > 0 {
+		fmt.Fprintf(os.Stderr, "Converted %d mounted file(s) to configs:\n", len(r.Converted))
+		for _, path := range r.Converted {
+			fmt.Fprintf(os.Stderr, "  %s\n", path)
+		}
+	}
+	if len(r.Skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %d mounted file(s) that cannot be converted to configs:\n", len(r.Skipped))
+		for _, skipped := range r.Skipped {
+			fmt.Fprintf(os.Stderr, "  %s (%s)\n", skipped.Path, skipped.Reason)
+		}
+		fmt.Fprintln(os.Stderr, "Bake these files into the image instead, or raise the size limit with OMNISTRATE_MAX_CONFIG_FILE_SIZE_IN_KB.")
+	}
+}
+
+// configFileSkipReason returns why a file cannot be converted to a config: it is larger than maxSize or its
+// content looks binary (NUL bytes or invalid UTF-8). It returns an empty string for files that can be converted.
+func configFileSkipReason(path string, maxSize int64) (string, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get file info for %s", path)
+	}
+	if fileInfo.Size() > maxSize {
+		return fmt.Sprintf("%d KB exceeds the %d KB limit", (fileInfo.Size()+1023)/1024, maxSize/1024), nil
+	}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read %s", path)
+	}
+	if bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+		return "binary content", nil
+	}
+	return "", nil
+}
+
 // Most compose files mount the configs directly as volumes. This function converts the volumes to configs.
-func convertVolumesToConfigs(project *types.Project) (converted *types.Project, modified bool, err error) {
+// Files larger than maxFileSize or with binary content are skipped; a single-file mount that is skipped, or a
+// directory mount with no convertible files, is left as a volume.
+func convertVolumesToConfigs(project *types.Project, maxFileSize int64) (converted *types.Project, modified bool, report configConversionReport, err error) {
 	modified = false
+	if project.Configs == nil {
+		project.Configs = make(types.Configs)
+	}
 	volumesToBeRemoved := make(map[int]map[int]struct{}) // map of service index to list of volume indexes to be removed
 	for svcIdx, service := range project.Services {
 		for volIdx, volume := range service.Volumes {
@@ -1088,12 +1234,18 @@ func convertVolumesToConfigs(project *types.Project) (converted *types.Project,
 						return
 					}
 
-					if len(files) == 0 {
-						continue
-					}
-
-					// Create a config for each file
+					// Create a config for each file that can be converted
+					convertedInDir := 0
 					for _, fileInDir := range files {
+						var skipReason string
+						if skipReason, err = configFileSkipReason(fileInDir, maxFileSize); err != nil {
+							return
+						}
+						if skipReason != "" {
+							report.Skipped = append(report.Skipped, skippedConfigFile{Path: fileInDir, Reason: skipReason})
+							continue
+						}
+
 						sourceFileNameSHA := utils.HashSha256(fileInDir)
 						config := types.ConfigObjConfig{
 							Name: sourceFileNameSHA,
@@ -1118,8 +1270,23 @@ func convertVolumesToConfigs(project *types.Project) (converted *types.Project,
 							Source: sourceFileNameSHA,
 							Target: filepath.Join(volume.Target, relativePathInTarget),
 						})
+						report.Converted = append(report.Converted, fileInDir)
+						convertedInDir++
+					}
+
+					if convertedInDir == 0 {
+						continue
 					}
 				} else {
+					var skipReason string
+					if skipReason, err = configFileSkipReason(source, maxFileSize); err != nil {
+						return
+					}
+					if skipReason != "" {
+						report.Skipped = append(report.Skipped, skippedConfigFile{Path: source, Reason: skipReason})
+						continue
+					}
+
 					sourceFileNameSHA := utils.HashSha256(source)
 					config := types.ConfigObjConfig{
 						Name: sourceFileNameSHA,
@@ -1132,6 +1299,7 @@ func convertVolumesToConfigs(project *types.Project) (converted *types.Project,
 						Source: sourceFileNameSHA,
 						Target: volume.Target,
 					})
+					report.Converted = append(report.Converted, source)
 				}
 
 				// Remove the volume from the service
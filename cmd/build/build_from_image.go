@@ -0,0 +1,385 @@
+package build
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	buildFromImageExample = `# Build service from a public image
+omnistrate-ctl build from-image --image docker.io/library/postgres:16 --name postgres --env-var POSTGRES_PASSWORD=default
+
+# Build service from a private image built in CI
+omnistrate-ctl build from-image --image ghcr.io/acme/app:v1.2.0 --name app --image-registry-auth-username acme --image-registry-auth-password $GH_PAT
+
+# Build service from an image for a BYOA deployment in an AWS account
+omnistrate-ctl build from-image --image ghcr.io/acme/app:v1.2.0 --name app --deployment-type byoa --aws-account-id 442426883376
+
+# Build service from an image with release description
+omnistrate-ctl build from-image --image ghcr.io/acme/app:v1.2.0 --name app --release-description "v1.2.0"`
+
+	buildFromImageLong = `This command builds a service from a container image that is already published to a registry, e.g. by a CI pipeline. The compose spec is generated from the image, the deployment section is added when --deployment-type is set, and the service is built and released as preferred in the dev environment.
+
+No local Docker daemon, Dockerfile or git repository is required. Provide registry credentials with --image-registry-auth-username and --image-registry-auth-password when the image is private.`
+)
+
+// fromImageCmd represents the build from-image command
+var fromImageCmd = &cobra.Command{
+	Use:          "from-image --image=image-url --name=service-name [flags]",
+	Short:        "Build Service from a published container image",
+	Long:         buildFromImageLong,
+	Example:      buildFromImageExample,
+	Args:         cobra.NoArgs,
+	RunE:         runBuildFromImage,
+	SilenceUsage: true,
+}
+
+func init() {
+	fromImageCmd.Flags().String("image", "", "Complete image URL with registry, repository and tag (e.g. ghcr.io/org/app:v1.2)")
+	fromImageCmd.Flags().String("name", "", "Name of the service to build")
+	fromImageCmd.Flags().String("description", "", "A short description for the whole service")
+	fromImageCmd.Flags().String("image-registry-auth-username", "", "Username to authenticate with the image registry if the image is private")
+	fromImageCmd.Flags().String("image-registry-auth-password", "", "Password or token to authenticate with the image registry if the image is private")
+	fromImageCmd.Flags().StringArray("env-var", nil, "Specify environment variables required for running the image. Use the format: --env-var key1=var1 --env-var key2=var2")
+	fromImageCmd.Flags().String("deployment-type", "", "Set the deployment type. Options: 'hosted' or 'byoa' (Bring Your Own Account)")
+	fromImageCmd.Flags().String("aws-account-id", "", "AWS account ID. Must be used with --deployment-type")
+	fromImageCmd.Flags().String("gcp-project-id", "", "GCP project ID. Must be used with --gcp-project-number and --deployment-type")
+	fromImageCmd.Flags().String("gcp-project-number", "", "GCP project number. Must be used with --gcp-project-id and --deployment-type")
+	fromImageCmd.Flags().String("azure-subscription-id", "", "Azure subscription ID. Must be used with --azure-tenant-id and --deployment-type")
+	fromImageCmd.Flags().String("azure-tenant-id", "", "Azure tenant ID. Must be used with --azure-subscription-id and --deployment-type")
+	fromImageCmd.Flags().String("release-description", "", "Provide a description for the release version")
+	fromImageCmd.Flags().Bool("force-create-service-plan-version", false, "Force create a new service plan version on release.")
+	fromImageCmd.Flags().Bool("dry-run", false, "Simulate building the service without actually creating resources")
+
+	if err := fromImageCmd.MarkFlagRequired("image"); err != nil {
+		return
+	}
+	if err := fromImageCmd.MarkFlagRequired("name"); err != nil {
+		return
+	}
+	fromImageCmd.MarkFlagsRequiredTogether("image-registry-auth-username", "image-registry-auth-password")
+
+	BuildCmd.AddCommand(fromImageCmd)
+}
+
+func runBuildFromImage(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	// Retrieve the flags
+	imageURL, err := cmd.Flags().GetString("image")
+	if err != nil {
+		return err
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return err
+	}
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		return err
+	}
+	username, err := cmd.Flags().GetString("image-registry-auth-username")
+	if err != nil {
+		return err
+	}
+	password, err := cmd.Flags().GetString("image-registry-auth-password")
+	if err != nil {
+		return err
+	}
+	envVars, err := cmd.Flags().GetStringArray("env-var")
+	if err != nil {
+		return err
+	}
+	deploymentType, err := cmd.Flags().GetString("deployment-type")
+	if err != nil {
+		return err
+	}
+	awsAccountID, err := cmd.Flags().GetString("aws-account-id")
+	if err != nil {
+		return err
+	}
+	gcpProjectID, err := cmd.Flags().GetString("gcp-project-id")
+	if err != nil {
+		return err
+	}
+	gcpProjectNumber, err := cmd.Flags().GetString("gcp-project-number")
+	if err != nil {
+		return err
+	}
+	azureSubscriptionID, err := cmd.Flags().GetString("azure-subscription-id")
+	if err != nil {
+		return err
+	}
+	azureTenantID, err := cmd.Flags().GetString("azure-tenant-id")
+	if err != nil {
+		return err
+	}
+	releaseDescription, err := cmd.Flags().GetString("release-description")
+	if err != nil {
+		return err
+	}
+	forceCreateServicePlanVersion, err := cmd.Flags().GetBool("force-create-service-plan-version")
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+
+	// Validate the input arguments
+	if err = validateDeploymentAccounts(deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, azureSubscriptionID, azureTenantID); err != nil {
+		err = utils.WithExitCode(utils.ExitCodeValidation, err)
+		utils.PrintError(err)
+		return err
+	}
+
+	request, err := newComposeSpecFromImageRequest(imageURL, username, password, envVars)
+	if err != nil {
+		err = utils.WithExitCode(utils.ExitCodeValidation, err)
+		utils.PrintError(err)
+		return err
+	}
+
+	// Validate user is currently logged in
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	var sm utils.SpinnerManager
+	var spinner *utils.Spinner
+	if output != "json" {
+		sm = utils.NewSpinnerManager()
+		sm.Start()
+		spinner = sm.AddSpinner(fmt.Sprintf("Generating compose spec from image %s", imageURL))
+	}
+
+	// Step 1: Generate the compose spec from the image
+	generateComposeSpecRes, err := generateComposeSpecWithRetry(cmd.Context(), token, request, dataaccess.GenerateComposeSpecFromContainerImage)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	fileData, err := base64.StdEncoding.DecodeString(generateComposeSpecRes.FileContent)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	// Step 2: Append the deployment section to the compose spec
+	if deploymentType != "" {
+		var gcpServiceAccountEmail string
+		if gcpProjectID != "" {
+			if gcpServiceAccountEmail, err = gcpBootstrapServiceAccountEmail(cmd.Context(), token, gcpProjectID); err != nil {
+				utils.HandleSpinnerError(spinner, sm, err)
+				return err
+			}
+		}
+		fileData = appendDeploymentSection(fileData, deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, gcpServiceAccountEmail, azureSubscriptionID, azureTenantID)
+	}
+	if spinner != nil {
+		spinner.Complete()
+		spinner = sm.AddSpinner(fmt.Sprintf("Building service %s", name))
+	}
+
+	// Step 3: Build the service and release it as preferred
+	var descriptionPtr, releaseDescriptionPtr *string
+	if description != "" {
+		descriptionPtr = &description
+	}
+	if releaseDescription != "" {
+		releaseDescriptionPtr = &releaseDescription
+	}
+
+	serviceID, environmentID, productTierID, undefinedResources, isNewVersionCreated, err := BuildService(
+		cmd.Context(),
+		fileData,
+		token,
+		name,
+		DockerComposeSpecType,
+		descriptionPtr,
+		nil,
+		nil,
+		nil,
+		true,
+		true,
+		releaseDescriptionPtr,
+		dryRun,
+		forceCreateServicePlanVersion,
+	)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	header := "Successfully built service"
+	if dryRun {
+		header = "Simulated service build completed successfully (dry run)"
+	}
+	utils.HandleSpinnerSuccess(spinner, sm, header)
+
+	productTier, err := dataaccess.DescribeProductTier(cmd.Context(), token, serviceID, productTierID)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	servicePlanDetails := model.ServicePlanVersion{
+		PlanID:                         productTierID,
+		PlanName:                       productTier.Name,
+		ServiceID:                      serviceID,
+		ServiceName:                    name,
+		Environment:                    DefaultDevEnvName,
+		IsNewServicePlanVersionCreated: isNewVersionCreated,
+	}
+	if !dryRun {
+		versionDetails, err := dataaccess.DescribeLatestVersion(cmd.Context(), token, serviceID, productTierID)
+		if err != nil {
+			err = errors.Wrap(err, "failed to get the latest version")
+			utils.PrintError(err)
+			return err
+		}
+		servicePlanDetails.Version = versionDetails.Version
+		if versionDetails.Name != nil {
+			servicePlanDetails.ReleaseDescription = *versionDetails.Name
+		}
+		servicePlanDetails.VersionSetStatus = versionDetails.Status
+		servicePlanDetails.Preferred = versionDetails.Status == "Preferred"
+		servicePlanDetails.ReleasedAt = versionDetails.ReleasedAt
+	}
+
+	if err = utils.PrintTextTableJsonOutput(output, servicePlanDetails); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return nil
+	}
+
+	if len(undefinedResources) > 0 {
+		utils.PrintWarning("The following resources appear in the service plan but were not defined in the spec:")
+		for resourceName, resourceID := range undefinedResources {
+			utils.PrintWarning(fmt.Sprintf("  %s: %s", resourceName, resourceID))
+		}
+	}
+
+	utils.PrintURL("Check the service plan result at", fmt.Sprintf("https://%s/product-tier?serviceId=%s&environmentId=%s", config.GetRootDomain(), serviceID, environmentID))
+
+	return nil
+}
+
+// newComposeSpecFromImageRequest builds the request to generate a compose spec from imageURL, which must include
+// the registry host. Credentials are only sent when both are set, and environment variables are given as key=value.
+func newComposeSpecFromImageRequest(imageURL, username, password string, envVars []string) (openapiclient.GenerateComposeSpecFromContainerImageRequest2, error) {
+	var request openapiclient.GenerateComposeSpecFromContainerImageRequest2
+
+	registry, image, found := strings.Cut(imageURL, "/")
+	if !found || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		return request, errors.New(fmt.Sprintf("invalid image %q: expected the registry host, repository and tag, such as ghcr.io/org/app:v1", imageURL))
+	}
+	if err := validateComposeSpecImage(registry, image); err != nil {
+		return request, err
+	}
+	request.ImageRegistry = registry
+	request.Image = image
+
+	if username != "" && password != "" {
+		request.Username = utils.ToPtr(username)
+		request.Password = utils.ToPtr(password)
+	}
+
+	for _, envVar := range envVars {
+		if envVar == "[]" {
+			continue
+		}
+		key, value, found := strings.Cut(envVar, "=")
+		if !found || key == "" {
+			return request, errors.New(fmt.Sprintf("invalid environment variable %q: expected the format key=value", envVar))
+		}
+		request.EnvironmentVariables = append(request.EnvironmentVariables, openapiclient.EnvironmentVariable{
+			Key:   key,
+			Value: value,
+		})
+	}
+
+	return request, nil
+}
+
+// validateDeploymentAccounts checks the deployment type and that the cloud provider account details it needs are
+// complete.
+func validateDeploymentAccounts(deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, azureSubscriptionID, azureTenantID string) error {
+	if deploymentType == "" {
+		return nil
+	}
+	if deploymentType != DeploymentTypeHosted && deploymentType != DeploymentTypeByoa {
+		return errors.New("invalid deployment type. Options: 'hosted' or 'byoa'")
+	}
+	if awsAccountID == "" && gcpProjectID == "" && azureSubscriptionID == "" {
+		return errors.New(fmt.Sprintf("AWS account ID or GCP project ID or Azure subscription ID are required for %s deployment type", deploymentType))
+	}
+	if gcpProjectID != "" && gcpProjectNumber == "" {
+		return errors.New("GCP project number is required with GCP project ID")
+	}
+	if gcpProjectID == "" && gcpProjectNumber != "" {
+		return errors.New("GCP project ID is required with GCP project number")
+	}
+	if azureSubscriptionID != "" && azureTenantID == "" {
+		return errors.New("Azure tenant ID is required with Azure subscription ID")
+	}
+	return nil
+}
+
+// gcpBootstrapServiceAccountEmail returns the email of the bootstrap service account of the organization of the
+// current user in the GCP project
+func gcpBootstrapServiceAccountEmail(ctx context.Context, token, gcpProjectID string) (string, error) {
+	user, err := dataaccess.DescribeUser(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("bootstrap-%s@%s.iam.gserviceaccount.com", *user.OrgId, gcpProjectID), nil
+}
+
+// appendDeploymentSection appends the deployment section of deploymentType, with the given cloud provider accounts,
+// to the service plan at the end of a generated compose spec
+func appendDeploymentSection(fileData []byte, deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, gcpServiceAccountEmail, azureSubscriptionID, azureTenantID string) []byte {
+	switch deploymentType {
+	case DeploymentTypeHosted:
+		fileData = append(fileData, []byte("  deployment:\n")...)
+		fileData = append(fileData, []byte("    hostedDeployment:\n")...)
+	case DeploymentTypeByoa:
+		fileData = append(fileData, []byte("  deployment:\n")...)
+		fileData = append(fileData, []byte("    byoaDeployment:\n")...)
+	default:
+		return fileData
+	}
+
+	if awsAccountID != "" {
+		fileData = append(fileData, []byte(fmt.Sprintf("      AwsAccountId: '%s'\n", awsAccountID))...)
+		awsBootstrapRoleAccountARN := fmt.Sprintf("arn:aws:iam::%s:role/omnistrate-bootstrap-role", awsAccountID)
+		fileData = append(fileData, []byte(fmt.Sprintf("      AwsBootstrapRoleAccountArn: '%s'\n", awsBootstrapRoleAccountARN))...)
+	}
+	if gcpProjectID != "" {
+		fileData = append(fileData, []byte(fmt.Sprintf("      GcpProjectId: '%s'\n", gcpProjectID))...)
+		fileData = append(fileData, []byte(fmt.Sprintf("      GcpProjectNumber: '%s'\n", gcpProjectNumber))...)
+		fileData = append(fileData, []byte(fmt.Sprintf("      GcpServiceAccountEmail: '%s'\n", gcpServiceAccountEmail))...)
+	}
+	return appendAzureConfig(fileData, azureSubscriptionID, azureTenantID)
+}
@@ -0,0 +1,79 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComposeSpecFromImageRequest(t *testing.T) {
+	require := require.New(t)
+
+	request, err := newComposeSpecFromImageRequest("ghcr.io/acme/app:v1", "acme", "ghp_secret", []string{"KEY=VALUE", "DSN=user=a;password=b", "[]"})
+	require.NoError(err)
+	require.Equal(openapiclient.GenerateComposeSpecFromContainerImageRequest2{
+		ImageRegistry: "ghcr.io",
+		Image:         "acme/app:v1",
+		Username:      utils.ToPtr("acme"),
+		Password:      utils.ToPtr("ghp_secret"),
+		EnvironmentVariables: []openapiclient.EnvironmentVariable{
+			{Key: "KEY", Value: "VALUE"},
+			{Key: "DSN", Value: "user=a;password=b"},
+		},
+	}, request)
+
+	// Credentials are only sent when both are set
+	request, err = newComposeSpecFromImageRequest("localhost:5000/app", "", "", nil)
+	require.NoError(err)
+	require.Equal("localhost:5000", request.ImageRegistry)
+	require.Nil(request.Username)
+	require.Nil(request.Password)
+
+	for _, imageURL := range []string{"app:v1", "acme/app:v1", "ghcr.io/", "https://ghcr.io/acme/app"} {
+		_, err = newComposeSpecFromImageRequest(imageURL, "", "", nil)
+		require.Error(err, imageURL)
+	}
+
+	_, err = newComposeSpecFromImageRequest("ghcr.io/acme/app:v1", "", "", []string{"KEY"})
+	require.Error(err)
+}
+
+func TestAppendDeploymentSection(t *testing.T) {
+	require := require.New(t)
+
+	spec := []byte("x-omnistrate-service-plan:\n  name: app\n")
+
+	require.Equal(`x-omnistrate-service-plan:
+  name: app
+  deployment:
+    byoaDeployment:
+      AwsAccountId: '123456789012'
+      AwsBootstrapRoleAccountArn: 'arn:aws:iam::123456789012:role/omnistrate-bootstrap-role'
+      GcpProjectId: 'my-project'
+      GcpProjectNumber: '42'
+      GcpServiceAccountEmail: 'bootstrap-org-1@my-project.iam.gserviceaccount.com'
+`, string(appendDeploymentSection(append([]byte{}, spec...), DeploymentTypeByoa, "123456789012", "my-project", "42", "bootstrap-org-1@my-project.iam.gserviceaccount.com", "", "")))
+
+	require.Equal(`x-omnistrate-service-plan:
+  name: app
+  deployment:
+    hostedDeployment:
+      AzureSubscriptionId: 'sub'
+      AzureTenantId: 'tenant'
+`, string(appendDeploymentSection(append([]byte{}, spec...), DeploymentTypeHosted, "", "", "", "", "sub", "tenant")))
+
+	require.Equal(string(spec), string(appendDeploymentSection(append([]byte{}, spec...), "", "123456789012", "", "", "", "", "")))
+}
+
+func TestValidateDeploymentAccounts(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(validateDeploymentAccounts("", "", "", "", "", ""))
+	require.NoError(validateDeploymentAccounts(DeploymentTypeByoa, "123456789012", "", "", "", ""))
+	require.Error(validateDeploymentAccounts("serverless", "123456789012", "", "", "", ""))
+	require.Error(validateDeploymentAccounts(DeploymentTypeHosted, "", "", "", "", ""))
+	require.Error(validateDeploymentAccounts(DeploymentTypeHosted, "", "my-project", "", "", ""))
+	require.Error(validateDeploymentAccounts(DeploymentTypeHosted, "", "", "", "sub", ""))
+}
@@ -1,15 +1,14 @@
 package build
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,6 +20,7 @@ import (
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/spec"
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
 	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
 	"github.com/pkg/browser"
@@ -56,23 +56,32 @@ omnistrate-ctl build-from-repo --dry-run
 # Build for multiple platforms
 omnistrate-ctl build-from-repo --platforms linux/amd64 --platforms linux/arm64
 
+# Build from a Dockerfile that is not in the repository root
+omnistrate-ctl build-from-repo --dockerfile docker/Dockerfile.prod
+
+# Stamp the images with the commit SHA and build time
+omnistrate-ctl build-from-repo --label org.opencontainers.image.revision=$(git rev-parse HEAD) --label org.opencontainers.image.created=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+
 # Build with release description
 omnistrate-ctl build-from-repo --release-description "v1.0.0-alpha"
 
+# Push with the registry credentials of the docker client (e.g. a credential helper) instead of a GitHub PAT
+omnistrate-ctl build-from-repo --use-docker-credentials
+
 # Build using github token from environment variable (GH_PAT)
 set GH_PAT=ghp_xxxxxxxx
 omnistrate-ctl build-from-repo
 "`
-	GitHubPATGenerateURL      = "https://github.com/settings/tokens"
-	DefaultProdEnvName        = "Production"
-	defaultServiceName        = "default" // Default service name when no compose spec exists in the repo. It won't show up in the resulting image or compose spec. Only intermediate use.
-	ignoreKeyForFileEmbedding = "x-embed-$"
+	GitHubPATGenerateURL = "https://github.com/settings/tokens"
+	DefaultProdEnvName   = "Production"
+	DefaultDevEnvName    = "Dev"
+	defaultServiceName   = "default" // Default service name when no compose spec exists in the repo. It won't show up in the resulting image or compose spec. Only intermediate use.
 )
 
 var BuildFromRepoCmd = &cobra.Command{
 	Use:          "build-from-repo",
 	Short:        "Build Service from Git Repository",
-	Long:         "This command helps to build service from git repository. Run this command from the root of the repository. Make sure you have the Dockerfile in the repository and have the Docker daemon running on your machine. By default, the service name will be the repository name, but you can specify a custom service name with the --product-name flag.\n\nYou can also skip specific stages of the build process using the --skip-* flags. For example, you can skip building the Docker image with --skip-docker-build, skip creating the service with --skip-service-build, or skip SaaS portal initialization with --skip-saas-portal-init.\n\nEnvironment promotion is skipped by default. To promote the service to production, use --skip-environment-promotion=false.\n\nFor testing purposes, use the --dry-run flag to only build the Docker image locally without pushing, skip service creation, and generate a local spec file with a '-dry-run' suffix. Note that --dry-run cannot be used together with any of the --skip-* flags as they are mutually exclusive.",
+	Long:         "This command helps to build service from git repository. Run this command from the root of the repository. Make sure you have the Dockerfile in the repository and have the Docker daemon running on your machine. By default, the service name will be the repository name, but you can specify a custom service name with the --product-name flag.\n\nYou can also skip specific stages of the build process using the --skip-* flags. For example, you can skip building the Docker image with --skip-docker-build, skip creating the service with --skip-service-build, or skip SaaS portal initialization with --skip-saas-portal-init.\n\nEnvironment promotion is skipped by default. To promote the service to production, use --skip-environment-promotion=false.\n\nFor testing purposes, use the --dry-run flag to only build the Docker image locally without pushing, skip service creation, and generate a local spec file with a '-dry-run' suffix. Note that --dry-run cannot be used together with any of the --skip-* flags as they are mutually exclusive.\n\nIf a build is interrupted, e.g. by a failed push, the images already pushed are recorded for the checked out commit, and the next run of the command offers to resume the build without building and pushing them again. Use --no-resume to build every image again.",
 	Example:      buildFromRepoExample,
 	RunE:         runBuildFromRepo,
 	SilenceUsage: true,
@@ -106,6 +115,12 @@ func init() {
 
 	// Platform flag
 	BuildFromRepoCmd.Flags().StringArray("platforms", []string{"linux/amd64"}, "Specify the platforms to build for. Use the format: --platforms linux/amd64 --platforms linux/arm64. Default is linux/amd64.")
+	BuildFromRepoCmd.Flags().String("dockerfile", "", "Path to the Dockerfile to build when no compose spec exists in the repo (default: Dockerfile in the repository root). The repository root is used as the build context.")
+	BuildFromRepoCmd.Flags().StringArray("label", nil, "Add a label to the built Docker images, e.g. --label org.opencontainers.image.revision=$(git rev-parse HEAD). Repeat the flag for several labels.")
+	BuildFromRepoCmd.Flags().Bool("show-timings", false, "Print the duration of each phase of the build, such as the docker build and push and the service build, when it finishes")
+	BuildFromRepoCmd.Flags().Bool("raw-docker-output", false, "Stream the full docker build and push output instead of a progress bar")
+	BuildFromRepoCmd.Flags().Bool("no-resume", false, "Build and push every image again instead of resuming an interrupted build of the same commit")
+	BuildFromRepoCmd.Flags().Bool("use-docker-credentials", false, "Push images with the registry credentials of the docker client configuration (~/.docker/config.json or a credential helper) instead of logging in with a GitHub PAT, and don't write the PAT into the compose spec")
 
 	// Release description flag
 	BuildFromRepoCmd.Flags().String("release-description", "", "Provide a description for the release version")
@@ -125,6 +140,7 @@ func init() {
 	if err != nil {
 		return
 	}
+	BuildFromRepoCmd.MarkFlagsMutuallyExclusive("use-docker-credentials", "reset-pat")
 }
 
 func runBuildFromRepo(cmd *cobra.Command, args []string) error {
@@ -291,34 +307,22 @@ func runBuildFromRepo(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate deployment type and cloud provider account details
-	if deploymentType != "" {
-		if deploymentType != DeploymentTypeHosted && deploymentType != DeploymentTypeByoa {
-			err = errors.New("invalid deployment type. Options: 'hosted' or 'byoa'")
-			utils.PrintError(err)
-			return err
-		}
-
-		if awsAccountID == "" && gcpProjectID == "" && azureSubscriptionID == "" {
-			err = errors.New(fmt.Sprintf("AWS account ID or GCP project ID or Azure subscription ID are required for %s deployment type", deploymentType))
-			utils.PrintError(err)
-			return err
-		}
-		if gcpProjectID != "" && gcpProjectNumber == "" {
-			err = errors.New("GCP project number is required with GCP project ID")
-			utils.PrintError(err)
-			return err
-		}
-		if gcpProjectID == "" && gcpProjectNumber != "" {
-			err = errors.New("GCP project ID is required with GCP project number")
-			utils.PrintError(err)
-			return err
-		}
-		if azureSubscriptionID != "" && azureTenantID == "" {
-			err = errors.New("Azure tenant ID is required with Azure subscription ID")
-			utils.PrintError(err)
-			return err
-		}
+	if err = validateDeploymentAccounts(deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, azureSubscriptionID, azureTenantID); err != nil {
+		utils.PrintError(err)
+		return err
+	}
 
+	showTimings, err := cmd.Flags().GetBool("show-timings")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	ctx := cmd.Context()
+	var timings *utils.PhaseTimings
+	if showTimings {
+		timings = utils.NewPhaseTimings()
+		ctx = utils.WithPhaseTimings(ctx, timings)
+		defer timings.Print(os.Stderr)
 	}
 
 	// Initialize the spinner manager
@@ -336,10 +340,11 @@ func runBuildFromRepo(cmd *cobra.Command, args []string) error {
 	// Pass all required arguments including the SpinnerManager
 	serviceID, devEnvironmentID, devPlanID, _, err := BuildServiceFromRepository(
 		cmd,
-		cmd.Context(),
+		ctx,
 		token,
 		serviceName,
 		releaseDescription,
+		"",
 		resetPAT,
 		dryRun,
 		skipDockerBuild,
@@ -369,8 +374,8 @@ func runBuildFromRepo(cmd *cobra.Command, args []string) error {
 		spinner.Complete()
 	} else {
 		// Step 16: Check if the production environment is set up
+		timings.Start("Environment promotion")
 		spinner = sm.AddSpinner("Checking if the production environment is set up")
-		time.Sleep(1 * time.Second) // Add a delay to show the spinner
 		prodEnvironmentID, err = checkIfProdEnvExists(cmd.Context(), token, serviceID)
 		if err != nil {
 			utils.HandleSpinnerError(spinner, sm, err)
@@ -452,6 +457,7 @@ func runBuildFromRepo(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 20: Initialize the SaaS Portal
+	timings.Start("SaaS Portal initialization")
 	var prodEnvironment *openapiclient.DescribeServiceEnvironmentResult
 
 	if skipSaasPortalInit || skipEnvironmentPromotion {
@@ -492,7 +498,6 @@ func runBuildFromRepo(cmd *cobra.Command, args []string) error {
 
 		// Step 21: Retrieve the SaaS Portal URL
 		spinner = sm.AddSpinner("Retrieving the SaaS Portal URL")
-		time.Sleep(1 * time.Second) // Add a delay to show the spinner
 		spinner.Complete()
 	}
 
@@ -589,9 +594,32 @@ func runBuildFromRepo(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token, serviceName, releaseDescription string, resetPAT, dryRun, skipDockerBuild, skipServiceBuild bool, deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, azureSubscriptionID, azureTenantID string, sm utils.SpinnerManager, file string, envVars, platforms []string, forceCreateServicePlanVersion bool) (serviceID, devEnvironmentID, devPlanID string, undefinedResources map[string]string, err error) {
+// ParseEnvVars parses the key=value environment variables passed to the image of a service built from a repository.
+// Each entry must have exactly one '='.
+func ParseEnvVars(envVars []string) ([]openapiclient.EnvironmentVariable, error) {
+	var formattedEnvVars []openapiclient.EnvironmentVariable
+	for _, envVar := range envVars {
+		if envVar == "[]" {
+			continue
+		}
+		envVarParts := strings.Split(envVar, "=")
+		if len(envVarParts) != 2 {
+			return nil, fmt.Errorf("invalid environment variable format %q: expected key=value with exactly one '='", envVar)
+		}
+		formattedEnvVars = append(formattedEnvVars, openapiclient.EnvironmentVariable{
+			Key:   envVarParts[0],
+			Value: envVarParts[1],
+		})
+	}
+	return formattedEnvVars, nil
+}
+
+func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token, serviceName, releaseDescription, description string, resetPAT, dryRun, skipDockerBuild, skipServiceBuild bool, deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, azureSubscriptionID, azureTenantID string, sm utils.SpinnerManager, file string, envVars, platforms []string, forceCreateServicePlanVersion bool) (serviceID, devEnvironmentID, devPlanID string, undefinedResources map[string]string, err error) {
+
+	timings := utils.PhaseTimingsFromContext(ctx)
 
 	// Step 0: Validate user is currently logged in
+	timings.Start("Repository checks")
 	spinner := sm.AddSpinner("Checking if user is logged in")
 	spinner.Complete()
 	sm.Stop()
@@ -603,7 +631,6 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 
 	// Step 1: Check if the user is in the root of the repository
 	spinner = sm.AddSpinner("Checking if user is in the root of the repository")
-	time.Sleep(1 * time.Second) // Add a delay to show the spinner
 	cwd, err := os.Getwd()
 	if err != nil {
 		utils.HandleSpinnerError(spinner, sm, err)
@@ -620,7 +647,6 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 
 	// Step 2: Retrieve the repository name
 	spinner = sm.AddSpinner("Retrieving repository name")
-	time.Sleep(1 * time.Second) // Add a delay to show the spinner
 	output, err := exec.Command("sh", "-c", `git config --get remote.origin.url | sed -E 's/:([^\/])/\/\1/g' | sed -e 's/ssh\/\/\///g' | sed -e 's/git@/https:\/\//g'`).Output()
 	if err != nil {
 		utils.HandleSpinnerError(spinner, sm, err)
@@ -635,7 +661,6 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 
 	// Step 3: Check if there exists a compose spec in the repository
 	spinner = sm.AddSpinner("Checking if there exists a compose spec in the repository")
-	time.Sleep(1 * time.Second) // Add a delay to show the spinner
 	var composeSpecExists bool
 	if _, err = os.Stat(file); os.IsNotExist(err) {
 		composeSpecExists = false
@@ -653,14 +678,30 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 	var parsedYaml map[string]interface{}
 	var project *types.Project
 	dockerfilePaths := make(map[string]string)        // service -> dockerfile path
+	dockerContexts := make(map[string]string)         // service -> docker build context directory
+	imageLabels := make(map[string]string)            // service -> image label
 	dockerCacheFrom := make(map[string][]string)      // service -> cache_from entries
 	dockerCacheTo := make(map[string][]string)        // service -> cache_to entries
 	versionTaggedImageUrls := make(map[string]string) // service -> image url with digest tag
+	var buildState *repoBuildState                    // images pushed by an interrupted earlier run
 	var pat string
 	var ghUsername string
 
+	dockerfileOverride, _ := cmd.Flags().GetString("dockerfile")
+	useDockerCredentials, _ := cmd.Flags().GetBool("use-docker-credentials")
+	labelFlags, _ := cmd.Flags().GetStringArray("label")
+	imageLabelValues, err := ParseImageLabels(labelFlags)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return "", "", "", nil, err
+	}
+
 	composeSpecHasBuildContext := false
 	if composeSpecExists {
+		if dockerfileOverride != "" {
+			fmt.Fprintf(os.Stderr, " Warning: --dockerfile is ignored because %s exists. Set build.dockerfile in the compose spec instead.\n", file)
+		}
+
 		// Load the compose file
 		if _, err = os.Stat(file); os.IsNotExist(err) {
 			utils.PrintError(err)
@@ -714,6 +755,25 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 				}
 			}
 		}
+	} else if dockerfileOverride != "" {
+		var dockerfilePath string
+		dockerfilePath, err = filepath.Abs(dockerfileOverride)
+		if err != nil {
+			utils.HandleSpinnerError(spinner, sm, err)
+			return "", "", "", nil, err
+		}
+
+		var dockerfileInfo os.FileInfo
+		if dockerfileInfo, err = os.Stat(dockerfilePath); err != nil || dockerfileInfo.IsDir() {
+			err = fmt.Errorf("dockerfile %s not found. Please check the --dockerfile path and try again", dockerfileOverride)
+			utils.HandleSpinnerError(spinner, sm, err)
+			return "", "", "", nil, err
+		}
+
+		// Build from the repository root, as for a Dockerfile in the root, so COPY paths stay relative to it
+		dockerfilePaths[defaultServiceName] = dockerfilePath
+		dockerContexts[defaultServiceName] = rootDir
+		imageLabels[defaultServiceName] = DockerfileImageLabel(rootDir, dockerfilePath)
 	} else {
 		dockerfilePaths[defaultServiceName], err = filepath.Abs("Dockerfile")
 		if err != nil {
@@ -726,6 +786,14 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 	for _, dockerfilePath := range dockerfilePaths {
 		dockerfilePathsArr = append(dockerfilePathsArr, dockerfilePath)
 	}
+	for service, dockerfilePath := range dockerfilePaths {
+		if _, ok := dockerContexts[service]; !ok {
+			dockerContexts[service] = filepath.Dir(dockerfilePath)
+		}
+		if _, ok := imageLabels[service]; !ok {
+			imageLabels[service] = strings.ToLower(utils.GetFirstDifferentSegmentInFilePaths(dockerfilePath, dockerfilePathsArr))
+		}
+	}
 
 	if !composeSpecExists || composeSpecHasBuildContext {
 		// Skip Docker build if flag is set
@@ -733,82 +801,46 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 			spinner = sm.AddSpinner("Skipping Docker build (--skip-docker-build flag is set)")
 			spinner.Complete()
 
-			// We still need to get the GitHub username for the compose spec
-			spinner = sm.AddSpinner("Getting GitHub username for compose spec")
-			pat, err = config.LookupGitHubPersonalAccessToken()
-			if err != nil && !errors.As(err, &config.ErrGitHubPATNotFound) {
-				utils.HandleSpinnerError(spinner, sm, err)
-				return "", "", "", nil, err
-			}
-
-			if !errors.As(err, &config.ErrGitHubPATNotFound) {
-				if config.IsGithubTokenEnvVarConfigured() {
-					ghUsername = config.GithubTokenUserName
-				} else {
-					// Use GitHub API directly with PAT to get username
-					authHeader := fmt.Sprintf("Authorization: token %s", pat)
-					ghUsernameOutput, err := exec.Command("curl", "-s", "-H", authHeader, "https://api.github.com/user").Output()
-					if err != nil {
-						utils.HandleSpinnerError(spinner, sm, err)
-						return "", "", "", nil, err
-					}
+			if useDockerCredentials {
+				// Registry auth is managed outside of omnistrate-ctl, so no GitHub PAT is needed
+				ghUsername = dockerCredentialsUsername(cmd, repoOwner)
+			} else {
+				// We still need to get the GitHub username for the compose spec
+				spinner = sm.AddSpinner("Getting GitHub username for compose spec")
+				pat, err = config.LookupGitHubPersonalAccessToken()
+				if err != nil && !errors.As(err, &config.ErrGitHubPATNotFound) {
+					utils.HandleSpinnerError(spinner, sm, err)
+					return "", "", "", nil, err
+				}
 
-					// Parse JSON response to extract login field
-					var response map[string]interface{}
-					if err := json.Unmarshal(ghUsernameOutput, &response); err != nil {
-						utils.HandleSpinnerError(spinner, sm, fmt.Errorf("failed to parse GitHub API response: %v, response: %s", err, string(ghUsernameOutput)))
-						return "", "", "", nil, err
+				if !errors.As(err, &config.ErrGitHubPATNotFound) {
+					// GitHub is asked for the owner of the PAT in-process, keeping the token out of command lines
+					var login string
+					if !config.IsGithubTokenEnvVarConfigured() {
+						_, login, _ = getGitHubUser(ctx, http.DefaultClient, pat)
 					}
-
-					// Check if the API returned an error (like bad credentials)
-					if message, exists := response["message"]; exists {
-						// Try to use the GitHub username flag as a fallback
-						if flagUsername, _ := cmd.Flags().GetString("github-username"); flagUsername != "" {
-							ghUsername = flagUsername
-							spinner.UpdateMessage(fmt.Sprintf("GitHub API failed, using provided username: %s", ghUsername))
-						} else {
-							// Ask user to enter GitHub username interactively
-							spinner.UpdateMessage("GitHub API failed. Please enter your GitHub username:")
-							spinner.Complete()
-							sm.Stop()
-
-							fmt.Print("Enter your GitHub username: ")
-							var inputUsername string
-							if _, err := fmt.Scanln(&inputUsername); err != nil {
-								utils.HandleSpinnerError(spinner, sm, fmt.Errorf("failed to read GitHub username: %w", err))
-								return "", "", "", nil, fmt.Errorf("failed to read GitHub username: %w", err)
-							}
-
-							sm.Start()
-
-							if inputUsername != "" {
-								ghUsername = inputUsername
-								spinner = sm.AddSpinner(fmt.Sprintf("Using provided GitHub username: %s", ghUsername))
-								spinner.Complete()
-							} else {
-								utils.HandleSpinnerError(spinner, sm, fmt.Errorf("GitHub API error: %v. Please check your GitHub Personal Access Token or provide --github-username flag", message))
-								return "", "", "", nil, fmt.Errorf("GitHub API error: %v. Please update your GitHub Personal Access Token with proper permissions or use --github-username flag", message)
-							}
-						}
-					} else {
-						// Only try to extract login if there was no error
-						if login, ok := response["login"].(string); ok {
-							ghUsername = login
-						} else {
-							utils.HandleSpinnerError(spinner, sm, fmt.Errorf("unable to get GitHub username from API response: %v", response))
-							return "", "", "", nil, fmt.Errorf("unable to get GitHub username from API response")
+					switch {
+					case config.IsGithubTokenEnvVarConfigured():
+						ghUsername = config.GithubTokenUserName
+					case login != "":
+						ghUsername = login
+					default:
+						spinner, ghUsername, err = fallbackGitHubUsername(cmd, sm, spinner)
+						if err != nil {
+							utils.HandleSpinnerError(spinner, sm, err)
+							return "", "", "", nil, err
 						}
 					}
+					spinner.UpdateMessage(fmt.Sprintf("Getting GitHub username for compose spec: %s", ghUsername))
+				} else {
+					spinner.UpdateMessage("GitHub PAT not found, will prompt if needed later")
 				}
-				spinner.UpdateMessage(fmt.Sprintf("Getting GitHub username for compose spec: %s", ghUsername))
-			} else {
-				spinner.UpdateMessage("GitHub PAT not found, will prompt if needed later")
+				spinner.Complete()
 			}
-			spinner.Complete()
 
 			// Set placeholder image URLs if needed
 			for service := range dockerfilePaths {
-				label := strings.ToLower(utils.GetFirstDifferentSegmentInFilePaths(dockerfilePaths[service], dockerfilePathsArr))
+				label := imageLabels[service]
 				var imageUrl string
 				if label == "" {
 					imageUrl = fmt.Sprintf("ghcr.io/%s/%s", strings.ToLower(repoOwner), repoName)
@@ -818,198 +850,93 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 				versionTaggedImageUrls[service] = fmt.Sprintf("%s:latest", imageUrl)
 			}
 		} else {
-			// Step 4: Check if the Dockerfile exists
-			for _, dockerfilePath := range dockerfilePaths {
-				spinner = sm.AddSpinner(fmt.Sprintf("Checking if %s exists in the repository", dockerfilePath))
-				time.Sleep(1 * time.Second) // Add a delay to show the spinner
-
-				if _, err = os.Stat(dockerfilePath); os.IsNotExist(err) {
-					utils.HandleSpinnerError(spinner, sm, errors.New(fmt.Sprintf("%s not found in the repository", dockerfilePath)))
-					return "", "", "", nil, err
-				}
-
-				spinner.UpdateMessage(fmt.Sprintf("Checking if %s exists in the repository: Yes", dockerfilePath))
-				spinner.Complete()
-			}
-
-			// Step 5: Check if Docker is installed
-			spinner = sm.AddSpinner("Checking if Docker installed")
-			time.Sleep(1 * time.Second)                   // Add a delay to show the spinner
-			err = exec.Command("docker", "version").Run() // Simple way to check if Docker is available
-			if err != nil {
-				utils.HandleSpinnerError(spinner, sm, err)
-				return "", "", "", nil, err
-			}
-			spinner.UpdateMessage("Checking if Docker installed: Yes")
-			spinner.Complete()
-
-			// Step 6: Check if the Docker daemon is running
-			spinner = sm.AddSpinner("Checking if Docker daemon is running")
-			time.Sleep(1 * time.Second)                // Add a delay to show the spinner
-			err = exec.Command("docker", "info").Run() // Simple way to check if Docker is available
-			if err != nil {
-				utils.HandleSpinnerError(spinner, sm, err)
-				return "", "", "", nil, err
-			}
-			spinner.UpdateMessage("Checking if Docker daemon is running: Yes")
-			spinner.Complete()
-
-			// Step 7: Check if there is an existing GitHub pat
-			sm, pat, err = getOrCreatePAT(sm, resetPAT)
+			// Steps 4-6: Check that the Dockerfiles exist and that Docker is installed and running
+			timings.Start("Docker checks and registry login")
+			spinner, err = checkDockerPrerequisites(sm, dockerfilePaths, RunDockerCheck)
 			if err != nil {
 				utils.HandleSpinnerError(spinner, sm, err)
 				return "", "", "", nil, err
 			}
 
-			// Step 8: Retrieve the GitHub username
-			spinner = sm.AddSpinner("Retrieving GitHub username")
-			time.Sleep(1 * time.Second) // Add a delay to show the spinner
-			if config.IsGithubTokenEnvVarConfigured() {
-				ghUsername = config.GithubTokenUserName
-			} else {
-				// Use GitHub API directly with PAT to get username
-				authHeader := fmt.Sprintf("Authorization: token %s", pat)
-				ghUsernameOutput, err := exec.Command("curl", "-s", "-H", authHeader, "https://api.github.com/user").Output()
+			if useDockerCredentials {
+				// Steps 7-8: Check the docker credentials for ghcr.io instead of using a GitHub PAT
+				spinner = sm.AddSpinner("Checking docker credentials for ghcr.io")
+				var dockerConfig string
+				if dockerConfig, err = dockerConfigDir(); err == nil {
+					err = checkDockerRegistryCredentials(dockerConfig, "ghcr.io")
+				}
 				if err != nil {
 					utils.HandleSpinnerError(spinner, sm, err)
 					return "", "", "", nil, err
 				}
-
-				// Parse JSON response to extract login field
-				var response map[string]interface{}
-				if err := json.Unmarshal(ghUsernameOutput, &response); err != nil {
-					utils.HandleSpinnerError(spinner, sm, fmt.Errorf("failed to parse GitHub API response: %v, response: %s", err, string(ghUsernameOutput)))
-					return "", "", "", nil, err
-				}
-
-				// Check if the API returned an error (like bad credentials)
-				if message, exists := response["message"]; exists {
-					// Try to use the GitHub username flag as a fallback
-					if flagUsername, _ := cmd.Flags().GetString("github-username"); flagUsername != "" {
-						ghUsername = flagUsername
-						spinner.UpdateMessage(fmt.Sprintf("GitHub API failed, using provided username: %s", ghUsername))
-					} else {
-						// Ask user to enter GitHub username interactively
-						spinner.UpdateMessage("GitHub API failed.")
-						spinner.Complete()
-						sm.Stop()
-
-						fmt.Println()
-
-						fmt.Print("Enter your GitHub username: ")
-						var inputUsername string
-						if _, err := fmt.Scanln(&inputUsername); err != nil {
-							utils.HandleSpinnerError(spinner, sm, fmt.Errorf("failed to read GitHub username: %w", err))
-							return "", "", "", nil, fmt.Errorf("failed to read GitHub username: %w", err)
-						}
-
-						if inputUsername != "" {
-							ghUsername = inputUsername
-							spinner = sm.AddSpinner(fmt.Sprintf("Using provided GitHub username: %s", ghUsername))
-							spinner.Complete()
-						} else {
-							utils.HandleSpinnerError(spinner, sm, fmt.Errorf("GitHub API error: %v. Please check your GitHub Personal Access Token or provide --github-username flag", message))
-							return "", "", "", nil, fmt.Errorf("GitHub API error: %v. Please update your GitHub Personal Access Token with proper permissions or use --github-username flag", message)
-						}
-					}
-				} else {
-					// Only try to extract login if there was no error
-					if login, ok := response["login"].(string); ok {
-						ghUsername = login
-					} else {
-						utils.HandleSpinnerError(spinner, sm, fmt.Errorf("unable to get GitHub username from API response: %v", response))
-						return "", "", "", nil, fmt.Errorf("unable to get GitHub username from API response")
-					}
-				}
-			}
-			spinner.UpdateMessage(fmt.Sprintf("Retrieving GitHub username: %s", ghUsername))
-			spinner.Complete()
-
-			// Step 9: Label the docker image with the repository name
-			spinner = sm.AddSpinner("Labeling Docker image with the repository name")
-			for _, dockerfilePath := range dockerfilePaths {
-				// Read the Dockerfile
-				var dockerfileData []byte
-				dockerfileData, err = os.ReadFile(dockerfilePath)
+				ghUsername = dockerCredentialsUsername(cmd, repoOwner)
+				spinner.UpdateMessage(fmt.Sprintf("Checking docker credentials for ghcr.io: found in %s", dockerConfig))
+				spinner.Complete()
+			} else {
+				// Step 7: Check if there is an existing GitHub pat
+				var login string
+				sm, pat, login, err = getOrCreatePAT(ctx, sm, resetPAT)
 				if err != nil {
 					utils.HandleSpinnerError(spinner, sm, err)
 					return "", "", "", nil, err
 				}
 
-				// Check if the Dockerfile already has the label
-				if strings.Contains(string(dockerfileData), "LABEL org.opencontainers.image.source") {
-					spinner.UpdateMessage("Labeling Docker image with the repository name: Already labeled")
-				} else {
-					// Append the label to the Dockerfile
-					dockerfileData = append(dockerfileData, []byte(fmt.Sprintf("\nLABEL org.opencontainers.image.source=\"https://github.com/%s/%s\"\n", repoOwner, repoName))...)
-
-					// Write the Dockerfile back
-					err = os.WriteFile(filepath.Clean(dockerfilePath), dockerfileData, 0600) //nolint:gosec // dockerfilePath is from the user's local repo
+				// Step 8: Retrieve the GitHub username, which GitHub reported while verifying the PAT
+				spinner = sm.AddSpinner("Retrieving GitHub username")
+				switch {
+				case config.IsGithubTokenEnvVarConfigured():
+					ghUsername = config.GithubTokenUserName
+				case login != "":
+					ghUsername = login
+				default:
+					spinner, ghUsername, err = fallbackGitHubUsername(cmd, sm, spinner)
 					if err != nil {
 						utils.HandleSpinnerError(spinner, sm, err)
 						return "", "", "", nil, err
 					}
-
-					spinner.UpdateMessage(fmt.Sprintf("Labeling Docker image with the repository name: %s/%s", repoOwner, repoName))
 				}
+				spinner.UpdateMessage(fmt.Sprintf("Retrieving GitHub username: %s", ghUsername))
+				spinner.Complete()
 			}
 
-			spinner.Complete()
-
-			// Step 10: Login to GitHub Container Registry
-			spinner = sm.AddSpinner("Logging in to ghcr.io")
-			spinner.Complete()
-			sm.Stop()
-			loginCmd := exec.Command("docker", "login", "ghcr.io", "--username", ghUsername, "--password", pat)
-
-			// Redirect stdout and stderr to the terminal
-			loginCmd.Stdout = os.Stdout
-			loginCmd.Stderr = os.Stderr
-
-			fmt.Printf("Invoking 'docker login ghcr.io --username %s --password ******'...\n", ghUsername)
-			err = loginCmd.Run()
-			if err != nil {
-				utils.HandleSpinnerError(spinner, sm, err)
-				return "", "", "", nil, err
-			}
-
-			sm = utils.NewSpinnerManager()
-			sm.Start()
-
+			// Step 9: Label the docker image with the repository name. The label is added to a temporary copy of
+			// each Dockerfile so the repository is left untouched.
+			spinner = sm.AddSpinner("Labeling Docker image with the repository name")
+			sourceLabel := fmt.Sprintf("https://github.com/%s/%s", repoOwner, repoName)
+			labeledDockerfilePaths := make(map[string]string) // service -> dockerfile path used for the build
+			var cleanups []func()
+			defer func() {
+				for _, cleanup := range cleanups {
+					cleanup()
+				}
+			}()
 			for service, dockerfilePath := range dockerfilePaths {
-				// Set current working directory to the service context
-				err = os.Chdir(filepath.Dir(dockerfilePath))
+				var cleanup func()
+				labeledDockerfilePaths[service], cleanup, err = writeLabeledDockerfile(dockerfilePath, sourceLabel)
 				if err != nil {
 					utils.HandleSpinnerError(spinner, sm, err)
 					return "", "", "", nil, err
 				}
+				cleanups = append(cleanups, cleanup)
+			}
+			spinner.UpdateMessage(fmt.Sprintf("Labeling Docker image with the repository name: %s/%s", repoOwner, repoName))
+			spinner.Complete()
 
-				// Step 11: Build docker image
-				label := strings.ToLower(utils.GetFirstDifferentSegmentInFilePaths(dockerfilePath, dockerfilePathsArr))
-				var imageUrl string
-				if label == "" {
-					imageUrl = fmt.Sprintf("ghcr.io/%s/%s", strings.ToLower(repoOwner), repoName)
-				} else {
-					imageUrl = fmt.Sprintf("ghcr.io/%s/%s-%s", strings.ToLower(repoOwner), repoName, label)
-				}
-
-				spinner = sm.AddSpinner(fmt.Sprintf("Building Docker image: %s", imageUrl))
+			if !useDockerCredentials {
+				// Step 10: Login to GitHub Container Registry
+				spinner = sm.AddSpinner("Logging in to ghcr.io")
 				spinner.Complete()
 				sm.Stop()
-
-				// Join the platforms list with comma as separator
-				platformsStr := strings.Join(platforms, ",")
-
-				buildArgs := BuildDockerBuildArgs(platformsStr, dockerfilePath, imageUrl, dockerCacheFrom[service], dockerCacheTo[service])
-
-				buildCmd := exec.Command("docker", buildArgs...)
+				// Pass the PAT on stdin so that it does not show up in the process list
+				loginCmd := exec.Command("docker", "login", "ghcr.io", "--username", ghUsername, "--password-stdin")
+				loginCmd.Stdin = strings.NewReader(pat)
 
 				// Redirect stdout and stderr to the terminal
-				buildCmd.Stdout = os.Stdout
-				buildCmd.Stderr = os.Stderr
+				loginCmd.Stdout = os.Stdout
+				loginCmd.Stderr = os.Stderr
 
-				fmt.Printf("Invoking 'docker %s'...\n", strings.Join(buildArgs, " "))
-				err = buildCmd.Run()
+				fmt.Printf("Invoking 'docker login ghcr.io --username %s --password-stdin'...\n", ghUsername)
+				err = loginCmd.Run()
 				if err != nil {
 					utils.HandleSpinnerError(spinner, sm, err)
 					return "", "", "", nil, err
@@ -1017,140 +944,98 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 
 				sm = utils.NewSpinnerManager()
 				sm.Start()
+			}
 
-				// In dry-run mode, skip pushing to registry and use local image tag
-				if dryRun {
-					spinner = sm.AddSpinner("Dry run: Using local image tag (skipping push)")
-					spinner.Complete()
-					versionTaggedImageUrls[service] = fmt.Sprintf("%s:latest", imageUrl)
-					continue
-				}
+			// Docker build and push output is rendered as a progress bar unless --raw-docker-output is set
+			rawDockerOutput, _ := cmd.Flags().GetBool("raw-docker-output")
 
-				// Step 12: Push docker image to GitHub Container Registry
-				spinner = sm.AddSpinner("Pushing Docker image to GitHub Container Registry")
-				spinner.Complete()
-				sm.Stop()
-				pushCmd := exec.Command("docker", "push", imageUrl)
-
-				// Redirect stdout and stderr to the terminal
-				pushCmd.Stdout = os.Stdout
-				pushCmd.Stderr = os.Stderr
+			// Step 11: Build and push the docker image of each service. Builds run concurrently with absolute
+			// context and Dockerfile paths, so the working directory is never changed.
+			services := make([]string, 0, len(dockerfilePaths))
+			for service := range dockerfilePaths {
+				services = append(services, service)
+			}
+			sort.Strings(services)
 
-				fmt.Printf("Invoking 'docker push %s'...\n", imageUrl)
-				err = pushCmd.Run()
-				if err != nil {
-					utils.HandleSpinnerError(spinner, sm, err)
-					return "", "", "", nil, err
+			builds := make([]dockerImageBuild, 0, len(services))
+			for _, service := range services {
+				label := imageLabels[service]
+				var imageUrl string
+				if label == "" {
+					imageUrl = fmt.Sprintf("ghcr.io/%s/%s", strings.ToLower(repoOwner), repoName)
+				} else {
+					imageUrl = fmt.Sprintf("ghcr.io/%s/%s-%s", strings.ToLower(repoOwner), repoName, label)
 				}
 
-				sm = utils.NewSpinnerManager()
-				sm.Start()
+				builds = append(builds, dockerImageBuild{
+					Service:        service,
+					DockerfilePath: labeledDockerfilePaths[service],
+					ContextDir:     dockerContexts[service],
+					ImageURL:       imageUrl,
+					Platforms:      strings.Join(platforms, ","),
+					CacheFrom:      dockerCacheFrom[service],
+					CacheTo:        dockerCacheTo[service],
+					Labels:         imageLabelValues,
+				})
+			}
 
-				// Retrieve the digest
-				spinner = sm.AddSpinner("Retrieving the digest for the image")
-				digestCmd := exec.Command("docker", "buildx", "imagetools", "inspect", imageUrl)
+			timings.Start("Docker build and push")
+			spinner = sm.AddSpinner(fmt.Sprintf("Building and pushing %d Docker image(s)", len(builds)))
+			spinner.Complete()
+			sm.Stop()
 
-				var digestOutput []byte
-				digestOutput, err = digestCmd.Output()
-				if err != nil {
+			// Step 12: Resume an interrupted build of the same commit, reusing the images it already pushed
+			builder := newDockerImageBuilder(dryRun, rawDockerOutput)
+			if !dryRun {
+				noResume, _ := cmd.Flags().GetBool("no-resume")
+				if buildState, err = resumeRepoBuild(rootDir, file, repoOwner+"/"+repoName, builds, noResume); err != nil {
 					utils.HandleSpinnerError(spinner, sm, err)
 					return "", "", "", nil, err
 				}
-
-				// Convert output to string and search for the Digest line
-				var digest string
-				digestOutputStr := string(digestOutput)
-				for _, line := range strings.Split(digestOutputStr, "\n") {
-					if strings.Contains(line, "Digest:") {
-						parts := strings.Split(line, ":")
-						if len(parts) < 3 {
-							utils.HandleSpinnerError(spinner, sm, errors.New("unable to retrieve the digest"))
-							return "", "", "", nil, err
-						}
-						digest = fmt.Sprintf("sha-%s", strings.TrimSpace(strings.Split(line, ":")[2]))
-						break
-					}
+				if buildState != nil {
+					builder = buildState.recordingBuilder(builder)
 				}
+			}
 
-				spinner.Complete()
-				sm.Stop()
-
-				fmt.Printf("Retrieved digest: %s\n", digest)
-
-				sm = utils.NewSpinnerManager()
-				sm.Start()
-
-				imageUrlWithDigestTag := fmt.Sprintf("%s:%s", imageUrl, digest)
-				versionTaggedImageUrls[service] = imageUrlWithDigestTag
-
-				// Tag the image with the digest
-				spinner = sm.AddSpinner("Tagging the image with the digest")
-				spinner.Complete()
-				sm.Stop()
-
-				tagCmd := exec.Command("docker", "tag", imageUrl, imageUrlWithDigestTag)
-
-				tagCmd.Stdout = os.Stdout
-				tagCmd.Stderr = os.Stderr
-
-				fmt.Printf("Invoking 'docker tag %s %s'...\n", imageUrl, imageUrlWithDigestTag)
-				if err = tagCmd.Run(); err != nil {
-					utils.HandleSpinnerError(spinner, sm, err)
-					return "", "", "", nil, err
+			pendingBuilds := make([]dockerImageBuild, 0, len(builds))
+			for _, build := range builds {
+				if imageUrl, ok := buildState.pushedImage(build.Service); ok {
+					fmt.Printf("Reusing image %s pushed by the interrupted build\n", imageUrl)
+					versionTaggedImageUrls[build.Service] = imageUrl
+					continue
 				}
+				pendingBuilds = append(pendingBuilds, build)
+			}
 
-				sm = utils.NewSpinnerManager()
-				sm.Start()
-
-				// Push the image with the digest tag
-				spinner = sm.AddSpinner("Pushing the image with the digest tag")
-				spinner.Complete()
-				sm.Stop()
-
-				pushCmd = exec.Command("docker", "push", imageUrlWithDigestTag)
-
-				// Redirect stdout and stderr to the terminal
-				pushCmd.Stdout = os.Stdout
-				pushCmd.Stderr = os.Stderr
-
-				fmt.Printf("Invoking 'docker push %s'...\n", imageUrlWithDigestTag)
-				err = pushCmd.Run()
+			if len(pendingBuilds) > 0 {
+				var builtImageUrls map[string]string
+				builtImageUrls, err = buildDockerImages(ctx, pendingBuilds, builder)
 				if err != nil {
+					if buildState != nil {
+						err = errors.Wrap(err, "build interrupted; images pushed so far are reused when the command is run again (use --no-resume to start over)")
+					}
 					utils.HandleSpinnerError(spinner, sm, err)
 					return "", "", "", nil, err
 				}
-
-				sm = utils.NewSpinnerManager()
-				sm.Start()
+				for service, imageUrl := range builtImageUrls {
+					versionTaggedImageUrls[service] = imageUrl
+				}
 			}
 
-			// Change back to the root directory
-			err = os.Chdir(rootDir)
-			if err != nil {
-				utils.HandleSpinnerError(spinner, sm, err)
-				return "", "", "", nil, err
-			}
+			sm = utils.NewSpinnerManager()
+			sm.Start()
 		}
 
 		// Step 13: Generate compose spec from the Docker image
+		timings.Start("Compose spec generation")
 		spinner = sm.AddSpinner("Generating compose spec from the Docker image")
 		if !composeSpecExists {
 			// Parse the environment variables
 			var formattedEnvVars []openapiclient.EnvironmentVariable
-			for _, envVar := range envVars {
-				if envVar == "[]" {
-					continue
-				}
-				envVarParts := strings.Split(envVar, "=")
-				if len(envVarParts) != 2 {
-					err = errors.New("invalid environment variable format")
-					utils.PrintError(err)
-					return "", "", "", nil, err
-				}
-				formattedEnvVars = append(formattedEnvVars, openapiclient.EnvironmentVariable{
-					Key:   envVarParts[0],
-					Value: envVarParts[1],
-				})
+			formattedEnvVars, err = ParseEnvVars(envVars)
+			if err != nil {
+				utils.PrintError(err)
+				return "", "", "", nil, err
 			}
 
 			// Generate compose spec from image
@@ -1158,13 +1043,16 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 				ImageRegistry:        "ghcr.io",
 				Image:                strings.TrimPrefix(versionTaggedImageUrls[defaultServiceName], "ghcr.io/"),
 				Username:             utils.ToPtr(ghUsername),
-				Password:             utils.ToPtr(pat),
 				EnvironmentVariables: formattedEnvVars,
 			}
+			if pat != "" {
+				generateComposeSpecRequest.Password = utils.ToPtr(pat)
+			}
 
 			var generateComposeSpecRes *openapiclient.GenerateComposeSpecFromContainerImageResult
-			generateComposeSpecRes, err = dataaccess.GenerateComposeSpecFromContainerImage(ctx, token, generateComposeSpecRequest)
+			generateComposeSpecRes, err = generateComposeSpecWithRetry(ctx, token, generateComposeSpecRequest, dataaccess.GenerateComposeSpecFromContainerImage)
 			if err != nil {
+				err = scrubGitHubPATError(err, pat)
 				utils.HandleSpinnerError(spinner, sm, err)
 				return "", "", "", nil, err
 			}
@@ -1177,43 +1065,22 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 			}
 
 			// Replace the actual PAT with ${{ secrets.GitHubPAT }}
-			fileData = []byte(strings.ReplaceAll(string(fileData), pat, "${{ secrets.GitHubPAT }}"))
+			fileData = []byte(maskGitHubPAT(string(fileData), pat))
 
 			// Replace the image tag with build tag
 			fileData = []byte(strings.ReplaceAll(string(fileData), fmt.Sprintf("image: %s", versionTaggedImageUrls[defaultServiceName]), "build:\n      context: .\n      dockerfile: Dockerfile"))
 			composeSpecHasBuildContext = true
 
 			// Append the deployment section to the compose spec
-			switch deploymentType {
-			case DeploymentTypeHosted:
-				fileData = append(fileData, []byte("  deployment:\n")...)
-				fileData = append(fileData, []byte("    hostedDeployment:\n")...)
-			case DeploymentTypeByoa:
-				fileData = append(fileData, []byte("  deployment:\n")...)
-				fileData = append(fileData, []byte("    byoaDeployment:\n")...)
-			}
-
 			if deploymentType != "" {
-				if awsAccountID != "" {
-					fileData = append(fileData, []byte(fmt.Sprintf("      AwsAccountId: '%s'\n", awsAccountID))...)
-					awsBootstrapRoleAccountARN := fmt.Sprintf("arn:aws:iam::%s:role/omnistrate-bootstrap-role", awsAccountID)
-					fileData = append(fileData, []byte(fmt.Sprintf("      AwsBootstrapRoleAccountArn: '%s'\n", awsBootstrapRoleAccountARN))...)
-				}
+				var gcpServiceAccountEmail string
 				if gcpProjectID != "" {
-					fileData = append(fileData, []byte(fmt.Sprintf("      GcpProjectId: '%s'\n", gcpProjectID))...)
-					fileData = append(fileData, []byte(fmt.Sprintf("      GcpProjectNumber: '%s'\n", gcpProjectNumber))...)
-
-					// Get organization id
-					user, err := dataaccess.DescribeUser(ctx, token)
-					if err != nil {
+					if gcpServiceAccountEmail, err = gcpBootstrapServiceAccountEmail(ctx, token, gcpProjectID); err != nil {
 						utils.HandleSpinnerError(spinner, sm, err)
 						return "", "", "", nil, err
 					}
-
-					gcpServiceAccountEmail := fmt.Sprintf("bootstrap-%s@%s.iam.gserviceaccount.com", *user.OrgId, gcpProjectID)
-					fileData = append(fileData, []byte(fmt.Sprintf("      GcpServiceAccountEmail: '%s'\n", gcpServiceAccountEmail))...)
 				}
-				fileData = appendAzureConfig(fileData, azureSubscriptionID, azureTenantID)
+				fileData = appendDeploymentSection(fileData, deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, gcpServiceAccountEmail, azureSubscriptionID, azureTenantID)
 			}
 
 			// Write the compose spec to a file
@@ -1227,41 +1094,21 @@ func BuildServiceFromRepository(cmd *cobra.Command, ctx context.Context, token,
 		} else {
 			// Append the deployment section to the compose spec if it doesn't exist
 			if !strings.Contains(string(fileData), "deployment:") {
-				switch deploymentType {
-				case DeploymentTypeHosted:
-					fileData = append(fileData, []byte("  deployment:\n")...)
-					fileData = append(fileData, []byte("    hostedDeployment:\n")...)
-				case DeploymentTypeByoa:
-					fileData = append(fileData, []byte("  deployment:\n")...)
-					fileData = append(fileData, []byte("    byoaDeployment:\n")...)
-				}
-
 				if deploymentType != "" {
-					if awsAccountID != "" {
-						fileData = append(fileData, []byte(fmt.Sprintf("      AwsAccountId: '%s'\n", awsAccountID))...)
-						awsBootstrapRoleAccountARN := fmt.Sprintf("arn:aws:iam::%s:role/omnistrate-bootstrap-role", awsAccountID)
-						fileData = append(fileData, []byte(fmt.Sprintf("      AwsBootstrapRoleAccountArn: '%s'\n", awsBootstrapRoleAccountARN))...)
-					}
+					var gcpServiceAccountEmail string
 					if gcpProjectID != "" {
-						fileData = append(fileData, []byte(fmt.Sprintf("      GcpProjectId: '%s'\n", gcpProjectID))...)
-						fileData = append(fileData, []byte(fmt.Sprintf("      GcpProjectNumber: '%s'\n", gcpProjectNumber))...)
-
-						// Get organization id
-						user, err := dataaccess.DescribeUser(ctx, token)
-						if err != nil {
+						if gcpServiceAccountEmail, err = gcpBootstrapServiceAccountEmail(ctx, token, gcpProjectID); err != nil {
 							utils.HandleSpinnerError(spinner, sm, err)
 							return "", "", "", nil, err
 						}
-
-						gcpServiceAccountEmail := fmt.Sprintf("bootstrap-%s@%s.iam.gserviceaccount.com", *user.OrgId, gcpProjectID)
-						fileData = append(fileData, []byte(fmt.Sprintf("      GcpServiceAccountEmail: '%s'\n", gcpServiceAccountEmail))...)
 					}
-					fileData = appendAzureConfig(fileData, azureSubscriptionID, azureTenantID)
+					fileData = appendDeploymentSection(fileData, deploymentType, awsAccountID, gcpProjectID, gcpProjectNumber, gcpServiceAccountEmail, azureSubscriptionID, azureTenantID)
 				}
 			}
 
-			// Append the image registry attributes to the compose spec if it doesn't exist
-			if !strings.Contains(string(fileData), "x-omnistrate-image-registry-attributes") {
+			// Append the image registry attributes to the compose spec if it doesn't exist, unless registry auth is
+			// managed outside of omnistrate-ctl
+			if !useDockerCredentials && !strings.Contains(string(fileData), "x-omnistrate-image-registry-attributes") {
 				fileData = append(fileData, []byte(fmt.Sprintf(`
 x-omnistrate-image-registry-attributes:
   ghcr.io:
@@ -1283,30 +1130,31 @@ x-omnistrate-image-registry-attributes:
 	}
 
 	// Step 13: Get or create a GitHub PAT if needed
+	if strings.Contains(string(fileData), "${{ secrets.GitHubPAT }}") && useDockerCredentials {
+		err = errors.New("the compose spec references ${{ secrets.GitHubPAT }}, which is not filled in with --use-docker-credentials. Set the credentials in x-omnistrate-image-registry-attributes or build without --use-docker-credentials")
+		utils.HandleSpinnerError(spinner, sm, err)
+		return "", "", "", nil, err
+	}
 	if strings.Contains(string(fileData), "${{ secrets.GitHubPAT }}") && pat == "" {
-		sm, pat, err = getOrCreatePAT(sm, resetPAT)
+		sm, pat, _, err = getOrCreatePAT(ctx, sm, resetPAT)
 		if err != nil {
 			utils.HandleSpinnerError(spinner, sm, err)
 			return "", "", "", nil, err
 		}
 	}
 
-	// Step 14: Render the compose file: variable interpolation (if env_file appears), ${{ secrets.GitHubPAT }} replacement, build context replacement
+	// Step 14: Render the compose file: variable interpolation (if env_file appears) and build context replacement.
+	// ${{ secrets.GitHubPAT }} is only filled in for the API call, so the PAT is never written to disk.
 	spinner = sm.AddSpinner("Rendering compose spec")
 
 	if strings.Contains(string(fileData), "env_file:") {
-		fileData, err = renderEnvFileAndInterpolateVariables(fileData, rootDir, file, sm, spinner)
+		fileData, err = spec.InterpolateEnvVariables(fileData, rootDir)
 		if err != nil {
 			utils.HandleSpinnerError(spinner, sm, err)
 			return "", "", "", nil, err
 		}
 	}
 
-	// Render the ${{ secrets.GitHubPAT }} in the compose file if needed
-	if strings.Contains(string(fileData), "${{ secrets.GitHubPAT }}") {
-		fileData = []byte(strings.ReplaceAll(string(fileData), "${{ secrets.GitHubPAT }}", pat))
-	}
-
 	// Render build context sections into image fields in the compose file if needed
 	if composeSpecHasBuildContext {
 		dockerPathsToImageUrls := make(map[string]string)
@@ -1320,17 +1168,13 @@ x-omnistrate-image-registry-attributes:
 	spinner.Complete()
 
 	// Step 15: Building service from the compose spec
+	timings.Start("Service build")
 	spinner = sm.AddSpinner("Building service from the compose spec")
 
 	// If we're in dry-run mode, save the compose spec to a file with '-dry-run' suffix
 	if dryRun {
-		// Get the file extension
-		fileExt := filepath.Ext(file)
-		baseName := file[:len(file)-len(fileExt)]
-		dryRunFile := fmt.Sprintf("%s-dry-run%s", baseName, fileExt)
-
-		// Write the compose spec to the dry-run file
-		err = os.WriteFile(filepath.Clean(dryRunFile), fileData, 0600) //nolint:gosec // derived from user's local file path
+		var dryRunFile string
+		dryRunFile, err = writeDryRunComposeSpec(file, fileData, pat)
 		if err != nil {
 			utils.HandleSpinnerError(spinner, sm, err)
 			return "", "", "", nil, err
@@ -1349,6 +1193,7 @@ x-omnistrate-image-registry-attributes:
 		spinner.Complete()
 		sm.Stop()
 		fmt.Println("Service build was skipped. No service was created.")
+		buildState.clear()
 		return "", "", "", nil, nil
 	}
 
@@ -1358,20 +1203,24 @@ x-omnistrate-image-registry-attributes:
 		serviceNameToUse = serviceName
 	}
 
-	// Prepare release description pointer
+	// Prepare release description and service description pointers
 	var releaseDescriptionPtr *string
 	if releaseDescription != "" {
 		releaseDescriptionPtr = &releaseDescription
 	}
+	var descriptionPtr *string
+	if description != "" {
+		descriptionPtr = &description
+	}
 
 	// Build the service
 	serviceID, devEnvironmentID, devPlanID, undefinedResources, _, err = BuildService(
 		ctx,
-		fileData,
+		renderGitHubPAT(fileData, pat),
 		token,
 		serviceNameToUse,
 		DockerComposeSpecType,
-		nil,
+		descriptionPtr,
 		nil,
 		nil,
 		nil,
@@ -1382,17 +1231,34 @@ x-omnistrate-image-registry-attributes:
 		forceCreateServicePlanVersion,
 	)
 	if err != nil {
+		// The API may echo the rendered spec back in its error
+		err = scrubGitHubPATError(err, pat)
 		utils.HandleSpinnerError(spinner, sm, err)
 		return "", "", "", nil, err
 	}
 
 	spinner.UpdateMessage(fmt.Sprintf("Building service from the compose spec: built service %s (service ID: %s)", serviceNameToUse, serviceID))
 	spinner.Complete()
+	buildState.clear()
 
 	return serviceID, devEnvironmentID, devPlanID, undefinedResources, nil
 
 }
 
+// writeDryRunComposeSpec writes the compose spec of a dry run next to file, with a '-dry-run' suffix, and returns
+// its path. Any occurrence of the PAT, e.g. pulled in by env_file interpolation, is masked.
+func writeDryRunComposeSpec(file string, fileData []byte, pat string) (string, error) {
+	fileExt := filepath.Ext(file)
+	baseName := file[:len(file)-len(fileExt)]
+	dryRunFile := fmt.Sprintf("%s-dry-run%s", baseName, fileExt)
+
+	err := os.WriteFile(filepath.Clean(dryRunFile), []byte(maskGitHubPAT(string(fileData), pat)), 0600) //nolint:gosec // derived from user's local file path
+	if err != nil {
+		return "", err
+	}
+	return dryRunFile, nil
+}
+
 // Helper functions
 
 // appendAzureConfig appends Azure configuration to fileData if Azure credentials are provided
@@ -1443,10 +1309,121 @@ func createProdEnv(ctx context.Context, token string, serviceID string, devEnvir
 	return prodEnvironmentID, nil
 }
 
-func getOrCreatePAT(sm utils.SpinnerManager, resetPAT bool) (newSm utils.SpinnerManager, pat string, err error) {
+// checkDockerPrerequisites checks that every Dockerfile exists, that Docker is installed and that its daemon is
+// running. It returns the spinner of the failed check along with the error.
+func checkDockerPrerequisites(sm utils.SpinnerManager, dockerfilePaths map[string]string, runDocker func(args ...string) ([]byte, error)) (*utils.Spinner, error) {
+	services := make([]string, 0, len(dockerfilePaths))
+	for service := range dockerfilePaths {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		dockerfilePath := dockerfilePaths[service]
+		spinner := sm.AddSpinner(fmt.Sprintf("Checking if %s exists in the repository", dockerfilePath))
+		if _, err := os.Stat(dockerfilePath); err != nil {
+			return spinner, errors.New(fmt.Sprintf("%s not found in the repository", dockerfilePath))
+		}
+		spinner.UpdateMessage(fmt.Sprintf("Checking if %s exists in the repository: Yes", dockerfilePath))
+		spinner.Complete()
+	}
+
+	spinner := sm.AddSpinner("Checking if Docker installed")
+	if err := CheckDockerInstalled(runDocker); err != nil {
+		return spinner, err
+	}
+	spinner.UpdateMessage("Checking if Docker installed: Yes")
+	spinner.Complete()
+
+	spinner = sm.AddSpinner("Checking if Docker daemon is running")
+	daemon, err := CheckDockerDaemon(runDocker)
+	if err != nil {
+		return spinner, err
+	}
+	spinner.UpdateMessage(fmt.Sprintf("Checking if Docker daemon is running (%s): Yes", daemon))
+	spinner.Complete()
+
+	return spinner, nil
+}
+
+// RunDockerCheck runs a docker command and returns its combined output.
+func RunDockerCheck(args ...string) ([]byte, error) {
+	return exec.Command("docker", args...).CombinedOutput()
+}
+
+// CheckDockerInstalled checks that the docker CLI is installed.
+func CheckDockerInstalled(runDocker func(args ...string) ([]byte, error)) error {
+	if _, err := runDocker("version", "--format", "{{.Client.Version}}"); err != nil { // The client version does not need the daemon
+		return errors.Wrap(err, "docker CLI not found or not working. Please install Docker and try again")
+	}
+	return nil
+}
+
+// CheckDockerDaemon checks that the docker daemon is running and returns a description of the daemon used.
+func CheckDockerDaemon(runDocker func(args ...string) ([]byte, error)) (string, error) {
+	// docker commands use DOCKER_HOST or the active docker context, which may point at a remote or rootless daemon
+	dockerHost := os.Getenv("DOCKER_HOST")
+	contextName := ""
+	if dockerHost == "" {
+		if output, err := runDocker("context", "show"); err == nil {
+			contextName = strings.TrimSpace(string(output))
+		}
+	}
+	daemon := describeDockerDaemon(dockerHost, contextName)
+
+	if output, err := runDocker("info"); err != nil {
+		return daemon, dockerDaemonError(err, output, dockerHost, contextName)
+	}
+	return daemon, nil
+}
+
+// CheckDockerBuildx checks that the docker buildx plugin, used to build multi-platform images, is installed.
+func CheckDockerBuildx(runDocker func(args ...string) ([]byte, error)) error {
+	if output, err := runDocker("buildx", "version"); err != nil {
+		detail := strings.TrimSpace(string(output))
+		if detail == "" {
+			detail = err.Error()
+		}
+		return errors.New(fmt.Sprintf("docker buildx is not available: %s\nInstall the Docker buildx plugin, which ships with Docker Desktop", detail))
+	}
+	return nil
+}
+
+func describeDockerDaemon(dockerHost, contextName string) string {
+	switch {
+	case dockerHost != "":
+		return fmt.Sprintf("DOCKER_HOST=%s", dockerHost)
+	case contextName != "":
+		return fmt.Sprintf("docker context %s", contextName)
+	default:
+		return "local daemon"
+	}
+}
+
+// dockerDaemonError surfaces the output of a failed `docker info` with a hint that matches how the daemon is
+// configured: DOCKER_HOST, a non-default docker context, or a local daemon.
+func dockerDaemonError(err error, output []byte, dockerHost, contextName string) error {
+	detail := strings.TrimSpace(string(output))
+	if detail == "" {
+		detail = err.Error()
+	}
+
+	var hint string
+	switch {
+	case dockerHost != "":
+		hint = fmt.Sprintf("DOCKER_HOST is set to %s. Check that the remote or rootless Docker daemon is running and reachable at that address, or unset DOCKER_HOST to use the active docker context", dockerHost)
+	case contextName != "" && contextName != "default":
+		hint = fmt.Sprintf("The active docker context is %q. Check it with 'docker context inspect %s' or switch with 'docker context use'", contextName, contextName)
+	default:
+		hint = "Make sure Docker Desktop or the Docker daemon is running. For a remote or rootless daemon, set DOCKER_HOST or select it with 'docker context use'"
+	}
+
+	return errors.New(fmt.Sprintf("docker daemon is not reachable: %s\n%s", detail, hint))
+}
+
+func getOrCreatePAT(ctx context.Context, sm utils.SpinnerManager, resetPAT bool) (newSm utils.SpinnerManager, pat, login string, err error) {
 	newSm = sm
 	spinner := sm.AddSpinner("Checking for existing GitHub Personal Access Token")
-	time.Sleep(1 * time.Second) // Add a delay to show the spinner
 	pat, err = config.LookupGitHubPersonalAccessToken()
 	if err != nil && !errors.As(err, &config.ErrGitHubPATNotFound) {
 		utils.HandleSpinnerError(spinner, sm, err)
@@ -1514,176 +1491,58 @@ func getOrCreatePAT(sm utils.SpinnerManager, resetPAT bool) (newSm utils.Spinner
 		newSm.Start()
 	}
 
-	return
-}
-
-func RenderFile(fileData []byte, rootDir string, file string, sm utils.SpinnerManager, spinner *utils.Spinner) (
-	newFileData []byte, err error) {
-	newFileData = fileData
-
-	newFileData, err = renderFileReferences(newFileData, file, sm, spinner)
-	if err != nil {
+	// Tokens from GITHUB_TOKEN, e.g. in GitHub Actions, are scoped by the workflow rather than by OAuth scopes
+	if config.IsGithubTokenEnvVarConfigured() {
 		return
 	}
 
-	if strings.Contains(string(newFileData), "env_file:") {
-		newFileData, err = renderEnvFileAndInterpolateVariables(newFileData, rootDir, file, sm, spinner)
-		if err != nil {
-			return
-		}
+	spinner = newSm.AddSpinner("Verifying GitHub Personal Access Token scopes")
+	if login, err = validateGitHubPATScopes(ctx, http.DefaultClient, pat); err != nil {
+		utils.HandleSpinnerError(spinner, newSm, err)
+		return
 	}
+	spinner.Complete()
+
 	return
 }
 
-func renderEnvFileAndInterpolateVariables(
-	fileData []byte, rootDir string, file string, sm utils.SpinnerManager, spinner *utils.Spinner) (
-	newFileData []byte, err error) {
-	// Replace `$` with `$$` to avoid interpolation. Do not replace for `${...}` since it's used to specify variable interpolations
-	fileData = []byte(strings.ReplaceAll(string(fileData), "$", "$$"))   // Escape $ to $$
-	fileData = []byte(strings.ReplaceAll(string(fileData), "$${", "${")) // Unescape $${ to ${ for variable interpolation
-	fileData = []byte(strings.ReplaceAll(string(fileData), "${{ secrets.GitHubPAT }}", "$${{ secrets.GitHubPAT }}"))
-
-	// Write the compose spec to a temporary file
-	tempFile := filepath.Join(rootDir, filepath.Base(file)+".tmp")
-	err = os.WriteFile(tempFile, fileData, 0600) //nolint:gosec // tempFile is constructed from local rootDir + base filename
-	if err != nil {
-		utils.HandleSpinnerError(spinner, sm, err)
-		return
+// fallbackGitHubUsername returns the GitHub username when GitHub did not report the owner of the PAT: the
+// --github-username flag if set, otherwise a username read from stdin. It returns the spinner to continue with.
+func fallbackGitHubUsername(cmd *cobra.Command, sm utils.SpinnerManager, spinner *utils.Spinner) (*utils.Spinner, string, error) {
+	if flagUsername, _ := cmd.Flags().GetString("github-username"); flagUsername != "" {
+		spinner.UpdateMessage(fmt.Sprintf("GitHub API failed, using provided username: %s", flagUsername))
+		return spinner, flagUsername, nil
 	}
 
-	// Render the compose file using docker compose config
-	renderCmd := exec.Command("docker", "compose", "-f", tempFile, "config")
-	cmdOut := &bytes.Buffer{}
-	cmdErr := &bytes.Buffer{}
-	renderCmd.Stdout = cmdOut
-	renderCmd.Stderr = cmdErr
-
-	err = renderCmd.Run()
-	if err != nil {
-		if spinner != nil {
-			spinner.Error()
-			sm.Stop()
-		}
-		_, _ = fmt.Fprintf(os.Stderr, "%s", cmdErr.String())
-		utils.HandleSpinnerError(spinner, sm, err)
+	// Ask user to enter GitHub username interactively
+	spinner.UpdateMessage("GitHub API failed.")
+	spinner.Complete()
+	sm.Stop()
 
-		return
+	fmt.Println()
+	fmt.Print("Enter your GitHub username: ")
+	var inputUsername string
+	if _, err := fmt.Scanln(&inputUsername); err != nil {
+		return spinner, "", fmt.Errorf("failed to read GitHub username: %w", err)
 	}
-	newFileData = cmdOut.Bytes()
-
-	// Remove the temporary file
-	err = os.Remove(tempFile)
-	if err != nil {
-		utils.HandleSpinnerError(spinner, sm, err)
-		return
+	if inputUsername == "" {
+		return spinner, "", errors.New("unable to get the GitHub username. Please update your GitHub Personal Access Token with proper permissions or use --github-username flag")
 	}
 
-	// Docker compose config command escapes the $ character by adding a $ in front of it, so we need to unescape it
-	newFileData = []byte(strings.ReplaceAll(string(newFileData), "$$", "$"))
-
-	// Quote numeric cpus values in deploy.resources
-	// Match: cpus: <number> where the number is NOT quoted
-	re := regexp.MustCompile(`(?m)(^\s*cpus:\s*)([0-9.]+)\s*$`)
-	newFileData = []byte(re.ReplaceAllString(string(newFileData), `$1"$2"`))
-
-	return
+	sm.Start()
+	spinner = sm.AddSpinner(fmt.Sprintf("Using provided GitHub username: %s", inputUsername))
+	return spinner, inputUsername, nil
 }
 
-func renderFileReferences(
-	fileData []byte, file string, sm utils.SpinnerManager, spinner *utils.Spinner) (
+// RenderFile renders file references and env variables in a spec using spec.RenderSpec.
+// References are resolved relative to the directory of file and env files relative to rootDir.
+func RenderFile(fileData []byte, rootDir string, file string, sm utils.SpinnerManager, spinner *utils.Spinner) (
 	newFileData []byte, err error) {
-	re := regexp.MustCompile(`(?m)^(?P<indent>[ \t]+)?(?P<key>[\S\t ]+)?{{[ \t]*\$file:(?P<filepath>[^\s}]+)[ \t]*}}`)
-	var filePathIndex, indentIndex, keyIndex int
-	groupNames := re.SubexpNames()
-	for i, name := range groupNames {
-		if name == "indent" {
-			indentIndex = i
-		}
-		if name == "key" {
-			keyIndex = i
-		}
-		if name == "filepath" {
-			filePathIndex = i
-		}
-	}
-
-	var renderingErr error
-	newFileDataStr := re.ReplaceAllStringFunc(string(fileData), func(match string) (replacement string) {
-		replacement = match
-
-		submatches := re.FindStringSubmatch(match)
-		addedIndentation := submatches[indentIndex]
-		key := submatches[keyIndex]
-		if strings.HasPrefix(key, ignoreKeyForFileEmbedding) {
-			key = ""
-		}
-
-		filePath := submatches[filePathIndex]
-		if len(filePath) == 0 {
-			renderingErr = fmt.Errorf("no file path found in file reference '%s'", match)
-			return
-		}
-
-		// Read file content
-		cleanedFilePath := filepath.Clean(filePath)
-		fileDir := filepath.Dir(file)
-		isRelative := !filepath.IsAbs(cleanedFilePath)
-		if isRelative {
-			cleanedFilePath = filepath.Join(fileDir, cleanedFilePath)
-		}
-
-		if _, fileErr := os.Stat(cleanedFilePath); os.IsNotExist(fileErr) {
-			renderingErr = fmt.Errorf("file '%s' does not exist", filePath)
-			return
-		}
-
-		fileContent, readErr := os.ReadFile(cleanedFilePath)
-		if readErr != nil {
-			renderingErr = fmt.Errorf("file '%s' could not be read", filePath)
-			return
-		}
-
-		// Render the file (in case it uses nested file references)
-		renderedFileContentBytes, nestedRenderErr := renderFileReferences(fileContent, cleanedFilePath, sm, spinner)
-		if nestedRenderErr != nil {
-			renderingErr = errors.Wrapf(nestedRenderErr,
-				"failed to replace file references for file '%s'", filePath)
-			return
-		}
-
-		// Add indentation of parent context
-		replacement = string(renderedFileContentBytes)
-		if len(addedIndentation) > 0 {
-			// Add indentation to each line
-			lines := strings.Split(replacement, "\n")
-			for i, line := range lines {
-				if i == 0 {
-					lines[i] = addedIndentation + key + line
-				} else if len(line) > 0 {
-					lines[i] = addedIndentation + line
-				}
-			}
-			replacement = strings.Join(lines, "\n")
-		} else {
-			replacement = key + replacement
-		}
-
-		return
-	})
-
-	// Handle error
-	if renderingErr != nil {
-		err = errors.Wrapf(renderingErr, "error rendering file '%s'", file)
-		if spinner != nil {
-			spinner.Error()
-			sm.Stop()
-		}
-		_, _ = fmt.Fprintf(os.Stderr, "%s", err.Error())
+	newFileData, err = spec.RenderSpec(fileData, filepath.Dir(file), rootDir)
+	if err != nil {
+		err = errors.Wrapf(err, "error rendering file '%s'", file)
 		utils.HandleSpinnerError(spinner, sm, err)
-
 		return
 	}
-
-	newFileData = []byte(newFileDataStr)
 	return
 }
@@ -1,11 +1,13 @@
 package build
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
 	"github.com/stretchr/testify/require"
@@ -30,3 +32,85 @@ func TestRenderEnvFileAndInterpolateVariables(t *testing.T) {
 	require.NoError(t, err, "Error rendering env file and interpolating variables: %v", err)
 	require.Equal(t, strings.ReplaceAll(string(result), " ", ""), strings.ReplaceAll(string(expectedFileData), " ", ""), "Rendered file content does not match expected content")
 }
+
+func TestCheckDockerPrerequisitesHasNoArtificialDelay(t *testing.T) {
+	dir := t.TempDir()
+	dockerfiles := map[string]string{
+		"api":    path.Join(dir, "api", "Dockerfile"),
+		"worker": path.Join(dir, "worker", "Dockerfile"),
+	}
+	for _, dockerfile := range dockerfiles {
+		require.NoError(t, os.MkdirAll(path.Dir(dockerfile), 0755))
+		require.NoError(t, os.WriteFile(dockerfile, []byte("FROM scratch\n"), 0600))
+	}
+
+	var dockerCalls []string
+	runDocker := func(args ...string) ([]byte, error) {
+		dockerCalls = append(dockerCalls, strings.Join(args, " "))
+		return []byte("default\n"), nil
+	}
+
+	t.Setenv("DOCKER_HOST", "")
+
+	start := time.Now()
+	_, err := checkDockerPrerequisites(utils.NewSpinnerManager(), dockerfiles, runDocker)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 500*time.Millisecond, "prerequisite checks should only take as long as the checks themselves")
+	require.Equal(t, []string{"version --format {{.Client.Version}}", "context show", "info"}, dockerCalls)
+
+	dockerfiles["web"] = path.Join(dir, "web", "Dockerfile")
+	_, err = checkDockerPrerequisites(utils.NewSpinnerManager(), dockerfiles, runDocker)
+	require.ErrorContains(t, err, "web/Dockerfile not found in the repository")
+}
+
+func TestDockerDaemonError(t *testing.T) {
+	output := []byte("Cannot connect to the Docker daemon at tcp://10.0.0.5:2376. Is the docker daemon running?\n")
+
+	err := dockerDaemonError(errors.New("exit status 1"), output, "tcp://10.0.0.5:2376", "")
+	require.ErrorContains(t, err, "Cannot connect to the Docker daemon at tcp://10.0.0.5:2376")
+	require.ErrorContains(t, err, "DOCKER_HOST is set to tcp://10.0.0.5:2376")
+
+	err = dockerDaemonError(errors.New("exit status 1"), nil, "", "rootless")
+	require.ErrorContains(t, err, "exit status 1")
+	require.ErrorContains(t, err, "docker context inspect rootless")
+
+	err = dockerDaemonError(errors.New("exit status 1"), output, "", "default")
+	require.ErrorContains(t, err, "Make sure Docker Desktop or the Docker daemon is running")
+
+	require.Equal(t, "local daemon", describeDockerDaemon("", ""))
+	require.Equal(t, "docker context colima", describeDockerDaemon("", "colima"))
+	require.Equal(t, "DOCKER_HOST=unix:///run/user/1000/docker.sock", describeDockerDaemon("unix:///run/user/1000/docker.sock", "colima"))
+}
+
+func TestWriteDryRunComposeSpecNeverContainsPAT(t *testing.T) {
+	require := require.New(t)
+
+	file := path.Join(t.TempDir(), "omnistrate-compose.yaml")
+	fileData := []byte("x-omnistrate-image-registry-attributes:\n  ghcr.io:\n    auth:\n      password: ${{ secrets.GitHubPAT }}\nservices:\n  web:\n    environment:\n      TOKEN: ghp_secret\n")
+
+	dryRunFile, err := writeDryRunComposeSpec(file, fileData, "ghp_secret")
+	require.NoError(err)
+	require.Equal(path.Join(path.Dir(file), "omnistrate-compose-dry-run.yaml"), dryRunFile)
+
+	content, err := os.ReadFile(dryRunFile)
+	require.NoError(err)
+	require.NotContains(string(content), "ghp_secret")
+	require.Contains(string(content), "password: ${{ secrets.GitHubPAT }}")
+}
+
+func TestParseEnvVars(t *testing.T) {
+	require := require.New(t)
+
+	envVars, err := ParseEnvVars([]string{"POSTGRES_PASSWORD=secret", "EMPTY=", "[]"})
+	require.NoError(err)
+	require.Len(envVars, 2)
+	require.Equal("POSTGRES_PASSWORD", envVars[0].Key)
+	require.Equal("secret", envVars[0].Value)
+	require.Equal("EMPTY", envVars[1].Key)
+	require.Equal("", envVars[1].Value)
+
+	for _, envVar := range []string{"NOVALUE", "A=b=c"} {
+		_, err = ParseEnvVars([]string{envVar})
+		require.ErrorContains(err, "invalid environment variable format", envVar)
+	}
+}
@@ -0,0 +1,98 @@
+package build
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertVolumesToConfigsSkipsLargeAndBinaryFiles(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	configDir := filepath.Join(dir, "config")
+	require.NoError(os.MkdirAll(configDir, 0755))
+	require.NoError(os.WriteFile(filepath.Join(configDir, "app.conf"), []byte("listen 8080\n"), 0600))
+	require.NoError(os.WriteFile(filepath.Join(configDir, "logo.png"), []byte{0x89, 'P', 'N', 'G', 0x00, 0x01}, 0600))
+	require.NoError(os.WriteFile(filepath.Join(configDir, "large.txt"), make([]byte, 0), 0600))
+	require.NoError(os.Truncate(filepath.Join(configDir, "large.txt"), 4096))
+	binaryFile := filepath.Join(dir, "data.bin")
+	require.NoError(os.WriteFile(binaryFile, []byte{0xff, 0xfe, 0xfd}, 0600))
+
+	project := &types.Project{
+		Services: types.Services{
+			{
+				Name: "web",
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeBind, Source: configDir, Target: "/etc/app"},
+					{Type: types.VolumeTypeBind, Source: binaryFile, Target: "/data/data.bin"},
+				},
+			},
+		},
+	}
+
+	converted, modified, report, err := convertVolumesToConfigs(project, 1024)
+	require.NoError(err)
+	require.True(modified)
+
+	require.Equal([]string{filepath.Join(configDir, "app.conf")}, report.Converted)
+	require.Equal([]skippedConfigFile{
+		{Path: filepath.Join(configDir, "large.txt"), Reason: "4 KB exceeds the 1 KB limit"},
+		{Path: filepath.Join(configDir, "logo.png"), Reason: "binary content"},
+		{Path: binaryFile, Reason: "binary content"},
+	}, report.Skipped)
+
+	service := converted.Services[0]
+	require.Len(service.Configs, 1)
+	require.Equal("/etc/app/app.conf", service.Configs[0].Target)
+	require.Len(converted.Configs, 1)
+
+	// The skipped single-file mount stays a volume
+	require.Len(service.Volumes, 1)
+	require.Equal(binaryFile, service.Volumes[0].Source)
+}
+
+func TestNewServiceBuildResult(t *testing.T) {
+	require := require.New(t)
+
+	details := model.ServicePlanVersion{PlanID: "pt-1", PlanName: "Basic", ServiceID: "s-1", ServiceName: "My Service", Environment: "Dev"}
+	result := newServiceBuildResult(details, "se-1", true, map[string]string{"worker": "r-2", "cache": "r-1"})
+
+	data, err := json.Marshal(result)
+	require.NoError(err)
+	require.JSONEq(`{
+		"plan_id": "pt-1",
+		"plan_name": "Basic",
+		"service_id": "s-1",
+		"service_name": "My Service",
+		"environment": "Dev",
+		"preferred": false,
+		"environment_id": "se-1",
+		"is_new_service": true,
+		"undefined_resources": [{"name": "cache", "id": "r-1"}, {"name": "worker", "id": "r-2"}]
+	}`, string(data))
+
+	data, err = json.Marshal(newServiceBuildResult(details, "se-1", false, nil))
+	require.NoError(err)
+	require.Contains(string(data), `"is_new_service":false`)
+	require.Contains(string(data), `"undefined_resources":[]`)
+}
+
+func TestUndefinedResourcesError(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(UndefinedResourcesError(nil))
+	require.NoError(UndefinedResourcesError(map[string]string{}))
+
+	err := UndefinedResourcesError(map[string]string{"worker": "r-2", "cache": "r-1"})
+	require.Error(err)
+	require.Equal(utils.ExitCodeValidation, utils.ExitCodeFor(err))
+	require.Contains(err.Error(), "2 resource(s)")
+	require.Contains(err.Error(), "cache (r-1), worker (r-2)")
+}
@@ -0,0 +1,96 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/pkg/errors"
+)
+
+// composeSpecGenerationAttempts is how many times generating the compose spec from an image is attempted before
+// giving up on a transient failure
+const composeSpecGenerationAttempts = 3
+
+// composeSpecGenerationRetryDelay is the wait before the first retry; each further retry waits one more delay
+var composeSpecGenerationRetryDelay = 5 * time.Second
+
+// generateComposeSpecFunc generates a compose spec from a container image, as
+// dataaccess.GenerateComposeSpecFromContainerImage does
+type generateComposeSpecFunc func(ctx context.Context, token string, request openapiclient.GenerateComposeSpecFromContainerImageRequest2) (*openapiclient.GenerateComposeSpecFromContainerImageResult, error)
+
+// generateComposeSpecWithRetry validates the image of request and generates a compose spec from it with generate,
+// retrying backend errors and timeouts. A persistent failure is returned with guidance on the usual causes, as
+// reading a private image without the right credentials is the common one.
+func generateComposeSpecWithRetry(ctx context.Context, token string, request openapiclient.GenerateComposeSpecFromContainerImageRequest2, generate generateComposeSpecFunc) (*openapiclient.GenerateComposeSpecFromContainerImageResult, error) {
+	if err := validateComposeSpecImage(request.ImageRegistry, request.Image); err != nil {
+		return nil, utils.WithExitCode(utils.ExitCodeValidation, err)
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var res *openapiclient.GenerateComposeSpecFromContainerImageResult
+		if res, err = generate(ctx, token, request); err == nil {
+			return res, nil
+		}
+		if attempt == composeSpecGenerationAttempts || !isTransientComposeSpecError(err) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, composeSpecGenerationError(request, err)
+		case <-time.After(time.Duration(attempt) * composeSpecGenerationRetryDelay):
+		}
+	}
+	return nil, composeSpecGenerationError(request, err)
+}
+
+// validateComposeSpecImage checks that the registry is a bare host and the image a repository path, with an
+// optional tag, before they are sent to generate a compose spec.
+func validateComposeSpecImage(registry, image string) error {
+	if registry == "" {
+		return errors.New("the image registry to generate the compose spec from is empty")
+	}
+	if strings.Contains(registry, "://") || strings.ContainsAny(registry, "/ \t") {
+		return errors.New(fmt.Sprintf("invalid image registry %q: expected a host name such as ghcr.io, without scheme or path", registry))
+	}
+	if image == "" {
+		return errors.New("the image to generate the compose spec from is empty")
+	}
+	if strings.ContainsAny(image, " \t\n") || strings.HasPrefix(image, "/") || strings.HasSuffix(image, "/") || strings.HasSuffix(image, ":") {
+		return errors.New(fmt.Sprintf("invalid image %q: expected a repository path with an optional tag, such as owner/repo:v1", image))
+	}
+	return nil
+}
+
+// isTransientComposeSpecError reports whether generating the compose spec may succeed when retried
+func isTransientComposeSpecError(err error) bool {
+	switch utils.ExitCodeFor(err) {
+	case utils.ExitCodeBackend, utils.ExitCodeTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// composeSpecGenerationError wraps a failure to generate the compose spec from the image of request with the
+// checks that usually resolve it. The exit code of err is kept.
+func composeSpecGenerationError(request openapiclient.GenerateComposeSpecFromContainerImageRequest2, err error) error {
+	image := request.ImageRegistry + "/" + request.Image
+
+	checks := []string{fmt.Sprintf("the image %s exists and its tag was pushed", image)}
+	if request.Password == nil {
+		checks = append(checks, "the image is public: no registry credentials were sent, so a private image cannot be read")
+	} else {
+		checks = append(checks, fmt.Sprintf("the credentials of %s can pull the image: a GitHub Personal Access Token needs the read:packages scope, and an expired one can be replaced with --reset-pat", utils.FromPtr(request.Username)))
+	}
+	if !isTransientComposeSpecError(err) {
+		checks = append(checks, "the image registry and image name are correct")
+	}
+
+	return fmt.Errorf("failed to generate the compose spec from image %s: %w\nCheck that:\n  - %s", image, err, strings.Join(checks, "\n  - "))
+}
@@ -0,0 +1,99 @@
+package build
+
+import (
+	"context"
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposeSpecGenerationError(t *testing.T) {
+	require := require.New(t)
+
+	request := openapiclient.GenerateComposeSpecFromContainerImageRequest2{
+		ImageRegistry: "ghcr.io",
+		Image:         "acme/app:v1",
+		Username:      utils.ToPtr("acme"),
+		Password:      utils.ToPtr("ghp_secret"),
+	}
+	err := composeSpecGenerationError(request, utils.WithExitCode(utils.ExitCodeAuth, errors.New("unauthorized")))
+	require.Equal(`failed to generate the compose spec from image ghcr.io/acme/app:v1: unauthorized
+Check that:
+  - the image ghcr.io/acme/app:v1 exists and its tag was pushed
+  - the credentials of acme can pull the image: a GitHub Personal Access Token needs the read:packages scope, and an expired one can be replaced with --reset-pat
+  - the image registry and image name are correct`, err.Error())
+	require.Equal(utils.ExitCodeAuth, utils.ExitCodeFor(err))
+
+	// Without credentials, only a public image can be read
+	request.Username, request.Password = nil, nil
+	err = composeSpecGenerationError(request, utils.WithExitCode(utils.ExitCodeBackend, errors.New("internal error")))
+	require.Equal(`failed to generate the compose spec from image ghcr.io/acme/app:v1: internal error
+Check that:
+  - the image ghcr.io/acme/app:v1 exists and its tag was pushed
+  - the image is public: no registry credentials were sent, so a private image cannot be read`, err.Error())
+	require.Equal(utils.ExitCodeBackend, utils.ExitCodeFor(err))
+}
+
+func TestValidateComposeSpecImage(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(validateComposeSpecImage("ghcr.io", "acme/app:v1"))
+	require.NoError(validateComposeSpecImage("localhost:5000", "app"))
+
+	for _, tc := range []struct{ registry, image string }{
+		{"", "acme/app:v1"},
+		{"https://ghcr.io", "acme/app:v1"},
+		{"ghcr.io/acme", "app:v1"},
+		{"ghcr.io", ""},
+		{"ghcr.io", "/acme/app"},
+		{"ghcr.io", "acme/app:"},
+		{"ghcr.io", "acme/my app"},
+	} {
+		require.Error(validateComposeSpecImage(tc.registry, tc.image), "registry %q image %q", tc.registry, tc.image)
+	}
+}
+
+func TestGenerateComposeSpecWithRetry(t *testing.T) {
+	require := require.New(t)
+
+	original := composeSpecGenerationRetryDelay
+	composeSpecGenerationRetryDelay = 0
+	defer func() { composeSpecGenerationRetryDelay = original }()
+
+	request := openapiclient.GenerateComposeSpecFromContainerImageRequest2{ImageRegistry: "ghcr.io", Image: "acme/app:v1"}
+	result := &openapiclient.GenerateComposeSpecFromContainerImageResult{FileContent: "c2VydmljZXM6"}
+
+	// Backend errors are retried until the call succeeds
+	calls := 0
+	res, err := generateComposeSpecWithRetry(context.Background(), "token", request, func(context.Context, string, openapiclient.GenerateComposeSpecFromContainerImageRequest2) (*openapiclient.GenerateComposeSpecFromContainerImageResult, error) {
+		calls++
+		if calls < composeSpecGenerationAttempts {
+			return nil, utils.WithExitCode(utils.ExitCodeBackend, errors.New("internal error"))
+		}
+		return result, nil
+	})
+	require.NoError(err)
+	require.Same(result, res)
+	require.Equal(composeSpecGenerationAttempts, calls)
+
+	// Other errors fail at once
+	calls = 0
+	_, err = generateComposeSpecWithRetry(context.Background(), "token", request, func(context.Context, string, openapiclient.GenerateComposeSpecFromContainerImageRequest2) (*openapiclient.GenerateComposeSpecFromContainerImageResult, error) {
+		calls++
+		return nil, utils.WithExitCode(utils.ExitCodeAuth, errors.New("unauthorized"))
+	})
+	require.Error(err)
+	require.Equal(1, calls)
+	require.Contains(err.Error(), "the image is public")
+
+	// Invalid images are rejected before the call
+	request.Image = ""
+	_, err = generateComposeSpecWithRetry(context.Background(), "token", request, func(context.Context, string, openapiclient.GenerateComposeSpecFromContainerImageRequest2) (*openapiclient.GenerateComposeSpecFromContainerImageResult, error) {
+		t.Fatal("generate must not be called for an invalid image")
+		return nil, nil
+	})
+	require.Equal(utils.ExitCodeValidation, utils.ExitCodeFor(err))
+}
@@ -0,0 +1,69 @@
+package build
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DeprecatedComposeKeys maps compose spec keys the platform has deprecated to their suggested replacement.
+// Keys are matched at any level of the spec. Add entries here ahead of hard removals.
+var DeprecatedComposeKeys = map[string]string{
+	"x-omnistrate-hosted": "x-omnistrate-service-plan.deployment.hostedDeployment",
+	"x-omnistrate-byoa":   "x-omnistrate-service-plan.deployment.byoaDeployment",
+}
+
+// DeprecatedKeyWarning describes a deprecated key found in a spec
+type DeprecatedKeyWarning struct {
+	Path        string
+	Key         string
+	Replacement string
+}
+
+func (w DeprecatedKeyWarning) String() string {
+	return fmt.Sprintf("'%s' is deprecated, use '%s' instead", w.Path, w.Replacement)
+}
+
+// FindDeprecatedKeys recursively searches a compose spec for the keys in deprecated and returns a warning for each
+// occurrence, sorted by path.
+func FindDeprecatedKeys(yamlContent map[string]interface{}, deprecated map[string]string) []DeprecatedKeyWarning {
+	var warnings []DeprecatedKeyWarning
+	findDeprecatedKeys(yamlContent, "", deprecated, &warnings)
+	sort.Slice(warnings, func(i, j int) bool {
+		return warnings[i].Path < warnings[j].Path
+	})
+	return warnings
+}
+
+func findDeprecatedKeys(m map[string]interface{}, prefix string, deprecated map[string]string, warnings *[]DeprecatedKeyWarning) {
+	for k, v := range m {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if replacement, ok := deprecated[k]; ok {
+			*warnings = append(*warnings, DeprecatedKeyWarning{Path: path, Key: k, Replacement: replacement})
+		}
+		// Recurse into nested maps
+		if sub, ok := v.(map[string]interface{}); ok {
+			findDeprecatedKeys(sub, path, deprecated, warnings)
+		}
+		// Recurse into slices of maps
+		if arr, ok := v.([]interface{}); ok {
+			for i, item := range arr {
+				if subm, ok := item.(map[string]interface{}); ok {
+					findDeprecatedKeys(subm, fmt.Sprintf("%s[%d]", path, i), deprecated, warnings)
+				}
+			}
+		}
+	}
+}
+
+// FormatDeprecatedKeyWarnings renders the warnings as one indented line each
+func FormatDeprecatedKeyWarnings(warnings []DeprecatedKeyWarning) string {
+	lines := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		lines = append(lines, "  - "+w.String())
+	}
+	return strings.Join(lines, "\n")
+}
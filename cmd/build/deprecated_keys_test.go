@@ -0,0 +1,38 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDeprecatedKeys(t *testing.T) {
+	require := require.New(t)
+
+	yamlContent := map[string]interface{}{
+		"x-omnistrate-byoa": map[string]interface{}{"AwsAccountId": "123456789012"},
+		"services": map[string]interface{}{
+			"web": map[string]interface{}{
+				"image":               "nginx",
+				"x-omnistrate-hosted": map[string]interface{}{},
+			},
+		},
+		"volumes": []interface{}{
+			map[string]interface{}{"legacy": "x"},
+		},
+	}
+
+	warnings := FindDeprecatedKeys(yamlContent, DeprecatedComposeKeys)
+	require.Len(warnings, 2)
+	require.Equal("services.web.x-omnistrate-hosted", warnings[0].Path)
+	require.Equal("x-omnistrate-service-plan.deployment.hostedDeployment", warnings[0].Replacement)
+	require.Equal("x-omnistrate-byoa", warnings[1].Path)
+
+	// The set of deprecated keys is configurable, including keys nested in lists
+	warnings = FindDeprecatedKeys(yamlContent, map[string]string{"legacy": "modern"})
+	require.Len(warnings, 1)
+	require.Equal("volumes[0].legacy", warnings[0].Path)
+	require.Equal("  - 'volumes[0].legacy' is deprecated, use 'modern' instead", FormatDeprecatedKeyWarnings(warnings))
+
+	require.Empty(FindDeprecatedKeys(map[string]interface{}{"services": map[string]interface{}{}}, DeprecatedComposeKeys))
+}
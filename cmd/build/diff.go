@@ -0,0 +1,241 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffExample = `# Compare two versions of a service plan
+omnistrate-ctl build diff --service [service-id] --plan [plan-id] --from 1.0 --to 2.0
+
+# Review what promoting the latest version would change
+omnistrate-ctl build diff --service [service-id] --plan [plan-id] --from preferred --to latest --output json`
+
+	planVersionChangeAdded    = "added"
+	planVersionChangeRemoved  = "removed"
+	planVersionChangeModified = "modified"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff --service=[service-id] --plan=[plan-id] --from=[version] --to=[version]",
+	Short: "Compare two versions of a service plan",
+	Long: `This command compares two versions of a service plan and lists the resources, input parameters and image tags that were added, removed or modified.
+Use it to review a version before setting it as preferred. Versions can be given as version numbers or as latest or preferred.`,
+	Example:      diffExample,
+	RunE:         runDiff,
+	SilenceUsage: true,
+}
+
+// planVersionResource is the part of a resource that is compared between service plan versions.
+type planVersionResource struct {
+	Name       string
+	Type       string
+	Image      string
+	Parameters map[string]string
+}
+
+func init() {
+	BuildCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().String("service", "", "Service ID")
+	diffCmd.Flags().String("plan", "", "Service plan ID")
+	diffCmd.Flags().String("from", "", "Version to compare from (latest|preferred|1.0 etc.)")
+	diffCmd.Flags().String("to", "", "Version to compare to (latest|preferred|1.0 etc.)")
+
+	for _, flag := range []string{"service", "plan", "from", "to"} {
+		if err := diffCmd.MarkFlagRequired(flag); err != nil {
+			return
+		}
+	}
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	serviceID, _ := cmd.Flags().GetString("service")
+	planID, _ := cmd.Flags().GetString("plan")
+	fromVersion, _ := cmd.Flags().GetString("from")
+	toVersion, _ := cmd.Flags().GetString("to")
+	output, _ := cmd.Flags().GetString("output")
+
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	var sm utils.SpinnerManager
+	var spinner *utils.Spinner
+	if output != "json" {
+		sm = utils.NewSpinnerManager()
+		spinner = sm.AddSpinner("Comparing service plan versions...")
+		sm.Start()
+	}
+
+	fromVersion, err = resolvePlanVersion(cmd.Context(), token, serviceID, planID, fromVersion)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+	toVersion, err = resolvePlanVersion(cmd.Context(), token, serviceID, planID, toVersion)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	fromResources, err := loadPlanVersionResources(cmd.Context(), token, serviceID, planID, fromVersion)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+	toResources, err := loadPlanVersionResources(cmd.Context(), token, serviceID, planID, toVersion)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	changes := diffPlanVersionResources(fromResources, toResources)
+	utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Compared versions %s and %s", fromVersion, toVersion))
+
+	if len(changes) == 0 && output != "json" {
+		utils.PrintInfo(fmt.Sprintf("No differences between versions %s and %s", fromVersion, toVersion))
+		return nil
+	}
+
+	return utils.PrintTextTableJsonArrayOutput(output, changes)
+}
+
+func resolvePlanVersion(ctx context.Context, token, serviceID, planID, version string) (string, error) {
+	switch version {
+	case "latest":
+		return dataaccess.FindLatestVersion(ctx, token, serviceID, planID)
+	case "preferred":
+		return dataaccess.FindPreferredVersion(ctx, token, serviceID, planID)
+	default:
+		return version, nil
+	}
+}
+
+// loadPlanVersionResources fetches the resources of a service plan version keyed by resource key,
+// with their image and input parameters.
+func loadPlanVersionResources(ctx context.Context, token, serviceID, planID, version string) (map[string]planVersionResource, error) {
+	versionSet, err := dataaccess.DescribeVersionSet(ctx, token, serviceID, planID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make(map[string]planVersionResource)
+	for _, versionSetResource := range versionSet.Resources {
+		desRes, err := dataaccess.DescribeResource(ctx, token, serviceID, versionSetResource.Id, utils.ToPtr(planID), utils.ToPtr(version))
+		if err != nil {
+			return nil, err
+		}
+
+		resource := planVersionResource{
+			Name:       desRes.Name,
+			Type:       desRes.ResourceType,
+			Parameters: make(map[string]string),
+		}
+
+		if imageConfigID := utils.FromPtr(desRes.ImageConfigId); imageConfigID != "" {
+			imageConfig, err := dataaccess.DescribeImageConfig(ctx, token, serviceID, imageConfigID)
+			if err != nil {
+				return nil, err
+			}
+			resource.Image = fmt.Sprintf("%s:%s", imageConfig.ImageName, imageConfig.ImageTag)
+		}
+
+		params, err := dataaccess.ListInputParameters(ctx, token, serviceID, versionSetResource.Id, planID, version)
+		if err != nil {
+			return nil, err
+		}
+		for _, param := range params.InputParameters {
+			resource.Parameters[param.Key] = describeInputParameter(param)
+		}
+
+		key := desRes.Key
+		if key == "" {
+			key = desRes.Name
+		}
+		resources[key] = resource
+	}
+
+	return resources, nil
+}
+
+func describeInputParameter(param openapiclient.DescribeInputParameterResult) string {
+	return fmt.Sprintf("type=%s, required=%t, modifiable=%t, default=%s",
+		param.Type, param.Required, param.Modifiable, utils.FromPtr(param.DefaultValue))
+}
+
+// diffPlanVersionResources lists the differences between two sets of resources, ordered by resource key.
+func diffPlanVersionResources(from, to map[string]planVersionResource) []model.ServicePlanVersionChange {
+	changes := make([]model.ServicePlanVersionChange, 0)
+
+	for _, key := range unionKeys(from, to) {
+		fromRes, inFrom := from[key]
+		toRes, inTo := to[key]
+
+		switch {
+		case !inFrom:
+			changes = append(changes, model.ServicePlanVersionChange{Resource: key, Kind: "resource", Change: planVersionChangeAdded, To: toRes.Type})
+			continue
+		case !inTo:
+			changes = append(changes, model.ServicePlanVersionChange{Resource: key, Kind: "resource", Change: planVersionChangeRemoved, From: fromRes.Type})
+			continue
+		}
+
+		if fromRes.Type != toRes.Type {
+			changes = append(changes, model.ServicePlanVersionChange{Resource: key, Kind: "type", Change: planVersionChangeModified, From: fromRes.Type, To: toRes.Type})
+		}
+		if change, ok := diffValue(key, "image", "", fromRes.Image, toRes.Image); ok {
+			changes = append(changes, change)
+		}
+		for _, param := range unionKeys(fromRes.Parameters, toRes.Parameters) {
+			if change, ok := diffValue(key, "parameter", param, fromRes.Parameters[param], toRes.Parameters[param]); ok {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	return changes
+}
+
+func diffValue(resource, kind, name, from, to string) (model.ServicePlanVersionChange, bool) {
+	change := model.ServicePlanVersionChange{Resource: resource, Kind: kind, Name: name, From: from, To: to}
+	switch {
+	case from == to:
+		return change, false
+	case from == "":
+		change.Change = planVersionChangeAdded
+	case to == "":
+		change.Change = planVersionChangeRemoved
+	default:
+		change.Change = planVersionChangeModified
+	}
+	return change, true
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
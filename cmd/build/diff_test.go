@@ -0,0 +1,55 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPlanVersionResources(t *testing.T) {
+	from := map[string]planVersionResource{
+		"web": {
+			Name:  "Web",
+			Type:  "Container",
+			Image: "nginx:1.25",
+			Parameters: map[string]string{
+				"replicas": "type=Float64, required=false, modifiable=true, default=1",
+				"legacy":   "type=String, required=false, modifiable=true, default=",
+			},
+		},
+		"worker": {Name: "Worker", Type: "Container", Parameters: map[string]string{}},
+	}
+	to := map[string]planVersionResource{
+		"web": {
+			Name:  "Web",
+			Type:  "Container",
+			Image: "nginx:1.27",
+			Parameters: map[string]string{
+				"replicas": "type=Float64, required=false, modifiable=true, default=3",
+				"region":   "type=String, required=true, modifiable=false, default=",
+			},
+		},
+		"cache": {Name: "Cache", Type: "HelmChart", Parameters: map[string]string{}},
+	}
+
+	changes := diffPlanVersionResources(from, to)
+	require.Equal(t, []model.ServicePlanVersionChange{
+		{Resource: "cache", Kind: "resource", Change: "added", To: "HelmChart"},
+		{Resource: "web", Kind: "image", Change: "modified", From: "nginx:1.25", To: "nginx:1.27"},
+		{Resource: "web", Kind: "parameter", Name: "legacy", Change: "removed", From: "type=String, required=false, modifiable=true, default="},
+		{Resource: "web", Kind: "parameter", Name: "region", Change: "added", To: "type=String, required=true, modifiable=false, default="},
+		{Resource: "web", Kind: "parameter", Name: "replicas", Change: "modified", From: "type=Float64, required=false, modifiable=true, default=1", To: "type=Float64, required=false, modifiable=true, default=3"},
+		{Resource: "worker", Kind: "resource", Change: "removed", From: "Container"},
+	}, changes)
+}
+
+func TestDiffPlanVersionResourcesNoChanges(t *testing.T) {
+	resources := map[string]planVersionResource{
+		"web": {Name: "Web", Type: "Container", Image: "nginx:1.25", Parameters: map[string]string{"replicas": "type=Float64"}},
+	}
+
+	changes := diffPlanVersionResources(resources, resources)
+	require.NotNil(t, changes)
+	require.Empty(t, changes)
+}
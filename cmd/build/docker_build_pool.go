@@ -0,0 +1,165 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// dockerBuildConcurrency bounds the number of images built and pushed at once
+const dockerBuildConcurrency = 4
+
+// dockerImageBuild describes the image of one service to build and push.
+type dockerImageBuild struct {
+	Service        string
+	DockerfilePath string
+	ContextDir     string
+	ImageURL       string
+	Platforms      string
+	CacheFrom      []string
+	CacheTo        []string
+	Labels         []string // key=value labels passed to buildx
+}
+
+type dockerImageBuilder func(ctx context.Context, build dockerImageBuild, out io.Writer) (string, error)
+
+// buildDockerImages runs builder for every build with at most dockerBuildConcurrency builds in flight and
+// returns the version-tagged image URL of each service. A single build writes straight to stdout; with
+// several builds the output of each service is buffered and printed in order once it completes, so the
+// logs do not interleave. The first failure cancels the remaining builds.
+func buildDockerImages(ctx context.Context, builds []dockerImageBuild, builder dockerImageBuilder) (map[string]string, error) {
+	imageURLs := make(map[string]string, len(builds))
+
+	if len(builds) == 1 {
+		imageURL, err := builder(ctx, builds[0], os.Stdout)
+		if err != nil {
+			return nil, err
+		}
+		imageURLs[builds[0].Service] = imageURL
+		return imageURLs, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	outputs := make([]bytes.Buffer, len(builds))
+	done := make([]chan struct{}, len(builds))
+	sem := make(chan struct{}, dockerBuildConcurrency)
+
+	fmt.Printf("Building %d Docker images, up to %d at a time...\n", len(builds), dockerBuildConcurrency)
+	for i := range builds {
+		done[i] = make(chan struct{})
+		go func(i int) {
+			defer close(done[i])
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			imageURL, err := builder(ctx, builds[i], &outputs[i])
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err, "failed to build image for service %s", builds[i].Service)
+					cancel()
+				}
+				return
+			}
+			imageURLs[builds[i].Service] = imageURL
+		}(i)
+	}
+
+	for i, build := range builds {
+		<-done[i]
+		fmt.Printf("==> %s (%s)\n%s", build.Service, build.ImageURL, outputs[i].String())
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return imageURLs, nil
+}
+
+// newDockerImageBuilder returns a builder that builds the image, and unless dryRun is set pushes it, tags it
+// with its digest and pushes the digest tag.
+func newDockerImageBuilder(dryRun, rawOutput bool) dockerImageBuilder {
+	return func(ctx context.Context, build dockerImageBuild, out io.Writer) (string, error) {
+		buildArgs := BuildDockerBuildArgs(build.Platforms, build.ContextDir, build.DockerfilePath, build.ImageURL, build.CacheFrom, build.CacheTo)
+		for _, label := range build.Labels {
+			buildArgs = append(buildArgs, "--label", label)
+		}
+		if !rawOutput {
+			buildArgs = append(buildArgs, "--progress", "plain")
+		}
+
+		fmt.Fprintf(out, "Invoking 'docker %s'...\n", strings.Join(buildArgs, " "))
+		if err := runDockerCommand(exec.CommandContext(ctx, "docker", buildArgs...), out, "Building", rawOutput, newBuildxProgress()); err != nil {
+			return "", err
+		}
+
+		// In dry-run mode, skip pushing to registry and use local image tag
+		if dryRun {
+			fmt.Fprintln(out, "Dry run: Using local image tag (skipping push)")
+			return fmt.Sprintf("%s:latest", build.ImageURL), nil
+		}
+
+		// Push docker image to GitHub Container Registry
+		fmt.Fprintf(out, "Invoking 'docker push %s'...\n", build.ImageURL)
+		if err := runDockerCommand(exec.CommandContext(ctx, "docker", "push", build.ImageURL), out, "Pushing", rawOutput, newPushProgress()); err != nil {
+			return "", err
+		}
+
+		// Retrieve the digest
+		digestOutput, err := exec.CommandContext(ctx, "docker", "buildx", "imagetools", "inspect", build.ImageURL).Output()
+		if err != nil {
+			return "", err
+		}
+		digest, err := parseImageDigest(string(digestOutput))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(out, "Retrieved digest: %s\n", digest)
+
+		// Tag the image with the digest
+		imageURLWithDigestTag := fmt.Sprintf("%s:%s", build.ImageURL, digest)
+		tagCmd := exec.CommandContext(ctx, "docker", "tag", build.ImageURL, imageURLWithDigestTag)
+		tagCmd.Stdout = out
+		tagCmd.Stderr = out
+
+		fmt.Fprintf(out, "Invoking 'docker tag %s %s'...\n", build.ImageURL, imageURLWithDigestTag)
+		if err = tagCmd.Run(); err != nil {
+			return "", err
+		}
+
+		// Push the image with the digest tag
+		fmt.Fprintf(out, "Invoking 'docker push %s'...\n", imageURLWithDigestTag)
+		if err = runDockerCommand(exec.CommandContext(ctx, "docker", "push", imageURLWithDigestTag), out, "Pushing", rawOutput, newPushProgress()); err != nil {
+			return "", err
+		}
+
+		return imageURLWithDigestTag, nil
+	}
+}
+
+// parseImageDigest extracts the digest tag, e.g. sha-0123abcd, from `docker buildx imagetools inspect` output.
+func parseImageDigest(output string) (string, error) {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "Digest:") {
+			parts := strings.Split(line, ":")
+			if len(parts) < 3 {
+				break
+			}
+			return fmt.Sprintf("sha-%s", strings.TrimSpace(parts[2])), nil
+		}
+	}
+	return "", errors.New("unable to retrieve the digest")
+}
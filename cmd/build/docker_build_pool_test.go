@@ -0,0 +1,71 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDockerImagesBoundsConcurrency(t *testing.T) {
+	require := require.New(t)
+
+	var builds []dockerImageBuild
+	for i := 0; i < 10; i++ {
+		builds = append(builds, dockerImageBuild{Service: fmt.Sprintf("svc-%d", i), ImageURL: fmt.Sprintf("ghcr.io/acme/svc-%d", i)})
+	}
+
+	var inFlight, maxInFlight int32
+	imageURLs, err := buildDockerImages(context.Background(), builds, func(ctx context.Context, build dockerImageBuild, out io.Writer) (string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+
+		fmt.Fprintf(out, "built %s\n", build.Service)
+		return build.ImageURL + ":sha-1", nil
+	})
+	require.NoError(err)
+	require.Len(imageURLs, 10)
+	require.Equal("ghcr.io/acme/svc-3:sha-1", imageURLs["svc-3"])
+	require.LessOrEqual(maxInFlight, int32(dockerBuildConcurrency))
+}
+
+func TestBuildDockerImagesCancelsOnFailure(t *testing.T) {
+	require := require.New(t)
+
+	builds := []dockerImageBuild{{Service: "api"}, {Service: "worker"}}
+	_, err := buildDockerImages(context.Background(), builds, func(ctx context.Context, build dockerImageBuild, out io.Writer) (string, error) {
+		if build.Service == "api" {
+			return "", errors.New("build failed")
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(5 * time.Second):
+			return "ghcr.io/acme/worker:sha-1", nil
+		}
+	})
+	require.EqualError(err, "failed to build image for service api: build failed")
+}
+
+func TestParseImageDigest(t *testing.T) {
+	require := require.New(t)
+
+	digest, err := parseImageDigest("Name:      ghcr.io/acme/app:latest\nMediaType: application/vnd.oci.image.index.v1+json\nDigest:    sha256:0123abcd\n")
+	require.NoError(err)
+	require.Equal("sha-0123abcd", digest)
+
+	_, err = parseImageDigest("Name: ghcr.io/acme/app:latest\n")
+	require.Error(err)
+}
@@ -0,0 +1,70 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// dockerClientConfig is the part of the docker client configuration, ~/.docker/config.json, that records where
+// registry credentials come from.
+type dockerClientConfig struct {
+	Auths       map[string]json.RawMessage `json:"auths"`
+	CredsStore  string                     `json:"credsStore"`
+	CredHelpers map[string]string          `json:"credHelpers"`
+}
+
+// dockerConfigDir returns the directory of the docker client configuration: $DOCKER_CONFIG, or ~/.docker.
+func dockerConfigDir() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker"), nil
+}
+
+// checkDockerRegistryCredentials verifies that the docker client configuration in dir provides credentials for
+// registry, stored by 'docker login' or through a credential helper, so images can be pushed without logging in.
+func checkDockerRegistryCredentials(dir, registry string) error {
+	configPath := filepath.Join(dir, "config.json")
+	data, err := os.ReadFile(configPath) //nolint:gosec // docker client configuration of the user
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.New(fmt.Sprintf("no docker credentials found: %s does not exist. Run 'docker login %s' or configure a credential helper, or build without --use-docker-credentials", configPath, registry))
+		}
+		return errors.Wrapf(err, "failed to read %s", configPath)
+	}
+
+	var dockerConfig dockerClientConfig
+	if err = json.Unmarshal(data, &dockerConfig); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", configPath)
+	}
+
+	// A credentials store serves every registry, so whether it has credentials for this one cannot be told here
+	if dockerConfig.CredsStore != "" || dockerConfig.CredHelpers[registry] != "" {
+		return nil
+	}
+	for host := range dockerConfig.Auths {
+		if strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://"), "/") == registry {
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("no docker credentials for %s found in %s. Run 'docker login %s' or configure a credential helper, or build without --use-docker-credentials", registry, configPath, registry))
+}
+
+// dockerCredentialsUsername returns the registry username used with --use-docker-credentials: --github-username
+// if set, otherwise the repository owner.
+func dockerCredentialsUsername(cmd *cobra.Command, repoOwner string) string {
+	if username, _ := cmd.Flags().GetString("github-username"); username != "" {
+		return username
+	}
+	return repoOwner
+}
@@ -0,0 +1,47 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDockerRegistryCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{name: "auths entry", config: `{"auths": {"ghcr.io": {"auth": "dXNlcjpwYXNz"}}}`},
+		{name: "auths entry with scheme", config: `{"auths": {"https://ghcr.io/": {}}}`},
+		{name: "credential helper for the registry", config: `{"credHelpers": {"ghcr.io": "gh"}}`},
+		{name: "credentials store", config: `{"credsStore": "desktop"}`},
+		{name: "other registry only", config: `{"auths": {"docker.io": {}}, "credHelpers": {"gcr.io": "gcloud"}}`, wantErr: "no docker credentials for ghcr.io"},
+		{name: "malformed", config: `{`, wantErr: "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(tt.config), 0600))
+
+			err := checkDockerRegistryCredentials(dir, "ghcr.io")
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	err := checkDockerRegistryCredentials(t.TempDir(), "ghcr.io")
+	require.ErrorContains(t, err, "does not exist")
+}
+
+func TestDockerConfigDir(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", "/tmp/docker-config")
+	dir, err := dockerConfigDir()
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/docker-config", dir)
+}
@@ -0,0 +1,227 @@
+package build
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"golang.org/x/term"
+)
+
+const (
+	// dockerOutputTailLines is the number of docker output lines replayed when a command fails
+	dockerOutputTailLines = 30
+	dockerProgressWidth   = 40
+	dockerStatusMaxLength = 60
+)
+
+var (
+	// buildxStepPattern matches buildx plain progress step lines, e.g. "#8 [build 2/5] RUN go mod download"
+	buildxStepPattern = regexp.MustCompile(`^#(\d+) \[(?:(.*?)\s+)?(\d+)/(\d+)\]\s*(.*)$`)
+	// buildxDonePattern matches buildx plain progress lines that finish a step
+	buildxDonePattern = regexp.MustCompile(`^#(\d+) (DONE|CACHED)\b`)
+	// pushLayerPattern matches docker push layer status lines, e.g. "5f70bf18a086: Pushed"
+	pushLayerPattern = regexp.MustCompile(`^([0-9a-f]{12}): (.+)$`)
+)
+
+// dockerProgressTracker turns the output lines of a docker command into a completion fraction.
+type dockerProgressTracker interface {
+	observe(line string)
+	percent() float64
+	status() string
+}
+
+// buildxProgress tracks the steps of a `docker buildx build --progress=plain`.
+type buildxProgress struct {
+	stageTotals map[string]int
+	steps       map[string]bool // vertex -> done
+	lastStep    string
+}
+
+func newBuildxProgress() *buildxProgress {
+	return &buildxProgress{
+		stageTotals: make(map[string]int),
+		steps:       make(map[string]bool),
+	}
+}
+
+func (p *buildxProgress) observe(line string) {
+	if m := buildxStepPattern.FindStringSubmatch(line); m != nil {
+		total, _ := strconv.Atoi(m[4])
+		if total > p.stageTotals[m[2]] {
+			p.stageTotals[m[2]] = total
+		}
+		if _, ok := p.steps[m[1]]; !ok {
+			p.steps[m[1]] = false
+		}
+		p.lastStep = fmt.Sprintf("[%s/%s] %s", m[3], m[4], m[5])
+		return
+	}
+	if m := buildxDonePattern.FindStringSubmatch(line); m != nil {
+		if _, ok := p.steps[m[1]]; ok {
+			p.steps[m[1]] = true
+		}
+	}
+}
+
+func (p *buildxProgress) percent() float64 {
+	total := 0
+	for _, stageTotal := range p.stageTotals {
+		total += stageTotal
+	}
+	if total == 0 {
+		return 0
+	}
+	done := 0
+	for _, stepDone := range p.steps {
+		if stepDone {
+			done++
+		}
+	}
+	return min(float64(done)/float64(total), 1)
+}
+
+func (p *buildxProgress) status() string {
+	return p.lastStep
+}
+
+// pushProgress tracks the layers of a `docker push`.
+type pushProgress struct {
+	layers map[string]bool // layer -> done
+}
+
+func newPushProgress() *pushProgress {
+	return &pushProgress{layers: make(map[string]bool)}
+}
+
+func (p *pushProgress) observe(line string) {
+	m := pushLayerPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return
+	}
+	status := m[2]
+	p.layers[m[1]] = p.layers[m[1]] ||
+		strings.HasPrefix(status, "Pushed") ||
+		strings.HasPrefix(status, "Layer already exists") ||
+		strings.HasPrefix(status, "Mounted from")
+}
+
+func (p *pushProgress) done() int {
+	done := 0
+	for _, layerDone := range p.layers {
+		if layerDone {
+			done++
+		}
+	}
+	return done
+}
+
+func (p *pushProgress) percent() float64 {
+	if len(p.layers) == 0 {
+		return 0
+	}
+	return float64(p.done()) / float64(len(p.layers))
+}
+
+func (p *pushProgress) status() string {
+	if len(p.layers) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d layers pushed", p.done(), len(p.layers))
+}
+
+// runDockerCommand runs a docker command and writes its output to out. With rawOutput the docker output is
+// copied as is; otherwise it is consumed by tracker and rendered as a single progress bar, and the last lines
+// of output are printed if the command fails.
+func runDockerCommand(cmd *exec.Cmd, out io.Writer, title string, rawOutput bool, tracker dockerProgressTracker) error {
+	if rawOutput {
+		cmd.Stdout = out
+		cmd.Stderr = out
+		return cmd.Run()
+	}
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		_ = pw.CloseWithError(err)
+		waitErr <- err
+	}()
+
+	// The progress bar is only redrawn in place when writing straight to a terminal
+	errOut := out
+	redraw := false
+	if out == io.Writer(os.Stdout) {
+		errOut = os.Stderr
+		redraw = dockerOutputIsTerminal()
+	}
+
+	tail := renderDockerProgress(out, pr, title, tracker, redraw)
+	_, _ = io.Copy(io.Discard, pr)
+
+	if err := <-waitErr; err != nil {
+		fmt.Fprintf(errOut, "Last %d lines of docker output:\n%s\n", len(tail), strings.Join(tail, "\n"))
+		return err
+	}
+	return nil
+}
+
+// renderDockerProgress feeds every line of r to tracker and draws the progress bar to w. On a terminal the bar
+// is redrawn in place; otherwise a line is printed whenever the status changes. It returns the last lines read.
+func renderDockerProgress(w io.Writer, r io.Reader, title string, tracker dockerProgressTracker, redraw bool) []string {
+	bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(dockerProgressWidth))
+
+	tail := make([]string, 0, dockerOutputTailLines)
+	lastStatus := ""
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(tail) == dockerOutputTailLines {
+			tail = tail[1:]
+		}
+		tail = append(tail, line)
+
+		tracker.observe(line)
+		status := truncateDockerStatus(tracker.status())
+		if redraw {
+			fmt.Fprintf(w, "\r\033[2K%s %s %s", title, bar.ViewAs(tracker.percent()), status)
+		} else if status != lastStatus {
+			fmt.Fprintf(w, "%s %3.0f%% %s\n", title, tracker.percent()*100, status)
+		}
+		lastStatus = status
+	}
+
+	if redraw {
+		fmt.Fprintf(w, "\r\033[2K%s %s %s\n", title, bar.ViewAs(tracker.percent()), truncateDockerStatus(tracker.status()))
+	}
+	return tail
+}
+
+func truncateDockerStatus(status string) string {
+	status = strings.Join(strings.Fields(status), " ")
+	if len(status) > dockerStatusMaxLength {
+		return status[:dockerStatusMaxLength-3] + "..."
+	}
+	return status
+}
+
+func dockerOutputIsTerminal() bool {
+	fd := os.Stdout.Fd()
+	if fd > uintptr(^uint(0)>>1) {
+		return false
+	}
+	return term.IsTerminal(int(fd))
+}
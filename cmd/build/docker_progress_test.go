@@ -0,0 +1,75 @@
+package build
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildxProgress(t *testing.T) {
+	require := require.New(t)
+
+	p := newBuildxProgress()
+	require.Equal(0.0, p.percent())
+
+	for _, line := range []string{
+		"#1 [internal] load build definition from Dockerfile",
+		"#1 DONE 0.0s",
+		"#5 [build 1/2] FROM docker.io/library/golang:1.25",
+		"#5 CACHED",
+		"#6 [build 2/2] RUN go build ./...",
+		"#6 DONE 12.3s",
+		"#7 [stage-1 1/2] FROM gcr.io/distroless/static",
+		"#7 DONE 0.4s",
+		"#8 [stage-1 2/2] COPY --from=build /app /app",
+	} {
+		p.observe(line)
+	}
+
+	require.Equal(0.75, p.percent())
+	require.Equal("[2/2] COPY --from=build /app /app", p.status())
+
+	p.observe("#8 DONE 0.1s")
+	require.Equal(1.0, p.percent())
+}
+
+func TestPushProgress(t *testing.T) {
+	require := require.New(t)
+
+	p := newPushProgress()
+	require.Equal("", p.status())
+
+	for _, line := range []string{
+		"The push refers to repository [ghcr.io/acme/app]",
+		"5f70bf18a086: Preparing",
+		"a1b2c3d4e5f6: Preparing",
+		"0123456789ab: Preparing",
+		"5f70bf18a086: Layer already exists",
+		"a1b2c3d4e5f6: Pushed",
+		"0123456789ab: Pushing [==>      ]  1.2MB/10MB",
+	} {
+		p.observe(line)
+	}
+
+	require.InDelta(2.0/3.0, p.percent(), 0.001)
+	require.Equal("2/3 layers pushed", p.status())
+}
+
+func TestRenderDockerProgress(t *testing.T) {
+	require := require.New(t)
+
+	var lines []string
+	for i := 0; i < dockerOutputTailLines+5; i++ {
+		lines = append(lines, "0123456789ab: Waiting")
+	}
+	lines = append(lines, "0123456789ab: Pushed")
+
+	var out bytes.Buffer
+	tail := renderDockerProgress(&out, strings.NewReader(strings.Join(lines, "\n")), "Pushing", newPushProgress(), false)
+
+	require.Len(tail, dockerOutputTailLines)
+	require.Equal("0123456789ab: Pushed", tail[len(tail)-1])
+	require.Equal("Pushing   0% 0/1 layers pushed\nPushing 100% 1/1 layers pushed\n", out.String())
+}
@@ -0,0 +1,133 @@
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/pkg/errors"
+)
+
+const githubPATCheckTimeout = 10 * time.Second
+
+var (
+	// githubAPIURL is the GitHub API the PAT scopes are verified against
+	githubAPIURL = "https://api.github.com"
+
+	// requiredGitHubPATScopes are the classic PAT scopes needed to push images to GitHub Container Registry
+	requiredGitHubPATScopes = []string{"write:packages", "delete:packages"}
+)
+
+// ValidateGitHubPATScopes verifies that a GitHub Personal Access Token is valid and has the scopes needed to push
+// images to GitHub Container Registry.
+func ValidateGitHubPATScopes(ctx context.Context, pat string) error {
+	_, err := validateGitHubPATScopes(ctx, http.DefaultClient, pat)
+	return err
+}
+
+// getGitHubUser calls the GitHub API for the user a Personal Access Token belongs to. The login is only set when
+// GitHub answers with 200 OK; the response is returned so callers can check its status and headers.
+func getGitHubUser(ctx context.Context, client *http.Client, pat string) (res *http.Response, login string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, githubPATCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubAPIURL+"/user", nil)
+	if err != nil {
+		return nil, "", err
+	}
+	// The token is only ever sent in a request header, never as a command line argument
+	req.Header.Set("Authorization", "token "+pat)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	res, err = client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		var user struct {
+			Login string `json:"login"`
+		}
+		if json.NewDecoder(res.Body).Decode(&user) == nil {
+			login = user.Login
+		}
+	}
+	return res, login, nil
+}
+
+// validateGitHubPATScopes verifies that a GitHub Personal Access Token is valid and has the scopes needed to
+// push images, so a bad token fails before the image is built rather than on docker push. Tokens that do not
+// report scopes, such as fine-grained tokens, are accepted as is. If GitHub cannot be reached, a warning is
+// printed and the token is accepted. It returns the login of the token owner, which is empty when GitHub did
+// not report one.
+func validateGitHubPATScopes(ctx context.Context, client *http.Client, pat string) (login string, err error) {
+	res, login, err := getGitHubUser(ctx, client, pat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, " Warning: Could not verify the GitHub Personal Access Token scopes: %v\n", err)
+		return "", nil
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		return "", errors.New(fmt.Sprintf("the GitHub Personal Access Token is invalid or expired. Generate a new token (classic) with the scopes %s at %s and rerun with --reset-pat",
+			strings.Join(requiredGitHubPATScopes, ", "), GitHubPATGenerateURL))
+	}
+	if res.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, " Warning: Could not verify the GitHub Personal Access Token scopes: GitHub API returned %s\n", res.Status)
+		return "", nil
+	}
+
+	scopesHeader, ok := res.Header["X-Oauth-Scopes"]
+	if !ok {
+		return login, nil
+	}
+
+	granted := make(map[string]bool)
+	for _, header := range scopesHeader {
+		for _, scope := range strings.Split(header, ",") {
+			granted[strings.TrimSpace(scope)] = true
+		}
+	}
+
+	var missing []string
+	for _, scope := range requiredGitHubPATScopes {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return "", errors.New(fmt.Sprintf("the GitHub Personal Access Token is missing the required scopes: %s. Generate a new token (classic) with the scopes %s at %s and rerun with --reset-pat",
+			strings.Join(missing, ", "), strings.Join(requiredGitHubPATScopes, ", "), GitHubPATGenerateURL))
+	}
+	return login, nil
+}
+
+// renderGitHubPAT fills in ${{ secrets.GitHubPAT }} in a compose spec. Only the spec sent to the API is rendered, so
+// the PAT is never written to disk.
+func renderGitHubPAT(fileData []byte, pat string) []byte {
+	if !strings.Contains(string(fileData), "${{ secrets.GitHubPAT }}") {
+		return fileData
+	}
+	return []byte(strings.ReplaceAll(string(fileData), "${{ secrets.GitHubPAT }}", pat))
+}
+
+// maskGitHubPAT replaces every occurrence of the PAT in text with ${{ secrets.GitHubPAT }}.
+func maskGitHubPAT(text, pat string) string {
+	if pat == "" {
+		return text
+	}
+	return strings.ReplaceAll(text, pat, "${{ secrets.GitHubPAT }}")
+}
+
+// scrubGitHubPATError returns err with the PAT masked in its message, keeping its exit code.
+func scrubGitHubPATError(err error, pat string) error {
+	if err == nil || pat == "" || !strings.Contains(err.Error(), pat) {
+		return err
+	}
+	return utils.WithExitCode(utils.ExitCodeFor(err), errors.New(maskGitHubPAT(err.Error(), pat)))
+}
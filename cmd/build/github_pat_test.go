@@ -0,0 +1,70 @@
+package build
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGitHubPATScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "token valid":
+			w.Header().Set("X-OAuth-Scopes", "delete:packages, read:org, write:packages")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+			return
+		case "token read-only":
+			w.Header().Set("X-OAuth-Scopes", "read:packages, read:org")
+		case "token fine-grained":
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	original := githubAPIURL
+	githubAPIURL = server.URL
+	t.Cleanup(func() { githubAPIURL = original })
+
+	ctx := context.Background()
+	login, err := validateGitHubPATScopes(ctx, server.Client(), "valid")
+	require.NoError(t, err)
+	require.Equal(t, "octocat", login)
+
+	login, err = validateGitHubPATScopes(ctx, server.Client(), "fine-grained")
+	require.NoError(t, err)
+	require.Empty(t, login)
+
+	_, err = validateGitHubPATScopes(ctx, server.Client(), "read-only")
+	require.ErrorContains(t, err, "missing the required scopes: write:packages, delete:packages")
+	require.ErrorContains(t, err, GitHubPATGenerateURL)
+
+	_, err = validateGitHubPATScopes(ctx, server.Client(), "revoked")
+	require.ErrorContains(t, err, "invalid or expired")
+}
+
+func TestGitHubPATMasking(t *testing.T) {
+	require := require.New(t)
+
+	spec := []byte("auth:\n  password: ${{ secrets.GitHubPAT }}\n")
+	rendered := renderGitHubPAT(spec, "ghp_secret")
+	require.Equal("auth:\n  password: ghp_secret\n", string(rendered))
+	require.Equal(string(spec), maskGitHubPAT(string(rendered), "ghp_secret"))
+	require.Equal("unchanged", maskGitHubPAT("unchanged", ""))
+
+	err := utils.WithExitCode(utils.ExitCodeValidation, errors.New("invalid spec: password: ghp_secret"))
+	scrubbed := scrubGitHubPATError(err, "ghp_secret")
+	require.EqualError(scrubbed, "invalid spec: password: ${{ secrets.GitHubPAT }}")
+	require.Equal(utils.ExitCodeValidation, utils.ExitCodeFor(scrubbed))
+
+	other := errors.New("service not found")
+	require.Same(other, scrubGitHubPATError(other, "ghp_secret"))
+}
@@ -0,0 +1,237 @@
+package build
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"golang.org/x/term"
+)
+
+// repoBuildState records the images a repo build has pushed, keyed by repository and commit, so that a build
+// interrupted by a failed push, or by a failure after the pushes, can resume without building and pushing the
+// images again. It is removed once the service is built.
+type repoBuildState struct {
+	Repository  string            `json:"repository"`
+	Commit      string            `json:"commit"`
+	Fingerprint string            `json:"fingerprint"`
+	ImageURLs   map[string]string `json:"imageUrls"` // service -> image url with digest tag
+	UpdatedAt   time.Time         `json:"updatedAt"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// repoBuildStateDir returns the directory the state of interrupted repo builds is kept in
+func repoBuildStateDir() string {
+	return filepath.Join(config.ConfigDir(), "build-state")
+}
+
+// repoBuildStatePath returns the state file of the build of a commit of a repository
+func repoBuildStatePath(dir, repository, commit string) string {
+	sum := sha256.Sum256([]byte(repository + "@" + commit))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// repoBuildFingerprint identifies the images a build produces, so that a saved state is only reused for the same
+// services, image URLs, platforms, caches and labels. The Dockerfile paths are temporary labeled copies, so the
+// build contexts stand in for them.
+func repoBuildFingerprint(builds []dockerImageBuild) string {
+	type fingerprintedBuild struct {
+		Service    string   `json:"service"`
+		ContextDir string   `json:"contextDir"`
+		ImageURL   string   `json:"imageUrl"`
+		Platforms  string   `json:"platforms"`
+		CacheFrom  []string `json:"cacheFrom,omitempty"`
+		CacheTo    []string `json:"cacheTo,omitempty"`
+		Labels     []string `json:"labels,omitempty"`
+	}
+	fingerprinted := make([]fingerprintedBuild, 0, len(builds))
+	for _, build := range builds {
+		fingerprinted = append(fingerprinted, fingerprintedBuild{
+			Service:    build.Service,
+			ContextDir: build.ContextDir,
+			ImageURL:   build.ImageURL,
+			Platforms:  build.Platforms,
+			CacheFrom:  build.CacheFrom,
+			CacheTo:    build.CacheTo,
+			Labels:     build.Labels,
+		})
+	}
+	data, _ := json.Marshal(fingerprinted)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// resumeRepoBuild returns the state of the build of the commit checked out in rootDir, holding the images pushed by
+// an interrupted earlier run of the same build when the user chooses to resume it. It returns nil, so that nothing
+// is saved, when the working tree has uncommitted changes.
+func resumeRepoBuild(rootDir, specFile, repository string, builds []dockerImageBuild, noResume bool) (*repoBuildState, error) {
+	commit, ok := gitHeadCommit(rootDir, specFile)
+	if !ok {
+		return nil, nil
+	}
+	path := repoBuildStatePath(repoBuildStateDir(), repository, commit)
+	return openRepoBuildState(path, repository, commit, repoBuildFingerprint(builds), noResume, confirmRepoBuildResume)
+}
+
+// openRepoBuildState loads the state at path and asks confirm whether to resume when it holds pushed images.
+// With noResume, or when the user declines, the pushed images are forgotten and every image is built again.
+func openRepoBuildState(path, repository, commit, fingerprint string, noResume bool, confirm func(message string) (bool, error)) (*repoBuildState, error) {
+	state := loadRepoBuildState(path, repository, commit, fingerprint)
+	if noResume || len(state.ImageURLs) == 0 {
+		state.reset()
+		return state, nil
+	}
+
+	shortCommit := commit
+	if len(shortCommit) > 12 {
+		shortCommit = shortCommit[:12]
+	}
+	resume, err := confirm(fmt.Sprintf("An interrupted build of %s at commit %s pushed %d image(s), last on %s. Resume it, reusing the pushed images?",
+		repository, shortCommit, len(state.ImageURLs), state.UpdatedAt.Local().Format(time.RFC1123)))
+	if err != nil {
+		return nil, err
+	}
+	if !resume {
+		state.reset()
+	}
+	return state, nil
+}
+
+// confirmRepoBuildResume asks whether to resume an interrupted build, or resumes it without asking when stdin is
+// not a terminal; --no-resume starts over instead
+func confirmRepoBuildResume(message string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Println("Resuming an interrupted build, reusing the images it pushed. Use --no-resume to start over.")
+		return true, nil
+	}
+	return utils.ConfirmAction(message)
+}
+
+// loadRepoBuildState returns the saved state of the build at path if it matches the repository, commit and
+// fingerprint, or else a new empty state that is saved to path as images are pushed
+func loadRepoBuildState(path, repository, commit, fingerprint string) *repoBuildState {
+	state := &repoBuildState{
+		Repository:  repository,
+		Commit:      commit,
+		Fingerprint: fingerprint,
+		ImageURLs:   map[string]string{},
+		path:        path,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	var saved repoBuildState
+	if err = json.Unmarshal(data, &saved); err != nil {
+		return state
+	}
+	if saved.Repository != repository || saved.Commit != commit || saved.Fingerprint != fingerprint {
+		return state
+	}
+	for service, imageURL := range saved.ImageURLs {
+		state.ImageURLs[service] = imageURL
+	}
+	state.UpdatedAt = saved.UpdatedAt
+	return state
+}
+
+// reset forgets the pushed images, for a clean run
+func (s *repoBuildState) reset() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ImageURLs = map[string]string{}
+}
+
+// pushedImage returns the image URL pushed for service by an earlier run
+func (s *repoBuildState) pushedImage(service string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	imageURL, ok := s.ImageURLs[service]
+	return imageURL, ok
+}
+
+// record saves the image URL pushed for service
+func (s *repoBuildState) record(service, imageURL string) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ImageURLs[service] = imageURL
+	s.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so an interrupted write never leaves a truncated state behind
+	tmpPath := s.path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// clear removes the saved state once the build has completed
+func (s *repoBuildState) clear() {
+	if s == nil {
+		return
+	}
+	_ = os.Remove(s.path)
+}
+
+// recordingBuilder wraps builder to save the image URL of each build as soon as it is pushed, so that the images
+// of the builds that succeed are kept when another build fails
+func (s *repoBuildState) recordingBuilder(builder dockerImageBuilder) dockerImageBuilder {
+	return func(ctx context.Context, build dockerImageBuild, out io.Writer) (string, error) {
+		imageURL, err := builder(ctx, build, out)
+		if err != nil {
+			return "", err
+		}
+		if err := s.record(build.Service, imageURL); err != nil {
+			// The image is pushed; failing to save the state only costs a rebuild on the next run
+			_, _ = io.WriteString(out, "Warning: failed to save the build state: "+err.Error()+"\n")
+		}
+		return imageURL, nil
+	}
+}
+
+// gitHeadCommit returns the commit checked out in dir, or false when it cannot be resolved or the working tree has
+// uncommitted changes, which a build keyed by commit cannot account for. Changes to the compose spec at specFile,
+// which the build itself writes, are ignored.
+func gitHeadCommit(dir, specFile string) (string, bool) {
+	output, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", false
+	}
+	commit := strings.TrimSpace(string(output))
+
+	status, err := exec.Command("git", "-C", dir, "status", "--porcelain", "--", ".", ":(exclude)"+specFile).Output()
+	if err != nil || strings.TrimSpace(string(status)) != "" {
+		return "", false
+	}
+	return commit, commit != ""
+}
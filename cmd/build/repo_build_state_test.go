@@ -0,0 +1,87 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoBuildStateResume(t *testing.T) {
+	require := require.New(t)
+
+	path := repoBuildStatePath(t.TempDir(), "acme/app", "0123456789abcdef")
+	builds := []dockerImageBuild{
+		{Service: "api", ImageURL: "ghcr.io/acme/app-api", Platforms: "linux/amd64"},
+		{Service: "worker", ImageURL: "ghcr.io/acme/app-worker", Platforms: "linux/amd64"},
+	}
+	fingerprint := repoBuildFingerprint(builds)
+	neverAsked := func(string) (bool, error) {
+		t.Fatal("unexpected resume prompt")
+		return false, nil
+	}
+
+	// A first run that pushes the api image and then fails on the worker image
+	state, err := openRepoBuildState(path, "acme/app", "0123456789abcdef", fingerprint, false, neverAsked)
+	require.NoError(err)
+	builder := state.recordingBuilder(func(ctx context.Context, build dockerImageBuild, out io.Writer) (string, error) {
+		if build.Service == "worker" {
+			return "", errors.New("push failed")
+		}
+		return build.ImageURL + ":sha-1", nil
+	})
+	_, err = builder(context.Background(), builds[0], io.Discard)
+	require.NoError(err)
+	_, err = builder(context.Background(), builds[1], io.Discard)
+	require.Error(err)
+
+	// The next run resumes with the api image once the user confirms
+	var prompt string
+	state, err = openRepoBuildState(path, "acme/app", "0123456789abcdef", fingerprint, false, func(message string) (bool, error) {
+		prompt = message
+		return true, nil
+	})
+	require.NoError(err)
+	require.Contains(prompt, "acme/app at commit 0123456789ab pushed 1 image(s)")
+	imageURL, ok := state.pushedImage("api")
+	require.True(ok)
+	require.Equal("ghcr.io/acme/app-api:sha-1", imageURL)
+	_, ok = state.pushedImage("worker")
+	require.False(ok)
+
+	// Declining, --no-resume, another commit or changed builds start over
+	state, err = openRepoBuildState(path, "acme/app", "0123456789abcdef", fingerprint, false, func(string) (bool, error) { return false, nil })
+	require.NoError(err)
+	require.Empty(state.ImageURLs)
+
+	state, err = openRepoBuildState(path, "acme/app", "0123456789abcdef", fingerprint, true, neverAsked)
+	require.NoError(err)
+	require.Empty(state.ImageURLs)
+
+	state, err = openRepoBuildState(path, "acme/app", "fedcba9876543210", fingerprint, false, neverAsked)
+	require.NoError(err)
+	require.Empty(state.ImageURLs)
+
+	builds[1].Platforms = "linux/amd64,linux/arm64"
+	state, err = openRepoBuildState(path, "acme/app", "0123456789abcdef", repoBuildFingerprint(builds), false, neverAsked)
+	require.NoError(err)
+	require.Empty(state.ImageURLs)
+
+	// A completed build removes the state
+	state.clear()
+	require.NoFileExists(path)
+	var nilState *repoBuildState
+	nilState.clear()
+	_, ok = nilState.pushedImage("api")
+	require.False(ok)
+}
+
+func TestRepoBuildStatePath(t *testing.T) {
+	dir := t.TempDir()
+	require.Equal(t, repoBuildStatePath(dir, "acme/app", "abc"), repoBuildStatePath(dir, "acme/app", "abc"))
+	require.NotEqual(t, repoBuildStatePath(dir, "acme/app", "abc"), repoBuildStatePath(dir, "acme/app", "abd"))
+	require.Equal(t, dir, filepath.Dir(repoBuildStatePath(dir, "acme/app", "abc")))
+}
@@ -0,0 +1,236 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	schemaExample = `# Write the schema next to the compose spec
+omnistrate-ctl build schema > omnistrate-compose.schema.json
+
+# Then point the YAML language server at it from the first line of omnistrate-compose.yaml
+# yaml-language-server: $schema=./omnistrate-compose.schema.json`
+
+	jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema for the x-omnistrate keys of compose specs",
+	Long: `This command prints a JSON Schema describing the x-omnistrate-* keys supported in Omnistrate compose specs,
+at the top level and in services. Editors that support JSON Schema, such as those using the YAML language server,
+can use it to validate specs and autocomplete the keys. Keys that are not described are allowed, so the schema never rejects
+valid docker compose content.`,
+	Example:      schemaExample,
+	Args:         cobra.NoArgs,
+	RunE:         runSchema,
+	SilenceUsage: true,
+}
+
+func init() {
+	BuildCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	data, err := json.MarshalIndent(composeSpecSchema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the compose spec schema: %w", err)
+	}
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return err
+}
+
+// composeSpecSchema returns the JSON Schema of the x-omnistrate keys of a compose spec. Keys whose shape matches an
+// SDK request type are generated from it; the rest follow the compose layout read by the spec checklist.
+func composeSpecSchema() map[string]any {
+	apiParam := schemaForType(reflect.TypeOf(openapiclient.CreateInputParameterRequest2{}), "resourceId", "dependentResourceId")
+	apiParam["properties"].(map[string]any)["export"] = map[string]any{
+		"type":        "boolean",
+		"description": "Whether the parameter value is exported to the instance's output parameters",
+	}
+
+	service := openObject(map[string]any{
+		"x-omnistrate-compute": openObject(map[string]any{
+			"instanceTypes": arrayOf(openObject(map[string]any{
+				"name":          stringSchema("Instance type, e.g. t4g.small"),
+				"cloudProvider": stringSchema("Cloud provider of the instance type, e.g. aws, gcp or azure"),
+				"apiParam":      stringSchema("API parameter holding the instance type"),
+			})),
+			"replicaCount":         integerOrString("Number of replicas"),
+			"replicaCountAPIParam": stringSchema("API parameter holding the number of replicas"),
+			"rootVolumeSizeGi":     integerOrString("Root volume size in GiB"),
+		}),
+		"x-omnistrate-capabilities": openObject(map[string]any{
+			"autoscaling": openObject(map[string]any{
+				"minReplicas": integerOrString("Minimum number of replicas"),
+				"maxReplicas": integerOrString("Maximum number of replicas"),
+			}),
+			"httpReverseProxy": openObject(map[string]any{
+				"targetPort": integerOrString("Port the reverse proxy forwards to"),
+			}),
+			"enableMultiZone":          boolSchema("Spread replicas across availability zones"),
+			"enableEndpointPerReplica": boolSchema("Expose an endpoint for each replica"),
+			"enableNodeLoadBalancer":   boolSchema("Expose the replicas through a node load balancer"),
+		}),
+		"x-omnistrate-api-params":                arrayOf(apiParam),
+		"x-omnistrate-actionhooks":               arrayOf(schemaForType(reflect.TypeOf(openapiclient.ActionHook{}))),
+		"x-omnistrate-job-config":                schemaForType(reflect.TypeOf(openapiclient.JobConfig{})),
+		"x-omnistrate-mode-internal":             boolSchema("Hide the resource from customers, e.g. when it is a dependency of other resources"),
+		"x-omnistrate-proxy-type":                stringSchema("Proxy type of the resource"),
+		"x-omnistrate-storage":                   storageSchema(),
+		"x-omnistrate-image-registry-attributes": openObject(nil),
+		"volumes": arrayOf(map[string]any{
+			"anyOf": []any{
+				map[string]any{"type": "string"},
+				openObject(map[string]any{"x-omnistrate-storage": storageSchema()}),
+			},
+		}),
+	})
+
+	return map[string]any{
+		"$schema":     jsonSchemaDraft,
+		"title":       "Omnistrate compose spec",
+		"description": "x-omnistrate-* keys of an Omnistrate compose spec",
+		"type":        "object",
+		"properties": map[string]any{
+			"x-omnistrate-service-plan": openObject(map[string]any{
+				"name":        stringSchema("Name of the service plan"),
+				"tenancyType": stringSchema("Tenancy type, e.g. OMNISTRATE_DEDICATED_TENANCY or OMNISTRATE_MULTI_TENANCY"),
+				"deployment": openObject(map[string]any{
+					"hostedDeployment": openObject(nil),
+					"byoaDeployment":   openObject(nil),
+				}),
+			}),
+			"x-omnistrate-integrations": arrayOf(map[string]any{
+				"anyOf": []any{
+					map[string]any{"type": "string"},
+					openObject(nil),
+				},
+			}),
+			"x-omnistrate-load-balancer": openObject(map[string]any{
+				"https": arrayOf(openObject(map[string]any{
+					"name":        stringSchema("Name of the load balancer"),
+					"description": stringSchema("Description of the load balancer"),
+					"paths": arrayOf(openObject(map[string]any{
+						"associatedResourceKey": stringSchema("Key of the resource the path routes to"),
+						"path":                  stringSchema("Path prefix, e.g. /"),
+						"backendPort":           integerOrString("Port of the resource"),
+					})),
+				})),
+				"tcp": arrayOf(openObject(map[string]any{
+					"name":        stringSchema("Name of the load balancer"),
+					"description": stringSchema("Description of the load balancer"),
+					"ports": arrayOf(openObject(map[string]any{
+						"associatedResourceKeys": arrayOf(stringSchema("Key of a resource the port routes to")),
+						"ingressPort":            integerOrString("Port exposed by the load balancer"),
+						"backendPort":            integerOrString("Port of the resources"),
+					})),
+				})),
+			}),
+			"x-omnistrate-image-registry-attributes": openObject(nil),
+			"services": map[string]any{
+				"type":                 "object",
+				"additionalProperties": service,
+			},
+		},
+	}
+}
+
+// storageSchema describes x-omnistrate-storage, which holds the storage settings of each cloud provider.
+func storageSchema() map[string]any {
+	cloudStorage := openObject(map[string]any{
+		"instanceStorageType":               stringSchema("Storage type, e.g. AWS::EBS_GP3 or GCP::PD_BALANCED"),
+		"instanceStorageSizeGi":             integerOrString("Storage size in GiB"),
+		"instanceStorageSizeGiAPIParam":     stringSchema("API parameter holding the storage size"),
+		"instanceStorageIOPS":               integerOrString("Provisioned IOPS"),
+		"instanceStorageIOPSAPIParam":       stringSchema("API parameter holding the IOPS"),
+		"instanceStorageThroughputMiBps":    integerOrString("Provisioned throughput in MiB/s"),
+		"instanceStorageThroughputAPIParam": stringSchema("API parameter holding the throughput"),
+		"clusterStorageType":                stringSchema("Shared storage type, e.g. AWS::EFS or AWS::S3"),
+	})
+	return openObject(map[string]any{
+		"aws":   cloudStorage,
+		"gcp":   cloudStorage,
+		"azure": cloudStorage,
+		"oci":   cloudStorage,
+	})
+}
+
+// schemaForType generates the schema of an SDK type from its json tags. Fields without omitempty are required,
+// and the omitted fields are left out.
+func schemaForType(t reflect.Type, omit ...string) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return arrayOf(schemaForType(t.Elem()))
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaForType(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		required := make([]string, 0)
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "" || name == "-" || slices.Contains(omit, name) {
+				continue
+			}
+			properties[name] = schemaForType(field.Type)
+			if !strings.Contains(options, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]any{}
+	}
+}
+
+// openObject describes an object with the given properties that also allows any other property.
+func openObject(properties map[string]any) map[string]any {
+	schema := map[string]any{"type": "object", "additionalProperties": true}
+	if len(properties) > 0 {
+		schema["properties"] = properties
+	}
+	return schema
+}
+
+func arrayOf(items map[string]any) map[string]any {
+	return map[string]any{"type": "array", "items": items}
+}
+
+func stringSchema(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func boolSchema(description string) map[string]any {
+	return map[string]any{"type": "boolean", "description": description}
+}
+
+// integerOrString describes a number that may also be given as a string, e.g. a $var reference.
+func integerOrString(description string) map[string]any {
+	return map[string]any{"type": []string{"integer", "string"}, "description": description}
+}
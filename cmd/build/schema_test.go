@@ -0,0 +1,56 @@
+package build
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaForTypeUsesJSONTags(t *testing.T) {
+	require := require.New(t)
+
+	schema := schemaForType(reflect.TypeOf(openapiclient.ActionHook{}))
+	require.Equal("object", schema["type"])
+	require.ElementsMatch([]string{"commandTemplate", "scope", "type"}, schema["required"])
+
+	properties := schema["properties"].(map[string]any)
+	require.Equal(map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, properties["customCommand"])
+	require.Equal(map[string]any{"type": "string"}, properties["customImage"])
+
+	// Omitted fields are left out of the properties and the required fields
+	schema = schemaForType(reflect.TypeOf(openapiclient.CreateInputParameterRequest2{}), "resourceId")
+	require.NotContains(schema["properties"], "resourceId")
+	require.NotContains(schema["required"], "resourceId")
+	require.Contains(schema["required"], "key")
+}
+
+func TestComposeSpecSchema(t *testing.T) {
+	require := require.New(t)
+
+	var out bytes.Buffer
+	schemaCmd.SetOut(&out)
+	require.NoError(runSchema(schemaCmd, nil))
+
+	var schema map[string]any
+	require.NoError(json.Unmarshal(out.Bytes(), &schema))
+	require.Equal(jsonSchemaDraft, schema["$schema"])
+
+	properties := schema["properties"].(map[string]any)
+	require.Contains(properties, "x-omnistrate-service-plan")
+	require.Contains(properties, "x-omnistrate-load-balancer")
+
+	service := properties["services"].(map[string]any)["additionalProperties"].(map[string]any)
+	require.Equal(true, service["additionalProperties"])
+	serviceProperties := service["properties"].(map[string]any)
+	for _, key := range []string{"x-omnistrate-compute", "x-omnistrate-capabilities", "x-omnistrate-api-params", "x-omnistrate-actionhooks", "x-omnistrate-storage"} {
+		require.Contains(serviceProperties, key)
+	}
+
+	apiParam := serviceProperties["x-omnistrate-api-params"].(map[string]any)["items"].(map[string]any)
+	require.Contains(apiParam["properties"], "export")
+	require.NotContains(apiParam["properties"], "resourceId")
+}
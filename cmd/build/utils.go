@@ -514,13 +514,13 @@ func ExpandOmctlEnvVars(data []byte) ([]byte, error) {
 	return result, nil
 }
 
-// BuildDockerBuildArgs constructs the arguments for a `docker buildx build` command,
+// BuildDockerBuildArgs constructs the arguments for a `docker buildx build` command of the contextDir,
 // including optional --cache-from and --cache-to flags from the compose spec.
 // --load is always included for single-platform builds so the image is available
 // locally for subsequent `docker push`. It is only omitted for multi-platform
 // builds where --load is incompatible with the buildx driver.
-func BuildDockerBuildArgs(platforms, dockerfilePath, imageURL string, cacheFrom, cacheTo []string) []string {
-	args := []string{"buildx", "build", "--pull", "--platform", platforms, ".", "-f", dockerfilePath, "-t", imageURL}
+func BuildDockerBuildArgs(platforms, contextDir, dockerfilePath, imageURL string, cacheFrom, cacheTo []string) []string {
+	args := []string{"buildx", "build", "--pull", "--platform", platforms, contextDir, "-f", dockerfilePath, "-t", imageURL}
 	for _, cf := range cacheFrom {
 		args = append(args, "--cache-from", cf)
 	}
@@ -533,3 +533,100 @@ func BuildDockerBuildArgs(platforms, dockerfilePath, imageURL string, cacheFrom,
 	}
 	return args
 }
+
+var imageLabelInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// DockerfileImageLabel derives the image label from the location of a Dockerfile relative to the repository
+// root, e.g. docker/prod/Dockerfile becomes docker-prod. A Dockerfile in the repository root has no label.
+func DockerfileImageLabel(rootDir, dockerfilePath string) string {
+	relDir, err := filepath.Rel(rootDir, filepath.Dir(dockerfilePath))
+	if err != nil || relDir == "." || strings.HasPrefix(relDir, "..") {
+		return ""
+	}
+	label := imageLabelInvalidChars.ReplaceAllString(strings.ToLower(filepath.ToSlash(relDir)), "-")
+	return strings.Trim(label, "-.")
+}
+
+// imageSourceLabel is set from the repository on every image built from a repo and cannot be overridden
+const imageSourceLabel = "org.opencontainers.image.source"
+
+// ParseImageLabels validates key=value image labels and returns them sorted by key, with later values of a
+// repeated key taking precedence.
+func ParseImageLabels(labels []string) ([]string, error) {
+	values := make(map[string]string, len(labels))
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", label)
+		}
+		if key == imageSourceLabel {
+			return nil, fmt.Errorf("label %s is set from the repository and cannot be overridden", imageSourceLabel)
+		}
+		values[key] = value
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parsed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parsed = append(parsed, key+"="+values[key])
+	}
+	return parsed, nil
+}
+
+// writeLabeledDockerfile returns the path of a Dockerfile to build that sets the image source label. Unless the
+// Dockerfile already sets it, the label is appended to a temporary copy and the original file is not modified.
+// A Dockerfile-specific ignore file (<Dockerfile>.dockerignore) is copied next to the temporary copy, so that
+// docker still applies it. The returned cleanup removes the temporary files.
+func writeLabeledDockerfile(dockerfilePath, source string) (labeledPath string, cleanup func(), err error) {
+	cleanup = func() {}
+
+	dockerfileData, err := os.ReadFile(filepath.Clean(dockerfilePath))
+	if err != nil {
+		return "", cleanup, err
+	}
+	if strings.Contains(string(dockerfileData), "LABEL "+imageSourceLabel) {
+		return dockerfilePath, cleanup, nil
+	}
+
+	tempFile, err := os.CreateTemp("", "omnistrate-*."+filepath.Base(dockerfilePath))
+	if err != nil {
+		return "", cleanup, err
+	}
+	cleanup = func() { _ = os.Remove(tempFile.Name()) }
+
+	dockerfileData = append(dockerfileData, []byte(fmt.Sprintf("\nLABEL %s=\"%s\"\n", imageSourceLabel, source))...)
+	if _, err = tempFile.Write(dockerfileData); err != nil {
+		_ = tempFile.Close()
+		cleanup()
+		return "", func() {}, err
+	}
+	if err = tempFile.Close(); err != nil {
+		cleanup()
+		return "", func() {}, err
+	}
+
+	ignoreData, err := os.ReadFile(filepath.Clean(dockerfilePath + ".dockerignore"))
+	if err != nil && !os.IsNotExist(err) {
+		cleanup()
+		return "", func() {}, err
+	}
+	if err == nil {
+		ignorePath := tempFile.Name() + ".dockerignore"
+		cleanup = func() {
+			_ = os.Remove(tempFile.Name())
+			_ = os.Remove(ignorePath)
+		}
+		if err = os.WriteFile(ignorePath, ignoreData, 0600); err != nil {
+			cleanup()
+			return "", func() {}, err
+		}
+	}
+
+	return tempFile.Name(), cleanup, nil
+}
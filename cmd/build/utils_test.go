@@ -531,6 +531,72 @@ func TestExpandOmctlEnvVars(t *testing.T) {
 	}
 }
 
+func TestDockerfileImageLabel(t *testing.T) {
+	root := filepath.Join("/", "src", "repo")
+
+	assert.Equal(t, "", DockerfileImageLabel(root, filepath.Join(root, "Dockerfile")))
+	assert.Equal(t, "", DockerfileImageLabel(root, filepath.Join(root, "Dockerfile.prod")))
+	assert.Equal(t, "docker", DockerfileImageLabel(root, filepath.Join(root, "docker", "Dockerfile.prod")))
+	assert.Equal(t, "deploy-api-server", DockerfileImageLabel(root, filepath.Join(root, "Deploy", "API Server", "Dockerfile")))
+	assert.Equal(t, "", DockerfileImageLabel(root, filepath.Join("/", "elsewhere", "Dockerfile")))
+}
+
+func TestParseImageLabels(t *testing.T) {
+	labels, err := ParseImageLabels([]string{"org.opencontainers.image.revision=abc123", "team=data", "build-time=2026-01-02T03:04:05Z", "team=platform", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build-time=2026-01-02T03:04:05Z", "empty=", "org.opencontainers.image.revision=abc123", "team=platform"}, labels)
+
+	_, err = ParseImageLabels([]string{"no-value"})
+	assert.ErrorContains(t, err, "expected key=value")
+
+	_, err = ParseImageLabels([]string{"=value"})
+	assert.ErrorContains(t, err, "expected key=value")
+
+	_, err = ParseImageLabels([]string{"org.opencontainers.image.source=https://example.com"})
+	assert.ErrorContains(t, err, "cannot be overridden")
+}
+
+func TestWriteLabeledDockerfileLeavesOriginalUntouched(t *testing.T) {
+	dockerfilePath := filepath.Join(t.TempDir(), "Dockerfile")
+	original := []byte("FROM alpine:3.20\nCMD [\"echo\", \"hi\"]")
+	require.NoError(t, os.WriteFile(dockerfilePath, original, 0600))
+
+	labeledPath, cleanup, err := writeLabeledDockerfile(dockerfilePath, "https://github.com/acme/app")
+	require.NoError(t, err)
+	assert.NotEqual(t, dockerfilePath, labeledPath)
+
+	labeled, err := os.ReadFile(labeledPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(original)+"\nLABEL org.opencontainers.image.source=\"https://github.com/acme/app\"\n", string(labeled))
+
+	after, err := os.ReadFile(dockerfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, original, after)
+
+	cleanup()
+	_, err = os.Stat(labeledPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// A Dockerfile-specific ignore file is copied next to the temporary Dockerfile
+	require.NoError(t, os.WriteFile(dockerfilePath+".dockerignore", []byte("node_modules\n"), 0600))
+	labeledPath, cleanup, err = writeLabeledDockerfile(dockerfilePath, "https://github.com/acme/app")
+	require.NoError(t, err)
+	ignore, err := os.ReadFile(labeledPath + ".dockerignore")
+	require.NoError(t, err)
+	assert.Equal(t, "node_modules\n", string(ignore))
+
+	cleanup()
+	_, err = os.Stat(labeledPath + ".dockerignore")
+	assert.True(t, os.IsNotExist(err))
+
+	// A Dockerfile that already sets the source label is built as is
+	require.NoError(t, os.WriteFile(dockerfilePath, labeled, 0600))
+	labeledPath, cleanup, err = writeLabeledDockerfile(dockerfilePath, "https://github.com/acme/app")
+	require.NoError(t, err)
+	defer cleanup()
+	assert.Equal(t, dockerfilePath, labeledPath)
+}
+
 func TestBuildDockerBuildArgs(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -599,7 +665,7 @@ func TestBuildDockerBuildArgs(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := BuildDockerBuildArgs(tt.platforms, tt.dockerfile, tt.imageURL, tt.cacheFrom, tt.cacheTo)
+			result := BuildDockerBuildArgs(tt.platforms, ".", tt.dockerfile, tt.imageURL, tt.cacheFrom, tt.cacheTo)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -2,13 +2,21 @@ package common
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"text/template"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
 )
 
 const (
 	OutputFlag string = "output"
 
-	OutputTypeJson string = "json"
+	OutputTypeJson     string = "json"
+	OutputTypeCsv      string = "csv"
+	OutputTypeTemplate string = "template"
+
+	TemplateFlag string = "template"
 )
 
 func FormatParams(param, paramFile string) (formattedParams map[string]any, err error) {
@@ -32,3 +40,15 @@ func FormatParams(param, paramFile string) (formattedParams map[string]any, err
 
 	return
 }
+
+// ParseOutputTemplate validates the --output and --template combination and parses the template against
+// the fields of T. It returns a nil template when the output is not a template.
+func ParseOutputTemplate[T any](output, templateText string) (*template.Template, error) {
+	if output != OutputTypeTemplate {
+		if templateText != "" {
+			return nil, fmt.Errorf("--template can only be used with --output=template")
+		}
+		return nil, nil
+	}
+	return utils.ParseOutputTemplate[T](templateText)
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias [operation] [flags]",
+	Short: "Manage aliases of instance and service IDs",
+	Long: `This command helps you manage friendly aliases of instance and service IDs.
+Instance aliases are resolved wherever an instance ID is expected, e.g. 'instance debug <alias>' or 'deploy --instance-id <alias>',
+and service aliases wherever a service is expected, e.g. 'instance create --service <alias>'.`,
+	Run:          runAlias,
+	SilenceUsage: true,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+}
+
+func runAlias(cmd *cobra.Command, args []string) {
+	err := cmd.Help()
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	aliasListExample = `# List instance and service aliases
+omnistrate-ctl config alias list`
+)
+
+var aliasListCmd = &cobra.Command{
+	Use:          "list [flags]",
+	Short:        "List aliases of instance and service IDs",
+	Long:         `This command lists the aliases saved for the active profile, including the aliases of instances adopted with 'instance adopt-local'.`,
+	Example:      aliasListExample,
+	Args:         cobra.NoArgs,
+	RunE:         runAliasList,
+	SilenceUsage: true,
+}
+
+func runAliasList(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	output, _ := cmd.Flags().GetString("output")
+
+	saved, err := config.ListAliases()
+	if err != nil && !errors.Is(err, config.ErrConfigFileNotFound) {
+		utils.PrintError(err)
+		return err
+	}
+
+	if len(saved) == 0 {
+		utils.PrintInfo("No aliases found. Run 'omnistrate-ctl config alias set <alias> <id>' to add one.")
+		return nil
+	}
+
+	aliases := make([]model.Alias, 0, len(saved))
+	for _, alias := range saved {
+		aliases = append(aliases, model.Alias{
+			Namespace: alias.Namespace,
+			Alias:     alias.Name,
+			ID:        alias.ID,
+		})
+	}
+
+	if err = utils.PrintTextTableJsonArrayOutput(output, aliases); err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	return nil
+}
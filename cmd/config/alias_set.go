@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	aliasSetExample = `# Target instance-abcd1234 as orders-db
+omnistrate-ctl config alias set orders-db instance-abcd1234
+omnistrate-ctl instance debug orders-db
+
+# Alias a service
+omnistrate-ctl config alias set orders s-abcd1234 --namespace service`
+)
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set [alias] [id] [flags]",
+	Short: "Set an alias of an instance or service ID",
+	Long: `This command saves an alias of an instance or service ID for the active profile, replacing any previous target of the alias.
+Aliases are namespaced, so the same alias can name both an instance and a service.`,
+	Example:      aliasSetExample,
+	Args:         cobra.ExactArgs(2),
+	RunE:         runAliasSet,
+	SilenceUsage: true,
+}
+
+func init() {
+	aliasSetCmd.Flags().String("namespace", config.AliasNamespaceInstance, "Namespace of the alias ("+strings.Join(config.AliasNamespaces, "|")+")")
+}
+
+func runAliasSet(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	namespace, _ := cmd.Flags().GetString("namespace")
+
+	alias, id := args[0], args[1]
+	if err := config.SetAlias(namespace, alias, id); err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	utils.PrintSuccess(fmt.Sprintf("Set %s alias %s to %s", namespace, alias, id))
+	return nil
+}
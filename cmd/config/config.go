@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:          "config [operation] [flags]",
+	Short:        "Manage omnistrate-ctl configuration",
+	Long:         `This command helps you manage omnistrate-ctl configuration, such as the saved credential profiles, API endpoints and aliases.`,
+	Run:          runConfig,
+	SilenceUsage: true,
+}
+
+func init() {
+	Cmd.AddCommand(useProfileCmd)
+	Cmd.AddCommand(listProfilesCmd)
+	Cmd.AddCommand(setEndpointCmd)
+	Cmd.AddCommand(aliasCmd)
+	Cmd.AddCommand(doctorCmd)
+}
+
+func runConfig(cmd *cobra.Command, args []string) {
+	err := cmd.Help()
+	if err != nil {
+		return
+	}
+}
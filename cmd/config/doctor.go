@@ -0,0 +1,196 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/build"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	doctorExample = `# Check that the tools and credentials omnistrate-ctl relies on are set up
+omnistrate-ctl config doctor`
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment prerequisites of omnistrate-ctl",
+	Long: `This command checks the tools and credentials omnistrate-ctl relies on and prints a checklist:
+the GitHub CLI and its login, Docker, its daemon and the buildx plugin, the stored GitHub Personal Access Token and its scopes,
+and the saved Omnistrate credentials and their expiry. It exits with a non-zero code when a check fails.`,
+	Example:      doctorExample,
+	Args:         cobra.NoArgs,
+	RunE:         runDoctor,
+	SilenceUsage: true,
+}
+
+// doctorCheck is the result of one prerequisite check.
+type doctorCheck struct {
+	Name   string
+	Detail string
+	Err    error
+}
+
+// doctorEnv is what the checks run against, so that they can be tested without the real tools.
+type doctorEnv struct {
+	runCommand   func(name string, args ...string) ([]byte, error)
+	lookupPAT    func() (string, error)
+	validatePAT  func(ctx context.Context, pat string) error
+	lookupAuth   func() (config.AuthConfig, error)
+	githubEnvPAT bool
+	apiKey       string
+	now          time.Time
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	env := doctorEnv{
+		runCommand: func(name string, args ...string) ([]byte, error) {
+			return exec.Command(name, args...).CombinedOutput()
+		},
+		lookupPAT:    config.LookupGitHubPersonalAccessToken,
+		validatePAT:  build.ValidateGitHubPATScopes,
+		lookupAuth:   config.LookupAuthConfig,
+		githubEnvPAT: config.IsGithubTokenEnvVarConfigured(),
+		apiKey:       config.GetAPIKey(),
+		now:          time.Now(),
+	}
+
+	checks := runDoctorChecks(cmd.Context(), env)
+	printDoctorChecks(cmd.OutOrStdout(), checks)
+
+	failed := 0
+	for _, check := range checks {
+		if check.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		err := fmt.Errorf("%d of %d checks failed", failed, len(checks))
+		utils.PrintError(err)
+		return err
+	}
+	return nil
+}
+
+// runDoctorChecks runs every prerequisite check in order.
+func runDoctorChecks(ctx context.Context, env doctorEnv) []doctorCheck {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	runDocker := func(args ...string) ([]byte, error) {
+		return env.runCommand("docker", args...)
+	}
+
+	var checks []doctorCheck
+
+	ghVersion, err := env.runCommand("gh", "--version")
+	checks = append(checks, doctorCheck{Name: "GitHub CLI installed", Detail: firstLine(ghVersion), Err: commandError("gh CLI not found. Install it from https://cli.github.com", ghVersion, err)})
+	if err == nil {
+		output, err := env.runCommand("gh", "auth", "status")
+		checks = append(checks, doctorCheck{Name: "GitHub CLI logged in", Err: commandError("gh is not logged in. Run 'gh auth login'", output, err)})
+	}
+
+	dockerErr := build.CheckDockerInstalled(runDocker)
+	checks = append(checks, doctorCheck{Name: "Docker installed", Err: dockerErr})
+	if dockerErr == nil {
+		daemon, err := build.CheckDockerDaemon(runDocker)
+		checks = append(checks, doctorCheck{Name: "Docker daemon running", Detail: daemon, Err: err})
+		checks = append(checks, doctorCheck{Name: "Docker buildx installed", Err: build.CheckDockerBuildx(runDocker)})
+	}
+
+	checks = append(checks, checkGitHubPAT(ctx, env))
+	checks = append(checks, checkOmnistrateCredentials(env))
+
+	return checks
+}
+
+// checkGitHubPAT checks the GitHub Personal Access Token used to push images and its scopes.
+func checkGitHubPAT(ctx context.Context, env doctorEnv) doctorCheck {
+	check := doctorCheck{Name: "GitHub Personal Access Token"}
+	pat, err := env.lookupPAT()
+	if err != nil || pat == "" {
+		check.Err = errors.New("no GitHub Personal Access Token found. 'build-from-repo' prompts for one, or set " + config.GithubPATEnvVar)
+		return check
+	}
+	// Tokens from GH_TOKEN, e.g. in GitHub Actions, are scoped by the workflow rather than by OAuth scopes
+	if env.githubEnvPAT {
+		check.Detail = "from " + config.GithubTokenEnvVar
+		return check
+	}
+	check.Err = env.validatePAT(ctx, pat)
+	if check.Err == nil {
+		check.Detail = "scopes verified"
+	}
+	return check
+}
+
+// checkOmnistrateCredentials checks the saved Omnistrate credentials and when they expire.
+func checkOmnistrateCredentials(env doctorEnv) doctorCheck {
+	check := doctorCheck{Name: "Omnistrate credentials"}
+	if env.apiKey != "" {
+		check.Detail = "from " + config.OmnistrateAPIKeyEnv
+		return check
+	}
+
+	authConfig, err := env.lookupAuth()
+	if err != nil || authConfig.Token == "" {
+		check.Err = errors.New("not logged in. Run 'omnistrate-ctl login'")
+		return check
+	}
+
+	expiry, ok := config.TokenExpiry(authConfig.Token)
+	switch {
+	case !ok:
+		check.Err = errors.New("the saved token is malformed. Run 'omnistrate-ctl login'")
+	case expiry.After(env.now):
+		check.Detail = fmt.Sprintf("expires %s", expiry.UTC().Format(time.RFC3339))
+	case authConfig.RefreshToken != "":
+		check.Detail = fmt.Sprintf("expired %s, will be refreshed on the next command", expiry.UTC().Format(time.RFC3339))
+	default:
+		check.Err = fmt.Errorf("the saved token expired %s. Run 'omnistrate-ctl login'", expiry.UTC().Format(time.RFC3339))
+	}
+	return check
+}
+
+// commandError returns nil when err is nil, otherwise an error with msg and the command output.
+func commandError(msg string, output []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+	if detail := strings.TrimSpace(string(output)); detail != "" {
+		return fmt.Errorf("%s: %s", msg, firstLine([]byte(detail)))
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func firstLine(output []byte) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(line)
+}
+
+// printDoctorChecks prints the checks as a green/red checklist.
+func printDoctorChecks(w io.Writer, checks []doctorCheck) {
+	pass := color.New(color.FgGreen, color.Bold).SprintFunc()
+	fail := color.New(color.FgRed, color.Bold).SprintFunc()
+	for _, check := range checks {
+		switch {
+		case check.Err != nil:
+			fmt.Fprintf(w, "%s %s: %v\n", fail("✗"), check.Name, check.Err)
+		case check.Detail != "":
+			fmt.Fprintf(w, "%s %s (%s)\n", pass("✓"), check.Name, check.Detail)
+		default:
+			fmt.Fprintf(w, "%s %s\n", pass("✓"), check.Name)
+		}
+	}
+}
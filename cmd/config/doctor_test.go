@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func doctorTestJWT(exp time.Time) string {
+	payload, _ := json.Marshal(map[string]int64{"exp": exp.Unix()})
+	return fmt.Sprintf("e30.%s.sig", base64.RawURLEncoding.EncodeToString(payload))
+}
+
+func TestRunDoctorChecks(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var commands []string
+	env := doctorEnv{
+		runCommand: func(name string, args ...string) ([]byte, error) {
+			command := strings.Join(append([]string{name}, args...), " ")
+			commands = append(commands, command)
+			switch command {
+			case "gh --version":
+				return []byte("gh version 2.40.0 (2023-12-07)\nhttps://github.com/cli/cli\n"), nil
+			case "gh auth status":
+				return []byte("You are not logged into any GitHub hosts.\n"), errors.New("exit status 1")
+			case "docker buildx version":
+				return []byte("docker: 'buildx' is not a docker command.\n"), errors.New("exit status 1")
+			}
+			return []byte("default\n"), nil
+		},
+		lookupPAT:   func() (string, error) { return "ghp_token", nil },
+		validatePAT: func(ctx context.Context, pat string) error { return nil },
+		lookupAuth: func() (config.AuthConfig, error) {
+			return config.AuthConfig{Token: doctorTestJWT(now.Add(time.Hour))}, nil
+		},
+		now: now,
+	}
+	t.Setenv("DOCKER_HOST", "")
+
+	checks := runDoctorChecks(context.Background(), env)
+
+	names := make([]string, 0, len(checks))
+	for _, check := range checks {
+		names = append(names, check.Name)
+	}
+	require.Equal([]string{
+		"GitHub CLI installed",
+		"GitHub CLI logged in",
+		"Docker installed",
+		"Docker daemon running",
+		"Docker buildx installed",
+		"GitHub Personal Access Token",
+		"Omnistrate credentials",
+	}, names)
+
+	require.Equal("gh version 2.40.0 (2023-12-07)", checks[0].Detail)
+	require.ErrorContains(checks[1].Err, "You are not logged into any GitHub hosts")
+	require.NoError(checks[3].Err)
+	require.Equal("docker context default", checks[3].Detail)
+	require.ErrorContains(checks[4].Err, "docker buildx is not available")
+	require.Equal("scopes verified", checks[5].Detail)
+	require.Equal("expires 2026-01-02T04:04:05Z", checks[6].Detail)
+
+	var out bytes.Buffer
+	printDoctorChecks(&out, checks)
+	require.Contains(out.String(), "GitHub CLI logged in: gh is not logged in")
+	require.Contains(out.String(), "Docker installed\n")
+}
+
+func TestCheckOmnistrateCredentials(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	expired := doctorTestJWT(now.Add(-time.Hour))
+
+	env := doctorEnv{now: now, lookupAuth: func() (config.AuthConfig, error) {
+		return config.AuthConfig{}, config.ErrConfigFileNotFound
+	}}
+	require.ErrorContains(checkOmnistrateCredentials(env).Err, "not logged in")
+
+	env.lookupAuth = func() (config.AuthConfig, error) { return config.AuthConfig{Token: expired}, nil }
+	require.ErrorContains(checkOmnistrateCredentials(env).Err, "expired 2026-01-02T02:04:05Z")
+
+	env.lookupAuth = func() (config.AuthConfig, error) {
+		return config.AuthConfig{Token: expired, RefreshToken: "refresh"}, nil
+	}
+	check := checkOmnistrateCredentials(env)
+	require.NoError(check.Err)
+	require.Contains(check.Detail, "will be refreshed")
+
+	env.apiKey = "key"
+	require.Equal("from OMNISTRATE_API_KEY", checkOmnistrateCredentials(env).Detail)
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listProfilesExample = `# List credential profiles
+omnistrate-ctl config list-profiles`
+)
+
+var listProfilesCmd = &cobra.Command{
+	Use:          "list-profiles [flags]",
+	Short:        "List saved credential profiles",
+	Long:         `This command lists the credential profiles saved in the config file and marks the active one.`,
+	Example:      listProfilesExample,
+	Args:         cobra.NoArgs,
+	RunE:         runListProfiles,
+	SilenceUsage: true,
+}
+
+func runListProfiles(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	output, _ := cmd.Flags().GetString("output")
+
+	names, err := config.ListProfiles()
+	if err != nil && !errors.Is(err, config.ErrConfigFileNotFound) {
+		utils.PrintError(err)
+		return err
+	}
+
+	profiles := buildProfileList(names, config.ActiveProfile())
+	if len(profiles) == 0 {
+		utils.PrintInfo("No profiles found. Run 'omnistrate-ctl login' to save credentials.")
+		return nil
+	}
+
+	if err = utils.PrintTextTableJsonArrayOutput(output, profiles); err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	return nil
+}
+
+// buildProfileList converts saved profile names into display rows, flagging the active profile.
+func buildProfileList(names []string, active string) []model.Profile {
+	profiles := make([]model.Profile, 0, len(names))
+	for _, name := range names {
+		profiles = append(profiles, model.Profile{
+			Name:   name,
+			Active: strconv.FormatBool(name == active),
+		})
+	}
+	return profiles
+}
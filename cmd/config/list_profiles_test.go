@@ -0,0 +1,17 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProfileList(t *testing.T) {
+	profiles := buildProfileList([]string{"default", "staging"}, "staging")
+
+	require.Len(t, profiles, 2)
+	require.Equal(t, "default", profiles[0].Name)
+	require.Equal(t, "false", profiles[0].Active)
+	require.Equal(t, "staging", profiles[1].Name)
+	require.Equal(t, "true", profiles[1].Active)
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	setEndpointExample = `# Point the active profile at a staging API
+omnistrate-ctl config set-endpoint https://api.staging.example.com
+
+# Save an endpoint for a specific profile
+omnistrate-ctl config set-endpoint https://api.eu.example.com --profile eu
+
+# Go back to the default endpoint
+omnistrate-ctl config set-endpoint --unset`
+)
+
+var setEndpointCmd = &cobra.Command{
+	Use:   "set-endpoint [endpoint-url]",
+	Short: "Set the API endpoint for the active profile",
+	Long: `This command saves the API endpoint used by the active credential profile, for example to target staging or a dedicated region.
+The --endpoint flag and the OMCTL_ENDPOINT environment variable take precedence over the saved endpoint.`,
+	Example:      setEndpointExample,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runSetEndpoint,
+	SilenceUsage: true,
+}
+
+func init() {
+	setEndpointCmd.Flags().Bool("unset", false, "Remove the saved endpoint so the default endpoint is used")
+}
+
+func runSetEndpoint(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	unset, err := cmd.Flags().GetBool("unset")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	if unset == (len(args) == 1) {
+		err = fmt.Errorf("provide either an endpoint URL or --unset")
+		utils.PrintError(err)
+		return err
+	}
+
+	endpoint := ""
+	if !unset {
+		endpoint = args[0]
+	}
+
+	if err = config.SaveEndpoint(endpoint); err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	profile := config.ActiveProfile()
+	if unset {
+		utils.PrintSuccess(fmt.Sprintf("Removed the saved endpoint for profile %s", profile))
+		return nil
+	}
+
+	if config.IsInsecureEndpoint(endpoint) {
+		utils.PrintWarning(fmt.Sprintf("Endpoint %s does not use TLS; credentials will be sent unencrypted", endpoint))
+	}
+	utils.PrintSuccess(fmt.Sprintf("Endpoint for profile %s set to %s", profile, endpoint))
+	return nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	useProfileExample = `# Use the credentials saved for the staging profile by default
+omnistrate-ctl config use-profile staging
+
+# Log in to a new profile and make it the default
+omnistrate-ctl login --profile staging
+omnistrate-ctl config use-profile staging`
+)
+
+var useProfileCmd = &cobra.Command{
+	Use:   "use-profile [profile-name]",
+	Short: "Set the default credential profile",
+	Long: `This command sets the credential profile used when neither the --profile flag nor the OMCTL_PROFILE environment variable is set.
+Credentials for a profile are saved by running login with --profile.`,
+	Example:      useProfileExample,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runUseProfile,
+	SilenceUsage: true,
+}
+
+func runUseProfile(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	profile := args[0]
+	if err := config.UseProfile(profile); err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	utils.PrintSuccess(fmt.Sprintf("Switched to profile %s", profile))
+	return nil
+}
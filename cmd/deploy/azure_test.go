@@ -0,0 +1,93 @@
+package deploy
+
+import (
+	"testing"
+
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCloudProviderAndRegion_Azure(t *testing.T) {
+	t.Run("infers_azure_from_region_of_azure_only_offering", func(t *testing.T) {
+		offering := openapiclient.ServiceOffering{
+			CloudProviders: []string{"azure"},
+			AzureRegions:   []string{"eastus", "eastus2"},
+		}
+
+		cloudProvider, region, err := resolveCloudProviderAndRegion(offering, "", "eastus2")
+		require.NoError(t, err)
+		assert.Equal(t, "azure", cloudProvider)
+		assert.Equal(t, "eastus2", region)
+	})
+
+	t.Run("infers_azure_from_region_when_offering_lists_no_azure_regions", func(t *testing.T) {
+		offering := openapiclient.ServiceOffering{
+			CloudProviders: []string{"azure"},
+		}
+
+		cloudProvider, region, err := resolveCloudProviderAndRegion(offering, "", "eastus2")
+		require.NoError(t, err)
+		assert.Equal(t, "azure", cloudProvider)
+		assert.Equal(t, "eastus2", region)
+	})
+
+	t.Run("infers_azure_among_several_providers", func(t *testing.T) {
+		offering := openapiclient.ServiceOffering{
+			CloudProviders: []string{"aws", "gcp", "azure"},
+			AwsRegions:     []string{"us-east-2"},
+			GcpRegions:     []string{"us-central1"},
+			AzureRegions:   []string{"eastus2"},
+		}
+
+		cloudProvider, region, err := resolveCloudProviderAndRegion(offering, "", "eastus2")
+		require.NoError(t, err)
+		assert.Equal(t, "azure", cloudProvider)
+		assert.Equal(t, "eastus2", region)
+	})
+
+	t.Run("defaults_to_first_azure_region_from_offering", func(t *testing.T) {
+		offering := openapiclient.ServiceOffering{
+			CloudProviders: []string{"azure"},
+			AzureRegions:   []string{"westeurope", "eastus2"},
+		}
+
+		cloudProvider, region, err := resolveCloudProviderAndRegion(offering, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, "azure", cloudProvider)
+		assert.Equal(t, "westeurope", region)
+	})
+
+	t.Run("falls_back_to_default_azure_region", func(t *testing.T) {
+		offering := openapiclient.ServiceOffering{
+			CloudProviders: []string{"azure"},
+		}
+
+		cloudProvider, region, err := resolveCloudProviderAndRegion(offering, "azure", "")
+		require.NoError(t, err)
+		assert.Equal(t, "azure", cloudProvider)
+		assert.Equal(t, deployDefaultRegions["azure"], region)
+	})
+
+	t.Run("rejects_unsupported_azure_region", func(t *testing.T) {
+		offering := openapiclient.ServiceOffering{
+			CloudProviders: []string{"azure"},
+			AzureRegions:   []string{"eastus2"},
+		}
+
+		_, _, err := resolveCloudProviderAndRegion(offering, "azure", "westus")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "region 'westus' is not supported for cloud provider 'azure'")
+	})
+
+	t.Run("does_not_guess_provider_of_unknown_region_across_providers", func(t *testing.T) {
+		offering := openapiclient.ServiceOffering{
+			CloudProviders: []string{"aws", "azure"},
+			AwsRegions:     []string{"us-east-2"},
+		}
+
+		_, _, err := resolveCloudProviderAndRegion(offering, "", "eastus2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown region 'eastus2'")
+	})
+}
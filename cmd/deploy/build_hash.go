@@ -0,0 +1,184 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/build"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// deployBuildHashMarker prefixes the line of the release notes of a service plan version that records the hash of
+// the spec and images it was built from, so that deploying the same content again can skip the build.
+const deployBuildHashMarker = "omnistrate-ctl build hash: "
+
+// deployBuildTarget is the service, environment and plan an earlier deploy built.
+type deployBuildTarget struct {
+	serviceID     string
+	environmentID string
+	planID        string
+	version       string
+}
+
+// deployBuildInputs are the build.BuildService arguments other than the spec that shape the built version, and
+// the flags that change how the build is validated, so a change to any of them, e.g. a new --release-name or
+// --strict, rebuilds even when the spec and images are unchanged.
+type deployBuildInputs struct {
+	serviceName        string
+	specType           string
+	description        string
+	environment        string
+	environmentType    string
+	releaseName        string
+	release            bool
+	releaseAsPreferred bool
+	strict             bool
+}
+
+// specImageRefs returns the images of the services of a compose spec, sorted and without duplicates.
+func specImageRefs(specData []byte) ([]string, error) {
+	var spec struct {
+		Services map[string]struct {
+			Image string `yaml:"image"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(specData, &spec); err != nil {
+		return nil, errors.Wrap(err, "failed to parse spec for images")
+	}
+
+	seen := make(map[string]bool)
+	var images []string
+	for _, service := range spec.Services {
+		if service.Image == "" || seen[service.Image] {
+			continue
+		}
+		seen[service.Image] = true
+		images = append(images, service.Image)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// deployBuildHash hashes the build inputs, the processed spec and the digest of each image it references. Images
+// pinned by digest are part of the spec already; the others are resolved with inspect so that a moved tag, e.g.
+// latest, changes the hash.
+func deployBuildHash(inputs deployBuildInputs, specData []byte, inspect func(image string) (string, error)) (string, error) {
+	images, err := specImageRefs(specData)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "service=%q\nspecType=%q\ndescription=%q\nenvironment=%q\nenvironmentType=%q\nreleaseName=%q\nrelease=%t\nreleaseAsPreferred=%t\nstrict=%t\n",
+		inputs.serviceName, inputs.specType, inputs.description, inputs.environment, inputs.environmentType,
+		inputs.releaseName, inputs.release, inputs.releaseAsPreferred, inputs.strict)
+	h.Write(specData)
+	for _, image := range images {
+		digest := ""
+		if !strings.Contains(image, "@sha256:") {
+			if digest, err = inspect(image); err != nil {
+				return "", errors.Wrapf(err, "failed to resolve the digest of image %s", image)
+			}
+		}
+		fmt.Fprintf(h, "\n%s=%s", image, digest)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// inspectImageDigest returns the registry digest of an image.
+func inspectImageDigest(image string) (string, error) {
+	output, err := exec.Command("docker", "buildx", "imagetools", "inspect", image).Output()
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if digest, ok := strings.CutPrefix(strings.TrimSpace(line), "Digest:"); ok {
+			return strings.TrimSpace(digest), nil
+		}
+	}
+	return "", errors.New("no digest in docker buildx imagetools inspect output")
+}
+
+// releaseNotesBuildHash returns the build hash recorded in release notes, or an empty string.
+func releaseNotesBuildHash(notes string) string {
+	for _, line := range strings.Split(notes, "\n") {
+		if hash, ok := strings.CutPrefix(strings.TrimSpace(line), deployBuildHashMarker); ok {
+			return strings.TrimSpace(hash)
+		}
+	}
+	return ""
+}
+
+// releaseNotesWithBuildHash records hash in release notes, replacing an earlier hash and keeping the other lines.
+func releaseNotesWithBuildHash(notes, hash string) string {
+	var lines []string
+	for _, line := range strings.Split(notes, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), deployBuildHashMarker) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	kept := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if kept == "" {
+		return deployBuildHashMarker + hash
+	}
+	return kept + "\n" + deployBuildHashMarker + hash
+}
+
+// findUnchangedBuild returns the plan of the service in the environment when its latest version was built from
+// content with the given hash. A service whose environment has several plans is never considered unchanged.
+func findUnchangedBuild(ctx context.Context, token, serviceID, environment, hash string) (*deployBuildTarget, error) {
+	service, err := dataaccess.DescribeService(ctx, token, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, env := range service.ServiceEnvironments {
+		if !strings.EqualFold(env.Name, environment) {
+			continue
+		}
+		if len(env.ServicePlans) != 1 {
+			return nil, nil
+		}
+		planID := env.ServicePlans[0].ProductTierID
+
+		latest, err := dataaccess.DescribeLatestVersion(ctx, token, serviceID, planID)
+		if err != nil {
+			return nil, err
+		}
+		if releaseNotesBuildHash(latest.GetReleaseNotes()) != hash {
+			return nil, nil
+		}
+		return &deployBuildTarget{serviceID: serviceID, environmentID: env.Id, planID: planID, version: latest.Version}, nil
+	}
+	return nil, nil
+}
+
+// recordBuildHash records hash in the release notes of the latest version of the plan.
+func recordBuildHash(ctx context.Context, token, serviceID, planID, hash string) error {
+	latest, err := dataaccess.DescribeLatestVersion(ctx, token, serviceID, planID)
+	if err != nil {
+		return err
+	}
+	_, err = dataaccess.UpdateVersionSetReleaseNotes(ctx, token, serviceID, planID, latest.Version, releaseNotesWithBuildHash(latest.GetReleaseNotes(), hash))
+	return err
+}
+
+// unchangedBuildUndefinedResources returns the resources of the existing plan that the spec does not define when
+// the build is skipped. Only a build reports them, so the spec is checked against the plan with a dry-run build,
+// which creates no version.
+func unchangedBuildUndefinedResources(ctx context.Context, token string, specData []byte, serviceName, specType string, description *string, environment, environmentType string, releaseName *string) (map[string]string, error) {
+	_, _, _, undefinedResources, _, err := build.BuildService(ctx, specData, token, serviceName, specType, description, nil,
+		&environment, &environmentType, true, true, releaseName, true, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check the spec against the existing service plan")
+	}
+	return undefinedResources, nil
+}
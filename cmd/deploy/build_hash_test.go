@@ -0,0 +1,124 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/build"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployBuildHash(t *testing.T) {
+	require := require.New(t)
+
+	spec := []byte(`services:
+  web:
+    image: docker.io/acme/web:latest
+  worker:
+    image: docker.io/acme/worker@sha256:0123
+  cache:
+    image: docker.io/acme/web:latest
+`)
+	images, err := specImageRefs(spec)
+	require.NoError(err)
+	require.Equal([]string{"docker.io/acme/web:latest", "docker.io/acme/worker@sha256:0123"}, images)
+
+	digest := "sha256:aaaa"
+	var inspected []string
+	inspect := func(image string) (string, error) {
+		inspected = append(inspected, image)
+		return digest, nil
+	}
+
+	inputs := deployBuildInputs{serviceName: "acme", specType: "DockerCompose", environment: "Dev", environmentType: "DEV", release: true, releaseAsPreferred: true}
+	first, err := deployBuildHash(inputs, spec, inspect)
+	require.NoError(err)
+	require.Equal([]string{"docker.io/acme/web:latest"}, inspected, "images pinned by digest are not resolved")
+
+	again, err := deployBuildHash(inputs, spec, inspect)
+	require.NoError(err)
+	require.Equal(first, again)
+
+	// Every other build input changes the hash too
+	for _, changed := range []deployBuildInputs{
+		{serviceName: "acme", specType: "DockerCompose", environment: "Dev", environmentType: "DEV", releaseName: "v2", release: true, releaseAsPreferred: true},
+		{serviceName: "acme", specType: "DockerCompose", description: "new", environment: "Dev", environmentType: "DEV", release: true, releaseAsPreferred: true},
+		{serviceName: "acme", specType: "DockerCompose", environment: "Dev", environmentType: "QA", release: true, releaseAsPreferred: true},
+		{serviceName: "acme", specType: "DockerCompose", environment: "Dev", environmentType: "DEV", release: true, releaseAsPreferred: true, strict: true},
+	} {
+		other, err := deployBuildHash(changed, spec, inspect)
+		require.NoError(err)
+		require.NotEqual(first, other)
+	}
+
+	// A moved tag changes the hash even though the spec did not change
+	digest = "sha256:bbbb"
+	moved, err := deployBuildHash(inputs, spec, inspect)
+	require.NoError(err)
+	require.NotEqual(first, moved)
+
+	_, err = deployBuildHash(inputs, spec, func(string) (string, error) { return "", errors.New("unauthorized") })
+	require.ErrorContains(err, "docker.io/acme/web:latest")
+}
+
+func TestReleaseNotesBuildHash(t *testing.T) {
+	require := require.New(t)
+
+	require.Empty(releaseNotesBuildHash("Fix connection pool exhaustion"))
+
+	notes := releaseNotesWithBuildHash("", "sha256:1111")
+	require.Equal("omnistrate-ctl build hash: sha256:1111", notes)
+	require.Equal("sha256:1111", releaseNotesBuildHash(notes))
+
+	notes = releaseNotesWithBuildHash("Fix connection pool exhaustion\n"+notes, "sha256:2222")
+	require.Equal("Fix connection pool exhaustion\nomnistrate-ctl build hash: sha256:2222", notes)
+	require.Equal("sha256:2222", releaseNotesBuildHash(notes))
+}
+
+func TestSkippedBuildStillFailsUnderStrict(t *testing.T) {
+	require := require.New(t)
+
+	var dryRuns []bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2022-09-01-00/service/serviceplanspec", r.URL.Path)
+		var request struct {
+			Dryrun *bool `json:"dryrun"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		dryRuns = append(dryRuns, request.Dryrun != nil && *request.Dryrun)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"serviceID":            "s-1",
+			"serviceEnvironmentID": "se-1",
+			"productTierID":        "pt-1",
+			"undefinedResources":   map[string]string{"cache": "r-cache"},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.EndpointEnvVar, server.URL)
+	t.Cleanup(func() { config.SetEndpoint("") })
+	_, err := config.ResolveEndpoint()
+	require.NoError(err)
+	t.Setenv("CLIENT_TIMEOUT_IN_SECONDS", "5")
+	t.Setenv("OMNISTRATE_RETRY_MAX", "0")
+
+	// The build is skipped, so the undefined resources come from a dry run against the existing plan
+	undefinedResources, err := unchangedBuildUndefinedResources(context.Background(), "token", []byte("name: acme"), "acme", build.ServicePlanSpecType, nil, "Dev", "DEV", nil)
+	require.NoError(err)
+	require.Equal([]bool{true}, dryRuns, "no version is built")
+	require.Equal(map[string]string{"cache": "r-cache"}, undefinedResources)
+
+	err = build.UndefinedResourcesError(undefinedResources)
+	require.ErrorContains(err, "cache (r-cache)")
+	require.Equal(utils.ExitCodeValidation, utils.ExitCodeFor(err))
+}
@@ -0,0 +1,150 @@
+package deploy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/account"
+)
+
+// cloudCredentials holds the cloud account credentials piped to deploy with --credentials-stdin, so they are not
+// passed as flags. Its keys are those of the cloud account parameters that deploy otherwise prompts for.
+type cloudCredentials struct {
+	CloudProvider              string `json:"cloud_provider"`
+	AccountConfigurationMethod string `json:"account_configuration_method,omitempty"`
+	AwsAccountID               string `json:"aws_account_id,omitempty"`
+	AwsBootstrapRoleArn        string `json:"aws_bootstrap_role_arn,omitempty"`
+	GcpProjectID               string `json:"gcp_project_id,omitempty"`
+	GcpProjectNumber           string `json:"gcp_project_number,omitempty"`
+	AzureSubscriptionID        string `json:"azure_subscription_id,omitempty"`
+	AzureTenantID              string `json:"azure_tenant_id,omitempty"`
+}
+
+// readCloudCredentials reads a JSON credentials object from r. The cloud provider is taken from cloudProvider, the
+// --cloud-provider flag, or else from the cloud_provider key, and the fields it requires must all be set.
+func readCloudCredentials(r io.Reader, cloudProvider string) (*cloudCredentials, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the credentials from stdin: %w", err)
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return nil, fmt.Errorf("no credentials on stdin: pipe a JSON object such as {\"cloud_provider\": \"aws\", \"aws_account_id\": \"123456789012\"}")
+	}
+	return parseCloudCredentials(data, cloudProvider)
+}
+
+// parseCloudCredentials parses and validates a JSON credentials object for cloudProvider, as readCloudCredentials.
+func parseCloudCredentials(data []byte, cloudProvider string) (*cloudCredentials, error) {
+	var credentials cloudCredentials
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&credentials); err != nil {
+		return nil, fmt.Errorf("invalid credentials JSON: %w", err)
+	}
+
+	credentials.CloudProvider = strings.ToLower(credentials.CloudProvider)
+	switch {
+	case cloudProvider == "" && credentials.CloudProvider == "":
+		return nil, fmt.Errorf("the credentials have no cloud_provider: set it to one of aws, gcp or azure, or pass --cloud-provider")
+	case cloudProvider != "" && credentials.CloudProvider != "" && cloudProvider != credentials.CloudProvider:
+		return nil, fmt.Errorf("the credentials are for cloud provider %s, but --cloud-provider is %s", credentials.CloudProvider, cloudProvider)
+	case credentials.CloudProvider == "":
+		credentials.CloudProvider = cloudProvider
+	}
+
+	var required, others map[string]string
+	switch credentials.CloudProvider {
+	case "aws":
+		required = map[string]string{"aws_account_id": credentials.AwsAccountID}
+		others = map[string]string{
+			"gcp_project_id":        credentials.GcpProjectID,
+			"gcp_project_number":    credentials.GcpProjectNumber,
+			"azure_subscription_id": credentials.AzureSubscriptionID,
+			"azure_tenant_id":       credentials.AzureTenantID,
+		}
+	case "gcp":
+		required = map[string]string{
+			"gcp_project_id":     credentials.GcpProjectID,
+			"gcp_project_number": credentials.GcpProjectNumber,
+		}
+		others = map[string]string{
+			"aws_account_id":         credentials.AwsAccountID,
+			"aws_bootstrap_role_arn": credentials.AwsBootstrapRoleArn,
+			"azure_subscription_id":  credentials.AzureSubscriptionID,
+			"azure_tenant_id":        credentials.AzureTenantID,
+		}
+	case "azure":
+		required = map[string]string{
+			"azure_subscription_id": credentials.AzureSubscriptionID,
+			"azure_tenant_id":       credentials.AzureTenantID,
+		}
+		others = map[string]string{
+			"aws_account_id":         credentials.AwsAccountID,
+			"aws_bootstrap_role_arn": credentials.AwsBootstrapRoleArn,
+			"gcp_project_id":         credentials.GcpProjectID,
+			"gcp_project_number":     credentials.GcpProjectNumber,
+		}
+	case "nebius":
+		return nil, fmt.Errorf("action required: %s", nebiusDeployOnboardingMessage)
+	default:
+		return nil, fmt.Errorf("unsupported cloud provider in the credentials: %s", credentials.CloudProvider)
+	}
+
+	var missing, unexpected []string
+	for key, value := range required {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, key)
+		}
+	}
+	for key, value := range others {
+		if value != "" {
+			unexpected = append(unexpected, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("the %s credentials are missing %s", credentials.CloudProvider, strings.Join(missing, ", "))
+	}
+	if len(unexpected) > 0 {
+		sort.Strings(unexpected)
+		return nil, fmt.Errorf("the %s credentials must not set %s", credentials.CloudProvider, strings.Join(unexpected, ", "))
+	}
+
+	if credentials.CloudProvider == "aws" && credentials.AwsBootstrapRoleArn == "" {
+		credentials.AwsBootstrapRoleArn = fmt.Sprintf("arn:aws:iam::%s:role/omnistrate-bootstrap-role", credentials.AwsAccountID)
+	}
+	if credentials.AccountConfigurationMethod == "" {
+		credentials.AccountConfigurationMethod = map[string]string{
+			"aws":   "CloudFormation",
+			"gcp":   "GCPScript",
+			"azure": "AzureScript",
+		}[credentials.CloudProvider]
+	}
+
+	return &credentials, nil
+}
+
+// accountParams returns the parameters to create a cloud account named name with the credentials
+func (c *cloudCredentials) accountParams(name string) account.CloudAccountParams {
+	return account.CloudAccountParams{
+		Name:                name,
+		AwsAccountID:        c.AwsAccountID,
+		GcpProjectID:        c.GcpProjectID,
+		GcpProjectNumber:    c.GcpProjectNumber,
+		AzureSubscriptionID: c.AzureSubscriptionID,
+		AzureTenantID:       c.AzureTenantID,
+	}
+}
+
+// instanceParams returns the credentials as the JSON parameters of a cloud account instance
+func (c *cloudCredentials) instanceParams() (string, error) {
+	jsonBytes, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+	return string(jsonBytes), nil
+}
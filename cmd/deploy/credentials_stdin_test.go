@@ -0,0 +1,52 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/account"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCloudCredentials(t *testing.T) {
+	require := require.New(t)
+
+	credentials, err := readCloudCredentials(strings.NewReader(`{"cloud_provider": "aws", "aws_account_id": "123456789012"}`), "")
+	require.NoError(err)
+	require.Equal(account.CloudAccountParams{Name: "acct", AwsAccountID: "123456789012"}, credentials.accountParams("acct"))
+
+	params, err := credentials.instanceParams()
+	require.NoError(err)
+	require.JSONEq(`{"account_configuration_method":"CloudFormation","aws_account_id":"123456789012","aws_bootstrap_role_arn":"arn:aws:iam::123456789012:role/omnistrate-bootstrap-role","cloud_provider":"aws"}`, params)
+
+	// The cloud provider may come from --cloud-provider
+	credentials, err = readCloudCredentials(strings.NewReader(`{"azure_subscription_id": "sub", "azure_tenant_id": "tenant"}`), "azure")
+	require.NoError(err)
+	require.Equal(account.CloudAccountParams{Name: "acct", AzureSubscriptionID: "sub", AzureTenantID: "tenant"}, credentials.accountParams("acct"))
+
+	for input, expected := range map[string]string{
+		``:                                   "no credentials on stdin",
+		`{"cloud_provider": "aws"`:           "invalid credentials JSON",
+		`{"aws_account_id": "123456789012"}`: "have no cloud_provider",
+		`{"cloud_provider": "gcp", "gcp_project_id": "p"}`:                         "the gcp credentials are missing gcp_project_number",
+		`{"cloud_provider": "aws", "aws_account_id": "1", "azure_tenant_id": "t"}`: "the aws credentials must not set azure_tenant_id",
+		`{"cloud_provider": "aws", "aws_secret_key": "k"}`:                         `unknown field "aws_secret_key"`,
+		`{"cloud_provider": "oci"}`:                                                "unsupported cloud provider",
+		`{"cloud_provider": "nebius"}`:                                             "action required",
+	} {
+		_, err = readCloudCredentials(strings.NewReader(input), "")
+		require.ErrorContains(err, expected, input)
+	}
+
+	_, err = readCloudCredentials(strings.NewReader(`{"cloud_provider": "gcp", "gcp_project_id": "p", "gcp_project_number": "1"}`), "aws")
+	require.ErrorContains(err, "the credentials are for cloud provider gcp, but --cloud-provider is aws")
+}
+
+func TestParseCloudCredentialsFromPrompt(t *testing.T) {
+	require := require.New(t)
+
+	// The prompted credentials parse the same way as piped ones
+	credentials, err := parseCloudCredentials([]byte(`{"account_configuration_method":"GCPScript","gcp_project_id":"my-project","gcp_project_number":"123456","cloud_provider":"gcp"}`), "gcp")
+	require.NoError(err)
+	require.Equal(account.CloudAccountParams{Name: "acct", GcpProjectID: "my-project", GcpProjectNumber: "123456"}, credentials.accountParams("acct"))
+}
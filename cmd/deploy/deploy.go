@@ -1,18 +1,19 @@
 package deploy
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"reflect"
 	"regexp"
 	"slices"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
@@ -27,6 +28,7 @@ import (
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/instance"
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/spec"
 	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
 	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
 	pkgerrors "github.com/pkg/errors"
@@ -52,6 +54,9 @@ omnistrate-ctl deploy --cloud-provider aws --region us-east-1
 # Build and deploy using BYOA (Bring Your Own Account)
 omnistrate-ctl deploy --deployment-type byoa
 
+# Build and deploy using BYOA, with the cloud account credentials read from stdin instead of prompted
+omnistrate-ctl deploy --deployment-type byoa --credentials-stdin < aws-credentials.json
+
 # Build and deploy with instance parameters supplied inline
 omnistrate-ctl deploy --param '{"disk_size":"20Gi", "username":"test", "password":"Test@123"}'
 
@@ -61,11 +66,29 @@ omnistrate-ctl deploy --param-file params.json
 # Build and upgrade an existing instance
 omnistrate-ctl deploy --instance-id inst-12345
 
+# Build and create one instance per resource
+omnistrate-ctl deploy --resource-id r-12345 --resource-id r-67890
+
 # Build from repository but skip Docker build (use pre-built image) and then deploy
 omnistrate-ctl deploy --skip-docker-build --product-name "My Service"
 
 # Multi-arch build from repo and deploy
 omnistrate-ctl deploy --platforms "linux/amd64,linux/arm64"
+
+# Build a version named 2024-q2-hotfix and deploy it
+omnistrate-ctl deploy --release-name 2024-q2-hotfix --description "Fix connection pool exhaustion"
+
+# Build, deploy and then tail the instance logs until Ctrl-C
+omnistrate-ctl deploy --watch-logs
+
+# Redeploy the spec with an already-built image for the web service
+omnistrate-ctl deploy --set-image web=docker.io/acme/web:v1.2
+
+# Build a new version even if the spec is unchanged since the last deploy
+omnistrate-ctl deploy --force-build
+
+# Build from the repository, passing environment variables to the container
+omnistrate-ctl deploy --env POSTGRES_PASSWORD=secret --env POSTGRES_DB=app
 `
 
 	deployLong = `Deploy command is the unified entry point to build (or update) a service and then
@@ -114,10 +137,41 @@ Instance selection and deployment:
   - When creating a new instance, deploy determines the cloud, region, resource
     (if applicable), BYOA account (if applicable), and any required parameters.
 
+  - The --param and --param-file values are checked against the parameters of
+    the resource before the instance is created. Unknown keys, values of the
+    wrong type and missing required parameters are all reported together.
+
+  - With --strict, deploy fails before creating or upgrading an instance when
+    the service plan has resources that the spec does not define, which usually
+    indicates a mistake in the spec.
+
+  - Repeat --resource-id to create an instance for each of several resources in
+    one run. Without a terminal, --resource-id is required when the plan has more
+    than one resource. With --instance-id, at most one --resource-id is accepted.
+
+Spec file references:
+
+  - Embed another file with {{ $file:path }}, resolved relative to the file that
+    contains the reference. The older ${{ file:path }} form is deprecated.
+
+Unchanged specs:
+
+  - When deploying a spec to an existing service, deploy records a hash of the
+    processed spec and the digests of its images in the release notes of the
+    version it builds, once the deploy has succeeded. If the latest version was
+    built from the same content, the build is skipped and deploy goes straight to
+    the instance step. The spec is still checked against the service plan with a
+    dry run, so --strict applies. Use --force-build to build a new version anyway.
+
 Dry run:
 
   - With --dry-run, deploy performs full validation and build steps but stops
-      before launching or upgrading an instance.`
+      before launching or upgrading an instance.
+
+  - With --check-accounts, deploy only checks that the cloud accounts of the
+    spec are linked and READY, and reports them. It does not run docker, build
+    the service or create cloud accounts, so it is a fast pre-flight check for
+    BYOA deployments.`
 
 	nebiusDeployOnboardingMessage = "Nebius account onboarding from deploy is not supported. Run 'omnistrate-ctl account create <name> --nebius-tenant-id <tenant-id> --nebius-bindings-file <bindings-file>' first, wait for the desired binding to become READY, and then rerun deploy"
 )
@@ -146,22 +200,41 @@ func init() {
 	DeployCmd.Flags().StringP("file", "f", "", fmt.Sprintf("Path to the Omnistrate spec or compose file (defaults to %s)", build.OmnistrateComposeFileName))
 	DeployCmd.Flags().String("product-name", "", "Specify a custom service name. If not provided, the directory name will be used.")
 	DeployCmd.Flags().Bool("dry-run", false, "Perform validation checks without actually building or deploying")
-	DeployCmd.Flags().String("resource-id", "", "Specify the resource ID to use when multiple resources exist.")
-	DeployCmd.Flags().String("instance-id", "", "Specify the instance ID to use when multiple deployments exist.")
+	DeployCmd.Flags().Bool("check-accounts", false, "Only check that the cloud provider accounts are linked and READY, without building or deploying")
+	DeployCmd.Flags().Bool("strict", false, "Fail before deploying an instance when the service plan has resources that the spec does not define, e.g. to catch spec drift in CI")
+	DeployCmd.Flags().Bool("force-build", false, "Build a new service version even if the spec and its images are unchanged since the last deploy")
+	DeployCmd.Flags().StringArray("resource-id", nil, "Specify the resource ID to use when multiple resources exist. Repeat the flag to create instances for several resources in one run.")
+	DeployCmd.Flags().String("instance-id", "", "Specify the instance ID, or an alias set with 'config alias set', to use when multiple deployments exist.")
 
 	DeployCmd.Flags().StringP("environment", "e", "Prod", "Name of the environment to build the service in (default: Prod)")
 	DeployCmd.Flags().StringP("environment-type", "t", "prod", "Type of environment. Valid options: dev, prod, qa, canary, staging, private (default: prod)")
 
 	DeployCmd.Flags().String("cloud-provider", "", "Cloud provider (aws|gcp|azure|nebius)")
-	DeployCmd.Flags().String("region", "", "Region code (e.g. us-east-2, us-central1)")
+	DeployCmd.Flags().Bool("credentials-stdin", false, "Read the cloud account credentials of a BYOA deployment as a JSON object from stdin instead of prompting for them, e.g. {\"cloud_provider\": \"aws\", \"aws_account_id\": \"123456789012\"}")
+	DeployCmd.Flags().String("region", "", "Region code (e.g. us-east-2, us-central1, eastus2)")
 	DeployCmd.Flags().String("param", "", "JSON parameters for the instance deployment")
 	DeployCmd.Flags().String("param-file", "", "JSON file containing parameters for the instance deployment")
 
 	// Additional flags from build command
 	DeployCmd.Flags().Bool("skip-docker-build", false, "Skip building and pushing the Docker image")
 	DeployCmd.Flags().StringArray("platforms", []string{"linux/amd64"}, "Specify the platforms to build for. Example: --platforms linux/amd64 --platforms linux/arm64")
+	DeployCmd.Flags().String("dockerfile", "", "Path to the Dockerfile to build when no spec file exists in the repo (default: Dockerfile in the repository root). The repository root is used as the build context.")
+	DeployCmd.Flags().StringArray("set-image", nil, "Deploy a service of the compose spec with an already-built image instead of its image or build section, e.g. --set-image web=docker.io/acme/web:v1.2. Repeat the flag for several services.")
+	DeployCmd.Flags().StringArray("env", nil, "Set an environment variable of the container when building from the repository, e.g. --env POSTGRES_PASSWORD=secret. Repeat the flag for several variables. Only effective when no compose spec exists in the repo")
+	DeployCmd.Flags().StringArray("label", nil, "Add a label to the Docker images built from the repo, e.g. --label org.opencontainers.image.revision=$(git rev-parse HEAD). Repeat the flag for several labels.")
+	DeployCmd.Flags().Bool("no-resume", false, "When building from the repository, build and push every image again instead of resuming an interrupted build of the same commit")
+	DeployCmd.Flags().Bool("raw-docker-output", false, "Stream the full docker build and push output instead of a progress bar")
+	DeployCmd.Flags().Bool("use-docker-credentials", false, "When building from the repository, push images with the registry credentials of the docker client configuration (~/.docker/config.json or a credential helper) instead of logging in with a GitHub PAT, and don't write the PAT into the compose spec")
 	DeployCmd.Flags().String("deployment-type", "hosted", "Type of deployment. Valid values: hosted, byoa (default \"hosted\" i.e. deployments are hosted in the service provider account)")
 	DeployCmd.Flags().String("github-username", "", "GitHub username to use if GitHub API fails to retrieve it automatically")
+	DeployCmd.Flags().String("description", "", "A short description of the service, e.g. a changelog note for this deployment. Defaults to keeping the current description")
+	DeployCmd.Flags().String("release-name", "", "Name the service plan version built by this deployment, e.g. 2024-q2-hotfix. It is shown as the release description in 'service-plan list-versions'")
+	DeployCmd.Flags().Bool("show-timings", false, "Print the duration of each phase of the deploy, such as the account check, build and push, instance creation and workflow wait, when it finishes")
+	DeployCmd.Flags().Bool("watch-logs", false, "Stream the logs of the deployed instance after the deployment succeeds, until Ctrl-C. When several resources are deployed, the logs of every instance are streamed, each line prefixed with its resource")
+	DeployCmd.Flags().String("ca-cert", "", "Path to a PEM CA bundle to trust for --watch-logs, e.g. behind a TLS-intercepting proxy (overrides the "+config.CACertEnvVar+" environment variable)")
+	DeployCmd.Flags().Bool("no-color", false, "Strip ANSI color codes from streamed logs (also enabled by the NO_COLOR environment variable)")
+	DeployCmd.Flags().Duration("deploy-timeout", 0, "Abort the deploy, cancelling in-flight API calls, if it has not finished within this duration, e.g. 45m (0 for no limit). Log streaming with --watch-logs is not bound by it")
+	DeployCmd.Flags().Int("retries", config.GetRetryMax(), "Maximum number of retries for transient API failures (5xx responses and timeouts). Overrides OMNISTRATE_RETRY_MAX")
 
 	if err := DeployCmd.MarkFlagFilename("param-file"); err != nil {
 		return
@@ -182,7 +255,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	token, err := common.GetTokenWithLogin()
 	if err != nil {
 		printAuthError()
-		return fmt.Errorf("authentication error: %w", err)
+		return utils.WithExitCode(utils.ExitCodeAuth, fmt.Errorf("authentication error: %w", err))
 	}
 
 	// Retrieve flags
@@ -210,6 +283,25 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	credentialsStdin, err := cmd.Flags().GetBool("credentials-stdin")
+	if err != nil {
+		return err
+	}
+
+	caCert, err := cmd.Flags().GetString("ca-cert")
+	if err != nil {
+		return err
+	}
+	if caCert != "" {
+		config.SetCACert(caCert)
+	}
+	// Check the CA bundle up front, as log streaming only starts once the deployment succeeded
+	if watchLogs, _ := cmd.Flags().GetBool("watch-logs"); watchLogs {
+		if _, err = config.TLSConfig(); err != nil {
+			utils.PrintError(err)
+			return err
+		}
+	}
 
 	skipDockerBuild, err := cmd.Flags().GetBool("skip-docker-build")
 	if err != nil {
@@ -221,23 +313,120 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	deployTimeout, err := cmd.Flags().GetDuration("deploy-timeout")
+	if err != nil {
+		return err
+	}
+	if deployTimeout < 0 {
+		err = utils.WithExitCode(utils.ExitCodeValidation, errors.New("--deploy-timeout must be zero or greater"))
+		utils.PrintError(err)
+		return err
+	}
+	ctx, cancel := newDeployContext(cmd.Context(), deployTimeout)
+	defer cancel()
+
+	showTimings, err := cmd.Flags().GetBool("show-timings")
+	if err != nil {
+		return err
+	}
+	var timings *utils.PhaseTimings
+	if showTimings {
+		timings = utils.NewPhaseTimings()
+		ctx = utils.WithPhaseTimings(ctx, timings)
+		// Registered before the spinner cleanup, so the breakdown is printed after the spinners stop
+		defer timings.Print(os.Stderr)
+	}
+
+	setImages, err := cmd.Flags().GetStringArray("set-image")
+	if err != nil {
+		return err
+	}
+	imageOverrides, err := parseImageOverrides(setImages)
+	if err != nil {
+		err = utils.WithExitCode(utils.ExitCodeValidation, err)
+		utils.PrintError(err)
+		return err
+	}
+
+	envVars, err := cmd.Flags().GetStringArray("env")
+	if err != nil {
+		return err
+	}
+	if _, err = build.ParseEnvVars(envVars); err != nil {
+		err = utils.WithExitCode(utils.ExitCodeValidation, err)
+		utils.PrintError(err)
+		return err
+	}
+
+	releaseName, err := cmd.Flags().GetString("release-name")
+	if err != nil {
+		return err
+	}
+	var releaseNamePtr *string
+	if releaseName != "" {
+		releaseNamePtr = &releaseName
+	}
+
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		return err
+	}
+	var descriptionPtr *string
+	if description != "" {
+		descriptionPtr = &description
+	}
+
 	// Get dry-run flags
 	dryRun, err := cmd.Flags().GetBool("dry-run")
 	if err != nil {
 		return err
 	}
 
+	checkAccounts, err := cmd.Flags().GetBool("check-accounts")
+	if err != nil {
+		return err
+	}
+
+	strict, err := cmd.Flags().GetBool("strict")
+	if err != nil {
+		return err
+	}
+
+	forceBuild, err := cmd.Flags().GetBool("force-build")
+	if err != nil {
+		return err
+	}
+
+	// Get retries flag value; only an explicit flag overrides OMNISTRATE_RETRY_MAX
+	if cmd.Flags().Changed("retries") {
+		retries, err := cmd.Flags().GetInt("retries")
+		if err != nil {
+			return err
+		}
+		if retries < 0 {
+			return errors.New("--retries must be zero or greater")
+		}
+		config.SetRetryMax(retries)
+	}
+
 	// Get instance-id flag value
 	instanceID, err := cmd.Flags().GetString("instance-id")
 	if err != nil {
 		return err
 	}
+	if instanceID != "" {
+		instanceID = config.ResolveInstanceID(instanceID)
+	}
 
-	// Get resource-id flag value
-	resourceID, err := cmd.Flags().GetString("resource-id")
+	// Get resource-id flag values
+	resourceIDs, err := cmd.Flags().GetStringArray("resource-id")
 	if err != nil {
 		return err
 	}
+	if err = validateResourceIDsForInstance(instanceID, resourceIDs); err != nil {
+		utils.PrintError(err)
+		return err
+	}
 
 	param, err := cmd.Flags().GetString("param")
 	if err != nil {
@@ -272,17 +461,28 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	// Validate deployment-type
 	if deploymentType != build.DeploymentTypeHosted && deploymentType != build.DeploymentTypeByoa {
-		err := fmt.Errorf("invalid deployment-type '%s'. Valid values are: hosted, byoa", deploymentType)
+		err := utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf("invalid deployment-type '%s'. Valid values are: hosted, byoa", deploymentType))
 		utils.PrintError(err)
 		return err
 	}
 
 	if cloudProvider != "" && !isSupportedDeployCloudProvider(cloudProvider) {
-		err := fmt.Errorf("invalid cloud-provider '%s'. Valid values are: %s", cloudProvider, strings.Join(deployCloudProviders, ", "))
+		err := utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf("invalid cloud-provider '%s'. Valid values are: %s", cloudProvider, strings.Join(deployCloudProviders, ", ")))
 		utils.PrintError(err)
 		return err
 	}
 
+	// Read the piped cloud account credentials before anything else can prompt on stdin
+	var credentials *cloudCredentials
+	if credentialsStdin {
+		if credentials, err = readCloudCredentials(os.Stdin, cloudProvider); err != nil {
+			err = utils.WithExitCode(utils.ExitCodeValidation, err)
+			utils.PrintError(err)
+			return err
+		}
+		cloudProvider = credentials.CloudProvider
+	}
+
 	// Initialize spinner manager (only after we know we're logged in)
 	sm := utils.NewSpinnerManager()
 	defer func() {
@@ -293,6 +493,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	}()
 
 	// Inform user of deployment start
+	timings.Start("Spec processing")
 	spinner := sm.AddSpinner("Step 1/2: Starting service creation...")
 	sm.Start()
 
@@ -345,7 +546,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 		// Check if spec file exists
 		if _, err := os.Stat(absSpecFile); os.IsNotExist(err) {
-			err := fmt.Errorf("spec file does not exist: %s", absSpecFile)
+			err := utils.WithExitCode(utils.ExitCodeNotFound, fmt.Errorf("spec file does not exist: %s", absSpecFile))
 			return deployProgressError(spinner, sm, err)
 		}
 
@@ -355,8 +556,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			return deployProgressError(spinner, sm, pkgerrors.Wrap(err, "failed to read spec file"))
 		}
 
-		// Process template expressions recursively
-		processedData, err = processTemplateExpressions(fileData, filepath.Dir(absSpecFile))
+		processedData, err = renderSpecFile(fileData, absSpecFile)
 		if err != nil {
 			return deployProgressError(spinner, sm, pkgerrors.Wrap(err, "failed to process template expressions"))
 		}
@@ -380,6 +580,13 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 				return deployProgressError(spinner, sm, err)
 			}
 
+			// Warn about deprecated compose keys without blocking the deploy
+			if specType == build.DockerComposeSpecType {
+				if warnings := build.FindDeprecatedKeys(planCheck, build.DeprecatedComposeKeys); len(warnings) > 0 {
+					fmt.Fprintf(os.Stderr, "Warning: spec file '%s' uses deprecated keys that will be removed in a future release:\n%s\n",
+						specFile, build.FormatDeprecatedKeyWarnings(warnings))
+				}
+			}
 		} else {
 			// Fallback to file extension based detection
 			fileToRead := filepath.Base(absSpecFile)
@@ -389,8 +596,23 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 				specType = build.DockerComposeSpecType
 			}
 		}
+
+		// Point the overridden services at the given images before the spec is built
+		if len(imageOverrides) > 0 {
+			if specType != build.DockerComposeSpecType {
+				return deployProgressError(spinner, sm, errors.New("--set-image can only be used with a compose spec"))
+			}
+			if processedData, err = applyImageOverrides(processedData, imageOverrides); err != nil {
+				return deployProgressError(spinner, sm, err)
+			}
+		}
 	}
 
+	if len(imageOverrides) > 0 && buildFromRepo {
+		return deployProgressError(spinner, sm, errors.New("--set-image requires a compose spec; none was found to deploy"))
+	}
+
+	timings.Start("Cloud account check")
 	spinner.UpdateMessage("Step 1/2: Checking cloud provider accounts...")
 
 	isAccountId := false
@@ -427,6 +649,14 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		cloudProvidersToCheck = append(cloudProvidersToCheck, "azure")
 	}
 
+	// If spec constrains to exactly one provider, use it rather than the offering's first provider
+	if cloudProvider == "" {
+		if inferred := inferCloudProviderFromSpec(awsAccountID, gcpProjectID, azureSubscriptionID); inferred != "" {
+			cloudProvider = inferred
+			spinner.UpdateMessage(fmt.Sprintf("Step 1/2: Checking cloud provider accounts... (cloud provider '%s' inferred from spec)", cloudProvider))
+		}
+	}
+
 	// If spec does not constrain providers, check all
 	if len(cloudProvidersToCheck) == 0 {
 		if cloudProvider != "" {
@@ -445,7 +675,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	for _, cp := range cloudProvidersToCheck {
 		// Pre-check 1: Check for linked cloud provider accounts
-		accounts, err := dataaccess.ListAccounts(cmd.Context(), token, cp)
+		accounts, err := dataaccess.ListAccounts(ctx, token, cp)
 		if err != nil {
 			return deployProgressError(spinner, sm, backendError("cloud provider account lookup", err))
 		}
@@ -550,57 +780,42 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 				)
 				spinner.UpdateMessage("Step 1/2: Service creation requires at least one READY cloud provider account")
 				return deployProgressError(spinner, sm, err)
+			} else if checkAccounts {
+				err := utils.WithExitCode(utils.ExitCodeNotFound, errors.New(
+					"no cloud provider accounts found. Link one with 'omnistrate-ctl account create'"))
+				return deployProgressError(spinner, sm, err)
 			} else {
 				// No accounts at all: start interactive account creation flow
 				utils.HandleSpinnerSuccess(spinner, sm, "No cloud provider accounts found. Starting cloud account creation flow...")
 
-				// Determine which cloud provider to use and get credentials
-				if cloudProvider == "" {
-					cloudProvider = promptForCloudProvider()
-				}
+				// Determine which cloud provider to use and get credentials, unless they were piped with
+				// --credentials-stdin
+				accountCredentials := credentials
+				if accountCredentials == nil {
+					if cloudProvider == "" {
+						if cloudProvider, err = promptForCloudProvider(utils.StdinPrompter()); err != nil {
+							return err
+						}
+					}
 
-				// Get cloud-specific credentials
-				paramsJSON, err := promptForCloudCredentials(cloudProvider)
-				if err != nil {
-					return fmt.Errorf("failed to get cloud credentials: %w", err)
-				}
+					// Get cloud-specific credentials
+					paramsJSON, err := promptForCloudCredentials(utils.StdinPrompter(), cloudProvider)
+					if err != nil {
+						return fmt.Errorf("failed to get cloud credentials: %w", err)
+					}
 
-				// Parse the JSON to extract credentials
-				var paramsMap map[string]interface{}
-				if err := json.Unmarshal([]byte(paramsJSON), &paramsMap); err != nil {
-					return fmt.Errorf("failed to parse credentials: %w", err)
+					if accountCredentials, err = parseCloudCredentials([]byte(paramsJSON), cloudProvider); err != nil {
+						return fmt.Errorf("failed to parse credentials: %w", err)
+					}
 				}
 
 				// Create account params based on cloud provider
-				accountParams := account.CloudAccountParams{
-					Name: fmt.Sprintf("%s-account-%d", cloudProvider, time.Now().Unix()),
-				}
-
-				switch cloudProvider {
-				case "aws":
-					if awsAccountID, ok := paramsMap["aws_account_id"].(string); ok {
-						accountParams.AwsAccountID = awsAccountID
-					}
-				case "gcp":
-					if gcpProjectID, ok := paramsMap["gcp_project_id"].(string); ok {
-						accountParams.GcpProjectID = gcpProjectID
-					}
-					if gcpProjectNumber, ok := paramsMap["gcp_project_number"].(string); ok {
-						accountParams.GcpProjectNumber = gcpProjectNumber
-					}
-				case "azure":
-					if azureSubscriptionID, ok := paramsMap["azure_subscription_id"].(string); ok {
-						accountParams.AzureSubscriptionID = azureSubscriptionID
-					}
-					if azureTenantID, ok := paramsMap["azure_tenant_id"].(string); ok {
-						accountParams.AzureTenantID = azureTenantID
-					}
-				}
+				accountParams := accountCredentials.accountParams(fmt.Sprintf("%s-account-%d", accountCredentials.CloudProvider, time.Now().Unix()))
 
 				// Create the cloud provider account
 				sm.Start()
 				spinner = sm.AddSpinner("Creating cloud provider account...")
-				accountData, err := account.CreateCloudAccount(cmd.Context(), token, accountParams, spinner, sm)
+				accountData, err := account.CreateCloudAccount(ctx, token, accountParams, spinner, sm)
 				if err != nil || accountData == nil {
 					if err == nil {
 						err = errors.New("cloud provider account creation returned empty result")
@@ -609,7 +824,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 				}
 				dataaccess.PrintNextStepVerifyAccountMsg(accountData)
 				// Wait for account to become READY (poll up to 10 min)
-				err = account.WaitForAccountReady(cmd.Context(), token, accountData.Id)
+				err = account.WaitForAccountReady(ctx, token, accountData.Id)
 				if err != nil {
 					return deployProgressError(spinner, sm, fmt.Errorf("account did not become READY: %w", err))
 				}
@@ -643,7 +858,17 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	}
 
+	// Account check exit point: nothing is built or deployed
+	if checkAccounts {
+		utils.HandleSpinnerSuccess(spinner, sm, "Cloud provider account check passed")
+		fmt.Println()
+		fmt.Print(formatAccountCheckSummary(readyAccounts, accountStatusSummary))
+		fmt.Println("No service or instance was created. Run the command without --check-accounts to deploy.")
+		return nil
+	}
+
 	// Pre-check 2: Determine service name
+	timings.Start("Service lookup")
 	spinner = sm.AddSpinner("Step 1/2: Determining service name...")
 
 	var serviceNameToUse string
@@ -671,7 +896,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	// Pre-check 3: Check if service exists and validate service plan count
 	spinner = sm.AddSpinner(fmt.Sprintf("Step 1/2: Checking for existing service '%s'...", serviceNameToUse))
-	existingServiceID, err := findExistingService(cmd.Context(), token, serviceNameToUse)
+	existingServiceID, err := findExistingService(ctx, token, serviceNameToUse)
 	if err != nil {
 		utils.HandleSpinnerError(spinner, sm, err)
 		return err
@@ -690,16 +915,20 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	var serviceID, environmentID, planID string
 	var undefinedResources map[string]string
+	var unchangedBuild *deployBuildTarget
+	var buildHash string // recorded on the built version once the deploy has passed every check
 
 	if specType == build.DockerComposeSpecType && buildFromRepo {
+		// The repo build records its own phases: docker build and push, compose spec generation and service build
 		spinner.UpdateMessage("Step 1/2: No spec file found, building service from repository...")
 		spinner.Complete()
 		serviceID, environmentID, planID, undefinedResources, err = build.BuildServiceFromRepository(
 			cmd,
-			cmd.Context(),
+			ctx,
 			token,
 			serviceNameToUse,
-			"",
+			releaseName,
+			description,
 			false,
 			dryRun,
 			skipDockerBuild,
@@ -712,7 +941,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			azureTenantID,
 			sm,
 			build.OmnistrateComposeFileName,
-			[]string{},
+			envVars,
 			platforms,
 			false,
 		)
@@ -725,6 +954,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		sm.Start()
 
 	} else {
+		timings.Start("Service build")
 
 		if !isAccountId {
 			// Use createDeploymentYAML to generate the deployment section
@@ -786,26 +1016,65 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		serviceID, environmentID, planID, undefinedResources, _, err = build.BuildService(
-			cmd.Context(),
-			processedData,
-			token,
-			serviceNameToUse,
-			specType,
-			nil,
-			nil,
-			&environment,
-			&environmentTypeUpper,
-			true,
-			true,
-			nil,
-			dryRun,
-			false,
-		)
-		if err != nil {
-			utils.HandleSpinnerError(spinner, sm, err)
-			wrapAndPrintServiceBuildError(err)
-			return err
+		// Skip the build when the spec, its images and the other build inputs are unchanged since the last deploy
+		if !forceBuild && !dryRun {
+			inputs := deployBuildInputs{
+				serviceName:        serviceNameToUse,
+				specType:           specType,
+				environment:        environment,
+				environmentType:    environmentTypeUpper,
+				release:            true,
+				releaseAsPreferred: true,
+				strict:             strict,
+			}
+			if descriptionPtr != nil {
+				inputs.description = *descriptionPtr
+			}
+			if releaseNamePtr != nil {
+				inputs.releaseName = *releaseNamePtr
+			}
+			if buildHash, err = deployBuildHash(inputs, processedData, inspectImageDigest); err != nil {
+				utils.Verbosef("Not checking for an unchanged build: %v", err)
+				buildHash = ""
+			} else if existingServiceID != "" {
+				unchangedBuild, err = findUnchangedBuild(ctx, token, existingServiceID, environment, buildHash)
+				if err != nil {
+					utils.Verbosef("Not checking for an unchanged build: %v", err)
+					unchangedBuild = nil
+				}
+			}
+		}
+
+		if unchangedBuild != nil {
+			serviceID, environmentID, planID = unchangedBuild.serviceID, unchangedBuild.environmentID, unchangedBuild.planID
+			undefinedResources, err = unchangedBuildUndefinedResources(ctx, token, processedData, serviceNameToUse, specType, descriptionPtr, environment, environmentTypeUpper, releaseNamePtr)
+			if err != nil {
+				utils.HandleSpinnerError(spinner, sm, err)
+				wrapAndPrintServiceBuildError(err)
+				return err
+			}
+		} else {
+			serviceID, environmentID, planID, undefinedResources, _, err = build.BuildService(
+				ctx,
+				processedData,
+				token,
+				serviceNameToUse,
+				specType,
+				descriptionPtr,
+				nil,
+				&environment,
+				&environmentTypeUpper,
+				true,
+				true,
+				releaseNamePtr,
+				dryRun,
+				false,
+			)
+			if err != nil {
+				utils.HandleSpinnerError(spinner, sm, err)
+				wrapAndPrintServiceBuildError(err)
+				return err
+			}
 		}
 
 	}
@@ -821,7 +1090,11 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		fmt.Println("To proceed with actual deployment, run the command without the --dry-run flag.")
 		return nil
 	}
-	spinner.UpdateMessage(fmt.Sprintf("Step 1/2: Built service '%s' in environment %s (%s), Service ID: %s", serviceNameToUse, environment, environmentTypeUpper, serviceID))
+	if unchangedBuild != nil {
+		spinner.UpdateMessage(fmt.Sprintf("Step 1/2: No changes, skipping build of service '%s' (version %s), Service ID: %s", serviceNameToUse, unchangedBuild.version, serviceID))
+	} else {
+		spinner.UpdateMessage(fmt.Sprintf("Step 1/2: Built service '%s' in environment %s (%s), Service ID: %s", serviceNameToUse, environment, environmentTypeUpper, serviceID))
+	}
 	spinner.Complete()
 
 	// Print warning if there are any undefined resources
@@ -831,16 +1104,28 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			utils.PrintWarning(fmt.Sprintf("  %s: %s", resourceName, resourceID))
 		}
 		utils.PrintWarning("These resources were not processed during the build.")
+		if strict {
+			err = build.UndefinedResourcesError(undefinedResources)
+			utils.PrintError(err)
+			return err
+		}
 		sm = utils.NewSpinnerManager()
 		sm.Start()
 	}
 
 	// Execute post-service-build deployment workflow
-	err = executeDeploymentWorkflow(cmd, sm, token, serviceID, environmentID, planID, serviceNameToUse, environment, environmentTypeUpper, instanceID, cloudProvider, region, param, paramFile, resourceID, deploymentType)
+	err = executeDeploymentWorkflow(cmd, ctx, sm, token, serviceID, environmentID, planID, serviceNameToUse, environment, environmentTypeUpper, instanceID, cloudProvider, region, param, paramFile, resourceIDs, deploymentType, credentials)
 	if err != nil {
 		return err
 	}
 
+	// Record the build hash only now, so that a failed or rejected deploy builds again on the next run
+	if unchangedBuild == nil && buildHash != "" {
+		if err = recordBuildHash(ctx, token, serviceID, planID, buildHash); err != nil {
+			utils.Verbosef("Failed to record the build hash: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -849,22 +1134,33 @@ type deployResolvedTarget struct {
 	region        string
 }
 
+// deployInstanceResult records the outcome of deploying one resource.
+type deployInstanceResult struct {
+	resourceID string
+	instanceID string
+	err        error
+}
+
 // executeDeploymentWorkflow handles the complete post-service-build deployment workflow
-// This function is reusable for both deploy and build_simple commands
-func executeDeploymentWorkflow(cmd *cobra.Command, sm utils.SpinnerManager, token, serviceID, environmentID, planID, serviceName, environment, environmentTypeUpper, instanceID, cloudProvider, region, param, paramFile, resourceID, deploymentType string) error {
+// This function is reusable for both deploy and build_simple commands.
+// When several resource IDs are given, an instance is created for each of them.
+func executeDeploymentWorkflow(cmd *cobra.Command, ctx context.Context, sm utils.SpinnerManager, token, serviceID, environmentID, planID, serviceName, environment, environmentTypeUpper, instanceID, cloudProvider, region, param, paramFile string, resourceIDs []string, deploymentType string, credentials *cloudCredentials) error {
+
+	timings := utils.PhaseTimingsFromContext(ctx)
 
 	// Step 7: Set service plan as preferred in environment
+	timings.Start("Service plan promotion")
 	spinner := sm.AddSpinner(fmt.Sprintf("Step 1/2: Setting service plan as preferred in %s...", environment))
 
 	// Find the latest version of the environment plan
-	targetVersion, err := dataaccess.FindLatestVersion(cmd.Context(), token, serviceID, planID)
+	targetVersion, err := dataaccess.FindLatestVersion(ctx, token, serviceID, planID)
 	if err != nil {
 		utils.HandleSpinnerError(spinner, sm, err)
 		return err
 	}
 
 	// Set as preferred
-	_, err = dataaccess.SetDefaultServicePlan(cmd.Context(), token, serviceID, planID, targetVersion)
+	_, err = dataaccess.SetDefaultServicePlan(ctx, token, serviceID, planID, targetVersion)
 	if err != nil {
 		utils.HandleSpinnerError(spinner, sm, err)
 		return err
@@ -873,8 +1169,10 @@ func executeDeploymentWorkflow(cmd *cobra.Command, sm utils.SpinnerManager, toke
 	spinner.Complete()
 
 	// Step 9: Create or upgrade instance deployment automatically
+	timings.Start("Instance create or upgrade")
 
 	var finalInstanceID string
+	var results []deployInstanceResult
 	instanceActionType := "create"
 	resolvedTarget := &deployResolvedTarget{cloudProvider: cloudProvider, region: region}
 
@@ -886,7 +1184,7 @@ func executeDeploymentWorkflow(cmd *cobra.Command, sm utils.SpinnerManager, toke
 		spinner.UpdateMessage(spinnerMsg)
 
 		var existingInstanceIDs []string
-		existingInstanceIDs, _, err = listInstances(cmd.Context(), token, serviceID, environmentID, planID, instanceID, "excludeCloudAccounts")
+		existingInstanceIDs, _, err = listInstances(ctx, token, serviceID, environmentID, planID, instanceID, "excludeCloudAccounts")
 		if err != nil {
 			utils.HandleSpinnerError(spinner, sm, err)
 			spinner.UpdateMessage(spinnerMsg + ": Failed (" + err.Error() + ")")
@@ -935,7 +1233,7 @@ func executeDeploymentWorkflow(cmd *cobra.Command, sm utils.SpinnerManager, toke
 		spinner = sm.AddSpinner(fmt.Sprintf("Step 2/2: Upgrading existing instance %s to latest version...", finalInstanceID))
 		spinner.Complete()
 		spinner = sm.AddSpinner("Step 2/2: Upgrading existing instance")
-		upgradeErr := upgradeExistingInstance(cmd.Context(), token, []string{finalInstanceID}, serviceID, environmentID, planID)
+		upgradeErr := upgradeExistingInstance(ctx, token, []string{finalInstanceID}, serviceID, environmentID, planID)
 		instanceActionType = "upgrade"
 		if upgradeErr != nil {
 			utils.HandleSpinnerError(spinner, sm, upgradeErr)
@@ -970,7 +1268,7 @@ func executeDeploymentWorkflow(cmd *cobra.Command, sm utils.SpinnerManager, toke
 			}
 
 			fmt.Printf("BYOA deployment detected. Creating cloud account instance...\n")
-			cloudAccountInstanceID, targetCloudProvider, err := createCloudAccountInstances(cmd.Context(), token, serviceID, environmentID, planID, cloudProvider, sm)
+			cloudAccountInstanceID, targetCloudProvider, err := createCloudAccountInstances(ctx, token, serviceID, environmentID, planID, cloudProvider, credentials, sm)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: Failed to create cloud account instances: %v\n", err)
 				return err
@@ -981,41 +1279,117 @@ func executeDeploymentWorkflow(cmd *cobra.Command, sm utils.SpinnerManager, toke
 
 		}
 
-		createdInstanceID, err := "", error(nil)
-		createdInstanceID, err = createInstanceUnifiedWithSpinnerManager(cmd.Context(), token, serviceID, environmentID, planID, cloudProvider, region, resourceID, "resourceInstance", formattedParams, sm, resolvedTarget)
-		finalInstanceID = createdInstanceID
-		// instanceActionType is already "create" from initialization
-		if err != nil {
-			if isMissingParamsError(err) {
-				err = missingParamsGuidanceError(err)
+		if len(resourceIDs) <= 1 {
+			var resourceID string
+			if len(resourceIDs) == 1 {
+				resourceID = resourceIDs[0]
 			}
-			return deployProgressError(nil, sm, err)
+			createdInstanceID, err := createInstanceUnifiedWithSpinnerManager(ctx, token, serviceID, environmentID, planID, cloudProvider, region, resourceID, "resourceInstance", formattedParams, sm, resolvedTarget)
+			finalInstanceID = createdInstanceID
+			// instanceActionType is already "create" from initialization
+			if err != nil {
+				if isMissingParamsError(err) {
+					err = missingParamsGuidanceError(err)
+				}
+				return deployProgressError(nil, sm, err)
+			}
+			// Instance created successfully - createInstanceUnified handles its own spinner
+		} else {
+			results = createInstancesForResources(ctx, token, serviceID, environmentID, planID, cloudProvider, region, resourceIDs, formattedParams, sm, resolvedTarget)
 		}
-		// Instance created successfully - createInstanceUnified handles its own spinner
+	}
 
+	if results == nil {
+		results = []deployInstanceResult{{instanceID: finalInstanceID}}
 	}
 
 	// Ensure spinner manager is fully stopped before printing summary
 	sm.Stop()
 
-	printDeploymentSummary(serviceName, serviceID, environment, environmentTypeUpper, planID, instanceActionType, finalInstanceID)
+	printDeploymentSummary(serviceName, serviceID, environment, environmentTypeUpper, planID, instanceActionType, results)
 
 	// Optionally display workflow progress if desired
-	if finalInstanceID != "" {
-		err = instance.DisplayWorkflowResourceDataWithSpinners(cmd.Context(), token, finalInstanceID, instanceActionType, resolvedTarget.cloudProvider, resolvedTarget.region)
+	timings.Start("Deployment workflow wait")
+	var failed []string
+	for _, result := range results {
+		if result.err != nil {
+			failed = append(failed, result.resourceID)
+			continue
+		}
+		if result.instanceID == "" {
+			continue
+		}
+		if result.resourceID != "" {
+			fmt.Printf("Following deployment of resource %s (instance %s)\n", result.resourceID, result.instanceID)
+		}
+		err = instance.DisplayWorkflowResourceDataWithSpinners(ctx, token, result.instanceID, instanceActionType, resolvedTarget.cloudProvider, resolvedTarget.region)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Deployment workflow failed: %s\n", err)
-			return err
+			if len(results) == 1 {
+				return err
+			}
+			failed = append(failed, result.resourceID)
+			continue
 		}
-		if endpointErr := instance.PrintEndpointsForInstance(cmd.Context(), token, serviceID, environmentID, finalInstanceID); endpointErr != nil {
+		if endpointErr := instance.PrintEndpointsForInstance(ctx, token, serviceID, environmentID, result.instanceID); endpointErr != nil {
 			fmt.Fprintf(os.Stderr, "Endpoint lookup failed: %s\n", endpointErr)
 		}
 		fmt.Println("Deployment successful")
 	}
 
+	timings.Stop()
+
+	if len(failed) > 0 {
+		return fmt.Errorf("deployment failed for %d of %d resources: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+
+	if watchLogs, _ := cmd.Flags().GetBool("watch-logs"); watchLogs {
+		var targets []instance.LogWatchTarget
+		for _, result := range results {
+			if result.instanceID != "" {
+				targets = append(targets, instance.LogWatchTarget{InstanceID: result.instanceID, ResourceID: result.resourceID})
+			}
+		}
+		if len(targets) > 0 {
+			noColor, _ := cmd.Flags().GetBool("no-color")
+			// Log streaming runs until Ctrl-C, so it is not bound by --deploy-timeout
+			err = instance.WatchInstancesLogs(cmd.Context(), token, serviceID, environmentID, targets, instance.WatchLogsNoColor(noColor), os.Stdout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Log streaming unavailable: %s\n", err)
+			}
+		}
+	}
+
 	return nil
 }
 
+// createInstancesForResources creates one instance per resource ID. A failure for one resource is
+// recorded in its result and does not stop the remaining resources from being deployed.
+func createInstancesForResources(ctx context.Context, token, serviceID, environmentID, planID, cloudProvider, region string, resourceIDs []string, formattedParams map[string]interface{}, sm utils.SpinnerManager, resolvedTarget *deployResolvedTarget) []deployInstanceResult {
+	results := make([]deployInstanceResult, 0, len(resourceIDs))
+	for idx, resourceID := range resourceIDs {
+		spinner := sm.AddSpinner(fmt.Sprintf("Step 2/2: Creating instance for resource %s (%d/%d)", resourceID, idx+1, len(resourceIDs)))
+
+		createdInstanceID, err := createInstanceUnifiedWithSpinnerManager(ctx, token, serviceID, environmentID, planID, cloudProvider, region, resourceID, "resourceInstance", formattedParams, sm, resolvedTarget)
+		if err != nil && isMissingParamsError(err) {
+			err = missingParamsGuidanceError(err)
+		}
+		if err != nil {
+			spinner.UpdateMessage(fmt.Sprintf("Step 2/2: Creating instance for resource %s (%d/%d): Failed (%s)", resourceID, idx+1, len(resourceIDs), err.Error()))
+			spinner.Error()
+		} else {
+			spinner.UpdateMessage(fmt.Sprintf("Step 2/2: Created instance %s for resource %s (%d/%d)", createdInstanceID, resourceID, idx+1, len(resourceIDs)))
+			spinner.Complete()
+		}
+		results = append(results, deployInstanceResult{
+			resourceID: resourceID,
+			instanceID: createdInstanceID,
+			err:        err,
+		})
+	}
+	return results
+}
+
 // createInstanceUnified creates an instance with or without subscription, removing duplicate code
 func createInstanceUnified(ctx context.Context, token, serviceID, environmentID, productTierID, cloudProvider, region, resourceID, instanceType string, formattedParams map[string]interface{}) (string, error) {
 	sm := utils.NewSpinnerManager()
@@ -1121,7 +1495,7 @@ func createInstanceUnifiedWithSpinnerManager(ctx context.Context, token, service
 				}
 			}
 			if resourceKey == "" {
-				return "", fmt.Errorf("resource ID '%s' not found in service plan", resourceID)
+				return "", utils.WithExitCode(utils.ExitCodeNotFound, fmt.Errorf("resource ID '%s' not found in service plan", resourceID))
 			}
 		}
 
@@ -1133,6 +1507,10 @@ func createInstanceUnifiedWithSpinnerManager(ctx context.Context, token, service
 				resourceID = resources.Resources[0].Id
 			}
 			if len(resources.Resources) > 1 {
+				if !isInteractivePromptEnabled() {
+					return "", multipleResourcesNonInteractiveError(resources.Resources)
+				}
+
 				// Stop spinner before prompting user
 
 				utils.HandleSpinnerSuccess(spinner, sm, "Multiple resources found in service plan. Please select one:")
@@ -1140,14 +1518,9 @@ func createInstanceUnifiedWithSpinnerManager(ctx context.Context, token, service
 				for idx, resource := range resources.Resources {
 					fmt.Printf("  %d. Name: %s, Key: %s, ID: %s\n", idx+1, resource.Name, resource.Key, resource.Id)
 				}
-				var choice int
-				for {
-					fmt.Printf("Select resource (1-%d): ", len(resources.Resources))
-					_, err := fmt.Scanln(&choice)
-					if err == nil && choice > 0 && choice <= len(resources.Resources) {
-						break
-					}
-					fmt.Println("Invalid selection. Please enter a valid number.")
+				choice, err := utils.StdinPrompter().PromptChoice(fmt.Sprintf("Select resource (1-%d): ", len(resources.Resources)), 1, len(resources.Resources))
+				if err != nil {
+					return "", fmt.Errorf("failed to read resource selection: %w", err)
 				}
 				selected := resources.Resources[choice-1]
 				resourceKey = selected.Key
@@ -1188,9 +1561,13 @@ func createInstanceUnifiedWithSpinnerManager(ctx context.Context, token, service
 
 		// Extract CREATE verb parameters and set defaults
 		paramDisplayNames := make(map[string]string)
+		var createInputParams []openapiclientfleet.InputParameterEntity
+		foundCreateAPI := false
 		if len(resApiParams.ConsumptionDescribeServiceOfferingResourceResult.Apis) > 0 {
 			for _, apiSpec := range resApiParams.ConsumptionDescribeServiceOfferingResourceResult.Apis {
 				if apiSpec.Verb == "CREATE" {
+					createInputParams = apiSpec.InputParameters
+					foundCreateAPI = true
 
 					for _, inputParam := range apiSpec.InputParameters {
 						if inputParam.DisplayName != "" {
@@ -1227,6 +1604,15 @@ func createInstanceUnifiedWithSpinnerManager(ctx context.Context, token, service
 			}
 		}
 
+		// Report every problem with the given parameters at once, before prompting or creating the instance.
+		// Missing required parameters are prompted for when the prompt is available.
+		if foundCreateAPI {
+			if err := validateParamsAgainstSchema(formattedParams, createInputParams, !isInteractivePromptEnabled()); err != nil {
+				sm.Stop()
+				return "", err
+			}
+		}
+
 		// Check for missing required parameters
 		var defaultRequiredParams []string
 		for k, v := range defaultParams {
@@ -1339,7 +1725,8 @@ func listInstances(ctx context.Context, token, serviceID, environmentID, service
 	status        string
 }, error) {
 
-	res, err := dataaccess.ListResourceInstance(ctx, token, serviceID, environmentID,
+	// Follow every page so instances beyond the first page are matched as well
+	resourceInstances, err := dataaccess.ListAllResourceInstances(ctx, token, serviceID, environmentID,
 		&dataaccess.ListResourceInstanceOptions{
 			ProductTierId: &servicePlanID,
 			Filter:        &filter,
@@ -1361,10 +1748,10 @@ func listInstances(ctx context.Context, token, serviceID, environmentID, service
 		status        string
 	}, 0)
 
-	if len(res.ResourceInstances) == 0 {
+	if len(resourceInstances) == 0 {
 		return []string{}, instances, nil
 	}
-	for _, instance := range res.ResourceInstances {
+	for _, instance := range resourceInstances {
 		var idStr string
 		if instance.ConsumptionResourceInstanceResult.Id != nil {
 			idStr = *instance.ConsumptionResourceInstanceResult.Id
@@ -1469,6 +1856,24 @@ func findAllOmnistrateServicePlanBlocks(yamlContent interface{}) []map[string]in
 	return results
 }
 
+// inferCloudProviderFromSpec returns the single cloud provider the spec's account identifiers point to, or "".
+func inferCloudProviderFromSpec(awsAccountID, gcpProjectID, azureSubscriptionID string) string {
+	var providers []string
+	if awsAccountID != "" {
+		providers = append(providers, "aws")
+	}
+	if gcpProjectID != "" {
+		providers = append(providers, "gcp")
+	}
+	if azureSubscriptionID != "" {
+		providers = append(providers, "azure")
+	}
+	if len(providers) != 1 {
+		return ""
+	}
+	return providers[0]
+}
+
 // extractCloudAccountsFromProcessedData extracts cloud provider account information from the YAML content
 func extractCloudAccountsFromProcessedData(processedData []byte) (awsAccountID, awsBootstrapRoleARN, gcpProjectID, gcpProjectNumber, gcpServiceAccountEmail, azureSubscriptionID, azureTenantID, extractDeploymentType string) {
 	if len(processedData) == 0 {
@@ -1617,78 +2022,6 @@ func sanitizeServiceName(name string) string {
 	return name
 }
 
-// processTemplateExpressions processes template expressions like {{ $file:path }} recursively
-func processTemplateExpressions(data []byte, baseDir string) ([]byte, error) {
-	content := string(data)
-
-	// Pattern to match {{ $file:path }}
-	re := regexp.MustCompile(`(?m)^(?P<indent>[ \t]*)?(?P<key>[\S\t ]*)?{{\s*\$file:(?P<filepath>[^\s}]+)\s*}}`)
-
-	for re.MatchString(content) {
-		var processingErr error
-		content = re.ReplaceAllStringFunc(content, func(match string) string {
-			submatches := re.FindStringSubmatch(match)
-			if len(submatches) < 4 {
-				processingErr = fmt.Errorf("invalid file reference: %s", match)
-				return match
-			}
-
-			indent := submatches[1]
-			key := submatches[2]
-			filePath := submatches[3]
-
-			if filePath == "" {
-				processingErr = fmt.Errorf("empty file path in reference: %s", match)
-				return match
-			}
-
-			// Resolve file path
-			var fullPath string
-			if filepath.IsAbs(filePath) {
-				fullPath = filePath
-			} else {
-				fullPath = filepath.Join(baseDir, filePath)
-			}
-
-			// Read file content
-			fileContent, err := os.ReadFile(fullPath)
-			if err != nil {
-				processingErr = fmt.Errorf("failed to read file %s: %v", fullPath, err)
-				return match
-			}
-
-			// Process nested template expressions
-			processedContent, err := processTemplateExpressions(fileContent, filepath.Dir(fullPath))
-			if err != nil {
-				processingErr = fmt.Errorf("failed to process templates in %s: %v", fullPath, err)
-				return match
-			}
-
-			// Apply indentation
-			lines := strings.Split(string(processedContent), "\n")
-			result := make([]string, len(lines))
-
-			for i, line := range lines {
-				if i == 0 {
-					result[i] = indent + key + line
-				} else if strings.TrimSpace(line) != "" {
-					result[i] = indent + line
-				} else {
-					result[i] = line
-				}
-			}
-
-			return strings.Join(result, "\n")
-		})
-
-		if processingErr != nil {
-			return nil, processingErr
-		}
-	}
-
-	return []byte(content), nil
-}
-
 // createDeploymentYAML generates a YAML document for deployment based on modelType, creationMethod, and cloud account flags
 // Returns a map[string]interface{} representing the YAML structure
 func createDeploymentYAML(
@@ -1827,7 +2160,7 @@ type CloudInstanceStatus struct {
 	Provider string
 }
 
-func createCloudAccountInstances(ctx context.Context, token, serviceID, environmentID, planID, cloudProvider string, sm utils.SpinnerManager) (string, string, error) {
+func createCloudAccountInstances(ctx context.Context, token, serviceID, environmentID, planID, cloudProvider string, credentials *cloudCredentials, sm utils.SpinnerManager) (string, string, error) {
 
 	spinnerMsg := "Step 2/2: Checking for existing cloud account instances"
 	spinner := sm.AddSpinner(spinnerMsg)
@@ -1858,8 +2191,9 @@ func createCloudAccountInstances(ctx context.Context, token, serviceID, environm
 
 	spinner.Complete()
 
-	// If we have READY instances for any cloud provider, show them and let user choose
-	if len(readyInstances) > 0 {
+	// If we have READY instances for any cloud provider, show them and let user choose. Piped credentials are
+	// always onboarded as a new instance, as there is no one to choose.
+	if len(readyInstances) > 0 && credentials == nil {
 		utils.HandleSpinnerSuccess(spinner, sm, "Available READY cloud account instances:")
 
 		// Create a list of all available instances with their providers
@@ -1883,13 +2217,9 @@ func createCloudAccountInstances(ctx context.Context, token, serviceID, environm
 		}
 		fmt.Println("  0. Create a new cloud account instance")
 
-		var choice int
-		for {
-			fmt.Printf("Select cloud account instance (0-%d): ", len(instanceOptions))
-			if _, err := fmt.Scanln(&choice); err == nil && choice >= 0 && choice <= len(instanceOptions) {
-				break
-			}
-			fmt.Println("Invalid selection. Please enter a valid number.")
+		choice, err := utils.StdinPrompter().PromptChoice(fmt.Sprintf("Select cloud account instance (0-%d): ", len(instanceOptions)), 0, len(instanceOptions))
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read cloud account instance selection: %w", err)
 		}
 
 		if choice == 0 {
@@ -1907,15 +2237,24 @@ func createCloudAccountInstances(ctx context.Context, token, serviceID, environm
 	// No READY instances found, create a new one
 	utils.HandleSpinnerSuccess(spinner, sm, "No READY cloud account instances found. Creating a new one.")
 
-	// Determine which cloud provider to use and get credentials
-	if targetCloudProvider == "" {
-		targetCloudProvider = promptForCloudProvider()
-	}
+	// Determine which cloud provider to use and get credentials, unless they were piped with --credentials-stdin
+	var params string
+	if credentials != nil {
+		targetCloudProvider = credentials.CloudProvider
+		if params, err = credentials.instanceParams(); err != nil {
+			return "", targetCloudProvider, err
+		}
+	} else {
+		if targetCloudProvider == "" {
+			if targetCloudProvider, err = promptForCloudProvider(utils.StdinPrompter()); err != nil {
+				return "", "", err
+			}
+		}
 
-	// Get cloud-specific credentials
-	params, err := promptForCloudCredentials(targetCloudProvider)
-	if err != nil {
-		return "", targetCloudProvider, fmt.Errorf("failed to get cloud credentials: %w", err)
+		// Get cloud-specific credentials
+		if params, err = promptForCloudCredentials(utils.StdinPrompter(), targetCloudProvider); err != nil {
+			return "", targetCloudProvider, fmt.Errorf("failed to get cloud credentials: %w", err)
+		}
 	}
 
 	// Format parameters
@@ -1924,11 +2263,6 @@ func createCloudAccountInstances(ctx context.Context, token, serviceID, environm
 		return "", targetCloudProvider, err
 	}
 
-	// Determine which cloud provider to use and get credentials
-	if targetCloudProvider == "" {
-		targetCloudProvider = promptForCloudProvider()
-	}
-
 	createdInstanceID, err := createInstanceUnified(ctx, token, serviceID, environmentID, planID, targetCloudProvider, "", "", "cloudAccount", formattedParams)
 	if err != nil {
 		sm = utils.NewSpinnerManager()
@@ -2018,6 +2352,12 @@ func inferCloudProviderFromRegion(offering openapiclient.ServiceOffering, region
 		}
 	}
 
+	// An offering with a single cloud provider that lists no regions for it, as some Azure offerings do, accepts
+	// the region as given
+	if len(offering.CloudProviders) == 1 && len(regionsForCloudProvider(offering, offering.CloudProviders[0])) == 0 {
+		return offering.CloudProviders[0]
+	}
+
 	return ""
 }
 
@@ -2118,55 +2458,35 @@ func ensureReadyNebiusAccountForRegion(ctx context.Context, token, region string
 }
 
 // promptForCloudProvider prompts user to select a cloud provider
-func promptForCloudProvider() string {
+func promptForCloudProvider(prompter *utils.LinePrompter) (string, error) {
 	fmt.Println("Available cloud providers:")
 	fmt.Println("  1. AWS")
 	fmt.Println("  2. GCP")
 	fmt.Println("  3. Azure")
 	fmt.Println("  4. Nebius")
 
-	var choice int
-	for {
-		fmt.Print("Select cloud provider (1-4): ")
-		_, err := fmt.Scanln(&choice)
-		if err == nil && choice >= 1 && choice <= 4 {
-			break
-		}
-		fmt.Println("Invalid selection. Please enter 1, 2, 3, or 4.")
-	}
-
-	switch choice {
-	case 1:
-		return "aws"
-	case 2:
-		return "gcp"
-	case 3:
-		return "azure"
-	case 4:
-		return "nebius"
-	default:
-		return "aws" // fallback
+	choice, err := prompter.PromptChoice(fmt.Sprintf("Select cloud provider (1-%d): ", len(deployCloudProviders)), 1, len(deployCloudProviders))
+	if err != nil {
+		return "", fmt.Errorf("failed to read cloud provider selection: %w", err)
 	}
+	return deployCloudProviders[choice-1], nil
 }
 
 // promptForCloudCredentials prompts user for cloud-specific credentials
-func promptForCloudCredentials(cloudProvider string) (string, error) {
+func promptForCloudCredentials(prompter *utils.LinePrompter, cloudProvider string) (string, error) {
 	var params map[string]interface{}
 
 	switch cloudProvider {
 	case "aws":
 		fmt.Println("Enter AWS credentials:")
-		var awsAccountID, awsBootstrapRoleArn string
-
-		fmt.Print("AWS Account ID: ")
-		if _, err := fmt.Scanln(&awsAccountID); err != nil {
+		awsAccountID, err := prompter.PromptText("AWS Account ID: ", utils.NonEmpty)
+		if err != nil {
 			return "", fmt.Errorf("failed to read AWS Account ID: %w", err)
 		}
 
-		fmt.Print("AWS Bootstrap Role ARN (optional, press enter for default): ")
-		if _, err := fmt.Scanln(&awsBootstrapRoleArn); err != nil {
-			// Ignore error for optional field
-			awsBootstrapRoleArn = ""
+		awsBootstrapRoleArn, err := prompter.PromptText("AWS Bootstrap Role ARN (optional, press enter for default): ", nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to read AWS Bootstrap Role ARN: %w", err)
 		}
 
 		if awsBootstrapRoleArn == "" {
@@ -2182,15 +2502,13 @@ func promptForCloudCredentials(cloudProvider string) (string, error) {
 
 	case "gcp":
 		fmt.Println("Enter GCP credentials:")
-		var gcpProjectID, gcpProjectNumber string
-
-		fmt.Print("GCP Project ID: ")
-		if _, err := fmt.Scanln(&gcpProjectID); err != nil {
+		gcpProjectID, err := prompter.PromptText("GCP Project ID: ", utils.NonEmpty)
+		if err != nil {
 			return "", fmt.Errorf("failed to read GCP Project ID: %w", err)
 		}
 
-		fmt.Print("GCP Project Number: ")
-		if _, err := fmt.Scanln(&gcpProjectNumber); err != nil {
+		gcpProjectNumber, err := prompter.PromptText("GCP Project Number: ", utils.NonEmpty)
+		if err != nil {
 			return "", fmt.Errorf("failed to read GCP Project Number: %w", err)
 		}
 
@@ -2203,15 +2521,13 @@ func promptForCloudCredentials(cloudProvider string) (string, error) {
 
 	case "azure":
 		fmt.Println("Enter Azure credentials:")
-		var azureSubscriptionID, azureTenantID string
-
-		fmt.Print("Azure Subscription ID: ")
-		if _, err := fmt.Scanln(&azureSubscriptionID); err != nil {
+		azureSubscriptionID, err := prompter.PromptText("Azure Subscription ID: ", utils.NonEmpty)
+		if err != nil {
 			return "", fmt.Errorf("failed to read Azure Subscription ID: %w", err)
 		}
 
-		fmt.Print("Azure Tenant ID: ")
-		if _, err := fmt.Scanln(&azureTenantID); err != nil {
+		azureTenantID, err := prompter.PromptText("Azure Tenant ID: ", utils.NonEmpty)
+		if err != nil {
 			return "", fmt.Errorf("failed to read Azure Tenant ID: %w", err)
 		}
 
@@ -2273,8 +2589,13 @@ func waitForAccountVerification(ctx context.Context, token, serviceID,
 		// Get all accounts for the cloud provider
 		_, existingInstances, err := listInstances(ctx, token, serviceID, environmentID, planID, "", "onlyCloudAccounts")
 		if err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
 			fmt.Fprintf(os.Stderr, "⚠️  Failed to check account status: %v\n", err)
-			time.Sleep(retryInterval)
+			if err = sleepContext(ctx, retryInterval); err != nil {
+				return "", err
+			}
 			continue
 		}
 
@@ -2300,24 +2621,71 @@ func waitForAccountVerification(ctx context.Context, token, serviceID,
 			}
 		}
 
-		time.Sleep(retryInterval)
+		if err = sleepContext(ctx, retryInterval); err != nil {
+			return "", err
+		}
+	}
+
+	return "", utils.WithExitCode(utils.ExitCodeTimeout, fmt.Errorf("account verification timed out after %d attempts", maxRetries))
+}
+
+// sleepContext waits for d, returning early with the error of ctx if it is cancelled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
+}
 
-	return "", fmt.Errorf("account verification timed out after %d attempts", maxRetries)
+// newDeployContext derives the context of a deploy from parent. It is cancelled on Ctrl-C or SIGTERM, so that
+// in-flight API calls and wait loops stop and spinners are cleaned up, and after timeout unless timeout is zero.
+func newDeployContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	// Restore the default signal handling once cancelled, so a second Ctrl-C exits right away
+	context.AfterFunc(ctx, stop)
+	if timeout <= 0 {
+		return ctx, stop
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	return timeoutCtx, func() {
+		cancel()
+		stop()
+	}
 }
 
 // --- helpers for nicer errors/messages ---
 
 func printAuthError() {
-	utils.PrintError(fmt.Errorf(
-		"❌ Authentication error\n\n" +
-			"  You are not logged in or your session has expired.\n\n" +
-			"Next steps:\n" +
-			"  1. Run:  omnistrate-ctl login\n" +
+	utils.PrintError(authError())
+}
+
+func authError() error {
+	return utils.WithExitCode(utils.ExitCodeAuth, fmt.Errorf(
+		"❌ Authentication error\n\n"+
+			"  You are not logged in or your session has expired.\n\n"+
+			"Next steps:\n"+
+			"  1. Run:  omnistrate-ctl login\n"+
 			"  2. Re-run your previous omnistrate-ctl deploy command",
 	))
 }
 
+// renderSpecFile renders the spec the same way build does: file references resolve relative to the spec file and
+// env_file paths relative to the working directory.
+func renderSpecFile(fileData []byte, specFile string) ([]byte, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to get current working directory")
+	}
+	return spec.RenderSpec(fileData, filepath.Dir(specFile), cwd)
+}
+
 func deployProgressError(spinner *utils.Spinner, sm utils.SpinnerManager, err error) error {
 	if spinner != nil {
 		spinner.Error()
@@ -2333,33 +2701,42 @@ func deployProgressError(spinner *utils.Spinner, sm utils.SpinnerManager, err er
 
 func backendError(context string, err error) error {
 	contextTitle := cases.Title(language.English).String(context)
-	return fmt.Errorf(
+	supportStep := "  - If the problem persists, contact Omnistrate support and share this error"
+	if requestID := dataaccess.RequestIDFromError(err); requestID != "" {
+		supportStep = fmt.Sprintf("  - If the problem persists, contact Omnistrate support and quote request ID %s", requestID)
+	}
+	// Failures the API classified, e.g. as not found, keep their exit code
+	code := utils.ExitCodeFor(err)
+	if code == utils.ExitCodeGeneral {
+		code = utils.ExitCodeBackend
+	}
+	return utils.WithExitCode(code, fmt.Errorf(
 		"❌ %s failed\n\n  %v\n\n"+
 			"Next steps:\n"+
 			"  - Retry the command in a few minutes\n"+
-			"  - If the problem persists, contact Omnistrate support and share this error",
-		contextTitle, err,
-	)
+			"%s",
+		contextTitle, err, supportStep,
+	))
 }
 
 func wrapAndPrintServiceBuildError(err error) {
 	msg := err.Error()
 	if strings.Contains(msg, "public service environment already exists") {
-		utils.PrintError(fmt.Errorf(
-			"❌ Environment conflict during service creation\n\n" +
-				"  The service already has a public environment in this account and a new conflicting\n" +
-				"  environment cannot be created automatically\n\n" +
-				"Next steps:\n" +
-				"  - To update the existing service and environment, re-run with the same service name\n" +
+		utils.PrintError(utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf(
+			"❌ Environment conflict during service creation\n\n"+
+				"  The service already has a public environment in this account and a new conflicting\n"+
+				"  environment cannot be created automatically\n\n"+
+				"Next steps:\n"+
+				"  - To update the existing service and environment, re-run with the same service name\n"+
 				"  - To create a new service, use a different name with --product-name",
-		))
+		)))
 		return
 	}
-	utils.PrintError(fmt.Errorf(
+	utils.PrintError(utils.WithExitCode(utils.ExitCodeFor(err), fmt.Errorf(
 		"❌ Service creation failed\n\n  %v\n\n"+
 			"Step 1/2 (service creation) failed. No instance was created",
 		err,
-	))
+	)))
 }
 
 func isMissingParamsError(err error) bool {
@@ -2367,7 +2744,7 @@ func isMissingParamsError(err error) bool {
 }
 
 func missingParamsGuidanceError(err error) error {
-	return fmt.Errorf(
+	return utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf(
 		"❌ Missing required parameters for instance creation\n\n"+
 			"  %s\n\n"+
 			"Next steps:\n"+
@@ -2375,7 +2752,7 @@ func missingParamsGuidanceError(err error) error {
 			"      omnistrate-ctl deploy --param '{\"key\":\"value\",...}'\n"+
 			"  - Or provide a JSON file with --param-file",
 		err.Error(),
-	)
+	))
 }
 
 func isMissingParamValue(value interface{}) bool {
@@ -2388,6 +2765,25 @@ func isMissingParamValue(value interface{}) bool {
 	return false
 }
 
+// validateResourceIDsForInstance rejects several --resource-id values together with --instance-id, since an existing
+// instance belongs to a single resource.
+func validateResourceIDsForInstance(instanceID string, resourceIDs []string) error {
+	if instanceID == "" || len(resourceIDs) <= 1 {
+		return nil
+	}
+	return utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf("--instance-id cannot be combined with several --resource-id values (%s); an instance belongs to a single resource", strings.Join(resourceIDs, ", ")))
+}
+
+// multipleResourcesNonInteractiveError explains which --resource-id values are available when the
+// resource cannot be chosen interactively.
+func multipleResourcesNonInteractiveError(resources []openapiclient.DescribeResourceResult) error {
+	options := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		options = append(options, fmt.Sprintf("%s (%s)", resource.Id, resource.Key))
+	}
+	return fmt.Errorf("multiple resources found in service plan; specify one or more --resource-id values in non-interactive mode. Available resources: %s", strings.Join(options, ", "))
+}
+
 func isInteractivePromptEnabled() bool {
 	if strings.EqualFold(os.Getenv("OMNISTRATE_NON_INTERACTIVE"), "true") {
 		return false
@@ -2410,22 +2806,14 @@ func promptForMissingRequiredParams(defaultParams map[string]interface{}, requir
 	fmt.Println()
 	fmt.Println("ℹ️  Missing required instance launch parameters. Please enter values to continue deployment.")
 
-	reader := bufio.NewReader(os.Stdin)
+	prompter := utils.StdinPrompter()
 	return applyPromptedParamValues(defaultParams, requiredParams, func(paramKey string) (string, error) {
-		for {
-			promptLabel := formatPromptLabel(paramKey, displayNames)
-			fmt.Printf("Enter value for '%s': ", promptLabel)
-			value, err := reader.ReadString('\n')
-			if err != nil {
-				return "", fmt.Errorf("failed to read value for '%s': %w", paramKey, err)
-			}
-			value = strings.TrimSpace(value)
-			if value == "" {
-				fmt.Println("Value cannot be empty. Please try again.")
-				continue
-			}
-			return value, nil
+		promptLabel := formatPromptLabel(paramKey, displayNames)
+		value, err := prompter.PromptText(fmt.Sprintf("Enter value for '%s': ", promptLabel), utils.NonEmpty)
+		if err != nil {
+			return "", fmt.Errorf("failed to read value for '%s': %w", paramKey, err)
 		}
+		return value, nil
 	})
 }
 
@@ -2464,3 +2852,38 @@ func parsePromptInputValue(value string) interface{} {
 	}
 	return value
 }
+
+// formatAccountCheckSummary lists the READY cloud provider accounts found by --check-accounts and counts the
+// accounts of each status
+func formatAccountCheckSummary(readyAccounts []*openapiclient.DescribeAccountConfigResult, statusCounts map[string]int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "READY cloud provider accounts: %d\n", len(readyAccounts))
+	for _, acc := range readyAccounts {
+		var identifier string
+		switch {
+		case acc.AwsAccountID != nil:
+			identifier = "AWS account " + *acc.AwsAccountID
+		case acc.GcpProjectID != nil:
+			identifier = "GCP project " + *acc.GcpProjectID
+		case acc.AzureSubscriptionID != nil:
+			identifier = "Azure subscription " + *acc.AzureSubscriptionID
+		case acc.NebiusTenantID != nil:
+			identifier = "Nebius tenant " + *acc.NebiusTenantID
+		default:
+			identifier = acc.CloudProviderId
+		}
+		fmt.Fprintf(&b, "  - %s: %s (ID: %s)\n", acc.Name, identifier, acc.Id)
+	}
+
+	statuses := make([]string, 0, len(statusCounts))
+	for status := range statusCounts {
+		if status != "READY" {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "Accounts with status %s: %d\n", status, statusCounts[status])
+	}
+	return b.String()
+}
@@ -5,11 +5,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
-
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/build"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/spec"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -300,6 +312,24 @@ func TestDryRunMode(t *testing.T) {
 	})
 }
 
+func TestFormatAccountCheckSummary(t *testing.T) {
+	awsAccountID := "123456789012"
+	gcpProjectID := "my-project"
+	ready := []*openapiclient.DescribeAccountConfigResult{
+		{Id: "ac-aws", Name: "aws-prod", AwsAccountID: &awsAccountID},
+		{Id: "ac-gcp", Name: "gcp-prod", GcpProjectID: &gcpProjectID},
+	}
+
+	summary := formatAccountCheckSummary(ready, map[string]int{"READY": 2, "PENDING": 1, "FAILED": 3})
+	assert.Equal(t, "READY cloud provider accounts: 2\n"+
+		"  - aws-prod: AWS account 123456789012 (ID: ac-aws)\n"+
+		"  - gcp-prod: GCP project my-project (ID: ac-gcp)\n"+
+		"Accounts with status FAILED: 3\n"+
+		"Accounts with status PENDING: 1\n", summary)
+
+	assert.Equal(t, "READY cloud provider accounts: 0\n", formatAccountCheckSummary(nil, nil))
+}
+
 // INST-* Test Cases: Instance Management
 func TestInstanceManagement(t *testing.T) {
 	t.Run("INST-001_NoExistingInstances", func(t *testing.T) {
@@ -371,6 +401,41 @@ func TestResourceManagement(t *testing.T) {
 	})
 }
 
+func TestDeployResourceIDFlagIsRepeatable(t *testing.T) {
+	flag := DeployCmd.Flags().Lookup("resource-id")
+	require.NotNil(t, flag)
+	require.Equal(t, "stringArray", flag.Value.Type())
+}
+
+func TestValidateResourceIDsForInstance(t *testing.T) {
+	require.NoError(t, validateResourceIDsForInstance("", []string{"r-postgres", "r-redis"}))
+	require.NoError(t, validateResourceIDsForInstance("instance-1", nil))
+	require.NoError(t, validateResourceIDsForInstance("instance-1", []string{"r-postgres"}))
+
+	err := validateResourceIDsForInstance("instance-1", []string{"r-postgres", "r-redis"})
+	require.Error(t, err)
+	assert.Equal(t, utils.ExitCodeValidation, utils.ExitCodeFor(err))
+	assert.Contains(t, err.Error(), "r-postgres, r-redis")
+}
+
+func TestDeployReleaseNameFlagDefaultsToUnnamed(t *testing.T) {
+	flag := DeployCmd.Flags().Lookup("release-name")
+	require.NotNil(t, flag)
+	require.Equal(t, "string", flag.Value.Type())
+	require.Empty(t, flag.DefValue)
+}
+
+func TestMultipleResourcesNonInteractiveError(t *testing.T) {
+	err := multipleResourcesNonInteractiveError([]openapiclient.DescribeResourceResult{
+		{Id: "r-postgres", Key: "postgres"},
+		{Id: "r-redis", Key: "redis"},
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--resource-id")
+	assert.Contains(t, err.Error(), "r-postgres (postgres), r-redis (redis)")
+}
+
 // CP-* Test Cases: Cloud Provider Specific
 func TestCloudProviderSpecific(t *testing.T) {
 	t.Run("CP-001_AWS_AllParameters", func(t *testing.T) {
@@ -532,7 +597,7 @@ func TestTemplateProcessingAdvanced(t *testing.T) {
 	t.Run("TMPL-003_MissingTemplateFile", func(t *testing.T) {
 		templateContent := `config: {{ $file:missing.yaml }}`
 
-		_, err := processTemplateExpressions([]byte(templateContent), tempDir)
+		_, err := spec.RenderFileReferences([]byte(templateContent), tempDir)
 		assert.Error(t, err, "Should fail with missing template file")
 		assert.Contains(t, err.Error(), "failed to read file")
 	})
@@ -550,7 +615,7 @@ nested:
 
 		templateContent := `  config: {{ $file:include.yaml }}`
 
-		result, err := processTemplateExpressions([]byte(templateContent), tempDir)
+		result, err := spec.RenderFileReferences([]byte(templateContent), tempDir)
 		require.NoError(t, err)
 
 		// Check that indentation is preserved
@@ -561,6 +626,36 @@ nested:
 	})
 }
 
+// TMPL-006: deploy and build render one spec identically
+func TestRenderSpecFileMatchesBuild(t *testing.T) {
+	rootDir := t.TempDir()
+	t.Chdir(rootDir)
+
+	specDir := filepath.Join(rootDir, "specs")
+	require.NoError(t, os.MkdirAll(specDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "config.yaml"), []byte("key: value\n"), 0600))
+
+	specFile := filepath.Join(specDir, "compose.yaml")
+	content := []byte(`services:
+  app:
+    image: nginx:latest
+    config: {{ $file:config.yaml }}
+`)
+
+	if _, err := exec.LookPath("docker"); err == nil {
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, "app.env"), []byte("APP_PORT=8080\n"), 0600))
+		content = append(content, []byte("    env_file: app.env\n    ports:\n      - ${APP_PORT}:80\n")...)
+	}
+
+	deployed, err := renderSpecFile(content, specFile)
+	require.NoError(t, err)
+	built, err := build.RenderFile(content, rootDir, specFile, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(built), string(deployed))
+	assert.Contains(t, string(deployed), "key: value")
+}
+
 // Error Handling Test Cases
 func TestErrorHandling(t *testing.T) {
 	t.Run("ERR-003_LargeSpecFileProcessing", func(t *testing.T) {
@@ -904,6 +999,28 @@ services:
 	}
 }
 
+func TestInferCloudProviderFromSpec(t *testing.T) {
+	tests := []struct {
+		name                string
+		awsAccountID        string
+		gcpProjectID        string
+		azureSubscriptionID string
+		expected            string
+	}{
+		{name: "aws only", awsAccountID: "123456789012", expected: "aws"},
+		{name: "gcp only", gcpProjectID: "my-project", expected: "gcp"},
+		{name: "azure only", azureSubscriptionID: "sub-1", expected: "azure"},
+		{name: "multiple providers", awsAccountID: "123456789012", gcpProjectID: "my-project", expected: ""},
+		{name: "provider agnostic", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, inferCloudProviderFromSpec(tt.awsAccountID, tt.gcpProjectID, tt.azureSubscriptionID))
+		})
+	}
+}
+
 func TestFormatPromptLabel(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1017,3 +1134,165 @@ func TestApplyPromptedParamValues(t *testing.T) {
 		require.False(t, isMissingParamValue(params["username"]))
 	})
 }
+
+func TestBackendErrorIncludesRequestID(t *testing.T) {
+	err := backendError("cloud provider account lookup", errors.New("INTERNAL\nDetail: upstream timeout"))
+	require.Contains(t, err.Error(), "Cloud Provider Account Lookup failed")
+	require.Contains(t, err.Error(), "share this error")
+
+	err = backendError("cloud provider account lookup", &dataaccess.RequestIDError{
+		Err:       errors.New("INTERNAL\nDetail: upstream timeout"),
+		RequestID: "req-123",
+	})
+	require.Contains(t, err.Error(), "Request ID: req-123")
+	require.Contains(t, err.Error(), "quote request ID req-123")
+}
+
+func TestDeployErrorExitCodes(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(utils.ExitCodeAuth, utils.ExitCodeFor(authError()))
+
+	missingParams := errors.New("missing required parameters for instance creation: [username]")
+	require.Equal(utils.ExitCodeValidation, utils.ExitCodeFor(missingParamsGuidanceError(missingParams)))
+
+	require.Equal(utils.ExitCodeBackend, utils.ExitCodeFor(backendError("cloud provider account lookup", errors.New("INTERNAL"))))
+	notFound := utils.WithExitCode(utils.ExitCodeNotFound, errors.New("NOT_FOUND\nDetail: account not found"))
+	require.Equal(utils.ExitCodeNotFound, utils.ExitCodeFor(backendError("cloud provider account lookup", notFound)))
+	unauthorized := utils.WithExitCode(utils.ExitCodeAuth, errors.New("UNAUTHORIZED"))
+	require.Equal(utils.ExitCodeAuth, utils.ExitCodeFor(backendError("cloud provider account lookup", unauthorized)))
+}
+
+func TestListInstancesFollowsPages(t *testing.T) {
+	newInstance := func(id string) openapiclientfleet.ResourceInstance {
+		return openapiclientfleet.ResourceInstance{
+			CloudProvider: "aws",
+			InputParams:   map[string]interface{}{},
+			ConsumptionResourceInstanceResult: openapiclientfleet.DescribeResourceInstanceResult{
+				Id:     &id,
+				Status: openapiclientfleet.PtrString("RUNNING"),
+			},
+		}
+	}
+	pages := map[string]openapiclientfleet.ListFleetResourceInstancesResultInternal{
+		"": {
+			ResourceInstances: []openapiclientfleet.ResourceInstance{newInstance("instance-1")},
+			NextPageToken:     openapiclientfleet.PtrString("page-2"),
+		},
+		"page-2": {
+			ResourceInstances: []openapiclientfleet.ResourceInstance{newInstance("instance-2")},
+		},
+	}
+
+	var pageTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pageToken := r.URL.Query().Get("nextPageToken")
+		pageTokens = append(pageTokens, pageToken)
+		assert.Equal(t, "pt-1", r.URL.Query().Get("ProductTierId"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pages[pageToken])
+	}))
+	defer server.Close()
+
+	// The endpoint takes precedence over any endpoint saved in the developer's config
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.EndpointEnvVar, server.URL)
+	t.Cleanup(func() { config.SetEndpoint("") })
+	_, err := config.ResolveEndpoint()
+	require.NoError(t, err)
+	t.Setenv("CLIENT_TIMEOUT_IN_SECONDS", "5")
+	t.Setenv("OMNISTRATE_RETRY_MAX", "0")
+
+	// The instance given with --instance-id is only on the second page
+	instanceIDs, instances, err := listInstances(context.Background(), "token", "s-1", "se-1", "pt-1", "instance-2", "excludeCloudAccounts")
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "page-2"}, pageTokens)
+	require.Equal(t, []string{"instance-2"}, instanceIDs)
+	require.Len(t, instances, 2)
+
+	pageTokens = nil
+	instanceIDs, _, err = listInstances(context.Background(), "token", "s-1", "se-1", "pt-1", "", "excludeCloudAccounts")
+	require.NoError(t, err)
+	require.Equal(t, []string{"instance-1", "instance-2"}, instanceIDs)
+}
+
+func TestParseImageOverrides(t *testing.T) {
+	overrides, err := parseImageOverrides([]string{"web=docker.io/acme/web:v1.2", " worker = ghcr.io/acme/worker:sha-1 "})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"web": "docker.io/acme/web:v1.2", "worker": "ghcr.io/acme/worker:sha-1"}, overrides)
+
+	_, err = parseImageOverrides([]string{"docker.io/acme/web:v1.2"})
+	require.ErrorContains(t, err, "expected service=image")
+
+	_, err = parseImageOverrides([]string{"web=a:1", "web=a:2"})
+	require.ErrorContains(t, err, "more than once")
+}
+
+func TestApplyImageOverrides(t *testing.T) {
+	spec := []byte(`x-omnistrate-service-plan:
+  name: web
+services:
+  web:
+    build:
+      context: .
+    ports:
+      - "80:80"
+  db:
+    image: postgres:16 # pinned
+`)
+
+	updated, err := applyImageOverrides(spec, map[string]string{"web": "docker.io/acme/web:v1.2", "db": "postgres:17"})
+	require.NoError(t, err)
+
+	var compose map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(updated, &compose))
+	services := compose["services"].(map[string]interface{})
+	web := services["web"].(map[string]interface{})
+	require.Equal(t, "docker.io/acme/web:v1.2", web["image"])
+	require.NotContains(t, web, "build")
+	require.Equal(t, []interface{}{"80:80"}, web["ports"])
+	require.Equal(t, "postgres:17", services["db"].(map[string]interface{})["image"])
+	require.Contains(t, string(updated), "x-omnistrate-service-plan")
+
+	_, err = applyImageOverrides(spec, map[string]string{"api": "acme/api:v1"})
+	require.ErrorContains(t, err, "unknown service(s) api; services in the spec: db, web")
+
+	unchanged, err := applyImageOverrides(spec, nil)
+	require.NoError(t, err)
+	require.Equal(t, spec, unchanged)
+}
+
+func TestNewDeployContext(t *testing.T) {
+	ctx, cancel := newDeployContext(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := sleepContext(ctx, time.Minute)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, utils.ExitCodeTimeout, utils.ExitCodeFor(err))
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel = newDeployContext(parent, 0)
+	defer cancel()
+	_, hasDeadline := ctx.Deadline()
+	require.False(t, hasDeadline)
+
+	cancelParent()
+	require.ErrorIs(t, sleepContext(ctx, time.Minute), context.Canceled)
+	require.NoError(t, sleepContext(context.Background(), time.Millisecond))
+}
+
+func TestPromptForCloudProviderAndCredentials(t *testing.T) {
+	prompter := utils.NewLinePrompter(strings.NewReader("5\ngcp\n2\n\nmy project\n123456\n"), io.Discard)
+
+	provider, err := promptForCloudProvider(prompter)
+	require.NoError(t, err)
+	require.Equal(t, "gcp", provider)
+
+	params, err := promptForCloudCredentials(prompter, provider)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"account_configuration_method":"GCPScript","gcp_project_id":"my project","gcp_project_number":"123456","cloud_provider":"gcp"}`, params)
+
+	_, err = promptForCloudProvider(prompter)
+	require.ErrorIs(t, err, utils.ErrNoPromptInput)
+}
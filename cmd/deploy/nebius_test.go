@@ -1,8 +1,11 @@
 package deploy
 
 import (
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
 	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,7 +75,7 @@ func TestHasReadyNebiusBindingForRegion(t *testing.T) {
 }
 
 func TestPromptForCloudCredentials_NebiusUnsupported(t *testing.T) {
-	_, err := promptForCloudCredentials("nebius")
+	_, err := promptForCloudCredentials(utils.NewLinePrompter(strings.NewReader(""), io.Discard), "nebius")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "Nebius account onboarding from deploy is not supported")
 }
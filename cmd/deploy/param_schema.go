@@ -0,0 +1,141 @@
+package deploy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+)
+
+// deploySystemParamKeys are the parameters that deploy sets from its flags or the cloud account it resolves, so they
+// are accepted whether or not the resource declares them
+var deploySystemParamKeys = map[string]bool{
+	"subscriptionId":                   true,
+	"cloud_provider":                   true,
+	"region":                           true,
+	"cloud_provider_account_config_id": true,
+}
+
+// validateParamsAgainstSchema checks the parameters given with --param or --param-file against the CREATE input
+// parameters of the resource before the instance is created, and reports every problem at once: unknown keys,
+// values of the wrong type and, when checkMissing is set, required parameters without a value or a default.
+func validateParamsAgainstSchema(params map[string]interface{}, inputParams []openapiclientfleet.InputParameterEntity, checkMissing bool) error {
+	schema := make(map[string]openapiclientfleet.InputParameterEntity, len(inputParams))
+	for _, param := range inputParams {
+		schema[param.Key] = param
+	}
+
+	var problems []string
+	for key, value := range params {
+		param, known := schema[key]
+		if !known {
+			if !deploySystemParamKeys[key] {
+				problems = append(problems, fmt.Sprintf("unknown parameter %q", key))
+			}
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if !paramValueMatchesType(value, param.Type, param.IsList) {
+			expected := param.Type
+			if param.IsList {
+				expected = "list of " + expected
+			}
+			problems = append(problems, fmt.Sprintf("parameter %q must be of type %s, got %s", key, expected, paramValueDescription(value)))
+		}
+	}
+
+	if checkMissing {
+		for _, param := range inputParams {
+			if deploySystemParamKeys[param.Key] {
+				continue
+			}
+			if param.Required && param.DefaultValue == nil && isMissingParamValue(params[param.Key]) {
+				problems = append(problems, fmt.Sprintf("missing required parameter %q", param.Key))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return utils.WithExitCode(utils.ExitCodeValidation, errors.New(
+		"invalid instance parameters:\n  - "+strings.Join(problems, "\n  - ")+
+			"\nRun 'omnistrate-ctl instance params' to list the parameters of the service plan"))
+}
+
+// paramValueMatchesType reports whether a JSON parameter value fits the parameter type. Scalars may also be given as
+// strings, as the API accepts them, and lists as a JSON array or a comma-separated string.
+func paramValueMatchesType(value interface{}, paramType string, isList bool) bool {
+	if isList {
+		switch value.(type) {
+		case []interface{}, string:
+			return true
+		}
+		return false
+	}
+
+	str, isString := value.(string)
+	switch strings.ToLower(strings.TrimSpace(paramType)) {
+	case "bool", "boolean":
+		if isString {
+			_, err := strconv.ParseBool(strings.TrimSpace(str))
+			return err == nil
+		}
+		_, ok := value.(bool)
+		return ok
+	case "int", "int32", "int64", "integer":
+		if isString {
+			_, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+			return err == nil
+		}
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "float", "float32", "float64", "double", "number":
+		if isString {
+			_, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+			return err == nil
+		}
+		_, ok := value.(float64)
+		return ok
+	case "object", "json", "map":
+		if isString {
+			return json.Valid([]byte(str))
+		}
+		_, ok := value.(map[string]interface{})
+		return ok
+	default:
+		// Strings, passwords and other textual types take any scalar
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+		return true
+	}
+}
+
+// paramValueDescription names the JSON type of a parameter value for error messages
+func paramValueDescription(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case bool:
+		return fmt.Sprintf("boolean %t", v)
+	case float64:
+		return fmt.Sprintf("number %v", v)
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,54 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateParamsAgainstSchema(t *testing.T) {
+	require := require.New(t)
+
+	schema := []openapiclientfleet.InputParameterEntity{
+		{Key: "password", Type: "Password", Required: true},
+		{Key: "replicas", Type: "Float64", DefaultValue: utils.ToPtr("1")},
+		{Key: "port", Type: "Int64"},
+		{Key: "enableTLS", Type: "Boolean"},
+		{Key: "zones", Type: "String", IsList: true},
+		{Key: "settings", Type: "JSON"},
+		{Key: "cloud_provider", Type: "String", Required: true},
+	}
+
+	// Values of the right type, scalars as strings and deploy's own keys are accepted
+	require.NoError(validateParamsAgainstSchema(map[string]interface{}{
+		"password":                         "secret",
+		"replicas":                         "3",
+		"port":                             float64(5432),
+		"enableTLS":                        true,
+		"zones":                            []interface{}{"a", "b"},
+		"settings":                         `{"maxConnections": 100}`,
+		"cloud_provider_account_config_id": "instance-123",
+	}, schema, true))
+
+	// Missing required parameters are only reported when they can't be prompted for
+	require.NoError(validateParamsAgainstSchema(map[string]interface{}{}, schema, false))
+
+	err := validateParamsAgainstSchema(map[string]interface{}{
+		"replicas":  "three",
+		"port":      1.5,
+		"enableTLS": "maybe",
+		"settings":  []interface{}{},
+		"unknown":   "x",
+	}, schema, true)
+	require.Error(err)
+	require.Equal(utils.ExitCodeValidation, utils.ExitCodeFor(err))
+	require.Contains(err.Error(), `missing required parameter "password"`)
+	require.Contains(err.Error(), `unknown parameter "unknown"`)
+	require.Contains(err.Error(), `parameter "replicas" must be of type Float64, got "three"`)
+	require.Contains(err.Error(), `parameter "port" must be of type Int64, got number 1.5`)
+	require.Contains(err.Error(), `parameter "enableTLS" must be of type Boolean, got "maybe"`)
+	require.Contains(err.Error(), `parameter "settings" must be of type JSON, got a list`)
+	require.NotContains(err.Error(), "cloud_provider")
+}
@@ -0,0 +1,112 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseImageOverrides parses --set-image values of the form service=repo:tag into a map of service name to image.
+func parseImageOverrides(values []string) (map[string]string, error) {
+	overrides := make(map[string]string, len(values))
+	for _, value := range values {
+		service, image, ok := strings.Cut(value, "=")
+		service, image = strings.TrimSpace(service), strings.TrimSpace(image)
+		if !ok || service == "" || image == "" {
+			return nil, fmt.Errorf("invalid --set-image %q: expected service=image, e.g. web=docker.io/acme/web:v1.2", value)
+		}
+		if _, exists := overrides[service]; exists {
+			return nil, fmt.Errorf("--set-image is given more than once for service %q", service)
+		}
+		overrides[service] = image
+	}
+	return overrides, nil
+}
+
+// applyImageOverrides sets the image of each overridden service in a compose spec. The build section of an
+// overridden service is removed so the given image is deployed as is. Unknown services are an error.
+func applyImageOverrides(specData []byte, overrides map[string]string) ([]byte, error) {
+	if len(overrides) == 0 {
+		return specData, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(specData, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse compose spec: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("compose spec has no services to set images for")
+	}
+
+	services := mappingNodeValue(doc.Content[0], "services")
+	if services == nil || services.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("compose spec has no services to set images for")
+	}
+
+	serviceNodes := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		serviceNodes[services.Content[i].Value] = services.Content[i+1]
+	}
+
+	var unknown []string
+	for service := range overrides {
+		if node, ok := serviceNodes[service]; !ok || node.Kind != yaml.MappingNode {
+			unknown = append(unknown, service)
+		}
+	}
+	if len(unknown) > 0 {
+		known := make([]string, 0, len(serviceNodes))
+		for service := range serviceNodes {
+			known = append(known, service)
+		}
+		sort.Strings(unknown)
+		sort.Strings(known)
+		return nil, fmt.Errorf("--set-image refers to unknown service(s) %s; services in the spec: %s",
+			strings.Join(unknown, ", "), strings.Join(known, ", "))
+	}
+
+	for service, image := range overrides {
+		node := serviceNodes[service]
+		removeMappingKey(node, "build")
+		if imageNode := mappingNodeValue(node, "image"); imageNode != nil {
+			imageNode.Kind, imageNode.Tag, imageNode.Style, imageNode.Value = yaml.ScalarNode, "!!str", 0, image
+			continue
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "image"},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: image},
+		)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal compose spec: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal compose spec: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func mappingNodeValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func removeMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -22,7 +22,7 @@ var (
 	deploySummaryValueStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#E5E7EB"))
 )
 
-func printDeploymentSummary(serviceName, serviceID, environment, environmentType, planID, instanceActionType, finalInstanceID string) {
+func printDeploymentSummary(serviceName, serviceID, environment, environmentType, planID, instanceActionType string, results []deployInstanceResult) {
 	var body strings.Builder
 	body.WriteString(deploySummaryTitleStyle.Render("Deployment submitted"))
 	body.WriteString("\n\n")
@@ -33,12 +33,22 @@ func printDeploymentSummary(serviceName, serviceID, environment, environmentType
 	body.WriteString(deploySummaryRow("Environment", fmt.Sprintf("%s (%s)", environment, environmentType)))
 	body.WriteString(deploySummaryRow("Plan ID", planID))
 
-	if finalInstanceID != "" {
+	for _, result := range results {
+		if result.instanceID == "" && result.err == nil {
+			continue
+		}
 		body.WriteString("\n")
 		body.WriteString(deploySummarySectionStyle.Render("Instance"))
 		body.WriteString("\n")
 		body.WriteString(deploySummaryRow("Action", instanceActionType))
-		body.WriteString(deploySummaryRow("ID", finalInstanceID))
+		if result.resourceID != "" {
+			body.WriteString(deploySummaryRow("Resource", result.resourceID))
+		}
+		if result.err != nil {
+			body.WriteString(deploySummaryRow("Status", fmt.Sprintf("failed: %v", result.err)))
+			continue
+		}
+		body.WriteString(deploySummaryRow("ID", result.instanceID))
 	}
 
 	fmt.Println()
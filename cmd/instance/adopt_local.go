@@ -0,0 +1,133 @@
+package instance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+)
+
+const adoptLocalExample = `# Adopt an instance created outside omnistrate-ctl, e.g. in the web console, under a friendly alias
+omnistrate-ctl instance adopt-local instance-abcd1234 --alias orders-db
+
+# Target the adopted instance by its alias
+omnistrate-ctl instance debug orders-db`
+
+var adoptLocalCmd = &cobra.Command{
+	Use:   "adopt-local [instance-id] [flags]",
+	Short: "Record an existing instance in the local config under an alias",
+	Long: `This command verifies that an existing instance, e.g. one created in the web console, exists and records it in
+the local config so that commands such as 'instance debug' and 'instance version-upgrade' can target it by the name
+set with --alias. Nothing is changed on the server. Use 'instance list-adopted' to list the adopted instances.`,
+	Example:      adoptLocalExample,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runAdoptLocal,
+	SilenceUsage: true,
+}
+
+func init() {
+	adoptLocalCmd.Flags().String("alias", "", "Alias to target the adopted instance by")
+}
+
+// runAdoptLocal verifies that the instance exists and records it in the local config under the requested alias.
+func runAdoptLocal(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	instanceID := instanceIDArg(args)
+
+	alias, err := cmd.Flags().GetString("alias")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	// Initialize spinner if output is not JSON
+	var sm utils.SpinnerManager
+	var spinner *utils.Spinner
+	if output != "json" {
+		sm = utils.NewSpinnerManager()
+		spinner = sm.AddSpinner(fmt.Sprintf("Adopting instance %s...", instanceID))
+		sm.Start()
+	}
+
+	searchRes, err := dataaccess.SearchInventory(cmd.Context(), token, fmt.Sprintf("resourceinstance:%s", instanceID))
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	var instance *openapiclientfleet.ResourceInstanceSearchRecord
+	for i := range searchRes.ResourceInstanceResults {
+		if searchRes.ResourceInstanceResults[i].Id == instanceID {
+			instance = &searchRes.ResourceInstanceResults[i]
+			break
+		}
+	}
+	if instance == nil {
+		err = fmt.Errorf("%s not found. Please check the instance ID and try again", instanceID)
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	adopted := newAdoptedInstance(instance, alias, time.Now())
+	if err = config.SaveAdoptedInstance(adopted); err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Successfully adopted instance %s", instanceID))
+
+	return utils.PrintTextTableJsonOutput(output, formatAdoptedInstance(adopted))
+}
+
+// newAdoptedInstance builds the local config record of an instance found in the inventory.
+func newAdoptedInstance(instance *openapiclientfleet.ResourceInstanceSearchRecord, alias string, adoptedAt time.Time) config.AdoptedInstance {
+	return config.AdoptedInstance{
+		InstanceID:      instance.Id,
+		Alias:           alias,
+		ServiceID:       instance.ServiceId,
+		ServiceName:     instance.ServiceName,
+		EnvironmentID:   instance.ServiceEnvironmentId,
+		EnvironmentName: instance.ServiceEnvironmentName,
+		PlanID:          instance.ProductTierId,
+		PlanName:        instance.GetProductTierName(),
+		AdoptedAt:       adoptedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func formatAdoptedInstance(instance config.AdoptedInstance) model.AdoptedInstance {
+	return model.AdoptedInstance{
+		InstanceID:  instance.InstanceID,
+		Alias:       instance.Alias,
+		Service:     adoptedDisplayName(instance.ServiceName, instance.ServiceID),
+		Environment: adoptedDisplayName(instance.EnvironmentName, instance.EnvironmentID),
+		Plan:        adoptedDisplayName(instance.PlanName, instance.PlanID),
+		AdoptedAt:   instance.AdoptedAt,
+	}
+}
+
+func adoptedDisplayName(name, id string) string {
+	if name == "" {
+		return id
+	}
+	return name
+}
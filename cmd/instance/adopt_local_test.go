@@ -0,0 +1,49 @@
+package instance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAdoptedInstance(t *testing.T) {
+	record := &openapiclientfleet.ResourceInstanceSearchRecord{
+		Id:                     "instance-1",
+		ServiceId:              "s-1",
+		ServiceName:            "postgres",
+		ServiceEnvironmentId:   "se-1",
+		ServiceEnvironmentName: "Production",
+		ProductTierId:          "pt-1",
+	}
+	adoptedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	adopted := newAdoptedInstance(record, "orders-db", adoptedAt)
+	assert.Equal(t, config.AdoptedInstance{
+		InstanceID:      "instance-1",
+		Alias:           "orders-db",
+		ServiceID:       "s-1",
+		ServiceName:     "postgres",
+		EnvironmentID:   "se-1",
+		EnvironmentName: "Production",
+		PlanID:          "pt-1",
+		AdoptedAt:       "2026-10-16T12:00:00Z",
+	}, adopted)
+
+	// The plan name is unknown, so the plan ID is shown instead
+	assert.Equal(t, model.AdoptedInstance{
+		InstanceID:  "instance-1",
+		Alias:       "orders-db",
+		Service:     "postgres",
+		Environment: "Production",
+		Plan:        "pt-1",
+		AdoptedAt:   "2026-10-16T12:00:00Z",
+	}, formatAdoptedInstance(adopted))
+
+	record.ProductTierName = utils.ToPtr("Premium")
+	assert.Equal(t, "Premium", formatAdoptedInstance(newAdoptedInstance(record, "", adoptedAt)).Plan)
+}
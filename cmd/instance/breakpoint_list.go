@@ -33,7 +33,7 @@ var breakpointListCmd = &cobra.Command{
 func runBreakpointList(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 	output, err := cmd.Flags().GetString("output")
 	if err != nil {
 		return err
@@ -31,7 +31,7 @@ func init() {
 func runBreakpointResume(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 	force, err := cmd.Flags().GetBool("force")
 	if err != nil {
 		return err
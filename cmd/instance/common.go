@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
 	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +20,12 @@ func getTerraformDeploymentName(resourceID, instanceID string) string {
 	return strings.ToLower(fmt.Sprintf("tf-%s-%s", resourceID, instanceID))
 }
 
+// instanceIDArg returns the instance ID given as the first argument of a command, resolving instance aliases set
+// with `config alias set` or when adopting the instance.
+func instanceIDArg(args []string) string {
+	return config.ResolveInstanceID(args[0])
+}
+
 // parseCustomTags reads the --tags flag and converts it into the SDK custom tag format.
 func parseCustomTags(cmd *cobra.Command) ([]openapiclientfleet.CustomTag, bool, error) {
 	if !cmd.Flags().Changed("tags") {
@@ -3,11 +3,24 @@ package instance
 import (
 	"testing"
 
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
 	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 )
 
+func TestInstanceIDArg(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { config.SetProfile("") })
+
+	require.NoError(t, config.SetAlias(config.AliasNamespaceInstance, "orders", "instance-1"))
+	require.NoError(t, config.SaveAdoptedInstance(config.AdoptedInstance{InstanceID: "instance-2", Alias: "billing"}))
+
+	require.Equal(t, "instance-1", instanceIDArg([]string{"orders"}))
+	require.Equal(t, "instance-2", instanceIDArg([]string{"billing", "extra"}))
+	require.Equal(t, "instance-3", instanceIDArg([]string{"instance-3"}))
+}
+
 func TestParseCustomTags(t *testing.T) {
 	tests := []struct {
 		name          string
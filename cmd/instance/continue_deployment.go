@@ -53,7 +53,7 @@ func runContinueDeployment(cmd *cobra.Command, args []string) error {
 	}
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 	// Retrieve flags
 	resourceName, err := cmd.Flags().GetString("resource-name")
 	if err != nil {
@@ -47,7 +47,7 @@ func runCopySnapshot(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	output, err := cmd.Flags().GetString("output")
 	if err != nil {
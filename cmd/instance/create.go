@@ -82,13 +82,13 @@ var createCmd = &cobra.Command{
 }
 
 func init() {
-	createCmd.Flags().String("service", "", "Service name")
+	createCmd.Flags().String("service", "", "Service name, or an alias set with 'config alias set --namespace service'")
 	createCmd.Flags().String("environment", "", "Environment name")
 	createCmd.Flags().String("plan", "", "Service plan name")
 	createCmd.Flags().String("version", "preferred", "Service plan version (latest|preferred|1.0 etc.)")
 	createCmd.Flags().String("resource", "", "Resource name")
 	createCmd.Flags().String("cloud-provider", "", "Cloud provider (aws|gcp|azure|nebius)")
-	createCmd.Flags().String("region", "", "Region code (e.g. us-east-2, us-central1)")
+	createCmd.Flags().String("region", "", "Region code (e.g. us-east-2, us-central1, eastus2)")
 	createCmd.Flags().String("param", "", "Parameters for the instance deployment")
 	createCmd.Flags().String("param-file", "", "Json file containing parameters for the instance deployment")
 	createCmd.Flags().String("customer-account-id", "", "Customer BYOA account onboarding instance ID to inject as the cloud account. Use 'omnistrate-ctl account customer list' or 'omnistrate-ctl account customer describe <instance-id>' to find it.")
@@ -96,7 +96,7 @@ func init() {
 	createCmd.Flags().String("tags", "", "Custom tags to add to the instance deployment (format: key=value,key2=value2)")
 	createCmd.Flags().String("breakpoints", "", "Workflow breakpoint resource IDs or resource keys, optionally scoped to events as id-or-key:event or id-or-key:event|event")
 	createCmd.Flags().StringP("subscription-id", "", "", "Subscription ID to use for the instance deployment. If not provided, instance deployment will be created in your own subscription.")
-	createCmd.Flags().String("instance-id", "", "ID of a previously deleted instance to restore")
+	createCmd.Flags().String("instance-id", "", "ID or alias of a previously deleted instance to restore")
 	createCmd.Flags().Bool("wait", false, "Wait for deployment to complete and show progress")
 
 	if err := createCmd.MarkFlagRequired("service"); err != nil {
@@ -133,6 +133,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		utils.PrintError(err)
 		return err
 	}
+	service = config.ResolveAlias(config.AliasNamespaceService, service)
 	environment, err := cmd.Flags().GetString("environment")
 	if err != nil {
 		utils.PrintError(err)
@@ -194,6 +195,9 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		utils.PrintError(err)
 		return err
 	}
+	if instanceID != "" {
+		instanceID = config.ResolveInstanceID(instanceID)
+	}
 	waitFlag, err := cmd.Flags().GetBool("wait")
 	if err != nil {
 		utils.PrintError(err)
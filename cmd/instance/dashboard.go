@@ -32,7 +32,7 @@ func init() {
 func runDashboard(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	output, err := cmd.Flags().GetString("output")
 	if err != nil {
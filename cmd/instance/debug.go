@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 
@@ -20,25 +23,45 @@ import (
 var debugCmd = &cobra.Command{
 	Use:   "debug [instance-id]",
 	Short: "Visualize the instance plan DAG",
-	Long:  "Visualize the plan DAG for an instance based on its product tier version. Use --output=json for non-interactive output.",
+	Long:  "Visualize the plan DAG for an instance based on its product tier version. Use --output=json for non-interactive output (its schemaVersion field is bumped on breaking changes to the JSON format, currently 1) and --only-failed to keep only the resources whose workflow failed, --follow-workflow to print newline-delimited JSON workflow status updates until the workflow finishes, --export-bundle to package the debug data, files and logs into a tarball for support, or --dump-helm-logs to save the helm install logs to files. Use --tf-outputs with --resource and --output=json to print the outputs of a terraform resource, such as connection strings and IPs, with sensitive values masked unless --reveal-sensitive is set. In the interactive view, press r to reload the instance data. The instance can also be given by the alias it was adopted under with 'instance adopt-local'.",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runDebug,
 	Example: `  omnistrate-ctl instance debug <instance-id>
-  omnistrate-ctl instance debug <instance-id> --output=json`,
+  omnistrate-ctl instance debug <instance-id> --output=json
+  omnistrate-ctl instance debug <instance-id> --refresh-interval=15s
+  omnistrate-ctl instance debug <instance-id> --progress-refresh-interval=15s --log-poll-interval=10s
+  omnistrate-ctl instance debug <instance-id> --follow-workflow
+  omnistrate-ctl instance debug <instance-id> --export-bundle=debug-bundle.tar.gz
+  omnistrate-ctl instance debug <instance-id> --output=json --no-workflow-events
+  omnistrate-ctl instance debug <instance-id> --time-format=relative
+  omnistrate-ctl instance debug <instance-id> --export-bundle=debug-bundle.tar.gz --grep='(?i)error'
+  omnistrate-ctl instance debug <instance-id> --output=json --resource-type=terraform
+  omnistrate-ctl instance debug <instance-id> --output=json --only-failed
+  omnistrate-ctl instance debug <instance-id> --resource=my-terraform --dump-tf-files=./tf-files
+  omnistrate-ctl instance debug <instance-id> --resource=my-terraform --tf-outputs --output=json
+  omnistrate-ctl instance debug <instance-id> --dump-helm-logs=./helm-logs
+  omnistrate-ctl instance debug <instance-id> --terraform-only`,
 }
 
+// DebugDataSchemaVersion is the version of the DebugData JSON output. Bump it on breaking changes, such as renamed
+// or removed fields, so that tooling reading the output can detect an incompatible format.
+const DebugDataSchemaVersion = 1
+
 type DebugData struct {
-	InstanceID        string                        `json:"instanceId"`
-	PlanDAG           *PlanDAG                      `json:"planDag,omitempty"`
-	ServiceID         string                        `json:"serviceId,omitempty"`
-	EnvironmentID     string                        `json:"environmentId,omitempty"`
-	ProductTierID     string                        `json:"productTierId,omitempty"`
-	TierVersion       string                        `json:"tierVersion,omitempty"`
-	DashboardCatalog  *dataaccess.DashboardCatalog  `json:"-"`
-	Token             string                        `json:"-"`
-	ResultParams      map[string]interface{}        `json:"-"`
-	InputParams       map[string]interface{}        `json:"-"`
-	ResourceDebugInfo map[string]*ResourceDebugInfo `json:"resourceDebugInfo,omitempty"`
+	SchemaVersion       int                           `json:"schemaVersion"`
+	InstanceID          string                        `json:"instanceId"`
+	PlanDAG             *PlanDAG                      `json:"planDag,omitempty"`
+	ServiceID           string                        `json:"serviceId,omitempty"`
+	EnvironmentID       string                        `json:"environmentId,omitempty"`
+	ProductTierID       string                        `json:"productTierId,omitempty"`
+	TierVersion         string                        `json:"tierVersion,omitempty"`
+	DashboardCatalog    *dataaccess.DashboardCatalog  `json:"-"`
+	Token               string                        `json:"-"`
+	ResultParams        map[string]interface{}        `json:"-"`
+	InputParams         map[string]interface{}        `json:"-"`
+	ResourceDebugInfo   map[string]*ResourceDebugInfo `json:"resourceDebugInfo,omitempty"`
+	NoWorkflowEvents    bool                          `json:"-"` // skip fetching workflow events
+	WorkflowEventsCache *debugWorkflowEventsCache     `json:"-"` // shares workflow events across the views of a debug run
 }
 
 // Messages for the loading spinner model
@@ -92,7 +115,7 @@ func (m loadingModel) View() string {
 	return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), m.status)
 }
 
-func fetchDebugData(instanceID, token string) tea.Cmd {
+func fetchDebugData(instanceID, token string, noWorkflowEvents bool, workflowEventsCache *debugWorkflowEventsCache) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 
@@ -135,44 +158,227 @@ func fetchDebugData(instanceID, token string) tea.Cmd {
 
 		return debugDataMsg{
 			data: DebugData{
-				InstanceID:       instanceID,
-				PlanDAG:          planDAG,
-				ServiceID:        serviceID,
-				EnvironmentID:    environmentID,
-				ProductTierID:    instanceData.ProductTierId,
-				TierVersion:      instanceData.TierVersion,
-				DashboardCatalog: dashboardCatalog,
-				Token:            token,
-				ResultParams:     resultParams,
-				InputParams:      inputParams,
+				InstanceID:          instanceID,
+				PlanDAG:             planDAG,
+				ServiceID:           serviceID,
+				EnvironmentID:       environmentID,
+				ProductTierID:       instanceData.ProductTierId,
+				TierVersion:         instanceData.TierVersion,
+				DashboardCatalog:    dashboardCatalog,
+				Token:               token,
+				ResultParams:        resultParams,
+				InputParams:         inputParams,
+				NoWorkflowEvents:    noWorkflowEvents,
+				WorkflowEventsCache: workflowEventsCache,
 			},
 		}
 	}
 }
 
 func runDebug(cmd *cobra.Command, args []string) error {
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	output, err := cmd.Flags().GetString("output")
 	if err != nil {
 		return fmt.Errorf("failed to get output flag: %w", err)
 	}
 
+	refreshInterval, err := cmd.Flags().GetDuration("refresh-interval")
+	if err != nil {
+		return fmt.Errorf("failed to get refresh-interval flag: %w", err)
+	}
+	if err = validateDebugPollInterval("refresh-interval", refreshInterval); err != nil {
+		return err
+	}
+	wfEventsRefreshInterval = refreshInterval
+
+	progressInterval, err := cmd.Flags().GetDuration("progress-refresh-interval")
+	if err != nil {
+		return fmt.Errorf("failed to get progress-refresh-interval flag: %w", err)
+	}
+	if err = validateDebugPollInterval("progress-refresh-interval", progressInterval); err != nil {
+		return err
+	}
+	progressRefreshInterval = progressInterval
+
+	logInterval, err := cmd.Flags().GetDuration("log-poll-interval")
+	if err != nil {
+		return fmt.Errorf("failed to get log-poll-interval flag: %w", err)
+	}
+	if err = validateDebugPollInterval("log-poll-interval", logInterval); err != nil {
+		return err
+	}
+	logPollInterval = logInterval
+
+	timeFormat, err := cmd.Flags().GetString("time-format")
+	if err != nil {
+		return fmt.Errorf("failed to get time-format flag: %w", err)
+	}
+	if err = validateDebugTimeFormat(timeFormat); err != nil {
+		return err
+	}
+	debugTimeFormat = timeFormat
+
+	auditLog, err := cmd.Flags().GetString("audit-log")
+	if err != nil {
+		return fmt.Errorf("failed to get audit-log flag: %w", err)
+	}
+	debugAuditLogPath = auditLog
+
+	followWorkflow, err := cmd.Flags().GetBool("follow-workflow")
+	if err != nil {
+		return fmt.Errorf("failed to get follow-workflow flag: %w", err)
+	}
+
+	exportBundle, err := cmd.Flags().GetString("export-bundle")
+	if err != nil {
+		return fmt.Errorf("failed to get export-bundle flag: %w", err)
+	}
+
+	noRedact, err := cmd.Flags().GetBool("no-redact")
+	if err != nil {
+		return fmt.Errorf("failed to get no-redact flag: %w", err)
+	}
+
+	redactPattern, err := cmd.Flags().GetString("redact-pattern")
+	if err != nil {
+		return fmt.Errorf("failed to get redact-pattern flag: %w", err)
+	}
+
+	grepPattern, err := cmd.Flags().GetString("grep")
+	if err != nil {
+		return fmt.Errorf("failed to get grep flag: %w", err)
+	}
+	logFilter, err := newDebugLogFilter(grepPattern)
+	if err != nil {
+		return err
+	}
+
+	resourceKind, err := cmd.Flags().GetString("resource-type")
+	if err != nil {
+		return fmt.Errorf("failed to get resource-type flag: %w", err)
+	}
+	if err = validateDebugResourceKind(resourceKind); err != nil {
+		return err
+	}
+
+	dumpTfFiles, err := cmd.Flags().GetString("dump-tf-files")
+	if err != nil {
+		return fmt.Errorf("failed to get dump-tf-files flag: %w", err)
+	}
+
+	resourceKey, err := cmd.Flags().GetString("resource")
+	if err != nil {
+		return fmt.Errorf("failed to get resource flag: %w", err)
+	}
+
+	tfOutputs, err := cmd.Flags().GetBool("tf-outputs")
+	if err != nil {
+		return fmt.Errorf("failed to get tf-outputs flag: %w", err)
+	}
+
+	revealSensitive, err := cmd.Flags().GetBool("reveal-sensitive")
+	if err != nil {
+		return fmt.Errorf("failed to get reveal-sensitive flag: %w", err)
+	}
+	if dumpTfFiles != "" && tfOutputs {
+		return fmt.Errorf("--dump-tf-files cannot be used with --tf-outputs")
+	}
+	if (dumpTfFiles != "" || tfOutputs) != (resourceKey != "") {
+		return fmt.Errorf("--resource must be used with --dump-tf-files or --tf-outputs, and they require --resource")
+	}
+	if tfOutputs && (output != "json" || followWorkflow || exportBundle != "") {
+		return fmt.Errorf("--tf-outputs can only be used with --output=json")
+	}
+	if revealSensitive && !tfOutputs {
+		return fmt.Errorf("--reveal-sensitive can only be used with --tf-outputs")
+	}
+
+	dumpHelmLogs, err := cmd.Flags().GetString("dump-helm-logs")
+	if err != nil {
+		return fmt.Errorf("failed to get dump-helm-logs flag: %w", err)
+	}
+	if dumpHelmLogs != "" && (output == "json" || followWorkflow || exportBundle != "" || dumpTfFiles != "") {
+		return fmt.Errorf("--dump-helm-logs cannot be used with --output=json, --follow-workflow, --export-bundle or --dump-tf-files")
+	}
+
+	noWorkflowEvents, err := cmd.Flags().GetBool("no-workflow-events")
+	if err != nil {
+		return fmt.Errorf("failed to get no-workflow-events flag: %w", err)
+	}
+	if noWorkflowEvents && followWorkflow {
+		return fmt.Errorf("--no-workflow-events cannot be used with --follow-workflow")
+	}
+	if logFilter != nil && exportBundle == "" && output != "json" {
+		return fmt.Errorf("--grep can only be used with --output=json or --export-bundle; press / in the Live Logs tab to search interactively")
+	}
+	if resourceKind != "" && exportBundle == "" && output != "json" {
+		return fmt.Errorf("--resource-type can only be used with --output=json or --export-bundle")
+	}
+
+	onlyFailed, err := cmd.Flags().GetBool("only-failed")
+	if err != nil {
+		return fmt.Errorf("failed to get only-failed flag: %w", err)
+	}
+	if onlyFailed && (output != "json" || exportBundle != "") {
+		return fmt.Errorf("--only-failed can only be used with --output=json")
+	}
+	if onlyFailed && noWorkflowEvents {
+		return fmt.Errorf("--only-failed cannot be used with --no-workflow-events, which skips the workflow status it filters on")
+	}
+
+	terraformOnly, err := cmd.Flags().GetBool("terraform-only")
+	if err != nil {
+		return fmt.Errorf("failed to get terraform-only flag: %w", err)
+	}
+	if terraformOnly && (output == "json" || followWorkflow || exportBundle != "" || dumpTfFiles != "" || dumpHelmLogs != "") {
+		return fmt.Errorf("--terraform-only can only be used in the interactive view")
+	}
+
 	token, err := common.GetTokenWithLogin()
 	if err != nil {
 		return fmt.Errorf("failed to get token: %w", err)
 	}
 
+	if followWorkflow {
+		return runDebugFollowWorkflow(cmd.Context(), instanceID, token, cmd.OutOrStdout())
+	}
+
+	var redactor *debugRedactor
+	if !noRedact {
+		if redactor, err = newDebugRedactor(redactPattern, token); err != nil {
+			return err
+		}
+	}
+
+	if tfOutputs {
+		return runDebugTerraformOutputValues(cmd.Context(), instanceID, token, resourceKey, revealSensitive, cmd.OutOrStdout())
+	}
+
+	if dumpTfFiles != "" {
+		return runDebugDumpTerraformFiles(cmd.Context(), instanceID, token, resourceKey, dumpTfFiles)
+	}
+
+	if dumpHelmLogs != "" {
+		return runDebugDumpHelmLogs(cmd.Context(), instanceID, token, dumpHelmLogs, redactor)
+	}
+
+	if exportBundle != "" {
+		return runDebugExportBundle(cmd.Context(), instanceID, token, exportBundle, noWorkflowEvents, resourceKind, redactor, logFilter)
+	}
+
 	if output == "json" {
-		return runDebugJSON(instanceID, token)
+		return runDebugJSON(instanceID, token, noWorkflowEvents, resourceKind, onlyFailed, redactor, logFilter)
 	}
 
-	// Interactive mode: show spinner while loading
+	// Interactive mode: show spinner while loading. The workflow events of all resources are memoized
+	// for the run, so the DAG and resource detail views share their fetches.
+	workflowEventsCache := newDebugWorkflowEventsCache()
 	model := newLoadingModel(instanceID)
 	p := tea.NewProgram(model)
 
 	go func() {
-		fetchCmd := fetchDebugData(instanceID, token)
+		fetchCmd := fetchDebugData(instanceID, token, noWorkflowEvents, workflowEventsCache)
 		msg := fetchCmd()
 		p.Send(msg)
 	}()
@@ -191,20 +397,45 @@ func runDebug(cmd *cobra.Command, args []string) error {
 		return m.result.err
 	}
 
+	if terraformOnly {
+		return launchTerraformOnlyTUI(m.result.data)
+	}
 	return launchDebugTUI(m.result.data)
 }
 
-func runDebugJSON(instanceID, token string) error {
-	ctx := context.Background()
+func runDebugJSON(instanceID, token string, noWorkflowEvents bool, resourceKind string, onlyFailed bool, redactor *debugRedactor, logFilter *regexp.Regexp) error {
+	data, err := collectDebugData(context.Background(), instanceID, token, noWorkflowEvents)
+	if err != nil {
+		return err
+	}
+	filterDebugResourcesByKind(&data, resourceKind)
+	if onlyFailed {
+		filterDebugFailedResources(&data)
+	}
+	filterDebugLogs(&data, logFilter)
+	redactor.redactDebugData(&data)
+	applyDebugTimeFormat(data.PlanDAG)
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal debug data to JSON: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
 
+// collectDebugData gathers the non-interactive debug data for an instance: the plan DAG with
+// workflow progress and the per-resource debug info. Workflow events are fetched once for all
+// resources, or not at all when noWorkflowEvents is set.
+func collectDebugData(ctx context.Context, instanceID, token string, noWorkflowEvents bool) (DebugData, error) {
 	serviceID, environmentID, _, _, err := getInstance(ctx, token, instanceID)
 	if err != nil {
-		return fmt.Errorf("failed to get instance: %w", err)
+		return DebugData{}, fmt.Errorf("failed to get instance: %w", err)
 	}
 
 	instanceData, err := dataaccess.DescribeResourceInstance(ctx, token, serviceID, environmentID, instanceID)
 	if err != nil {
-		return fmt.Errorf("failed to describe resource instance: %w", err)
+		return DebugData{}, fmt.Errorf("failed to describe resource instance: %w", err)
 	}
 
 	planDAG, err := buildPlanDAG(ctx, token, serviceID, instanceData)
@@ -213,8 +444,8 @@ func runDebugJSON(instanceID, token string) error {
 			Errors: []string{err.Error()},
 		}
 	}
-	if planDAG != nil {
-		attachWorkflowProgress(ctx, token, serviceID, environmentID, instanceID, planDAG)
+	if planDAG != nil && !noWorkflowEvents {
+		attachWorkflowProgress(planDAG, fetchDebugWorkflowEvents(ctx, token, serviceID, environmentID, instanceID))
 		// Enrich bootstrap steps with dependency timelines for all resources
 		for resourceKey, steps := range planDAG.WorkflowStepsByKey {
 			enrichBootstrapSteps(steps, resourceKey, planDAG)
@@ -239,14 +470,16 @@ func runDebugJSON(instanceID, token string) error {
 	}
 
 	data := DebugData{
-		InstanceID:    instanceID,
-		ServiceID:     serviceID,
-		EnvironmentID: environmentID,
-		ProductTierID: instanceData.ProductTierId,
-		TierVersion:   instanceData.TierVersion,
-		PlanDAG:       planDAG,
-		ResultParams:  resultParams,
-		InputParams:   inputParams,
+		SchemaVersion:    DebugDataSchemaVersion,
+		InstanceID:       instanceID,
+		ServiceID:        serviceID,
+		EnvironmentID:    environmentID,
+		ProductTierID:    instanceData.ProductTierId,
+		TierVersion:      instanceData.TierVersion,
+		PlanDAG:          planDAG,
+		ResultParams:     resultParams,
+		InputParams:      inputParams,
+		NoWorkflowEvents: noWorkflowEvents,
 	}
 
 	// Collect per-resource debug info (helm data, terraform progress/files/logs)
@@ -254,18 +487,14 @@ func runDebugJSON(instanceID, token string) error {
 		data.ResourceDebugInfo = collectResourceDebugInfo(ctx, token, serviceID, environmentID, instanceID, planDAG, instanceData)
 	}
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal debug data to JSON: %w", err)
-	}
-	fmt.Println(string(jsonData))
-	return nil
+	return data, nil
 }
 
 // collectResourceDebugInfo fetches all per-resource debug data for the JSON output path.
 // It collects helm data (logs, values), terraform data (progress, history, files, logs),
-// operator data (input/output parameters), and compose data (input/output parameters)
-// for each resource in the plan DAG.
+// operator data (input/output parameters), and compose data (input/output parameters, files)
+// for each resource in the plan DAG. Rendered files and logs of every resource type are also
+// exposed under the common Files and Logs fields.
 // Errors for individual resources or data sources are handled gracefully — partial data
 // is returned rather than failing the entire operation.
 func collectResourceDebugInfo(ctx context.Context, token, serviceID, environmentID, instanceID string, planDAG *PlanDAG, instanceData *openapiclientfleet.ResourceInstance) map[string]*ResourceDebugInfo {
@@ -316,23 +545,78 @@ func collectResourceDebugInfo(ctx context.Context, token, serviceID, environment
 	// Collect compose debug data (input/output parameters) for compose resources
 	collectComposeDebugInfo(ctx, token, serviceID, planDAG, instanceData, inputParams, resultParams, result)
 
-	// Remove entries that have no debug data
+	// Remove entries that have no debug data and expose files/logs in a uniform shape
 	for key, info := range result {
 		if !info.hasData() {
 			delete(result, key)
+			continue
 		}
+		info.normalizeFilesAndLogs()
 	}
 
 	return result
 }
 
+// debugResourceWorkers bounds the resources whose debug data is fetched at the same time
+const debugResourceWorkers = 8
+
+// debugResourceNode is a plan DAG node with the debug info it is collected into
+type debugResourceNode struct {
+	node PlanDAGNode
+	info *ResourceDebugInfo
+}
+
+// debugResourceNodes returns the nodes whose type contains typeSubstring and that have an entry in result, sorted by
+// resource key
+func debugResourceNodes(planDAG *PlanDAG, result map[string]*ResourceDebugInfo, typeSubstring string) []debugResourceNode {
+	var nodes []debugResourceNode
+	for _, node := range planDAG.Nodes {
+		if !strings.Contains(strings.ToLower(node.Type), typeSubstring) {
+			continue
+		}
+		key := node.Key
+		if key == "" {
+			key = node.ID
+		}
+		if info, exists := result[key]; exists {
+			nodes = append(nodes, debugResourceNode{node: node, info: info})
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].info.ResourceKey < nodes[j].info.ResourceKey })
+	return nodes
+}
+
+// forEachDebugResource calls fetch for every item with at most debugResourceWorkers calls running at a time, and
+// returns once all are done. Calls must only write the debug info of their own item.
+func forEachDebugResource[T any](items []T, fetch func(T)) {
+	sem := make(chan struct{}, debugResourceWorkers)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fetch(item)
+		}()
+	}
+	wg.Wait()
+}
+
 // collectHelmDebugInfo fetches helm debug data (logs, chart values) and input/output parameters for all helm resources.
+// Rendered files found in the debug payload of compose resources are collected as well.
 func collectHelmDebugInfo(ctx context.Context, token, serviceID, environmentID, instanceID string, planDAG *PlanDAG, instanceData *openapiclientfleet.ResourceInstance, inputParams map[string]interface{}, resultParams map[string]interface{}, result map[string]*ResourceDebugInfo) {
 	debugResult, err := dataaccess.DebugResourceInstance(ctx, token, serviceID, environmentID, instanceID)
 	if err != nil || debugResult.ResourcesDebug == nil {
 		return
 	}
 
+	type helmParamsJob struct {
+		info   *ResourceDebugInfo
+		nodeID string
+	}
+	var jobs []helmParamsJob
+
 	for resourceKey, resourceDebugInfo := range *debugResult.ResourcesDebug {
 		if resourceKey == "omnistrateobserv" {
 			continue
@@ -373,24 +657,32 @@ func collectHelmDebugInfo(ctx context.Context, token, serviceID, environmentID,
 			}
 
 			if nodeID != "" && instanceData != nil {
-				// Fetch input parameters
-				fetchedInputParams, _ := fetchInputParams(
-					ctx, token, serviceID, nodeID,
-					instanceData.ProductTierId, instanceData.TierVersion,
-					inputParams,
-				)
-				info.Helm.InputParams = fetchedInputParams
-
-				// Fetch output parameters
-				outputParams, _ := fetchOutputParams(
-					ctx, token, serviceID, nodeID,
-					instanceData.ProductTierId, instanceData.TierVersion,
-					resultParams,
-				)
-				info.Helm.OutputParams = outputParams
+				jobs = append(jobs, helmParamsJob{info: info, nodeID: nodeID})
+			}
+		} else if isComposeResourceType(info.ResourceType) {
+			// Compose resources expose their rendered config files in the same debug payload
+			if files, logs := parseDebugFiles(actualDebugData); len(files) > 0 || len(logs) > 0 {
+				info.Compose = &ComposeData{Files: files, Logs: logs}
 			}
 		}
 	}
+
+	// Fetch the input/output parameters of the helm resources concurrently; each job only writes its own resource
+	forEachDebugResource(jobs, func(job helmParamsJob) {
+		fetchedInputParams, _ := fetchInputParams(
+			ctx, token, serviceID, job.nodeID,
+			instanceData.ProductTierId, instanceData.TierVersion,
+			inputParams,
+		)
+		job.info.Helm.InputParams = fetchedInputParams
+
+		outputParams, _ := fetchOutputParams(
+			ctx, token, serviceID, job.nodeID,
+			instanceData.ProductTierId, instanceData.TierVersion,
+			resultParams,
+		)
+		job.info.Helm.OutputParams = outputParams
+	})
 }
 
 // collectTerraformDebugInfo fetches terraform debug data (progress, history, files, logs)
@@ -466,20 +758,8 @@ func collectTerraformDebugInfo(ctx context.Context, token string, instanceData *
 // collectOperatorDebugInfo fetches operator debug data (input/output parameters, CRD outputs)
 // for operator-type resources.
 func collectOperatorDebugInfo(ctx context.Context, token, serviceID string, planDAG *PlanDAG, instanceData *openapiclientfleet.ResourceInstance, inputParams map[string]interface{}, resultParams map[string]interface{}, result map[string]*ResourceDebugInfo) {
-	for _, node := range planDAG.Nodes {
-		lower := strings.ToLower(node.Type)
-		if !strings.Contains(lower, "operator") {
-			continue
-		}
-
-		key := node.Key
-		if key == "" {
-			key = node.ID
-		}
-		info, exists := result[key]
-		if !exists {
-			continue
-		}
+	forEachDebugResource(debugResourceNodes(planDAG, result, "operator"), func(resource debugResourceNode) {
+		node, info := resource.node, resource.info
 
 		opData := &OperatorData{}
 
@@ -524,13 +804,16 @@ func collectOperatorDebugInfo(ctx context.Context, token, serviceID string, plan
 					}
 					opData.CRDOutputParams = append(opData.CRDOutputParams, crdParam)
 				}
+				sort.Slice(opData.CRDOutputParams, func(i, j int) bool {
+					return opData.CRDOutputParams[i].Key < opData.CRDOutputParams[j].Key
+				})
 			}
 		}
 
 		if len(opData.InputParams) > 0 || len(opData.OutputParams) > 0 || len(opData.CRDOutputParams) > 0 {
 			info.Operator = opData
 		}
-	}
+	})
 }
 
 // collectComposeDebugInfo fetches compose debug data (input/output parameters)
@@ -543,23 +826,15 @@ type inputParamsFetcher func(context.Context, string, string, string, string, st
 type outputParamsFetcher func(context.Context, string, string, string, string, string, map[string]interface{}) ([]OperatorOutputParam, error)
 
 func collectComposeDebugInfoWithFetchers(ctx context.Context, token, serviceID string, planDAG *PlanDAG, instanceData *openapiclientfleet.ResourceInstance, inputParams map[string]interface{}, resultParams map[string]interface{}, result map[string]*ResourceDebugInfo, fetchInput inputParamsFetcher, fetchOutput outputParamsFetcher) {
-	for _, node := range planDAG.Nodes {
-		lower := strings.ToLower(node.Type)
-		if !strings.Contains(lower, "compose") {
-			continue
-		}
+	forEachDebugResource(debugResourceNodes(planDAG, result, "compose"), func(resource debugResourceNode) {
+		node, info := resource.node, resource.info
 
-		key := node.Key
-		if key == "" {
-			key = node.ID
-		}
-		info, exists := result[key]
-		if !exists {
-			continue
+		// Keep files already collected from the debug payload
+		cData := info.Compose
+		if cData == nil {
+			cData = &ComposeData{}
 		}
 
-		cData := &ComposeData{}
-
 		// Fetch all input parameters
 		fetchedInputParams, inputErr := fetchInput(
 			ctx, token, serviceID, node.ID,
@@ -580,14 +855,33 @@ func collectComposeDebugInfoWithFetchers(ctx context.Context, token, serviceID s
 			cData.OutputParams = outputParams
 		}
 
-		if len(cData.InputParams) > 0 || len(cData.OutputParams) > 0 {
+		if len(cData.InputParams) > 0 || len(cData.OutputParams) > 0 || len(cData.Files) > 0 {
 			info.Compose = cData
 		}
-	}
+	})
 }
 
 func init() {
 	debugCmd.Flags().StringP("output", "o", "interactive", "Output format (interactive|json)")
+	debugCmd.Flags().Duration("refresh-interval", defaultWfEventsRefreshInterval, "Base interval between workflow event refreshes, at least 1s. Backs off while refreshes fail")
+	debugCmd.Flags().Duration("progress-refresh-interval", defaultProgressRefreshInterval, "Interval between terraform progress refreshes in the interactive view (at least 1s)")
+	debugCmd.Flags().Duration("log-poll-interval", defaultLogPollInterval, "Interval between live log polls in the interactive view (at least 1s)")
+	debugCmd.Flags().Bool("follow-workflow", false, "Print newline-delimited JSON workflow status updates until the workflow succeeds (exit 0) or fails (non-zero exit)")
+	debugCmd.Flags().String("export-bundle", "", "Write the debug data, resource files and logs, and workflow events to a gzip-compressed tarball (e.g. bundle.tar.gz) for sharing with support")
+	debugCmd.Flags().String("dump-tf-files", "", "Write every file of the terraform workspace of --resource, as found on the live executor pod, to this directory")
+	debugCmd.Flags().String("resource", "", "Key of the terraform resource whose files --dump-tf-files writes, or whose outputs --tf-outputs prints")
+	debugCmd.Flags().Bool("tf-outputs", false, "Print the outputs of the latest terraform operation of --resource as JSON, with --output=json")
+	debugCmd.Flags().Bool("reveal-sensitive", false, "Print the values of sensitive terraform outputs with --tf-outputs instead of masking them")
+	debugCmd.Flags().String("dump-helm-logs", "", "Write the install log of every helm resource to this directory, one <resource-key>-<release>-install.log file per resource; resources without an install log are skipped")
+	debugCmd.Flags().Bool("terraform-only", false, "Open the terraform detail view directly, skipping the DAG view; lists the terraform resources first when there are several")
+	debugCmd.Flags().String("audit-log", "", "Append a JSON line to this file each time a sensitive terraform output is revealed in the interactive view or with --reveal-sensitive, recording the output key and time but not the value")
+	debugCmd.Flags().Bool("no-redact", false, "Emit secrets (helm values, parameters, file and log contents) verbatim in --output=json, --export-bundle and --dump-helm-logs instead of masking them")
+	debugCmd.Flags().Bool("no-workflow-events", false, "Skip fetching workflow events and progress, e.g. when only resource files and logs are needed")
+	debugCmd.Flags().String("time-format", debugTimeFormatUTC, "Display format of workflow event timestamps (utc|local|rfc3339|relative)")
+	debugCmd.Flags().String("grep", "", "Keep only the log lines matching this regular expression in --output=json and --export-bundle")
+	debugCmd.Flags().Bool("only-failed", false, "Keep only resources whose workflow failed in --output=json, for alerting and automation")
+	debugCmd.Flags().String("resource-type", "", "Keep only resources of this type (helm|terraform|generic) in --output=json and --export-bundle")
+	debugCmd.Flags().String("redact-pattern", defaultRedactPattern, "Regular expression matching the keys whose values are masked in --output=json and --export-bundle")
 	debugCmd.AddCommand(debugHelmLogsCmd)
 	debugCmd.AddCommand(debugHelmValuesCmd)
 	debugCmd.AddCommand(debugTerraformFilesCmd)
@@ -0,0 +1,50 @@
+package instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// debugAuditLogPath is the file reveals of sensitive values are recorded in, set from --audit-log. Empty disables
+// audit logging.
+var debugAuditLogPath string
+
+// sensitiveRevealAuditEntry records that a sensitive value was revealed. The value itself is never recorded.
+type sensitiveRevealAuditEntry struct {
+	Time        string `json:"time"`
+	Event       string `json:"event"`
+	InstanceID  string `json:"instanceId"`
+	ResourceKey string `json:"resourceKey"`
+	OutputKey   string `json:"outputKey"`
+}
+
+// recordSensitiveReveal appends a JSON line to the audit log at path recording that the sensitive terraform output
+// outputKey of a resource was revealed. It does nothing when path is empty.
+func recordSensitiveReveal(path, instanceID, resourceKey, outputKey string, now time.Time) error {
+	if path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(sensitiveRevealAuditEntry{
+		Time:        now.UTC().Format(time.RFC3339),
+		Event:       "sensitive_output_revealed",
+		InstanceID:  instanceID,
+		ResourceKey: resourceKey,
+		OutputKey:   outputKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write audit log %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,44 @@
+package instance
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordSensitiveRevealAppendsEntries(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	now := time.Date(2026, 6, 8, 15, 0, 0, 0, time.UTC)
+	require.NoError(recordSensitiveReveal(path, "instance-1", "network", "db_password", now))
+	require.NoError(recordSensitiveReveal(path, "instance-1", "network", "api_token", now.Add(time.Minute)))
+
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(lines, 2)
+
+	var entry sensitiveRevealAuditEntry
+	require.NoError(json.Unmarshal([]byte(lines[0]), &entry))
+	require.Equal(sensitiveRevealAuditEntry{
+		Time:        "2026-06-08T15:00:00Z",
+		Event:       "sensitive_output_revealed",
+		InstanceID:  "instance-1",
+		ResourceKey: "network",
+		OutputKey:   "db_password",
+	}, entry)
+
+	info, err := os.Stat(path)
+	require.NoError(err)
+	require.Equal(os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestRecordSensitiveRevealDisabledByDefault(t *testing.T) {
+	require.NoError(t, recordSensitiveReveal("", "instance-1", "network", "db_password", time.Now()))
+}
@@ -0,0 +1,225 @@
+package instance
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+)
+
+const (
+	debugBundleManifestName = "manifest.json"
+	debugBundleDataName     = "debug.json"
+	debugBundleEventsName   = "workflow/events.json"
+)
+
+type debugBundleManifest struct {
+	InstanceID string             `json:"instanceId"`
+	CreatedAt  string             `json:"createdAt"`
+	CLIVersion string             `json:"cliVersion,omitempty"`
+	Redacted   bool               `json:"redacted"`
+	Entries    []debugBundleEntry `json:"entries"`
+}
+
+type debugBundleEntry struct {
+	Path        string `json:"path"`
+	Size        int    `json:"size"`
+	ResourceKey string `json:"resourceKey,omitempty"`
+	Kind        string `json:"kind"`
+}
+
+type debugBundleFile struct {
+	entry   debugBundleEntry
+	content []byte
+}
+
+// runDebugExportBundle collects the non-interactive debug data for an instance and writes it,
+// together with resource files, logs and workflow events, to a gzip-compressed tarball.
+// Only resources of resourceKind are kept unless it is empty. A nil redactor leaves secrets in place,
+// and a nil logFilter keeps every log line.
+func runDebugExportBundle(ctx context.Context, instanceID, token, bundlePath string, noWorkflowEvents bool, resourceKind string, redactor *debugRedactor, logFilter *regexp.Regexp) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	data, err := collectDebugData(ctx, instanceID, token, noWorkflowEvents)
+	if err != nil {
+		return err
+	}
+	filterDebugResourcesByKind(&data, resourceKind)
+	filterDebugLogs(&data, logFilter)
+
+	f, err := os.OpenFile(bundlePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create debug bundle %s: %w", bundlePath, err)
+	}
+
+	if err = writeDebugBundle(f, data, redactor, time.Now().UTC()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to write debug bundle %s: %w", bundlePath, err)
+	}
+
+	utils.PrintSuccess(fmt.Sprintf("Debug bundle for instance %s saved to %s", instanceID, bundlePath))
+	return nil
+}
+
+// writeDebugBundle writes the debug data as a gzip-compressed tarball with a manifest listing every entry.
+// Unless redactor is nil, secrets are masked in the data and the token is removed from every entry.
+func writeDebugBundle(w io.Writer, data DebugData, redactor *debugRedactor, createdAt time.Time) error {
+	redactor.redactDebugData(&data)
+	files, err := buildDebugBundleFiles(data)
+	if err != nil {
+		return err
+	}
+
+	manifest := debugBundleManifest{
+		InstanceID: data.InstanceID,
+		CreatedAt:  createdAt.Format(time.RFC3339),
+		CLIVersion: config.Version,
+		Redacted:   redactor != nil,
+	}
+	for i := range files {
+		files[i].content = []byte(redactor.redactToken(string(files[i].content)))
+		files[i].entry.Size = len(files[i].content)
+		manifest.Entries = append(manifest.Entries, files[i].entry)
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal debug bundle manifest: %w", err)
+	}
+
+	gzWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzWriter)
+
+	if err = writeDebugBundleEntry(tarWriter, debugBundleManifestName, manifestData, createdAt); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err = writeDebugBundleEntry(tarWriter, file.entry.Path, file.content, createdAt); err != nil {
+			return err
+		}
+	}
+
+	// Close tar writer first, then gzip writer
+	if err = tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close tar writer: %w", err)
+	}
+	if err = gzWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close gzip writer: %w", err)
+	}
+	return nil
+}
+
+// buildDebugBundleFiles lays out the bundle contents: the full debug JSON, the workflow events,
+// and the files and logs of each resource under resources/<resource-key>/. Helm resources without an install log
+// get a placeholder in its place.
+func buildDebugBundleFiles(data DebugData) ([]debugBundleFile, error) {
+	debugJSON, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal debug data to JSON: %w", err)
+	}
+
+	files := []debugBundleFile{{
+		entry:   debugBundleEntry{Path: debugBundleDataName, Kind: "debug"},
+		content: debugJSON,
+	}}
+
+	if data.PlanDAG != nil && len(data.PlanDAG.WorkflowStepsByKey) > 0 {
+		events, err := json.MarshalIndent(data.PlanDAG.WorkflowStepsByKey, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal workflow events to JSON: %w", err)
+		}
+		files = append(files, debugBundleFile{
+			entry:   debugBundleEntry{Path: debugBundleEventsName, Kind: "workflowEvents"},
+			content: events,
+		})
+	}
+
+	resourceKeys := make([]string, 0, len(data.ResourceDebugInfo))
+	for key := range data.ResourceDebugInfo {
+		resourceKeys = append(resourceKeys, key)
+	}
+	sort.Strings(resourceKeys)
+
+	for _, key := range resourceKeys {
+		info := data.ResourceDebugInfo[key]
+		if info == nil {
+			continue
+		}
+		files = append(files, debugBundleResourceFiles(key, "files", "file", info.Files)...)
+		logs := info.Logs
+		if info.Helm != nil && logs[helmInstallLogKey] == "" {
+			logs = make(map[string]string, len(info.Logs)+1)
+			for name, content := range info.Logs {
+				logs[name] = content
+			}
+			logs[helmInstallLogKey] = helmInstallLogPlaceholder
+		}
+		files = append(files, debugBundleResourceFiles(key, "logs", "log", logs)...)
+	}
+
+	return files, nil
+}
+
+func debugBundleResourceFiles(resourceKey, dir, kind string, contents map[string]string) []debugBundleFile {
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]debugBundleFile, 0, len(names))
+	for _, name := range names {
+		files = append(files, debugBundleFile{
+			entry: debugBundleEntry{
+				Path:        path.Join("resources", sanitizeBundlePath(resourceKey), dir, sanitizeBundlePath(name)),
+				ResourceKey: resourceKey,
+				Kind:        kind,
+			},
+			content: []byte(contents[name]),
+		})
+	}
+	return files
+}
+
+// sanitizeBundlePath turns an arbitrary file or resource name into a relative path that stays inside
+// its directory in the archive.
+func sanitizeBundlePath(name string) string {
+	cleaned := path.Clean("/" + strings.ReplaceAll(name, "\\", "/"))
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" {
+		return "_"
+	}
+	return cleaned
+}
+
+func writeDebugBundleEntry(tarWriter *tar.Writer, name string, content []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write tar header for %s: %w", name, err)
+	}
+	if _, err := tarWriter.Write(content); err != nil {
+		return fmt.Errorf("failed to write %s to debug bundle: %w", name, err)
+	}
+	return nil
+}
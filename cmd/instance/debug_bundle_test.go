@@ -0,0 +1,103 @@
+package instance
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readDebugBundle(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	gzReader, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzReader)
+
+	entries := make(map[string]string)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		entries[header.Name] = string(content)
+	}
+	return entries
+}
+
+func TestWriteDebugBundle(t *testing.T) {
+	require := require.New(t)
+
+	data := DebugData{
+		InstanceID: "inst-1",
+		Token:      "tok-abc123",
+		PlanDAG: &PlanDAG{
+			WorkflowStepsByKey: map[string]*ResourceWorkflowSteps{
+				"db": {Steps: []WorkflowStepInfo{}},
+			},
+		},
+		ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"db": {
+				ResourceKey:  "db",
+				ResourceType: "terraform",
+				Files: map[string]string{
+					"main.tf":          "password = \"hunter2\"\nname = \"db\"",
+					"../../etc/passwd": "escaped",
+				},
+				Logs: map[string]string{
+					"log/apply.log": "authorization: Bearer tok-abc123",
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	redactor, err := newDebugRedactor("", data.Token)
+	require.NoError(err)
+	err = writeDebugBundle(&buf, data, redactor, createdAt)
+	require.NoError(err)
+
+	entries := readDebugBundle(t, buf.Bytes())
+	require.Contains(entries, debugBundleManifestName)
+	require.Contains(entries, debugBundleDataName)
+	require.Contains(entries, debugBundleEventsName)
+	require.Contains(entries, "resources/db/files/main.tf")
+	require.Contains(entries, "resources/db/files/etc/passwd")
+	require.Contains(entries, "resources/db/logs/log/apply.log")
+
+	require.Equal("password = \"[REDACTED]\"\nname = \"db\"", entries["resources/db/files/main.tf"])
+	require.NotContains(entries["resources/db/logs/log/apply.log"], "tok-abc123")
+	for name, content := range entries {
+		require.NotContains(content, "hunter2", name)
+		require.NotContains(content, "tok-abc123", name)
+	}
+
+	var manifest debugBundleManifest
+	require.NoError(json.Unmarshal([]byte(entries[debugBundleManifestName]), &manifest))
+	require.Equal("inst-1", manifest.InstanceID)
+	require.Equal("2026-01-02T03:04:05Z", manifest.CreatedAt)
+	require.True(manifest.Redacted)
+	require.Len(manifest.Entries, 5)
+	for _, entry := range manifest.Entries {
+		require.Equal(len(entries[entry.Path]), entry.Size, entry.Path)
+	}
+}
+
+func TestSanitizeBundlePath(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("main.tf", sanitizeBundlePath("main.tf"))
+	require.Equal("log/install.log", sanitizeBundlePath("log/install.log"))
+	require.Equal("etc/passwd", sanitizeBundlePath("../../etc/passwd"))
+	require.Equal("modules/vpc/main.tf", sanitizeBundlePath("/modules\\vpc/main.tf"))
+	require.Equal("_", sanitizeBundlePath(".."))
+}
@@ -9,16 +9,18 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
 )
 
 const (
 	composeTabInputVars  = 0
 	composeTabOutputVars = 1
-	composeTabWfErrors   = 2
-	composeNumTabs       = 3
+	composeTabFiles      = 2
+	composeTabWfErrors   = 3
+	composeNumTabs       = 4
 )
 
-var composeTabNames = []string{"Deployment API parameters", "Deployment Output Parameters", "Workflow Events"}
+var composeTabNames = []string{"Deployment API parameters", "Deployment Output Parameters", "Files", "Workflow Events"}
 
 func init() {
 	if len(composeTabNames) != composeNumTabs {
@@ -31,6 +33,7 @@ type composeDataMsg struct {
 	composeData *ComposeData
 	inputErr    error
 	outputErr   error
+	filesErr    error
 }
 
 type composeDetailModel struct {
@@ -44,6 +47,7 @@ type composeDetailModel struct {
 	loadErr   error
 	inputErr  error
 	outputErr error
+	filesErr  error
 	spinner   spinner.Model
 
 	composeData *ComposeData
@@ -58,6 +62,11 @@ type composeDetailModel struct {
 	outputCursor int
 	outputScroll int
 
+	// Rendered files tree
+	filesTree   []outputNode
+	filesCursor int
+	filesScroll int
+
 	// Workflow Events tab
 	wfErrors *workflowErrorsState
 
@@ -107,14 +116,49 @@ func (m composeDetailModel) fetchComposeData() tea.Cmd {
 			cData.OutputParams = outputParams
 		}
 
+		// Fetch rendered files from the resource debug payload
+		files, filesErr := m.fetchComposeFiles(ctx)
+		if filesErr == nil {
+			cData.Files = files
+		}
+
 		return composeDataMsg{
 			composeData: cData,
 			inputErr:    inputErr,
 			outputErr:   outputErr,
+			filesErr:    filesErr,
 		}
 	}
 }
 
+func (m composeDetailModel) fetchComposeFiles(ctx context.Context) (map[string]string, error) {
+	debugResult, err := dataaccess.DebugResourceInstance(
+		ctx, m.debugData.Token,
+		m.debugData.ServiceID, m.debugData.EnvironmentID, m.debugData.InstanceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debug info: %w", err)
+	}
+	if debugResult.ResourcesDebug == nil {
+		return nil, nil
+	}
+
+	resourceDebugInfo, ok := (*debugResult.ResourcesDebug)[m.node.Key]
+	if !ok {
+		return nil, nil
+	}
+	debugDataInterface, ok := resourceDebugInfo.GetDebugDataOk()
+	if !ok || debugDataInterface == nil {
+		return nil, nil
+	}
+	actualDebugData, ok := (*debugDataInterface).(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	files, _ := parseDebugFiles(actualDebugData)
+	return files, nil
+}
+
 func (m composeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -134,10 +178,12 @@ func (m composeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.inputErr = msg.inputErr
 		m.outputErr = msg.outputErr
+		m.filesErr = msg.filesErr
 		m.composeData = msg.composeData
 		if m.composeData != nil {
 			m.inputTree = buildOperatorParamTree(m.composeData.InputParams)
 			m.outputTree = buildOperatorOutputParamTree(m.composeData.OutputParams)
+			m.filesTree = buildDebugFilesTree(m.composeData.Files)
 		}
 		return m, scheduleResourceWorkflowRefreshIfNeeded(m.debugData, m.node)
 
@@ -184,6 +230,8 @@ func (m composeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.inputCursor, m.inputScroll = moveResourceDetailTreeUp(m.inputTree, m.inputCursor, m.inputScroll, m.composeVisibleRows())
 			case composeTabOutputVars:
 				m.outputCursor, m.outputScroll = moveResourceDetailTreeUp(m.outputTree, m.outputCursor, m.outputScroll, m.composeVisibleRows())
+			case composeTabFiles:
+				m.filesCursor, m.filesScroll = moveResourceDetailTreeUp(m.filesTree, m.filesCursor, m.filesScroll, m.composeVisibleRows())
 			case composeTabWfErrors:
 				items := flattenWfEventItems(m.getWfEvents())
 				if m.wfErrors.cursor > 0 {
@@ -205,6 +253,8 @@ func (m composeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.inputCursor, m.inputScroll = moveResourceDetailTreeDown(m.inputTree, m.inputCursor, m.inputScroll, m.composeVisibleRows())
 			case composeTabOutputVars:
 				m.outputCursor, m.outputScroll = moveResourceDetailTreeDown(m.outputTree, m.outputCursor, m.outputScroll, m.composeVisibleRows())
+			case composeTabFiles:
+				m.filesCursor, m.filesScroll = moveResourceDetailTreeDown(m.filesTree, m.filesCursor, m.filesScroll, m.composeVisibleRows())
 			case composeTabWfErrors:
 				items := flattenWfEventItems(m.getWfEvents())
 				if m.wfErrors.cursor < len(items)-1 {
@@ -218,6 +268,8 @@ func (m composeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				toggleResourceDetailTreeNode(m.inputTree, m.inputCursor)
 			case composeTabOutputVars:
 				toggleResourceDetailTreeNode(m.outputTree, m.outputCursor)
+			case composeTabFiles:
+				toggleResourceDetailTreeNode(m.filesTree, m.filesCursor)
 			case composeTabWfErrors:
 				items := flattenWfEventItems(m.getWfEvents())
 				if m.wfErrors.cursor < len(items) {
@@ -235,6 +287,8 @@ func (m composeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				expandResourceDetailTreeNode(m.inputTree, m.inputCursor)
 			case composeTabOutputVars:
 				expandResourceDetailTreeNode(m.outputTree, m.outputCursor)
+			case composeTabFiles:
+				expandResourceDetailTreeNode(m.filesTree, m.filesCursor)
 			}
 		case "left", "h":
 			switch m.activeTab {
@@ -242,6 +296,8 @@ func (m composeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				collapseResourceDetailTreeNode(m.inputTree, m.inputCursor)
 			case composeTabOutputVars:
 				collapseResourceDetailTreeNode(m.outputTree, m.outputCursor)
+			case composeTabFiles:
+				collapseResourceDetailTreeNode(m.filesTree, m.filesCursor)
 			}
 		case "pgup":
 			switch m.activeTab {
@@ -313,6 +369,8 @@ func (m composeDetailModel) getComposeTabContent() string {
 		return m.renderComposeInputVarsTab()
 	case composeTabOutputVars:
 		return m.renderComposeOutputVarsTab()
+	case composeTabFiles:
+		return m.renderComposeFilesTab()
 	case composeTabWfErrors:
 		return m.renderComposeWfErrorsTab()
 	}
@@ -327,6 +385,10 @@ func (m composeDetailModel) renderComposeOutputVarsTab() string {
 	return m.renderComposeParamTreeTab("Deployment Output Parameters", m.outputTree, m.outputCursor, m.outputScroll, m.outputErr)
 }
 
+func (m composeDetailModel) renderComposeFilesTab() string {
+	return renderResourceDetailParamTreeTab("Files", m.filesTree, m.filesCursor, m.filesScroll, m.composeVisibleRows(), m.composeContentWidth(), m.loading, m.spinner.View(), m.loadErr, m.filesErr, true)
+}
+
 func (m composeDetailModel) renderComposeParamTreeTab(title string, tree []outputNode, cursor, scroll int, fetchErr error) string {
 	return renderResourceDetailParamTreeTab(title, tree, cursor, scroll, m.composeVisibleRows(), m.composeContentWidth(), m.loading, m.spinner.View(), m.loadErr, fetchErr, false)
 }
@@ -345,6 +407,12 @@ func (m composeDetailModel) renderComposeFooter() string {
 		} else {
 			text = "tab/shift+tab: switch tabs  esc: back  q: quit"
 		}
+	case composeTabFiles:
+		if len(m.filesTree) > 0 {
+			text = "↑↓: navigate  ←→/enter: expand/collapse  y: copy  tab/shift+tab: switch tabs  esc: back  q: quit"
+		} else {
+			text = "tab/shift+tab: switch tabs  esc: back  q: quit"
+		}
 	case composeTabWfErrors:
 		text = "↑↓/pgup/pgdn: scroll  y: copy  tab/shift+tab: switch tabs  esc: back  q: quit"
 	default:
@@ -369,6 +437,13 @@ func (m composeDetailModel) composeCopyableContent() string {
 				return string(raw)
 			}
 		}
+	case composeTabFiles:
+		if m.composeData != nil && len(m.composeData.Files) > 0 {
+			raw, err := json.Marshal(m.composeData.Files)
+			if err == nil {
+				return string(raw)
+			}
+		}
 	case composeTabWfErrors:
 		return workflowEventsCopyText(m.getWfEvents())
 	}
@@ -13,6 +13,7 @@ func TestComposeTabNames(t *testing.T) {
 	require.Equal(t, composeNumTabs, len(composeTabNames), "composeTabNames length must match composeNumTabs")
 	require.Equal(t, "Deployment API parameters", composeTabNames[composeTabInputVars])
 	require.Equal(t, "Deployment Output Parameters", composeTabNames[composeTabOutputVars])
+	require.Equal(t, "Files", composeTabNames[composeTabFiles])
 	require.Equal(t, "Workflow Events", composeTabNames[composeTabWfErrors])
 }
 
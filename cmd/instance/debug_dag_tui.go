@@ -99,6 +99,10 @@ type dagModel struct {
 	tfBreakpointByName map[string]string
 	refreshing         bool // true during periodic refresh
 	spinner            spinner.Model
+
+	// Manual reload of the instance data with 'r'
+	reloading bool
+	reloadErr string
 }
 
 func launchDebugTUI(data DebugData) error {
@@ -142,6 +146,7 @@ func newDagModel(data DebugData) dagModel {
 		metricsRootNodes: metricsRootNodes,
 		metricsItems:     metricsItems,
 		progressLoading:  hasNodes,
+		wfResolved:       data.NoWorkflowEvents, // nothing to load when workflow events are disabled
 		spinner:          s,
 	}
 }
@@ -161,6 +166,9 @@ func buildSelectableNodeList(plan *PlanDAG) ([]string, [][]string) {
 
 func (m dagModel) Init() tea.Cmd {
 	if m.progressLoading {
+		if m.wfResolved {
+			return tea.Batch(m.spinner.Tick, m.fetchTerraformProgressForDAG())
+		}
 		return tea.Batch(m.spinner.Tick, m.fetchWorkflowProgressForDAG(), m.fetchTerraformProgressForDAG())
 	}
 	return nil
@@ -172,7 +180,7 @@ func (m dagModel) fetchWorkflowProgressForDAG() tea.Cmd {
 		data := m.debugData
 		// Build progress in a temporary plan to avoid shared-state race
 		tmpPlan := &PlanDAG{Nodes: m.plan.Nodes, Levels: m.plan.Levels}
-		attachWorkflowProgress(ctx, data.Token, data.ServiceID, data.EnvironmentID, data.InstanceID, tmpPlan)
+		attachWorkflowProgress(tmpPlan, data.workflowEvents(ctx))
 		return wfProgressMsg{
 			progressByID:       tmpPlan.ProgressByID,
 			progressByKey:      tmpPlan.ProgressByKey,
@@ -286,6 +294,9 @@ func (m dagModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// If in detail sub-view, delegate
 	if m.inDetail && m.detailModel != nil {
 		switch dmsg := msg.(type) {
+		case debugDataMsg:
+			// Apply a reload started before the detail view was opened; the detail view keeps its data
+			return m.applyReload(dmsg)
 		case backToDagMsg:
 			m.inDetail = false
 			m.detailModel = nil
@@ -366,6 +377,13 @@ func (m dagModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
+		case "r":
+			if !m.reloading {
+				m.reloading = true
+				m.reloadErr = ""
+				return m, fetchDebugData(m.instanceID, m.debugData.Token, m.debugData.NoWorkflowEvents, m.debugData.WorkflowEventsCache)
+			}
+			return m, nil
 		case "tab":
 			m.activeTab = (m.activeTab + 1) % dagNumTabs
 			m.clampScroll()
@@ -448,6 +466,8 @@ func (m dagModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.clampScroll()
 	case dashboardActionResultMsg:
 		return m.updateMetricsDashboard(msg)
+	case debugDataMsg:
+		return m.applyReload(msg)
 	case wfProgressMsg:
 		m.wfResolved = true
 		m.wfResult = &msg
@@ -581,6 +601,52 @@ func (m dagModel) updateMetricsDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// applyReload replaces the instance data with the result of a reload and rebuilds the resource tree, keeping the
+// selected node, expanded nodes, tab and scroll position where they still exist. A failed reload keeps the
+// current data and reports the error in the header.
+func (m dagModel) applyReload(msg debugDataMsg) (tea.Model, tea.Cmd) {
+	m.reloading = false
+	if msg.err != nil {
+		m.reloadErr = msg.err.Error()
+		return m, nil
+	}
+
+	selectedNodeID := ""
+	if m.showCursor && len(m.selectableNodes) > 0 {
+		selectedNodeID = m.selectableNodes[m.cursorIndex]
+	}
+	var selectedMetricsKey string
+	if item := m.selectedMetricsItem(); item != nil {
+		selectedMetricsKey = item.key
+	}
+
+	reloaded := newDagModel(msg.data)
+	reloaded.width = m.width
+	reloaded.height = m.height
+	reloaded.activeTab = m.activeTab
+	reloaded.highlightDeps = m.highlightDeps
+	reloaded.scrollX = m.scrollX
+	reloaded.scrollY = m.scrollY
+	reloaded.detailModel = m.detailModel
+	reloaded.inDetail = m.inDetail
+	if reloaded.plan != nil {
+		for nodeID, expanded := range m.expandedNodes {
+			if _, ok := reloaded.plan.Nodes[nodeID]; ok && expanded {
+				reloaded.expandedNodes[nodeID] = true
+			}
+		}
+	}
+	for index, nodeID := range reloaded.selectableNodes {
+		if nodeID == selectedNodeID {
+			reloaded.cursorIndex = index
+			break
+		}
+	}
+	reloaded.rebuildMetricsItems(selectedMetricsKey)
+	reloaded.rebuildLayout()
+	return reloaded, reloaded.Init()
+}
+
 func (m *dagModel) applyProgressIfReady() tea.Cmd {
 	if !m.wfResolved || !m.tfResolved {
 		return nil
@@ -690,11 +756,11 @@ func (m dagModel) fetchDagRefresh() tea.Cmd {
 		ctx := context.Background()
 		data := m.debugData
 
-		// Fetch workflow progress
+		// Fetch workflow progress unless workflow events are disabled
 		var wf wfProgressMsg
-		if m.plan != nil {
+		if m.plan != nil && !data.NoWorkflowEvents {
 			tmpPlan := &PlanDAG{Nodes: m.plan.Nodes, Levels: m.plan.Levels}
-			attachWorkflowProgress(ctx, data.Token, data.ServiceID, data.EnvironmentID, data.InstanceID, tmpPlan)
+			attachWorkflowProgress(tmpPlan, data.workflowEvents(ctx))
 			wf = wfProgressMsg{
 				progressByID:       tmpPlan.ProgressByID,
 				progressByKey:      tmpPlan.ProgressByKey,
@@ -1190,6 +1256,11 @@ func (m dagModel) renderHeader() string {
 		}
 		text += " · " + workflowText
 	}
+	if m.reloading {
+		text += " · refreshing..."
+	} else if m.reloadErr != "" {
+		text += " · refresh failed: " + m.reloadErr
+	}
 	return lipgloss.Place(m.width, 1, lipgloss.Left, lipgloss.Top, style.Render(text))
 }
 
@@ -1204,12 +1275,12 @@ func (m dagModel) renderHelp() string {
 		if m.highlightDeps {
 			depGraphLabel = "hide dep graph"
 		}
-		text = fmt.Sprintf("tab/shift+tab: switch tabs  space: deps  d: %s  enter: open  arrows: navigate  q: quit  │  %s", depGraphLabel, selectedStyle.Render(nodeLabel(node)))
+		text = fmt.Sprintf("tab/shift+tab: switch tabs  space: deps  d: %s  enter: open  arrows: navigate  r: refresh  q: quit  │  %s", depGraphLabel, selectedStyle.Render(nodeLabel(node)))
 	} else {
-		text = "tab/shift+tab: switch tabs  arrows: scroll  pgup/pgdn: page  home/end: jump  q: quit"
+		text = "tab/shift+tab: switch tabs  arrows: scroll  pgup/pgdn: page  home/end: jump  r: refresh  q: quit"
 	}
 	if m.activeTab == dagTabMetrics {
-		text = "tab/shift+tab: switch tabs  ↑/↓: navigate  enter: expand/collapse  c/y: copy  o: open URL  q: quit"
+		text = "tab/shift+tab: switch tabs  ↑/↓: navigate  enter: expand/collapse  c/y: copy  o: open URL  r: refresh  q: quit"
 	}
 	return lipgloss.Place(m.width, 1, lipgloss.Left, lipgloss.Top, style.Render(text))
 }
@@ -0,0 +1,231 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// Statuses of resources and files in a debug diff. Added means only the second instance has it, removed only
+	// the first.
+	debugDiffAdded     = "added"
+	debugDiffRemoved   = "removed"
+	debugDiffChanged   = "changed"
+	debugDiffUnchanged = "unchanged"
+)
+
+var debugDiffCmd = &cobra.Command{
+	Use:   "debug-diff [instance-id] [instance-id]",
+	Short: "Compare the debug data of two instances",
+	Long: `Compare the debug data of two instances side by side, to find the configuration drift between a deployment that works and one that doesn't.
+The resources of both instances are matched by key. For each resource, its rendered files (terraform files, compose config files and helm values as values.yaml) are compared line by line; resources and files found in only one of the instances are marked added or removed.
+Secrets are masked before comparing unless --no-redact is set, so differing secret values are not reported. Use --output=json for non-interactive output.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDebugDiff,
+	Example: `  omnistrate-ctl instance debug-diff <instance-id> <other-instance-id>
+  omnistrate-ctl instance debug-diff <instance-id> <other-instance-id> --output=json`,
+}
+
+func init() {
+	debugDiffCmd.Flags().StringP("output", "o", "interactive", "Output format (interactive|json)")
+	debugDiffCmd.Flags().Bool("no-redact", false, "Compare secrets (helm values, file contents) verbatim instead of masking them")
+	debugDiffCmd.Flags().String("redact-pattern", defaultRedactPattern, "Regular expression matching the keys whose values are masked before comparing")
+}
+
+// DebugDiff is the difference between the debug data of two instances
+type DebugDiff struct {
+	InstanceA string              `json:"instanceA"`
+	InstanceB string              `json:"instanceB"`
+	Resources []DebugResourceDiff `json:"resources"`
+}
+
+// DebugResourceDiff is the difference between the files of a resource in two instances
+type DebugResourceDiff struct {
+	ResourceKey  string          `json:"resourceKey"`
+	ResourceType string          `json:"resourceType,omitempty"`
+	Status       string          `json:"status"`
+	Files        []DebugFileDiff `json:"files,omitempty"`
+}
+
+// DebugFileDiff is the difference of a resource file between two instances. Diff is a unified line diff, set for
+// changed files only.
+type DebugFileDiff struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Diff   string `json:"diff,omitempty"`
+}
+
+func runDebugDiff(cmd *cobra.Command, args []string) error {
+	instanceA := config.ResolveInstanceID(args[0])
+	instanceB := config.ResolveInstanceID(args[1])
+	if instanceA == instanceB {
+		return fmt.Errorf("the two instances to compare are the same: %s", instanceA)
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output flag: %w", err)
+	}
+	if output != "interactive" && output != "json" {
+		return fmt.Errorf("invalid output format %q: expected interactive or json", output)
+	}
+
+	noRedact, err := cmd.Flags().GetBool("no-redact")
+	if err != nil {
+		return fmt.Errorf("failed to get no-redact flag: %w", err)
+	}
+
+	redactPattern, err := cmd.Flags().GetString("redact-pattern")
+	if err != nil {
+		return fmt.Errorf("failed to get redact-pattern flag: %w", err)
+	}
+
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	var redactor *debugRedactor
+	if !noRedact {
+		if redactor, err = newDebugRedactor(redactPattern, token); err != nil {
+			return err
+		}
+	}
+
+	if output != "json" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Fetching the debug data of %s and %s...\n", instanceA, instanceB)
+	}
+	dataA, dataB, err := collectDebugDataPair(cmd.Context(), instanceA, instanceB, token)
+	if err != nil {
+		return err
+	}
+	redactor.redactDebugData(&dataA)
+	redactor.redactDebugData(&dataB)
+
+	diff := diffDebugData(dataA, dataB)
+	if output == "json" {
+		jsonData, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal debug diff to JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		return nil
+	}
+	return launchDebugDiffTUI(diff)
+}
+
+// collectDebugDataPair collects the debug data of both instances concurrently, without workflow events
+func collectDebugDataPair(ctx context.Context, instanceA, instanceB, token string) (DebugData, DebugData, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var wg sync.WaitGroup
+	var dataB DebugData
+	var errB error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		dataB, errB = collectDebugData(ctx, instanceB, token, true)
+	}()
+	dataA, errA := collectDebugData(ctx, instanceA, token, true)
+	wg.Wait()
+
+	if errA != nil {
+		return DebugData{}, DebugData{}, fmt.Errorf("instance %s: %w", instanceA, errA)
+	}
+	if errB != nil {
+		return DebugData{}, DebugData{}, fmt.Errorf("instance %s: %w", instanceB, errB)
+	}
+	return dataA, dataB, nil
+}
+
+// diffDebugData compares the resources of a and b by key, and the files of the resources found in both by path.
+// Resources and files are sorted by key and path.
+func diffDebugData(a, b DebugData) DebugDiff {
+	diff := DebugDiff{InstanceA: a.InstanceID, InstanceB: b.InstanceID, Resources: []DebugResourceDiff{}}
+
+	for _, key := range unionDebugKeys(a.ResourceDebugInfo, b.ResourceDebugInfo) {
+		infoA, infoB := a.ResourceDebugInfo[key], b.ResourceDebugInfo[key]
+		resource := DebugResourceDiff{ResourceKey: key}
+
+		switch {
+		case infoA == nil:
+			resource.ResourceType = infoB.ResourceType
+			resource.Status = debugDiffAdded
+		case infoB == nil:
+			resource.ResourceType = infoA.ResourceType
+			resource.Status = debugDiffRemoved
+		default:
+			resource.ResourceType = infoB.ResourceType
+			resource.Files = diffDebugFiles(a.InstanceID, b.InstanceID, infoA.Files, infoB.Files)
+			resource.Status = debugDiffUnchanged
+			if len(resource.Files) > 0 {
+				resource.Status = debugDiffChanged
+			}
+		}
+		diff.Resources = append(diff.Resources, resource)
+	}
+	return diff
+}
+
+// diffDebugFiles returns the files that differ between a and b, with a line diff for those found in both
+func diffDebugFiles(instanceA, instanceB string, a, b map[string]string) []DebugFileDiff {
+	var files []DebugFileDiff
+	for _, path := range unionDebugKeys(a, b) {
+		contentA, inA := a[path]
+		contentB, inB := b[path]
+		switch {
+		case !inA:
+			files = append(files, DebugFileDiff{Path: path, Status: debugDiffAdded})
+		case !inB:
+			files = append(files, DebugFileDiff{Path: path, Status: debugDiffRemoved})
+		case contentA != contentB:
+			files = append(files, DebugFileDiff{
+				Path:   path,
+				Status: debugDiffChanged,
+				Diff:   unifiedLineDiff(instanceA+"/"+path, instanceB+"/"+path, contentA, contentB),
+			})
+		}
+	}
+	return files
+}
+
+// unifiedLineDiff returns the unified diff of two texts, with three lines of context
+func unifiedLineDiff(fromName, toName, from, to string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+	if err != nil {
+		// Only writing to the string buffer can fail
+		return ""
+	}
+	return diff
+}
+
+// unionDebugKeys returns the keys of a and b, sorted
+func unionDebugKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
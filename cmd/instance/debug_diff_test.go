@@ -0,0 +1,105 @@
+package instance
+
+import (
+	"encoding/json"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDebugData(t *testing.T) {
+	require := require.New(t)
+
+	a := DebugData{
+		InstanceID: "instance-a",
+		ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"db": {ResourceKey: "db", ResourceType: "terraform", Files: map[string]string{
+				"main.tf":      "resource \"aws_db_instance\" \"db\" {\n  instance_class = \"db.t3.micro\"\n}\n",
+				"variables.tf": "variable \"region\" {}\n",
+				"old.tf":       "# removed\n",
+			}},
+			"cache": {ResourceKey: "cache", ResourceType: "helm", Files: map[string]string{"values.yaml": "replicas: 1\n"}},
+			"queue": {ResourceKey: "queue", ResourceType: "generic"},
+		},
+	}
+	b := DebugData{
+		InstanceID: "instance-b",
+		ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"db": {ResourceKey: "db", ResourceType: "terraform", Files: map[string]string{
+				"main.tf":      "resource \"aws_db_instance\" \"db\" {\n  instance_class = \"db.t3.large\"\n}\n",
+				"variables.tf": "variable \"region\" {}\n",
+				"new.tf":       "# added\n",
+			}},
+			"cache": {ResourceKey: "cache", ResourceType: "helm", Files: map[string]string{"values.yaml": "replicas: 1\n"}},
+			"web":   {ResourceKey: "web", ResourceType: "compose"},
+		},
+	}
+
+	diff := diffDebugData(a, b)
+	require.Equal("instance-a", diff.InstanceA)
+	require.Equal("instance-b", diff.InstanceB)
+
+	statuses := map[string]string{}
+	for _, resource := range diff.Resources {
+		statuses[resource.ResourceKey] = resource.Status
+	}
+	require.Equal(map[string]string{
+		"cache": debugDiffUnchanged,
+		"db":    debugDiffChanged,
+		"queue": debugDiffRemoved,
+		"web":   debugDiffAdded,
+	}, statuses)
+
+	// Resources are sorted by key, and only the files that differ are listed
+	require.Equal("db", diff.Resources[1].ResourceKey)
+	files := diff.Resources[1].Files
+	require.Len(files, 3)
+	require.Equal(DebugFileDiff{Path: "new.tf", Status: debugDiffAdded}, files[1])
+	require.Equal(DebugFileDiff{Path: "old.tf", Status: debugDiffRemoved}, files[2])
+
+	require.Equal("main.tf", files[0].Path)
+	require.Equal(debugDiffChanged, files[0].Status)
+	require.Contains(files[0].Diff, "--- instance-a/main.tf")
+	require.Contains(files[0].Diff, "+++ instance-b/main.tf")
+	require.Contains(files[0].Diff, "-  instance_class = \"db.t3.micro\"\n")
+	require.Contains(files[0].Diff, "+  instance_class = \"db.t3.large\"\n")
+	require.Contains(files[0].Diff, " resource \"aws_db_instance\" \"db\" {\n")
+
+	// Unchanged files and the diff of files found in only one instance are left out of the JSON output
+	jsonData, err := json.Marshal(diff.Resources[0])
+	require.NoError(err)
+	require.JSONEq(`{"resourceKey":"cache","resourceType":"helm","status":"unchanged"}`, string(jsonData))
+}
+
+func TestDebugDiffModelNavigation(t *testing.T) {
+	require := require.New(t)
+
+	diff := DebugDiff{
+		InstanceA: "instance-a",
+		InstanceB: "instance-b",
+		Resources: []DebugResourceDiff{
+			{ResourceKey: "db", ResourceType: "terraform", Status: debugDiffChanged, Files: []DebugFileDiff{
+				{Path: "main.tf", Status: debugDiffChanged, Diff: "--- instance-a/main.tf\n+++ instance-b/main.tf\n@@ -1 +1 @@\n-a\n+b\n"},
+			}},
+			{ResourceKey: "web", ResourceType: "compose", Status: debugDiffAdded},
+		},
+	}
+
+	m := newDebugDiffModel(diff)
+	require.Len(m.rows, 3)
+	require.Contains(m.viewport.View(), "1 file(s) of resource db (terraform) differ")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(debugDiffModel)
+	require.Contains(m.viewport.View(), "+b")
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(debugDiffModel)
+	require.Contains(m.viewport.View(), "Resource web (compose) only exists in instance-b.")
+
+	// The cursor stops at the last row
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	require.Equal(2, updated.(debugDiffModel).cursor)
+	require.Contains(updated.View(), "Debug Diff · instance-a → instance-b")
+}
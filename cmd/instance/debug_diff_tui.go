@@ -0,0 +1,234 @@
+package instance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	debugDiffListWidth     = 44
+	debugDiffHeaderHeight  = 2
+	debugDiffHelpHeight    = 2
+	debugDiffDefaultWidth  = 120
+	debugDiffDefaultHeight = 40
+)
+
+var (
+	debugDiffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	debugDiffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	debugDiffChangedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	debugDiffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	debugDiffSubtleStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+)
+
+// debugDiffRow is a line of the list of the debug diff view: a resource, or one of its differing files when file
+// is set
+type debugDiffRow struct {
+	resource *DebugResourceDiff
+	file     *DebugFileDiff
+}
+
+// debugDiffModel lists the resources of two instances with their differing files, and shows the diff of the
+// selected one
+type debugDiffModel struct {
+	diff     DebugDiff
+	rows     []debugDiffRow
+	cursor   int
+	offset   int
+	width    int
+	height   int
+	viewport viewport.Model
+}
+
+func newDebugDiffModel(diff DebugDiff) debugDiffModel {
+	m := debugDiffModel{diff: diff, viewport: viewport.New(0, 0)}
+	for i := range m.diff.Resources {
+		resource := &m.diff.Resources[i]
+		m.rows = append(m.rows, debugDiffRow{resource: resource})
+		for j := range resource.Files {
+			m.rows = append(m.rows, debugDiffRow{resource: resource, file: &resource.Files[j]})
+		}
+	}
+	m.setSize(debugDiffDefaultWidth, debugDiffDefaultHeight)
+	return m
+}
+
+func (m debugDiffModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m debugDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				m.syncViewport()
+			}
+		case "down", "j":
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+				m.syncViewport()
+			}
+		case "pgdown", " ":
+			m.viewport.PageDown()
+		case "pgup":
+			m.viewport.PageUp()
+		case "J":
+			m.viewport.ScrollDown(1)
+		case "K":
+			m.viewport.ScrollUp(1)
+		}
+	}
+	return m, nil
+}
+
+func (m *debugDiffModel) setSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = max(width-debugDiffListWidth-3, 20)
+	m.viewport.Height = m.bodyHeight()
+	m.syncViewport()
+}
+
+func (m debugDiffModel) bodyHeight() int {
+	return max(m.height-debugDiffHeaderHeight-debugDiffHelpHeight, 5)
+}
+
+// syncViewport shows the diff of the selected row and keeps it in the visible part of the list
+func (m *debugDiffModel) syncViewport() {
+	body := m.bodyHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+body {
+		m.offset = m.cursor - body + 1
+	}
+
+	if len(m.rows) == 0 {
+		m.viewport.SetContent("Neither instance has resources to compare.")
+		return
+	}
+	m.viewport.SetContent(renderDebugDiffDetail(m.diff, m.rows[m.cursor]))
+	m.viewport.GotoTop()
+}
+
+// renderDebugDiffDetail describes the selected resource, or shows the colored line diff of the selected file
+func renderDebugDiffDetail(diff DebugDiff, row debugDiffRow) string {
+	if row.file == nil {
+		resource := row.resource
+		switch resource.Status {
+		case debugDiffAdded:
+			return fmt.Sprintf("Resource %s (%s) only exists in %s.", resource.ResourceKey, resource.ResourceType, diff.InstanceB)
+		case debugDiffRemoved:
+			return fmt.Sprintf("Resource %s (%s) only exists in %s.", resource.ResourceKey, resource.ResourceType, diff.InstanceA)
+		case debugDiffUnchanged:
+			return fmt.Sprintf("The files of resource %s (%s) are the same in both instances.", resource.ResourceKey, resource.ResourceType)
+		default:
+			return fmt.Sprintf("%d file(s) of resource %s (%s) differ. Select a file to see its diff.", len(resource.Files), resource.ResourceKey, resource.ResourceType)
+		}
+	}
+
+	switch row.file.Status {
+	case debugDiffAdded:
+		return fmt.Sprintf("File %s of resource %s only exists in %s.", row.file.Path, row.resource.ResourceKey, diff.InstanceB)
+	case debugDiffRemoved:
+		return fmt.Sprintf("File %s of resource %s only exists in %s.", row.file.Path, row.resource.ResourceKey, diff.InstanceA)
+	}
+
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(row.file.Diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			line = lipgloss.NewStyle().Bold(true).Render(line)
+		case strings.HasPrefix(line, "@@"):
+			line = debugDiffHunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = debugDiffAddedStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = debugDiffRemovedStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// debugDiffStatusMark returns the marker of a resource or file status in the list
+func debugDiffStatusMark(status string) string {
+	switch status {
+	case debugDiffAdded:
+		return debugDiffAddedStyle.Render("+")
+	case debugDiffRemoved:
+		return debugDiffRemovedStyle.Render("-")
+	case debugDiffChanged:
+		return debugDiffChangedStyle.Render("~")
+	default:
+		return debugDiffSubtleStyle.Render("=")
+	}
+}
+
+func (m debugDiffModel) View() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("230")).Background(lipgloss.Color("62")).Padding(0, 1)
+	cursorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+
+	var list strings.Builder
+	end := min(m.offset+m.bodyHeight(), len(m.rows))
+	for i := m.offset; i < end; i++ {
+		row := m.rows[i]
+		var line string
+		if row.file == nil {
+			label := truncateDebugDiffLabel(row.resource.ResourceKey+" "+row.resource.ResourceType, debugDiffListWidth-4)
+			key, resourceType, _ := strings.Cut(label, " ")
+			line = fmt.Sprintf("%s %s %s", debugDiffStatusMark(row.resource.Status), key, debugDiffSubtleStyle.Render(resourceType))
+		} else {
+			line = fmt.Sprintf("  %s %s", debugDiffStatusMark(row.file.Status), truncateDebugDiffLabel(row.file.Path, debugDiffListWidth-6))
+		}
+		if i == m.cursor {
+			fmt.Fprintf(&list, "%s %s\n", cursorStyle.Render("▸"), line)
+		} else {
+			fmt.Fprintf(&list, "  %s\n", line)
+		}
+	}
+
+	listPanel := lipgloss.NewStyle().Width(debugDiffListWidth).Height(m.bodyHeight()).Render(list.String())
+	detailPanel := lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderLeft(true).
+		BorderForeground(lipgloss.Color("240")).
+		PaddingLeft(1).
+		Render(m.viewport.View())
+
+	header := headerStyle.Render(fmt.Sprintf("Debug Diff · %s → %s", m.diff.InstanceA, m.diff.InstanceB))
+	help := debugDiffSubtleStyle.Render("↑↓: select  pgup/pgdn, J/K: scroll diff  q: quit  ·  + only in second  - only in first  ~ changed")
+	return fmt.Sprintf("%s\n\n%s\n%s", header, lipgloss.JoinHorizontal(lipgloss.Top, listPanel, detailPanel), help)
+}
+
+// truncateDebugDiffLabel shortens a list label to width cells
+func truncateDebugDiffLabel(label string, width int) string {
+	if lipgloss.Width(label) <= width {
+		return label
+	}
+	runes := []rune(label)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// launchDebugDiffTUI runs the interactive view of the diff of two instances
+func launchDebugDiffTUI(diff DebugDiff) error {
+	program := tea.NewProgram(newDebugDiffModel(diff), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("failed to run TUI: %w", err)
+	}
+	return nil
+}
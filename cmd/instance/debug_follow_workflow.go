@@ -0,0 +1,136 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+)
+
+// followWorkflowMaxConsecutiveErrors is how many failed fetches in a row --follow-workflow tolerates before giving up.
+const followWorkflowMaxConsecutiveErrors = 5
+
+// workflowFollowUpdate is one newline-delimited JSON status line printed by --follow-workflow.
+type workflowFollowUpdate struct {
+	InstanceID         string                 `json:"instanceId"`
+	WorkflowID         string                 `json:"workflowId,omitempty"`
+	Status             string                 `json:"status"`
+	Percent            int                    `json:"percent"`
+	CompletedResources int                    `json:"completedResources"`
+	FailedResources    int                    `json:"failedResources"`
+	TotalResources     int                    `json:"totalResources"`
+	Resources          []workflowFollowStatus `json:"resources,omitempty"`
+	Done               bool                   `json:"done"`
+	Message            string                 `json:"message,omitempty"`
+	Error              string                 `json:"error,omitempty"`
+	Time               string                 `json:"time"`
+}
+
+// workflowFollowStatus is the per-resource status included in a workflowFollowUpdate.
+type workflowFollowStatus struct {
+	Key     string `json:"key,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Status  string `json:"status"`
+	Percent int    `json:"percent"`
+}
+
+// newWorkflowFollowUpdate converts a workflow progress snapshot into a follow status line.
+func newWorkflowFollowUpdate(snapshot workflowProgressSnapshot) workflowFollowUpdate {
+	update := workflowFollowUpdate{
+		InstanceID:         snapshot.InstanceID,
+		WorkflowID:         snapshot.WorkflowID,
+		Status:             workflowProgressNormalizeStatus(snapshot.WorkflowStatus),
+		Percent:            snapshot.OverallPercent,
+		CompletedResources: snapshot.CompletedResources,
+		FailedResources:    snapshot.FailedResources,
+		TotalResources:     snapshot.TotalResources,
+		Done:               snapshot.Done,
+		Time:               snapshot.FetchedAt.UTC().Format(time.RFC3339),
+	}
+	if snapshot.Failed {
+		update.Status = "failed"
+		update.Message = snapshot.FailureMessage
+	} else if snapshot.Done {
+		update.Status = "completed"
+	}
+	for _, resource := range snapshot.Resources {
+		update.Resources = append(update.Resources, workflowFollowStatus{
+			Key:     resource.Key,
+			Name:    resource.Name,
+			Status:  resource.Status,
+			Percent: resource.Percent,
+		})
+	}
+	return update
+}
+
+// sameWorkflowFollowUpdate reports whether two updates differ only in their timestamp.
+func sameWorkflowFollowUpdate(a, b workflowFollowUpdate) bool {
+	a.Time, b.Time = "", ""
+	left, _ := json.Marshal(a)
+	right, _ := json.Marshal(b)
+	return string(left) == string(right)
+}
+
+// runDebugFollowWorkflow polls the latest workflow of the instance and writes a JSON line to out
+// whenever its status changes. It returns nil once the workflow succeeds and an error if it fails.
+func runDebugFollowWorkflow(ctx context.Context, instanceID, token string, out io.Writer) error {
+	serviceID, environmentID, _, _, err := getInstance(ctx, token, instanceID)
+	if err != nil {
+		return fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	encoder := json.NewEncoder(out)
+	var last *workflowFollowUpdate
+	consecutiveErrors := 0
+
+	for {
+		delay := wfEventsRefreshInterval
+
+		snapshot, err := buildWorkflowProgressSnapshot(ctx, token, serviceID, environmentID, instanceID, "")
+		if err != nil {
+			consecutiveErrors++
+			if consecutiveErrors >= followWorkflowMaxConsecutiveErrors {
+				return fmt.Errorf("failed to fetch workflow progress: %w", err)
+			}
+			if err = encoder.Encode(workflowFollowUpdate{
+				InstanceID: instanceID,
+				Status:     "unknown",
+				Error:      err.Error(),
+				Time:       time.Now().UTC().Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+			delay = wfEventsBackoffInterval(wfEventsRefreshInterval, consecutiveErrors, rand.Float64()) //nolint:gosec // jitter does not need a secure source
+		} else {
+			consecutiveErrors = 0
+			update := newWorkflowFollowUpdate(snapshot)
+			if last == nil || !sameWorkflowFollowUpdate(*last, update) {
+				if err = encoder.Encode(update); err != nil {
+					return err
+				}
+				last = &update
+			}
+
+			if snapshot.Done {
+				if snapshot.Failed {
+					if snapshot.FailureMessage != "" {
+						return fmt.Errorf("workflow failed %s", snapshot.FailureMessage)
+					}
+					return fmt.Errorf("workflow failed with status: %s", snapshot.WorkflowStatus)
+				}
+				return nil
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
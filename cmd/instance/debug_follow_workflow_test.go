@@ -0,0 +1,72 @@
+package instance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorkflowFollowUpdate(t *testing.T) {
+	fetchedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		snapshot       workflowProgressSnapshot
+		expectedStatus string
+		expectedDone   bool
+		expectedMsg    string
+	}{
+		{
+			name:           "running workflow",
+			snapshot:       workflowProgressSnapshot{WorkflowStatus: "running", OverallPercent: 40},
+			expectedStatus: "running",
+		},
+		{
+			name:           "completed workflow",
+			snapshot:       workflowProgressSnapshot{WorkflowStatus: "success", Done: true, OverallPercent: 100},
+			expectedStatus: "completed",
+			expectedDone:   true,
+		},
+		{
+			name:           "resources completed before workflow status",
+			snapshot:       workflowProgressSnapshot{WorkflowStatus: "running", Done: true},
+			expectedStatus: "completed",
+			expectedDone:   true,
+		},
+		{
+			name:           "failed workflow",
+			snapshot:       workflowProgressSnapshot{WorkflowStatus: "cancelled", Done: true, Failed: true, FailureMessage: "for resource database"},
+			expectedStatus: "failed",
+			expectedDone:   true,
+			expectedMsg:    "for resource database",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.snapshot.InstanceID = "instance-1"
+			tt.snapshot.FetchedAt = fetchedAt
+			tt.snapshot.Resources = []workflowProgressResource{{Key: "database", Name: "Database", Status: "running", Percent: 40}}
+
+			update := newWorkflowFollowUpdate(tt.snapshot)
+
+			require.Equal(t, "instance-1", update.InstanceID)
+			require.Equal(t, tt.expectedStatus, update.Status)
+			require.Equal(t, tt.expectedDone, update.Done)
+			require.Equal(t, tt.expectedMsg, update.Message)
+			require.Equal(t, "2025-01-02T03:04:05Z", update.Time)
+			require.Equal(t, []workflowFollowStatus{{Key: "database", Name: "Database", Status: "running", Percent: 40}}, update.Resources)
+		})
+	}
+}
+
+func TestSameWorkflowFollowUpdateIgnoresTime(t *testing.T) {
+	a := workflowFollowUpdate{InstanceID: "instance-1", Status: "running", Percent: 40, Time: "2025-01-02T03:04:05Z"}
+	b := a
+	b.Time = "2025-01-02T03:04:15Z"
+	require.True(t, sameWorkflowFollowUpdate(a, b))
+
+	b.Percent = 60
+	require.False(t, sameWorkflowFollowUpdate(a, b))
+}
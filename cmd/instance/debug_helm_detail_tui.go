@@ -299,7 +299,7 @@ func (m helmDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			)
 		}
 		if isWorkflowInProgress(m.getWfEvents()) {
-			cmds = append(cmds, scheduleWfEventsRefresh(), scheduleWfCountdownTick())
+			cmds = append(cmds, scheduleWfEventsRefresh(wfEventsRefreshInterval), scheduleWfCountdownTick())
 		}
 		if len(cmds) > 0 {
 			return m, tea.Batch(cmds...)
@@ -351,18 +351,19 @@ func (m helmDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, fetchWfEventsForResource(m.debugData, m.node.Key)
 		}
 	case wfEventsRefreshMsg:
-		m.wfErrors.refreshing = false
-		m.wfErrors.lastRefresh = time.Now()
+		m.wfErrors.recordRefresh(msg.err)
 		if msg.err == nil && msg.steps != nil {
 			if m.debugData.PlanDAG != nil {
 				if m.debugData.PlanDAG.WorkflowStepsByKey == nil {
 					m.debugData.PlanDAG.WorkflowStepsByKey = make(map[string]*ResourceWorkflowSteps)
 				}
+				enrichBootstrapSteps(msg.steps, m.node.Key, m.debugData.PlanDAG)
+				m.wfErrors.keepSelectedStep(m.debugData.PlanDAG.WorkflowStepsByKey[m.node.Key], msg.steps)
 				m.debugData.PlanDAG.WorkflowStepsByKey[m.node.Key] = msg.steps
 			}
 		}
 		if isWorkflowInProgress(m.getWfEvents()) {
-			return m, tea.Batch(scheduleWfEventsRefresh(), scheduleWfCountdownTick())
+			return m, tea.Batch(scheduleWfEventsRefresh(m.wfErrors.nextRefreshInterval()), scheduleWfCountdownTick())
 		}
 	case wfCountdownTickMsg:
 		if isWorkflowInProgress(m.getWfEvents()) {
@@ -0,0 +1,87 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+)
+
+// helmInstallLogPlaceholder stands in for the install log of a helm resource that has none, so the export bundle
+// shows that the log was looked for.
+const helmInstallLogPlaceholder = "No helm install log was recorded for this resource.\n"
+
+// runDebugDumpHelmLogs writes the install log of every helm resource of an instance to dir, one file per
+// resource named after the resource and its release. Resources without an install log are skipped with a note.
+func runDebugDumpHelmLogs(ctx context.Context, instanceID, token, dir string, redactor *debugRedactor) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	data, err := collectDebugData(ctx, instanceID, token, true)
+	if err != nil {
+		return err
+	}
+	redactor.redactDebugData(&data)
+
+	written, skipped, err := writeHelmInstallLogs(data, dir, redactor)
+	if err != nil {
+		return err
+	}
+	if len(written) == 0 && len(skipped) == 0 {
+		return fmt.Errorf("instance %s has no helm resources", instanceID)
+	}
+
+	for _, key := range skipped {
+		fmt.Printf("Skipped helm resource %s: no install log recorded\n", key)
+	}
+	utils.PrintSuccess(fmt.Sprintf("Saved %d helm install logs of instance %s to %s", len(written), instanceID, dir))
+	return nil
+}
+
+// writeHelmInstallLogs writes the install log of each helm resource to dir and returns the files written and the
+// keys of the helm resources skipped because their install log is empty, both sorted.
+func writeHelmInstallLogs(data DebugData, dir string, redactor *debugRedactor) (written, skipped []string, err error) {
+	keys := make([]string, 0, len(data.ResourceDebugInfo))
+	for key, info := range data.ResourceDebugInfo {
+		if info != nil && info.Helm != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		helm := data.ResourceDebugInfo[key].Helm
+		if strings.TrimSpace(helm.InstallLog) == "" {
+			skipped = append(skipped, key)
+			continue
+		}
+
+		if len(written) == 0 {
+			if err = os.MkdirAll(dir, 0700); err != nil {
+				return nil, nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+			}
+		}
+		target := filepath.Join(dir, helmInstallLogFileName(key, helm.ReleaseName))
+		if err = os.WriteFile(target, []byte(redactor.redactToken(helm.InstallLog)), 0600); err != nil {
+			return nil, nil, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		written = append(written, target)
+	}
+	return written, skipped, nil
+}
+
+// helmInstallLogFileName names the install log file of a helm resource after the resource key and the release,
+// keeping the name within its directory.
+func helmInstallLogFileName(resourceKey, releaseName string) string {
+	name := resourceKey
+	if releaseName != "" && releaseName != resourceKey {
+		name += "-" + releaseName
+	}
+	name = strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(name)
+	return name + "-install.log"
+}
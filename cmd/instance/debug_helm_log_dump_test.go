@@ -0,0 +1,68 @@
+package instance
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHelmInstallLogs(t *testing.T) {
+	require := require.New(t)
+
+	data := DebugData{
+		InstanceID: "inst-1",
+		ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"redis":  {Helm: &HelmData{ReleaseName: "redis-abc", InstallLog: "installed with tok-abc123"}},
+			"cache":  {Helm: &HelmData{ReleaseName: "cache"}},
+			"worker": {Helm: &HelmData{InstallLog: "deployed"}},
+			"db":     {ResourceType: "terraform", TerraformLogs: map[string]string{"log/apply.log": "applied"}},
+		},
+	}
+	redactor, err := newDebugRedactor("", "tok-abc123")
+	require.NoError(err)
+
+	dir := filepath.Join(t.TempDir(), "helm-logs")
+	written, skipped, err := writeHelmInstallLogs(data, dir, redactor)
+	require.NoError(err)
+	require.Equal([]string{filepath.Join(dir, "redis-redis-abc-install.log"), filepath.Join(dir, "worker-install.log")}, written)
+	require.Equal([]string{"cache"}, skipped)
+
+	content, err := os.ReadFile(filepath.Join(dir, "redis-redis-abc-install.log"))
+	require.NoError(err)
+	require.Equal("installed with "+redactedValue, string(content))
+}
+
+func TestHelmInstallLogFileName(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("redis-redis-abc-install.log", helmInstallLogFileName("redis", "redis-abc"))
+	require.Equal("redis-install.log", helmInstallLogFileName("redis", "redis"))
+	require.Equal("redis-install.log", helmInstallLogFileName("redis", ""))
+	require.Equal("_-__etc_passwd-install.log", helmInstallLogFileName("..", "../etc/passwd"))
+}
+
+func TestWriteDebugBundleHelmInstallLogPlaceholder(t *testing.T) {
+	require := require.New(t)
+
+	data := DebugData{
+		InstanceID: "inst-1",
+		ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"redis": {ResourceKey: "redis", Helm: &HelmData{InstallLog: "deployed"}},
+			"cache": {ResourceKey: "cache", Helm: &HelmData{}},
+		},
+	}
+	for _, info := range data.ResourceDebugInfo {
+		info.normalizeFilesAndLogs()
+	}
+
+	var buf bytes.Buffer
+	require.NoError(writeDebugBundle(&buf, data, nil, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	entries := readDebugBundle(t, buf.Bytes())
+	require.Equal("deployed", entries["resources/redis/logs/log/install.log"])
+	require.Equal(helmInstallLogPlaceholder, entries["resources/cache/logs/log/install.log"])
+}
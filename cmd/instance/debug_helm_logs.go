@@ -35,7 +35,7 @@ type HelmLogsResource struct {
 }
 
 func runDebugHelmLogs(cmd *cobra.Command, args []string) error {
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	resourceID, err := cmd.Flags().GetString("resource-id")
 	if err != nil {
@@ -1,24 +1,30 @@
 package instance
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var debugHelmValuesCmd = &cobra.Command{
 	Use:   "helm-values [instance-id]",
 	Short: "Get Helm chart values for instance resources",
-	Long:  `Get Helm chart values for instance resources. Use --resource-id or --resource-key to filter by specific resource.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDebugHelmValues,
+	Long: `Get Helm chart values for instance resources. Use --resource-id or --resource-key to filter by specific resource.
+Use --dump-helm-values to write the resolved values of a single resource to a values.yaml file that can be used to reproduce the helm install locally.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDebugHelmValues,
 	Example: `  omnistrate-ctl instance debug helm-values <instance-id>
   omnistrate-ctl instance debug helm-values <instance-id> --resource-key my-resource
-  omnistrate-ctl instance debug helm-values <instance-id> --resource-id abc123`,
+  omnistrate-ctl instance debug helm-values <instance-id> --resource-id abc123
+  omnistrate-ctl instance debug helm-values <instance-id> --resource-key my-resource --dump-helm-values values.yaml`,
 }
 
 type HelmValuesOutput struct {
@@ -38,7 +44,7 @@ type HelmValuesResource struct {
 }
 
 func runDebugHelmValues(cmd *cobra.Command, args []string) error {
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	resourceID, err := cmd.Flags().GetString("resource-id")
 	if err != nil {
@@ -50,6 +56,20 @@ func runDebugHelmValues(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get resource-key flag: %w", err)
 	}
 
+	dumpValuesPath, err := cmd.Flags().GetString("dump-helm-values")
+	if err != nil {
+		return fmt.Errorf("failed to get dump-helm-values flag: %w", err)
+	}
+	// --dump-values is the hidden former name of --dump-helm-values
+	if dumpValuesPath == "" {
+		if dumpValuesPath, err = cmd.Flags().GetString("dump-values"); err != nil {
+			return fmt.Errorf("failed to get dump-values flag: %w", err)
+		}
+	}
+	if dumpValuesPath != "" && resourceID == "" && resourceKeyFilter == "" {
+		return fmt.Errorf("--dump-helm-values requires --resource-key or --resource-id to select a single resource")
+	}
+
 	token, err := common.GetTokenWithLogin()
 	if err != nil {
 		return fmt.Errorf("failed to get token: %w", err)
@@ -132,6 +152,23 @@ func runDebugHelmValues(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if dumpValuesPath != "" {
+		if len(output.Resources) != 1 {
+			return fmt.Errorf("expected exactly one helm resource to dump values for, found %d", len(output.Resources))
+		}
+
+		data, err := marshalHelmValuesFile(instanceID, output.Resources[0])
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(dumpValuesPath, data, 0600); err != nil {
+			return fmt.Errorf("failed to write helm values to file %s: %w", dumpValuesPath, err)
+		}
+
+		utils.PrintSuccess(fmt.Sprintf("Helm values for resource %s saved to %s", output.Resources[0].ResourceKey, dumpValuesPath))
+		return nil
+	}
+
 	// Output as JSON
 	jsonData, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
@@ -141,7 +178,44 @@ func runDebugHelmValues(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// marshalHelmValuesFile renders the chart values of a resource as a values.yaml document, with the
+// chart repository and version in a comment header.
+func marshalHelmValuesFile(instanceID string, resource HelmValuesResource) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Helm values for resource %s of instance %s\n", resource.ResourceKey, instanceID)
+	if resource.ChartRepoName != "" || resource.ChartRepoURL != "" {
+		fmt.Fprintf(&buf, "# Chart repository: %s %s\n", resource.ChartRepoName, resource.ChartRepoURL)
+	}
+	if resource.ChartVersion != "" {
+		fmt.Fprintf(&buf, "# Chart version: %s\n", resource.ChartVersion)
+	}
+	if resource.ReleaseName != "" || resource.Namespace != "" {
+		fmt.Fprintf(&buf, "# Release: %s (namespace: %s)\n", resource.ReleaseName, resource.Namespace)
+	}
+
+	values := resource.ChartValues
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(values); err != nil {
+		return nil, fmt.Errorf("failed to marshal helm values to YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal helm values to YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
 func init() {
 	debugHelmValuesCmd.Flags().String("resource-id", "", "Filter by resource ID")
 	debugHelmValuesCmd.Flags().String("resource-key", "", "Filter by resource key")
+	debugHelmValuesCmd.Flags().String("dump-helm-values", "", "Write the chart values of the selected resource to this path as a values.yaml file")
+	debugHelmValuesCmd.Flags().String("dump-values", "", "Write the chart values of the selected resource to this path as a values.yaml file")
+	if err := debugHelmValuesCmd.Flags().MarkHidden("dump-values"); err != nil {
+		return
+	}
 }
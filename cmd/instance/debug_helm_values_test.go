@@ -0,0 +1,59 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarshalHelmValuesFile(t *testing.T) {
+	resource := HelmValuesResource{
+		ResourceKey:   "redis",
+		ChartRepoName: "bitnami",
+		ChartRepoURL:  "https://charts.bitnami.com/bitnami",
+		ChartVersion:  "17.0.0",
+		Namespace:     "redis-ns",
+		ReleaseName:   "redis-release",
+		ChartValues: map[string]interface{}{
+			"replicaCount": float64(3),
+			"auth": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+	}
+
+	data, err := marshalHelmValuesFile("instance-1", resource)
+	require.NoError(t, err)
+
+	content := string(data)
+	require.Contains(t, content, "# Helm values for resource redis of instance instance-1\n")
+	require.Contains(t, content, "# Chart repository: bitnami https://charts.bitnami.com/bitnami\n")
+	require.Contains(t, content, "# Chart version: 17.0.0\n")
+	require.Contains(t, content, "# Release: redis-release (namespace: redis-ns)\n")
+	require.Contains(t, content, "auth:\n  enabled: true\n")
+
+	var values map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(data, &values))
+	require.Equal(t, 3, values["replicaCount"])
+	require.Equal(t, map[string]interface{}{"enabled": true}, values["auth"])
+}
+
+func TestMarshalHelmValuesFileWithoutValues(t *testing.T) {
+	data, err := marshalHelmValuesFile("instance-1", HelmValuesResource{ResourceKey: "redis"})
+	require.NoError(t, err)
+	require.Equal(t, "# Helm values for resource redis of instance instance-1\n{}\n", string(data))
+}
+
+func TestDebugHelmValuesCommandFlags(t *testing.T) {
+	dumpValuesFlag := debugHelmValuesCmd.Flags().Lookup("dump-helm-values")
+	require.NotNil(t, dumpValuesFlag)
+	require.Equal(t, "", dumpValuesFlag.DefValue)
+	require.Equal(t, "string", dumpValuesFlag.Value.Type())
+	require.False(t, dumpValuesFlag.Hidden)
+
+	legacyFlag := debugHelmValuesCmd.Flags().Lookup("dump-values")
+	require.NotNil(t, legacyFlag)
+	require.Equal(t, "string", legacyFlag.Value.Type())
+	require.True(t, legacyFlag.Hidden)
+}
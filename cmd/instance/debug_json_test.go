@@ -3,7 +3,9 @@ package instance
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
 	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
@@ -52,6 +54,24 @@ func TestDebugDataJSONOmitsEmptyServiceAndEnvironment(t *testing.T) {
 	require.NotContains(decoded, "environmentId", "empty environmentId should be omitted")
 }
 
+func TestDebugDataJSONIncludesSchemaVersion(t *testing.T) {
+	require := require.New(t)
+
+	data := DebugData{
+		SchemaVersion: DebugDataSchemaVersion,
+		InstanceID:    "inst-1",
+	}
+
+	jsonBytes, err := json.Marshal(data)
+	require.NoError(err)
+
+	var decoded map[string]interface{}
+	err = json.Unmarshal(jsonBytes, &decoded)
+	require.NoError(err)
+
+	require.Equal(float64(1), decoded["schemaVersion"])
+}
+
 func TestPlanDAGJSONIncludesWorkflowSteps(t *testing.T) {
 	require := require.New(t)
 
@@ -308,6 +328,55 @@ func TestDebugCommandStructure(t *testing.T) {
 	require.Equal("interactive", flag.DefValue)
 }
 
+func TestAttachWorkflowProgress(t *testing.T) {
+	require := require.New(t)
+
+	plan := &PlanDAG{}
+	attachWorkflowProgress(plan, &debugWorkflowEvents{
+		resources: []dataaccess.ResourceWorkflowDebugEvents{
+			{
+				ResourceID:  "r-postgres",
+				ResourceKey: "postgres",
+				RawSteps: []dataaccess.RawWorkflowStep{
+					{StepName: "Deployment", Events: []dataaccess.DebugEvent{{EventType: "WorkflowStepCompleted"}}},
+				},
+			},
+		},
+		workflowInfo: &dataaccess.WorkflowInfo{WorkflowID: "wf-1", WorkflowStatus: "success"},
+	})
+	require.Equal("wf-1", plan.WorkflowID)
+	require.Contains(plan.ProgressByID, "r-postgres")
+	require.Contains(plan.ProgressByKey, "postgres")
+	require.Empty(plan.Errors)
+
+	// A failed fetch is reported on the plan instead of failing the debug run
+	plan = &PlanDAG{}
+	attachWorkflowProgress(plan, &debugWorkflowEvents{err: context.DeadlineExceeded})
+	require.Equal([]string{"workflow progress incomplete: context deadline exceeded"}, plan.Errors)
+	require.Nil(plan.ProgressByID)
+
+	// Nothing is attached when workflow events were not fetched
+	attachWorkflowProgress(plan, nil)
+	require.Len(plan.Errors, 1)
+}
+
+func TestDagModelSkipsWorkflowEventsWhenDisabled(t *testing.T) {
+	require := require.New(t)
+
+	data := DebugData{
+		PlanDAG: &PlanDAG{
+			Nodes:  map[string]PlanDAGNode{"r-postgres": {ID: "r-postgres", Key: "postgres", Name: "postgres"}},
+			Levels: [][]string{{"r-postgres"}},
+		},
+	}
+	require.False(newDagModel(data).wfResolved)
+
+	data.NoWorkflowEvents = true
+	model := newDagModel(data)
+	require.True(model.progressLoading)
+	require.True(model.wfResolved)
+}
+
 func TestResourceDebugInfoHelmJSON(t *testing.T) {
 	require := require.New(t)
 
@@ -511,6 +580,53 @@ func TestResourceDebugInfoOmitsEmptyFields(t *testing.T) {
 	require.NotContains(decoded, "terraformHistory")
 	require.NotContains(decoded, "terraformFiles")
 	require.NotContains(decoded, "terraformLogs")
+	require.NotContains(decoded, "files")
+	require.NotContains(decoded, "logs")
+}
+
+func TestResourceDebugInfoNormalizeFilesAndLogs(t *testing.T) {
+	require := require.New(t)
+
+	helmInfo := &ResourceDebugInfo{
+		ResourceType: "HelmChart",
+		Helm: &HelmData{
+			ChartValues: map[string]interface{}{"replicaCount": 2},
+			InstallLog:  "installed",
+		},
+	}
+	helmInfo.normalizeFilesAndLogs()
+	require.Equal("replicaCount: 2\n", helmInfo.Files["values.yaml"])
+	require.Equal(map[string]string{"log/install.log": "installed"}, helmInfo.Logs)
+
+	tfInfo := &ResourceDebugInfo{
+		ResourceType:   "Terraform",
+		TerraformFiles: map[string]string{"main.tf": "resource {}"},
+		TerraformLogs:  map[string]string{"apply.log": "done"},
+	}
+	tfInfo.normalizeFilesAndLogs()
+	require.Equal(map[string]string{"main.tf": "resource {}"}, tfInfo.Files)
+	require.Equal(map[string]string{"apply.log": "done"}, tfInfo.Logs)
+
+	// The schema version 1 field names are still filled
+	tfJSON, err := json.Marshal(tfInfo)
+	require.NoError(err)
+	require.Contains(string(tfJSON), `"terraformFiles":{"main.tf":"resource {}"}`)
+	require.Contains(string(tfJSON), `"terraformLogs":{"apply.log":"done"}`)
+
+	composeInfo := &ResourceDebugInfo{
+		ResourceType: "DockerCompose",
+		Compose:      &ComposeData{Files: map[string]string{"docker-compose.yaml": "services: {}"}},
+	}
+	composeInfo.normalizeFilesAndLogs()
+	require.Equal(composeInfo.Compose.Files, composeInfo.Files)
+	require.Nil(composeInfo.Logs)
+
+	jsonBytes, err := json.Marshal(composeInfo)
+	require.NoError(err)
+	var decoded map[string]interface{}
+	require.NoError(json.Unmarshal(jsonBytes, &decoded))
+	require.Contains(decoded, "files")
+	require.NotContains(decoded, "logs")
 }
 
 func TestDebugDataJSONOmitsEmptyResourceDebugInfo(t *testing.T) {
@@ -1036,6 +1152,58 @@ func TestCollectComposeDebugInfoPopulatesComposeField(t *testing.T) {
 	require.Nil(result["api"].Compose)
 }
 
+func TestForEachDebugResourceBoundsConcurrency(t *testing.T) {
+	require := require.New(t)
+
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	var mu sync.Mutex
+	var running, maxRunning int
+	seen := make(map[int]bool)
+	forEachDebugResource(items, func(item int) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		seen[item] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	require.Len(seen, len(items))
+	require.LessOrEqual(maxRunning, debugResourceWorkers)
+}
+
+func TestDebugResourceNodesSortedByKey(t *testing.T) {
+	require := require.New(t)
+
+	planDAG := &PlanDAG{
+		Nodes: map[string]PlanDAGNode{
+			"r-3": {ID: "r-3", Key: "zeta", Type: "OperatorCRD"},
+			"r-1": {ID: "r-1", Key: "alpha", Type: "OperatorCRD"},
+			"r-2": {ID: "r-2", Key: "beta", Type: "Helm"},
+			"r-4": {ID: "r-4", Key: "gamma", Type: "OperatorCRD"},
+		},
+	}
+	result := map[string]*ResourceDebugInfo{
+		"zeta":  {ResourceID: "r-3", ResourceKey: "zeta"},
+		"alpha": {ResourceID: "r-1", ResourceKey: "alpha"},
+		"beta":  {ResourceID: "r-2", ResourceKey: "beta"},
+	}
+
+	nodes := debugResourceNodes(planDAG, result, "operator")
+	require.Len(nodes, 2)
+	require.Equal("alpha", nodes[0].info.ResourceKey)
+	require.Equal("zeta", nodes[1].info.ResourceKey)
+}
+
 func TestResourceDebugInfoHasDataWithPlanPreview(t *testing.T) {
 	require := require.New(t)
 
@@ -1323,3 +1491,43 @@ func TestDebugDataJSONOmitsEmptyProductTierIDAndVersion(t *testing.T) {
 	require.NotContains(decoded, "productTierId", "empty productTierId should be omitted")
 	require.NotContains(decoded, "tierVersion", "empty tierVersion should be omitted")
 }
+
+func TestTerraformLogParsingToleratesMalformedNames(t *testing.T) {
+	require := require.New(t)
+
+	files := map[string]string{
+		"log/_terraform_.log": "malformed",
+		"-.log":               "no operation ID or operation",
+		".log":                "",
+		"-output.log":         `{"endpoint":{"value":"db:5432"}}`,
+		"-plan-preview":       `{"format_version":"1.0"}`,
+		"-plan-preview-error": "Error: refresh failed",
+		"":                    "empty name",
+	}
+	history := []TerraformHistoryEntry{
+		{},
+		{Operation: "apply"},
+		{OperationID: "op-1"},
+	}
+
+	require.NotPanics(func() {
+		findLatestApplyDestroyOperationID(files, history)
+		collectLogsForOperationID(files, history, "")
+		collectLogsForOperationID(files, history, "op-1")
+		findLatestOutputLog(files, history)
+		findAllPlanPreviews(files)
+		for name := range files {
+			normalizeTerraformLogKey(name)
+		}
+		debugBundleResourceFiles("", "logs", "log", files)
+	})
+
+	require.Equal("Resource", formatTypeTag(""))
+	require.Equal("_", sanitizeBundlePath(""))
+	previews, previewErrors := findAllPlanPreviews(files)
+	require.Empty(previews)
+	require.Empty(previewErrors)
+	lines, label := collectLogsForOperationID(files, history, "")
+	require.Empty(lines)
+	require.Empty(label)
+}
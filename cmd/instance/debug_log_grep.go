@@ -0,0 +1,60 @@
+package instance
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// newDebugLogFilter compiles the --grep pattern. An empty pattern keeps every log line.
+func newDebugLogFilter(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grep pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// filterDebugLogs keeps only the log lines matching filter in the terraform logs, helm install log and
+// common logs of every resource. Logs without a matching line are dropped. A nil filter keeps the data as is.
+// The data is modified in place.
+func filterDebugLogs(data *DebugData, filter *regexp.Regexp) {
+	if filter == nil || data == nil {
+		return
+	}
+	for _, info := range data.ResourceDebugInfo {
+		if info == nil {
+			continue
+		}
+		if info.Helm != nil {
+			info.Helm.InstallLog = grepLogLines(info.Helm.InstallLog, filter)
+		}
+		grepLogMap(info.TerraformLogs, filter)
+		grepLogMap(info.Logs, filter)
+	}
+}
+
+func grepLogMap(logs map[string]string, filter *regexp.Regexp) {
+	for name, content := range logs {
+		if filtered := grepLogLines(content, filter); filtered != "" {
+			logs[name] = filtered
+		} else {
+			delete(logs, name)
+		}
+	}
+}
+
+// grepLogLines returns the lines of content matching filter, newline-terminated.
+func grepLogLines(content string, filter *regexp.Regexp) string {
+	var b strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		if filter.MatchString(line) {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
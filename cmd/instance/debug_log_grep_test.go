@@ -0,0 +1,53 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterDebugLogs(t *testing.T) {
+	require := require.New(t)
+
+	filter, err := newDebugLogFilter(`(?i)error`)
+	require.NoError(err)
+
+	data := DebugData{
+		ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"db": {
+				TerraformLogs: map[string]string{
+					"log/apply.log":   "Plan: 1 to add\nError: quota exceeded\nApply failed",
+					"log/destroy.log": "Destroy complete",
+				},
+				Logs: map[string]string{
+					"log/apply.log": "Plan: 1 to add\nError: quota exceeded\nApply failed",
+				},
+			},
+			"cache": {
+				Helm: &HelmData{InstallLog: "installing\nERROR pulling image\ndone"},
+			},
+			"empty": nil,
+		},
+	}
+
+	filterDebugLogs(&data, filter)
+
+	db := data.ResourceDebugInfo["db"]
+	require.Equal(map[string]string{"log/apply.log": "Error: quota exceeded\n"}, db.TerraformLogs)
+	require.Equal(map[string]string{"log/apply.log": "Error: quota exceeded\n"}, db.Logs)
+	require.Equal("ERROR pulling image\n", data.ResourceDebugInfo["cache"].Helm.InstallLog)
+}
+
+func TestNewDebugLogFilter(t *testing.T) {
+	filter, err := newDebugLogFilter("")
+	require.NoError(t, err)
+	require.Nil(t, filter)
+
+	// A nil filter keeps the logs as they are
+	data := DebugData{ResourceDebugInfo: map[string]*ResourceDebugInfo{"db": {Logs: map[string]string{"a.log": "x\ny"}}}}
+	filterDebugLogs(&data, filter)
+	require.Equal(t, "x\ny", data.ResourceDebugInfo["db"].Logs["a.log"])
+
+	_, err = newDebugLogFilter("(")
+	require.ErrorContains(t, err, "invalid grep pattern")
+}
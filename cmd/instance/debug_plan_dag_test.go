@@ -1,6 +1,7 @@
 package instance
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -294,3 +295,59 @@ func TestPlanHasHitBreakpoint(t *testing.T) {
 		t.Fatalf("expected hit breakpoints")
 	}
 }
+
+func TestDagRefreshKeyReloadsAndKeepsSelection(t *testing.T) {
+	plan := func(extra ...string) *PlanDAG {
+		nodes := map[string]PlanDAGNode{
+			"r-api":      {ID: "r-api", Key: "api", Name: "api", Type: "Resource"},
+			"r-postgres": {ID: "r-postgres", Key: "postgres", Name: "postgres", Type: "Resource"},
+		}
+		level := []string{"r-api", "r-postgres"}
+		for _, id := range extra {
+			nodes[id] = PlanDAGNode{ID: id, Key: id, Name: id, Type: "Resource"}
+			level = append(level, id)
+		}
+		return &PlanDAG{Nodes: nodes, Levels: [][]string{level}}
+	}
+
+	model := newDagModel(DebugData{InstanceID: "instance-1", PlanDAG: plan(), NoWorkflowEvents: true})
+	model.width = 100
+	model.height = 30
+	for index, nodeID := range model.selectableNodes {
+		if nodeID == "r-postgres" {
+			model.cursorIndex = index
+		}
+	}
+	model.expandedNodes["r-postgres"] = true
+	model.rebuildLayout()
+
+	updatedAny, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	updated := updatedAny.(dagModel)
+	if !updated.reloading || cmd == nil {
+		t.Fatalf("expected r to start a reload, got reloading=%v cmd=%v", updated.reloading, cmd)
+	}
+	if !strings.Contains(updated.renderHeader(), "refreshing...") {
+		t.Fatalf("expected refreshing indicator in header, got %q", updated.renderHeader())
+	}
+
+	updatedAny, _ = updated.Update(debugDataMsg{err: errors.New("boom")})
+	failed := updatedAny.(dagModel)
+	if failed.reloading || failed.plan != model.plan || !strings.Contains(failed.renderHeader(), "refresh failed: boom") {
+		t.Fatalf("expected failed reload to keep the current data and report the error")
+	}
+
+	updatedAny, _ = failed.Update(debugDataMsg{data: DebugData{InstanceID: "instance-1", PlanDAG: plan("r-cache"), NoWorkflowEvents: true}})
+	reloaded := updatedAny.(dagModel)
+	if reloaded.reloading || reloaded.reloadErr != "" {
+		t.Fatalf("expected reload to finish cleanly, got reloading=%v err=%q", reloaded.reloading, reloaded.reloadErr)
+	}
+	if _, ok := reloaded.plan.Nodes["r-cache"]; !ok {
+		t.Fatalf("expected reloaded plan to contain the new node")
+	}
+	if got := reloaded.selectableNodes[reloaded.cursorIndex]; got != "r-postgres" {
+		t.Fatalf("expected selection to stay on r-postgres, got %s", got)
+	}
+	if !reloaded.expandedNodes["r-postgres"] {
+		t.Fatalf("expected r-postgres to stay expanded")
+	}
+}
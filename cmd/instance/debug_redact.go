@@ -0,0 +1,197 @@
+package instance
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// defaultRedactPattern matches the keys whose values are masked in debug output unless --no-redact is set
+	defaultRedactPattern = `(?i)password|secret|token|key`
+	redactedValue        = "[REDACTED]"
+)
+
+// redactAssignmentPattern finds "key: value", "key = value" and "\"key\": \"value\"" assignments in
+// rendered files and logs. The key is checked against the redactor's key pattern separately.
+var redactAssignmentPattern = regexp.MustCompile(`("?([\w.-]+)"?\s*[:=][ \t]*)("[^"\n]*"|[^\s,{}\[\]]+)`)
+
+// debugRedactor masks secrets in debug data before it is printed or exported.
+type debugRedactor struct {
+	keyPattern *regexp.Regexp
+	token      string
+}
+
+// newDebugRedactor returns a redactor masking values of keys that match pattern, plus every
+// occurrence of the auth token. An empty pattern uses defaultRedactPattern.
+func newDebugRedactor(pattern, token string) (*debugRedactor, error) {
+	if pattern == "" {
+		pattern = defaultRedactPattern
+	}
+	keyPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+	}
+	return &debugRedactor{keyPattern: keyPattern, token: token}, nil
+}
+
+// redactDebugData masks secrets in helm values, parameters, and terraform, compose and helm files
+// and logs. The data is modified in place.
+func (r *debugRedactor) redactDebugData(data *DebugData) {
+	if r == nil || data == nil {
+		return
+	}
+	for _, info := range data.ResourceDebugInfo {
+		if info == nil {
+			continue
+		}
+		if info.Helm != nil {
+			info.Helm.ChartValues = r.redactMap(info.Helm.ChartValues)
+			info.Helm.InstallLog = r.redactText(info.Helm.InstallLog)
+			r.redactInputParams(info.Helm.InputParams)
+			r.redactOutputParams(info.Helm.OutputParams)
+		}
+		if info.Operator != nil {
+			r.redactInputParams(info.Operator.InputParams)
+			r.redactOutputParams(info.Operator.OutputParams)
+			for i := range info.Operator.CRDOutputParams {
+				param := &info.Operator.CRDOutputParams[i]
+				if r.keyPattern.MatchString(param.Key) {
+					param.Value = maskIfSet(param.Value)
+					param.ResolvedValue = maskIfSet(param.ResolvedValue)
+				}
+			}
+		}
+		if info.Compose != nil {
+			r.redactInputParams(info.Compose.InputParams)
+			r.redactOutputParams(info.Compose.OutputParams)
+			r.redactTextMap(info.Compose.Files)
+		}
+		r.redactTextMap(info.TerraformFiles)
+		r.redactTextMap(info.TerraformLogs)
+		r.redactTextMap(info.Files)
+		r.redactTextMap(info.Logs)
+	}
+}
+
+func (r *debugRedactor) redactInputParams(params []OperatorInputParam) {
+	for i := range params {
+		if r.keyPattern.MatchString(params[i].Key) {
+			params[i].DefaultValue = maskIfSet(params[i].DefaultValue)
+			params[i].ResolvedValue = maskIfSet(params[i].ResolvedValue)
+		}
+	}
+}
+
+func (r *debugRedactor) redactOutputParams(params []OperatorOutputParam) {
+	for i := range params {
+		if r.keyPattern.MatchString(params[i].Key) {
+			params[i].Value = maskIfSet(params[i].Value)
+			params[i].ResolvedValue = maskIfSet(params[i].ResolvedValue)
+		}
+	}
+}
+
+// redactMap returns a copy of values with every scalar under a matching key masked.
+func (r *debugRedactor) redactMap(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if r.keyPattern.MatchString(key) {
+			redacted[key] = maskAll(value)
+		} else {
+			redacted[key] = r.redactValue(value)
+		}
+	}
+	return redacted
+}
+
+func (r *debugRedactor) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return r.redactMap(v)
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = r.redactValue(item)
+		}
+		return redacted
+	case string:
+		return r.redactToken(v)
+	default:
+		return value
+	}
+}
+
+func (r *debugRedactor) redactTextMap(contents map[string]string) {
+	for name, content := range contents {
+		contents[name] = r.redactText(content)
+	}
+}
+
+// redactText masks the token and the values of key/value assignments whose key matches the pattern.
+func (r *debugRedactor) redactText(content string) string {
+	content = r.redactToken(content)
+	matches := redactAssignmentPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		key := content[m[4]:m[5]]
+		if !r.keyPattern.MatchString(key) {
+			continue
+		}
+		value := content[m[6]:m[7]]
+		sb.WriteString(content[last:m[6]])
+		if strings.HasPrefix(value, `"`) {
+			sb.WriteString(`"` + redactedValue + `"`)
+		} else {
+			sb.WriteString(redactedValue)
+		}
+		last = m[7]
+	}
+	sb.WriteString(content[last:])
+	return sb.String()
+}
+
+// redactToken masks every occurrence of the auth token.
+func (r *debugRedactor) redactToken(content string) string {
+	if r == nil || r.token == "" {
+		return content
+	}
+	return strings.ReplaceAll(content, r.token, redactedValue)
+}
+
+// maskAll replaces every scalar in value with the redaction marker, keeping maps and lists intact.
+func maskAll(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			masked[key] = maskAll(item)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = maskAll(item)
+		}
+		return masked
+	case nil:
+		return nil
+	default:
+		return redactedValue
+	}
+}
+
+func maskIfSet(value string) string {
+	if value == "" {
+		return value
+	}
+	return redactedValue
+}
@@ -0,0 +1,77 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugRedactorRedactText(t *testing.T) {
+	require := require.New(t)
+
+	redactor, err := newDebugRedactor("", "tok-abc123")
+	require.NoError(err)
+
+	redacted := redactor.redactText("db_password = \"hunter2\"\nname = \"db\"\nurl: https://example.com\nAuthorization: Bearer tok-abc123\n{\"apiToken\": \"abc\", \"replicas\": 3}")
+	require.Equal("db_password = \"[REDACTED]\"\nname = \"db\"\nurl: https://example.com\nAuthorization: Bearer [REDACTED]\n{\"apiToken\": \"[REDACTED]\", \"replicas\": 3}", redacted)
+}
+
+func TestDebugRedactorRedactDebugData(t *testing.T) {
+	require := require.New(t)
+
+	data := DebugData{
+		InstanceID: "inst-1",
+		ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"db": {
+				ResourceKey: "db",
+				Helm: &HelmData{
+					ChartValues: map[string]interface{}{
+						"auth":     map[string]interface{}{"password": "hunter2", "username": "admin"},
+						"secrets":  map[string]interface{}{"tls": []interface{}{"cert", "key"}},
+						"replicas": float64(3),
+					},
+					InstallLog: "rootPassword: hunter2",
+					InputParams: []OperatorInputParam{
+						{Key: "adminPassword", ResolvedValue: "hunter2"},
+						{Key: "region", ResolvedValue: "us-east-1"},
+					},
+				},
+				TerraformFiles: map[string]string{"main.tf": "client_secret = \"hunter2\""},
+				Files:          map[string]string{"values.yaml": "auth:\n  password: hunter2\n"},
+				Logs:           map[string]string{"log/install.log": "rootPassword: hunter2"},
+			},
+		},
+	}
+
+	redactor, err := newDebugRedactor("", "")
+	require.NoError(err)
+	redactor.redactDebugData(&data)
+
+	info := data.ResourceDebugInfo["db"]
+	require.Equal(map[string]interface{}{"password": redactedValue, "username": "admin"}, info.Helm.ChartValues["auth"])
+	require.Equal(map[string]interface{}{"tls": []interface{}{redactedValue, redactedValue}}, info.Helm.ChartValues["secrets"])
+	require.Equal(float64(3), info.Helm.ChartValues["replicas"])
+	require.Equal("rootPassword: [REDACTED]", info.Helm.InstallLog)
+	require.Equal(redactedValue, info.Helm.InputParams[0].ResolvedValue)
+	require.Equal("us-east-1", info.Helm.InputParams[1].ResolvedValue)
+	require.Equal("client_secret = \"[REDACTED]\"", info.TerraformFiles["main.tf"])
+	require.Equal("auth:\n  password: [REDACTED]\n", info.Files["values.yaml"])
+	require.Equal("rootPassword: [REDACTED]", info.Logs["log/install.log"])
+}
+
+func TestDebugRedactorCustomPatternAndNil(t *testing.T) {
+	require := require.New(t)
+
+	redactor, err := newDebugRedactor(`(?i)^dsn$`, "")
+	require.NoError(err)
+	require.Equal("dsn: [REDACTED]\npassword: hunter2", redactor.redactText("dsn: postgres://u:p@db\npassword: hunter2"))
+
+	_, err = newDebugRedactor("(", "")
+	require.Error(err)
+
+	var none *debugRedactor
+	data := DebugData{ResourceDebugInfo: map[string]*ResourceDebugInfo{"db": {Logs: map[string]string{"a.log": "password: hunter2"}}}}
+	none.redactDebugData(&data)
+	require.Equal("password: hunter2", data.ResourceDebugInfo["db"].Logs["a.log"])
+	require.Equal("tok", none.redactToken("tok"))
+}
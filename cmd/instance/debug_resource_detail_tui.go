@@ -3,7 +3,6 @@ package instance
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -483,16 +482,17 @@ func handleResourceWorkflowRefreshTick(debugData DebugData, node PlanDAGNode, wf
 }
 
 func handleResourceWorkflowRefresh(debugData DebugData, node PlanDAGNode, wfErrors *workflowErrorsState, msg wfEventsRefreshMsg) tea.Cmd {
-	wfErrors.refreshing = false
-	wfErrors.lastRefresh = time.Now()
+	wfErrors.recordRefresh(msg.err)
 	if msg.err == nil && msg.steps != nil && debugData.PlanDAG != nil {
 		if debugData.PlanDAG.WorkflowStepsByKey == nil {
 			debugData.PlanDAG.WorkflowStepsByKey = make(map[string]*ResourceWorkflowSteps)
 		}
+		enrichBootstrapSteps(msg.steps, node.Key, debugData.PlanDAG)
+		wfErrors.keepSelectedStep(debugData.PlanDAG.WorkflowStepsByKey[node.Key], msg.steps)
 		debugData.PlanDAG.WorkflowStepsByKey[node.Key] = msg.steps
 	}
 	if isWorkflowInProgress(getResourceWorkflowEvents(debugData, node)) {
-		return tea.Batch(scheduleWfEventsRefresh(), scheduleWfCountdownTick())
+		return tea.Batch(scheduleWfEventsRefresh(wfErrors.nextRefreshInterval()), scheduleWfCountdownTick())
 	}
 	return nil
 }
@@ -506,7 +506,7 @@ func handleResourceWorkflowCountdown(debugData DebugData, node PlanDAGNode) tea.
 
 func scheduleResourceWorkflowRefreshIfNeeded(debugData DebugData, node PlanDAGNode) tea.Cmd {
 	if isWorkflowInProgress(getResourceWorkflowEvents(debugData, node)) {
-		return tea.Batch(scheduleWfEventsRefresh(), scheduleWfCountdownTick())
+		return tea.Batch(scheduleWfEventsRefresh(wfEventsRefreshInterval), scheduleWfCountdownTick())
 	}
 	return nil
 }
@@ -4,10 +4,37 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"gopkg.in/yaml.v3"
 )
 
+const (
+	// helmInstallLogKey is the debug payload key holding the helm install log
+	helmInstallLogKey = "log/install.log"
+	// helmValuesFileName is the name under which resolved chart values appear in ResourceDebugInfo.Files
+	helmValuesFileName = "values.yaml"
+
+	// debugPayloadFilesKey and debugPayloadLogsKey are the debug payload objects holding rendered files and logs
+	debugPayloadFilesKey = "files"
+	debugPayloadLogsKey  = "logs"
+	// debugPayloadLogDir is the directory of the top-level debug payload entries that hold logs
+	debugPayloadLogDir = "log/"
+)
+
+const (
+	// Resource kinds accepted by --resource-type
+	debugResourceKindHelm      = "helm"
+	debugResourceKindTerraform = "terraform"
+	debugResourceKindGeneric   = "generic"
+)
+
+var debugResourceKinds = []string{debugResourceKindHelm, debugResourceKindTerraform, debugResourceKindGeneric}
+
 type HelmData struct {
 	ChartRepoName string                 `json:"chartRepoName"`
 	ChartRepoURL  string                 `json:"chartRepoURL"`
@@ -68,6 +95,8 @@ type OperatorData struct {
 type ComposeData struct {
 	InputParams  []OperatorInputParam  `json:"inputParams,omitempty"`
 	OutputParams []OperatorOutputParam `json:"outputParams,omitempty"`
+	Files        map[string]string     `json:"files,omitempty"` // rendered config files from the debug payload, keyed by path
+	Logs         map[string]string     `json:"logs,omitempty"`  // logs from the debug payload, keyed by path
 }
 
 // ResourceDebugInfo holds all debug information for a specific resource in the plan DAG.
@@ -82,26 +111,82 @@ type ResourceDebugInfo struct {
 	// Terraform-specific data (populated for terraform resources)
 	TerraformProgress         *TerraformProgressData  `json:"terraformProgress,omitempty"`
 	TerraformHistory          []TerraformHistoryEntry `json:"terraformHistory,omitempty"`
-	TerraformFiles            map[string]string       `json:"terraformFiles,omitempty"`
-	TerraformLogs             map[string]string       `json:"terraformLogs,omitempty"`
 	TerraformPlanPreview      map[string]string       `json:"terraformPlanPreview,omitempty"`
 	TerraformPlanPreviewDiff  map[string]string       `json:"terraformPlanPreviewDiff,omitempty"`
 	TerraformPlanPreviewError map[string]string       `json:"terraformPlanPreviewError,omitempty"`
 
+	// TerraformFiles and TerraformLogs keep the field names of schema version 1; their entries are also
+	// reported in Files and Logs.
+	TerraformFiles map[string]string `json:"terraformFiles,omitempty"`
+	TerraformLogs  map[string]string `json:"terraformLogs,omitempty"`
+
 	// Operator-specific data (populated for operator resources)
 	Operator *OperatorData `json:"operator,omitempty"`
 
 	// Compose-specific data (populated for compose resources)
 	Compose *ComposeData `json:"compose,omitempty"`
+
+	// Files holds rendered file contents keyed by path, in the same shape for every resource type:
+	// terraform files, compose config files, and helm chart values as "values.yaml".
+	Files map[string]string `json:"files,omitempty"`
+	// Logs holds log contents keyed by path, in the same shape for every resource type:
+	// terraform logs, compose logs, and the helm install log as "log/install.log".
+	Logs map[string]string `json:"logs,omitempty"`
 }
 
 // hasData returns true if any debug data has been populated for this resource.
 func (r *ResourceDebugInfo) hasData() bool {
 	return r.Helm != nil || r.Operator != nil || r.Compose != nil || r.TerraformProgress != nil ||
 		len(r.TerraformHistory) > 0 || len(r.TerraformFiles) > 0 || len(r.TerraformLogs) > 0 ||
+		len(r.Files) > 0 || len(r.Logs) > 0 ||
 		len(r.TerraformPlanPreview) > 0 || len(r.TerraformPlanPreviewDiff) > 0 || len(r.TerraformPlanPreviewError) > 0
 }
 
+// normalizeFilesAndLogs fills Files and Logs from the type-specific fields so JSON consumers can read
+// rendered content without special-casing the resource type. The type-specific fields are left intact.
+func (r *ResourceDebugInfo) normalizeFilesAndLogs() {
+	files := make(map[string]string, len(r.Files))
+	logs := make(map[string]string, len(r.Logs))
+	for name, content := range r.Files {
+		files[name] = content
+	}
+	for name, content := range r.Logs {
+		logs[name] = content
+	}
+
+	if r.Helm != nil {
+		if len(r.Helm.ChartValues) > 0 {
+			if values, err := yaml.Marshal(r.Helm.ChartValues); err == nil {
+				files[helmValuesFileName] = string(values)
+			}
+		}
+		if r.Helm.InstallLog != "" {
+			logs[helmInstallLogKey] = r.Helm.InstallLog
+		}
+	}
+	for name, content := range r.TerraformFiles {
+		files[name] = content
+	}
+	for name, content := range r.TerraformLogs {
+		logs[name] = content
+	}
+	if r.Compose != nil {
+		for name, content := range r.Compose.Files {
+			files[name] = content
+		}
+		for name, content := range r.Compose.Logs {
+			logs[name] = content
+		}
+	}
+
+	if len(files) > 0 {
+		r.Files = files
+	}
+	if len(logs) > 0 {
+		r.Logs = logs
+	}
+}
+
 func parseHelmData(debugData map[string]interface{}) *HelmData {
 	helmData := &HelmData{
 		ChartValues: make(map[string]interface{}),
@@ -123,20 +208,100 @@ func parseHelmData(debugData map[string]interface{}) *HelmData {
 		helmData.ReleaseName = releaseName
 	}
 
-	if chartValuesStr, ok := debugData["chartValues"].(string); ok {
-		var chartValues map[string]interface{}
-		if err := json.Unmarshal([]byte(chartValuesStr), &chartValues); err == nil {
-			helmData.ChartValues = chartValues
+	// Chart values arrive either as a JSON string or as an already-decoded map
+	switch chartValues := debugData["chartValues"].(type) {
+	case string:
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(chartValues), &decoded); err == nil && decoded != nil {
+			helmData.ChartValues = decoded
 		}
+	case map[string]interface{}:
+		helmData.ChartValues = chartValues
 	}
 
-	if installLog, ok := debugData["log/install.log"].(string); ok {
+	if installLog, ok := debugData[helmInstallLogKey].(string); ok {
 		helmData.InstallLog = installLog
 	}
 
 	return helmData
 }
 
+// parseDebugFiles splits a resource debug payload into rendered files and logs, both keyed by path. Entries are
+// classified by where the payload puts them, never by words in their names: the entries of the nested "files" and
+// "logs" objects, and top-level entries under the "log/" directory, as the helm install log, are logs. Any other
+// top-level string entry whose key looks like a file path (contains a "/" or a "." extension) is a file.
+func parseDebugFiles(debugData map[string]interface{}) (files, logs map[string]string) {
+	files = make(map[string]string)
+	logs = make(map[string]string)
+	for key, value := range debugData {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			switch key {
+			case debugPayloadFilesKey:
+				addDebugPayloadEntries(files, value)
+			case debugPayloadLogsKey:
+				addDebugPayloadEntries(logs, value)
+			}
+		case string:
+			if strings.HasPrefix(key, debugPayloadLogDir) {
+				logs[key] = value
+			} else if strings.Contains(key, "/") || path.Ext(key) != "" {
+				files[key] = value
+			}
+		}
+	}
+	if len(files) == 0 {
+		files = nil
+	}
+	if len(logs) == 0 {
+		logs = nil
+	}
+	return files, logs
+}
+
+// addDebugPayloadEntries copies the string entries of a nested debug payload object into dst.
+func addDebugPayloadEntries(dst map[string]string, entries map[string]interface{}) {
+	for name, value := range entries {
+		if content, ok := value.(string); ok {
+			dst[name] = content
+		}
+	}
+}
+
+// buildDebugFilesTree converts files to a navigable tree with one expandable node per file
+// and one child node per line of content.
+func buildDebugFilesTree(files map[string]string) []outputNode {
+	if len(files) == 0 {
+		return nil
+	}
+
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+
+	roots := make([]outputNode, 0, len(paths))
+	for _, filePath := range paths {
+		node := outputNode{
+			key:        filePath,
+			nodeType:   "array",
+			expandable: true,
+		}
+		lines := strings.Split(strings.TrimRight(files[filePath], "\n"), "\n")
+		for i, line := range lines {
+			node.children = append(node.children, &outputNode{
+				key:      fmt.Sprintf("%d", i+1),
+				value:    line,
+				nodeType: "text",
+				depth:    1,
+			})
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
 // fetchInputParams fetches input parameters from the ListInputParameter V1 API
 // and converts them to OperatorInputParam structs. If inputParams is provided,
 // resolved values are looked up by key and populated. Used by both helm and operator TUIs.
@@ -211,3 +376,65 @@ func fetchOutputParams(ctx context.Context, token, serviceID, resourceID, produc
 	}
 	return params, nil
 }
+
+func validateDebugResourceKind(kind string) error {
+	if kind != "" && !slices.Contains(debugResourceKinds, kind) {
+		return fmt.Errorf("invalid resource-type %q: must be one of %s", kind, strings.Join(debugResourceKinds, ", "))
+	}
+	return nil
+}
+
+// debugResourceKind classifies a resource type as terraform, helm or generic, the same way the DAG view
+// picks the detail view of a resource.
+func debugResourceKind(resourceType string) string {
+	lower := strings.ToLower(resourceType)
+	switch {
+	case strings.Contains(lower, "terraform"):
+		return debugResourceKindTerraform
+	case strings.Contains(lower, "helm"):
+		return debugResourceKindHelm
+	default:
+		return debugResourceKindGeneric
+	}
+}
+
+// filterDebugResourcesByKind keeps only the debug info of resources of the given kind. An empty kind keeps
+// every resource. The plan DAG is left intact so dependencies stay visible.
+func filterDebugResourcesByKind(data *DebugData, kind string) {
+	if kind == "" || data == nil {
+		return
+	}
+	for key, info := range data.ResourceDebugInfo {
+		if info == nil || debugResourceKind(info.ResourceType) != kind {
+			delete(data.ResourceDebugInfo, key)
+		}
+	}
+}
+
+// filterDebugFailedResources keeps only the debug info of resources whose workflow progress failed, as derived from
+// the workflow status of the resource and the highest-priority event type of its steps. Resources without progress
+// are dropped. Like the kind filter, the plan DAG is left intact.
+func filterDebugFailedResources(data *DebugData) {
+	if data == nil {
+		return
+	}
+	for key, info := range data.ResourceDebugInfo {
+		if info == nil || debugResourceProgressStatus(data.PlanDAG, info) != "failed" {
+			delete(data.ResourceDebugInfo, key)
+		}
+	}
+}
+
+// debugResourceProgressStatus returns the workflow progress status of a resource, looked up by key and then by ID
+func debugResourceProgressStatus(planDAG *PlanDAG, info *ResourceDebugInfo) string {
+	if planDAG == nil {
+		return ""
+	}
+	if progress, ok := planDAG.ProgressByKey[info.ResourceKey]; ok {
+		return progress.Status
+	}
+	if progress, ok := planDAG.ProgressByID[info.ResourceID]; ok {
+		return progress.Status
+	}
+	return ""
+}
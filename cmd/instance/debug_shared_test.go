@@ -0,0 +1,242 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHelmDataChartValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		chartValue interface{}
+		omit       bool
+		expected   map[string]interface{}
+	}{
+		{
+			name:       "json string",
+			chartValue: `{"replicaCount": 3, "auth": {"enabled": true}}`,
+			expected: map[string]interface{}{
+				"replicaCount": float64(3),
+				"auth":         map[string]interface{}{"enabled": true},
+			},
+		},
+		{
+			name: "decoded map",
+			chartValue: map[string]interface{}{
+				"replicaCount": float64(3),
+				"auth":         map[string]interface{}{"enabled": true},
+			},
+			expected: map[string]interface{}{
+				"replicaCount": float64(3),
+				"auth":         map[string]interface{}{"enabled": true},
+			},
+		},
+		{
+			name:     "absent",
+			omit:     true,
+			expected: map[string]interface{}{},
+		},
+		{
+			name:       "invalid json string",
+			chartValue: "not-json",
+			expected:   map[string]interface{}{},
+		},
+		{
+			name:       "json null",
+			chartValue: "null",
+			expected:   map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debugData := map[string]interface{}{
+				"chartRepoName":   "bitnami",
+				"chartRepoURL":    "https://charts.bitnami.com/bitnami",
+				"chartVersion":    "17.0.0",
+				"namespace":       "redis-ns",
+				"releaseName":     "redis-release",
+				"log/install.log": "installed",
+			}
+			if !tt.omit {
+				debugData["chartValues"] = tt.chartValue
+			}
+
+			helmData := parseHelmData(debugData)
+
+			require.Equal(t, tt.expected, helmData.ChartValues)
+			require.Equal(t, "bitnami", helmData.ChartRepoName)
+			require.Equal(t, "https://charts.bitnami.com/bitnami", helmData.ChartRepoURL)
+			require.Equal(t, "17.0.0", helmData.ChartVersion)
+			require.Equal(t, "redis-ns", helmData.Namespace)
+			require.Equal(t, "redis-release", helmData.ReleaseName)
+			require.Equal(t, "installed", helmData.InstallLog)
+		})
+	}
+}
+
+func TestParseDebugFiles(t *testing.T) {
+	tests := []struct {
+		name          string
+		debugData     map[string]interface{}
+		expectedFiles map[string]string
+		expectedLogs  map[string]string
+	}{
+		{
+			name: "flat files",
+			debugData: map[string]interface{}{
+				"docker-compose.yaml": "services: {}\n",
+				"config/app.conf":     "port=8080",
+				"status":              "running",
+				"replicas":            float64(2),
+			},
+			expectedFiles: map[string]string{
+				"docker-compose.yaml": "services: {}\n",
+				"config/app.conf":     "port=8080",
+			},
+		},
+		{
+			name: "file names containing log stay files",
+			debugData: map[string]interface{}{
+				"modules/log_bucket.tf": "resource \"aws_s3_bucket\" \"logs\" {}",
+				"catalog.yaml":          "items: []",
+				"logging.conf":          "level=debug",
+			},
+			expectedFiles: map[string]string{
+				"modules/log_bucket.tf": "resource \"aws_s3_bucket\" \"logs\" {}",
+				"catalog.yaml":          "items: []",
+				"logging.conf":          "level=debug",
+			},
+		},
+		{
+			name: "entries under the log directory are logs",
+			debugData: map[string]interface{}{
+				"log/install.log":     "installed",
+				"log/main.tf":         "terraform apply output",
+				"docker-compose.yaml": "services: {}\n",
+			},
+			expectedFiles: map[string]string{"docker-compose.yaml": "services: {}\n"},
+			expectedLogs: map[string]string{
+				"log/install.log": "installed",
+				"log/main.tf":     "terraform apply output",
+			},
+		},
+		{
+			name: "nested objects decide regardless of names",
+			debugData: map[string]interface{}{
+				"files": map[string]interface{}{
+					"apply.log": "a file named like a log",
+					"count":     float64(1),
+				},
+				"logs": map[string]interface{}{
+					"main.tf": "a log named like a file",
+				},
+				"config": map[string]interface{}{"app.conf": "ignored"},
+			},
+			expectedFiles: map[string]string{"apply.log": "a file named like a log"},
+			expectedLogs:  map[string]string{"main.tf": "a log named like a file"},
+		},
+		{
+			name:      "no files or logs",
+			debugData: map[string]interface{}{"status": "running"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, logs := parseDebugFiles(tt.debugData)
+			require.Equal(t, tt.expectedFiles, files)
+			require.Equal(t, tt.expectedLogs, logs)
+		})
+	}
+}
+
+func TestBuildDebugFilesTree(t *testing.T) {
+	require := require.New(t)
+
+	tree := buildDebugFilesTree(map[string]string{
+		"z.yaml": "a: 1\nb: 2\n",
+		"a.conf": "x",
+	})
+	require.Len(tree, 2)
+	require.Equal("a.conf", tree[0].key)
+	require.Equal("z.yaml", tree[1].key)
+	require.True(tree[1].expandable)
+	require.Len(tree[1].children, 2)
+	require.Equal("1", tree[1].children[0].key)
+	require.Equal("a: 1", tree[1].children[0].value)
+	require.Equal(1, tree[1].children[0].depth)
+
+	require.Nil(buildDebugFilesTree(nil))
+}
+
+func TestDebugResourceKind(t *testing.T) {
+	require.Equal(t, debugResourceKindTerraform, debugResourceKind("Terraform"))
+	require.Equal(t, debugResourceKindHelm, debugResourceKind("HelmChart"))
+	require.Equal(t, debugResourceKindGeneric, debugResourceKind("OperatorCRD"))
+	require.Equal(t, debugResourceKindGeneric, debugResourceKind(""))
+
+	require.NoError(t, validateDebugResourceKind(""))
+	require.NoError(t, validateDebugResourceKind("helm"))
+	require.ErrorContains(t, validateDebugResourceKind("kustomize"), "must be one of helm, terraform, generic")
+}
+
+func TestFilterDebugResourcesByKind(t *testing.T) {
+	newData := func() DebugData {
+		return DebugData{ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"db":    {ResourceKey: "db", ResourceType: "Terraform"},
+			"cache": {ResourceKey: "cache", ResourceType: "Helm"},
+			"app":   {ResourceKey: "app", ResourceType: "Compose"},
+			"gone":  nil,
+		}}
+	}
+
+	data := newData()
+	filterDebugResourcesByKind(&data, debugResourceKindTerraform)
+	require.Len(t, data.ResourceDebugInfo, 1)
+	require.Contains(t, data.ResourceDebugInfo, "db")
+
+	data = newData()
+	filterDebugResourcesByKind(&data, debugResourceKindGeneric)
+	require.Len(t, data.ResourceDebugInfo, 1)
+	require.Contains(t, data.ResourceDebugInfo, "app")
+
+	data = newData()
+	filterDebugResourcesByKind(&data, "")
+	require.Len(t, data.ResourceDebugInfo, 4)
+}
+
+func TestFilterDebugFailedResources(t *testing.T) {
+	data := DebugData{
+		PlanDAG: &PlanDAG{
+			ProgressByKey: map[string]ResourceProgress{
+				"db":    {Status: "failed"},
+				"cache": {Status: "completed"},
+			},
+			ProgressByID: map[string]ResourceProgress{
+				"r-app": {Status: "failed"},
+			},
+		},
+		ResourceDebugInfo: map[string]*ResourceDebugInfo{
+			"db":      {ResourceKey: "db", ResourceType: "Terraform"},
+			"cache":   {ResourceKey: "cache", ResourceType: "Helm"},
+			"app":     {ResourceID: "r-app", ResourceKey: "app", ResourceType: "Compose"},
+			"pending": {ResourceKey: "pending", ResourceType: "Helm"},
+			"gone":    nil,
+		},
+	}
+
+	filterDebugFailedResources(&data)
+	require.Len(t, data.ResourceDebugInfo, 2)
+	require.Contains(t, data.ResourceDebugInfo, "db")
+	require.Contains(t, data.ResourceDebugInfo, "app")
+
+	filterDebugResourcesByKind(&data, debugResourceKindTerraform)
+	require.Len(t, data.ResourceDebugInfo, 1)
+	require.Contains(t, data.ResourceDebugInfo, "db")
+
+	empty := DebugData{ResourceDebugInfo: map[string]*ResourceDebugInfo{"db": {ResourceKey: "db"}}}
+	filterDebugFailedResources(&empty)
+	require.Empty(t, empty.ResourceDebugInfo)
+}
@@ -0,0 +1,48 @@
+package instance
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// k8sConnState is the health of the Kubernetes connection behind the live logs and progress of the terraform
+// detail view, so that "no new logs" can be told apart from "connection lost".
+type k8sConnState int
+
+const (
+	k8sConnUnknown k8sConnState = iota
+	k8sConnConnected
+	k8sConnReconnecting
+	k8sConnDisconnected
+)
+
+// k8sConnDisconnectedAfterErrors is how many progress refreshes in a row must fail before the connection is
+// shown as disconnected rather than reconnecting.
+const k8sConnDisconnectedAfterErrors = 3
+
+// connectionState derives the connection state from the Kubernetes connection, the log stream and the progress
+// refreshes.
+func (m terraformDetailModel) connectionState() k8sConnState {
+	if m.loading {
+		return k8sConnUnknown
+	}
+	if m.k8sConn == nil || m.progressRefreshErrs >= k8sConnDisconnectedAfterErrors {
+		return k8sConnDisconnected
+	}
+	if m.logReconnecting || m.progressRefreshErrs > 0 {
+		return k8sConnReconnecting
+	}
+	return k8sConnConnected
+}
+
+// renderConnectionBadge renders the connection state for the header.
+func renderConnectionBadge(state k8sConnState) string {
+	switch state {
+	case k8sConnConnected:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Render("● connected")
+	case k8sConnReconnecting:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render("◌ reconnecting")
+	case k8sConnDisconnected:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render("○ disconnected")
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -20,14 +21,15 @@ const (
 	tabTfFiles   = 1
 	tabTfOutput  = 2
 	tabLogs      = 3
-	tabOpHistory = 4
-	tabWfErrors  = 5
-	numTabs      = 6
+	tabK8sEvents = 4
+	tabOpHistory = 5
+	tabWfErrors  = 6
+	numTabs      = 7
 )
 
 const terraformDebugSessionTimeout = 45 * time.Second
 
-var tabNames = []string{"Progress", "Terraform Files", "Terraform Output", "Live Logs", "Operation History", "Workflow Events"}
+var tabNames = []string{"Progress", "Terraform Files", "Terraform Output", "Live Logs", "K8s Events", "Operation History", "Workflow Events"}
 
 func init() {
 	if len(tabNames) != numTabs {
@@ -152,12 +154,25 @@ type terraformDetailModel struct {
 	logLines     []string
 	logChan      chan logLineMsg
 	logCancel    context.CancelFunc // cancels the log polling goroutine
+	logConn      *k8sConnection     // the cluster holding the resource's tf-state configmap and executor pods
 	logScroll    int
 	logFollow    bool // auto-scroll to bottom
 	logStreaming bool
 	logDone      bool
 	logErr       error
 	logLabel     string // describes which operation's log is shown
+	logAllPods   bool   // show the merged logs of every executor pod instead of the operation logs
+	logSearch    *logSearchState
+
+	// Connection health shown in the header
+	logReconnecting     bool // the log stream failed and is being restarted
+	progressRefreshErrs int  // consecutive failed progress refreshes
+
+	// K8s Events tab data
+	k8sEvents        []k8sEvent
+	k8sEventsErr     error
+	k8sEventsLoading bool
+	k8sEventsScroll  int
 
 	// Operation History tab data
 	historyCursor int
@@ -201,6 +216,7 @@ func newTerraformDetailModel(node PlanDAGNode, data DebugData) terraformDetailMo
 		logChan:     make(chan logLineMsg, 50),
 		logFollow:   true,
 		wfErrors:    &workflowErrorsState{},
+		logSearch:   newLogSearchState(),
 	}
 }
 
@@ -208,7 +224,22 @@ func (m terraformDetailModel) Init() tea.Cmd {
 	return tea.Batch(m.spinner.Tick, m.fetchData())
 }
 
-const progressRefreshInterval = 5 * time.Second
+const (
+	defaultProgressRefreshInterval = 5 * time.Second
+	// minDebugPollInterval is the shortest accepted progress refresh or log poll interval, to avoid hammering the API
+	minDebugPollInterval = time.Second
+)
+
+// progressRefreshInterval is the interval between progress refreshes, set from --progress-refresh-interval.
+var progressRefreshInterval = defaultProgressRefreshInterval
+
+// validateDebugPollInterval checks that the interval set with flag is not shorter than minDebugPollInterval.
+func validateDebugPollInterval(flag string, interval time.Duration) error {
+	if interval < minDebugPollInterval {
+		return fmt.Errorf("--%s must be at least %s, got %s", flag, minDebugPollInterval, interval)
+	}
+	return nil
+}
 
 func (m terraformDetailModel) isProgressInFlight() bool {
 	if m.tfExecutionState.hasData() {
@@ -353,12 +384,7 @@ func (m terraformDetailModel) fetchData() tea.Cmd {
 		var tfOutputJSON string
 		var executionState TerraformExecutionState
 		var planPreviewByOpID, planPreviewErrByOpID map[string]string
-		type workspaceCandidate struct {
-			conn     *k8sConnection
-			podName  string
-			basePath string
-		}
-		var workspaceCandidates []workspaceCandidate
+		var workspaceCandidates []terraformWorkspaceCandidate
 		if conn == nil {
 			return terraformDataMsg{
 				progress: progress,
@@ -398,7 +424,7 @@ func (m terraformDetailModel) fetchData() tea.Cmd {
 					executionState = stateData.ExecutionState
 				}
 				if stateData.ExecutionState.hasWorkspace() {
-					workspaceCandidates = append(workspaceCandidates, workspaceCandidate{
+					workspaceCandidates = append(workspaceCandidates, terraformWorkspaceCandidate{
 						conn:     c,
 						podName:  stateData.ExecutionState.PodName,
 						basePath: stateData.ExecutionState.TerraformFilesPath,
@@ -419,34 +445,8 @@ func (m terraformDetailModel) fetchData() tea.Cmd {
 
 		// Fetch file tree from the terraform executor pod.
 		// Try both dataplane and control-plane clusters.
-		var fileTree *TerraformFileTree
-		if progress != nil && conn != nil && progress.TerraformName != "" {
-			defaultPodName := terraformExecutorPodName(progress.TerraformName)
-			for _, c := range []*k8sConnection{conn.dataplane, conn.controlPlane} {
-				if c == nil {
-					continue
-				}
-				for _, op := range []string{"apply", "diff", "output"} {
-					workspaceCandidates = append(workspaceCandidates, workspaceCandidate{
-						conn:     c,
-						podName:  defaultPodName,
-						basePath: terraformFilesBasePath(progress.TerraformName, progress.InstanceID, op),
-					})
-				}
-			}
-		}
-	fileTreeSearch:
-		for _, candidate := range workspaceCandidates {
-			if candidate.conn == nil || candidate.podName == "" || candidate.basePath == "" {
-				continue
-			}
-			tree, fetchErr := fetchTerraformFileTree(ctx, candidate.conn, terraformConfigMapNamespace, candidate.podName, candidate.basePath)
-			if fetchErr == nil && tree != nil && len(tree.Flat) > 0 {
-				tree.conn = candidate.conn
-				fileTree = tree
-				break fileTreeSearch
-			}
-		}
+		workspaceCandidates = append(workspaceCandidates, defaultTerraformWorkspaceCandidates(conn, progress)...)
+		fileTree := fetchFirstTerraformFileTree(ctx, workspaceCandidates)
 
 		return terraformDataMsg{
 			progress:             progress,
@@ -484,6 +484,9 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.editingFile {
 			return m.updateEditorKey(msg)
 		}
+		if m.logSearch.editing {
+			return m.updateLogSearchKey(msg)
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			if m.logCancel != nil {
@@ -516,6 +519,10 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.fileScroll = 0
 				return m, nil
 			}
+			if m.activeTab == tabLogs && (m.logSearch.pattern != nil || m.logSearch.err != nil) {
+				m.clearLogSearch()
+				return m, nil
+			}
 			// Cancel log polling goroutine before leaving
 			if m.logCancel != nil {
 				m.logCancel()
@@ -578,6 +585,10 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.logScroll > 0 {
 					m.logScroll--
 				}
+			} else if m.activeTab == tabK8sEvents {
+				if m.k8sEventsScroll > 0 {
+					m.k8sEventsScroll--
+				}
 			} else if m.activeTab == tabWfErrors {
 				items := flattenWfEventItems(m.getTfWfEvents())
 				if m.wfErrors.cursor > 0 {
@@ -638,6 +649,10 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.logScroll > m.logMaxScroll() {
 					m.logScroll = m.logMaxScroll()
 				}
+			} else if m.activeTab == tabK8sEvents {
+				if m.k8sEventsScroll < m.k8sEventsMaxScroll() {
+					m.k8sEventsScroll++
+				}
 			} else if m.activeTab == tabWfErrors {
 				items := flattenWfEventItems(m.getTfWfEvents())
 				if m.wfErrors.cursor < len(items)-1 {
@@ -674,7 +689,14 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if m.activeTab == tabTfOutput && len(m.outputTree) > 0 {
 				visibleNodes := flattenOutputTree(m.outputTree)
 				if m.outputCursor >= 0 && m.outputCursor < len(visibleNodes) {
-					toggleOutputNode(visibleNodes[m.outputCursor])
+					node := visibleNodes[m.outputCursor]
+					toggleOutputNode(node)
+					if node.sensitive && node.sensitiveShown {
+						if err := recordSensitiveReveal(debugAuditLogPath, m.debugData.InstanceID, m.node.Key, node.key, time.Now()); err != nil {
+							m.clipboardMsg = fmt.Sprintf("✗ %v", err)
+							return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return clearClipboardMsg{} })
+						}
+					}
 				}
 			} else if m.activeTab == tabOpHistory && len(m.historyDates) > 0 {
 				rows := flattenTimeline(m.historyDates, m.planPreviewByOpID, m.planPreviewErrByOpID)
@@ -730,6 +752,24 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, m.fetchFileContent(entry.Path)
 				}
 			}
+		case "o":
+			if m.activeTab == tabTfFiles {
+				entry := m.selectedFileEntry()
+				if entry == nil || entry.IsDir {
+					return m, nil
+				}
+				if m.viewingFile {
+					if !m.fileLoading && m.fileContentErr == nil {
+						m.workspaceMsg = fmt.Sprintf("Opened a local copy of %s in the editor. Exit the editor to return to the TUI.", entry.RelPath)
+						return m, openInExternalEditor(entry.Path, m.fileContent)
+					}
+					return m, nil
+				}
+				if m.k8sConn != nil && m.k8sConn.dataplane != nil {
+					m.workspaceMsg = fmt.Sprintf("Fetching %s for the editor...", entry.RelPath)
+					return m, m.fetchFileForExternalEditor(entry.Path)
+				}
+			}
 		case "s":
 			if m.activeTab == tabTfFiles && !m.viewingFile && !m.shellLaunching {
 				m.shellLaunching = true
@@ -741,6 +781,10 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.workspaceMsg = "Refreshing workspace..."
 				return m, m.refreshFileTree()
 			}
+			if m.activeTab == tabK8sEvents && m.k8sConn != nil && !m.k8sEventsLoading {
+				m.k8sEventsLoading = true
+				return m, m.fetchK8sEvents()
+			}
 		case "p":
 			if m.activeTab == tabTfFiles && !m.viewingFile && m.fileTree != nil && !m.patching {
 				if !m.patchConfirm {
@@ -781,6 +825,11 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.logScroll < 0 {
 					m.logScroll = 0
 				}
+			} else if m.activeTab == tabK8sEvents {
+				m.k8sEventsScroll -= m.bodyHeight()
+				if m.k8sEventsScroll < 0 {
+					m.k8sEventsScroll = 0
+				}
 			} else if m.activeTab == tabWfErrors {
 				items := flattenWfEventItems(m.getTfWfEvents())
 				pageItems := m.bodyHeight() / 2
@@ -834,6 +883,11 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.logScroll > m.logMaxScroll() {
 					m.logScroll = m.logMaxScroll()
 				}
+			} else if m.activeTab == tabK8sEvents {
+				m.k8sEventsScroll += m.bodyHeight()
+				if m.k8sEventsScroll > m.k8sEventsMaxScroll() {
+					m.k8sEventsScroll = m.k8sEventsMaxScroll()
+				}
 			} else if m.activeTab == tabWfErrors {
 				items := flattenWfEventItems(m.getTfWfEvents())
 				pageItems := m.bodyHeight() / 2
@@ -858,6 +912,20 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.scrollY = m.progressMaxScroll()
 				}
 			}
+		case "/":
+			if m.activeTab == tabLogs {
+				m.logSearch.editing = true
+				m.logSearch.err = nil
+				return m, m.logSearch.input.Focus()
+			}
+		case "n":
+			if m.activeTab == tabLogs {
+				m.stepLogMatch(1)
+			}
+		case "N":
+			if m.activeTab == tabLogs {
+				m.stepLogMatch(-1)
+			}
 		case "f":
 			if m.activeTab == tabLogs {
 				m.logFollow = !m.logFollow
@@ -874,6 +942,14 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.logScroll = maxSc
 				}
 			}
+		case "F":
+			if m.activeTab == tabProgress {
+				m.jumpToFirstFailedResource()
+			}
+		case "a":
+			if m.activeTab == tabLogs {
+				return m.toggleAllPodLogs()
+			}
 		case "y":
 			text := m.copyableContent()
 			if text != "" {
@@ -911,20 +987,17 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.k8sConn != nil {
 			logConn := findConnectionWithStateConfigMap(msg.k8sConn, m.debugData.InstanceID, m.node.ID)
 			if logConn != nil {
-				ctx, cancel := context.WithCancel(context.Background()) //nolint:gosec // cancel stored in m.logCancel for later use
-				m.logCancel = cancel
-				m.logStreaming = true
-				cmds = append(cmds,
-					watchApplyDestroyLogs(ctx, logConn, m.debugData.InstanceID, m.node.ID, m.history, m.logChan),
-					waitForLogLines(m.logChan),
-				)
+				m.logConn = logConn
+				cmds = append(cmds, m.startLogWatch(logConn))
 			}
+			m.k8sEventsLoading = true
+			cmds = append(cmds, m.fetchK8sEvents())
 		}
 		if m.isProgressInFlight() {
 			cmds = append(cmds, scheduleProgressRefresh())
 		}
 		if isWorkflowInProgress(m.getTfWfEvents()) {
-			cmds = append(cmds, scheduleWfEventsRefresh())
+			cmds = append(cmds, scheduleWfEventsRefresh(wfEventsRefreshInterval))
 		}
 		if m.isProgressInFlight() || isWorkflowInProgress(m.getTfWfEvents()) {
 			cmds = append(cmds, scheduleWfCountdownTick())
@@ -932,7 +1005,21 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(cmds) > 0 {
 			return m, tea.Batch(cmds...)
 		}
+	case k8sEventsMsg:
+		m.k8sEventsLoading = false
+		m.k8sEventsErr = msg.err
+		if msg.err == nil {
+			m.k8sEvents = msg.events
+			if m.k8sEventsScroll > m.k8sEventsMaxScroll() {
+				m.k8sEventsScroll = m.k8sEventsMaxScroll()
+			}
+		}
 	case logLineMsg:
+		if msg.ch != nil && msg.ch != m.logChan {
+			// A batch of a stream that was replaced, e.g. when switching to the all-pods view
+			return m, nil
+		}
+		m.logReconnecting = false
 		if msg.replace {
 			m.logLines = msg.lines
 		} else {
@@ -941,6 +1028,7 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.label != "" {
 			m.logLabel = msg.label
 		}
+		m.refreshLogMatches()
 		// If follow mode is on, snap to bottom
 		if m.logFollow {
 			bodyH := m.bodyHeight() - 4
@@ -955,22 +1043,22 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, waitForLogLines(m.logChan)
 	case logStreamDoneMsg:
+		if msg.ch != nil && msg.ch != m.logChan {
+			// The end of a stream that was replaced
+			return m, nil
+		}
 		m.logStreaming = false
 		if msg.err != nil {
 			// Cancel previous context if any, then restart
 			if m.logCancel != nil {
 				m.logCancel()
 			}
-			ctx, cancel := context.WithCancel(context.Background()) //nolint:gosec // cancel stored in m.logCancel for later use
-			m.logCancel = cancel
-			m.logChan = make(chan logLineMsg, 50)
-			m.logStreaming = true
 			m.logErr = nil
 			if m.k8sConn != nil && m.k8sConn.dataplane != nil {
+				m.logReconnecting = true
 				return m, tea.Batch(
 					tea.Tick(logPollInterval, func(time.Time) tea.Msg { return nil }),
-					watchApplyDestroyLogs(ctx, m.k8sConn.dataplane, m.debugData.InstanceID, m.node.ID, m.history, m.logChan),
-					waitForLogLines(m.logChan),
+					m.startLogWatch(m.k8sConn.dataplane),
 				)
 			}
 		}
@@ -983,7 +1071,10 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case progressRefreshMsg:
 		m.refreshing = false
 		m.lastProgressRefresh = time.Now()
-		if msg.err == nil {
+		if msg.err != nil {
+			m.progressRefreshErrs++
+		} else {
+			m.progressRefreshErrs = 0
 			if msg.progress != nil {
 				m.tfProgress = msg.progress
 			}
@@ -1006,18 +1097,19 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, fetchWfEventsForResource(m.debugData, m.node.Key)
 		}
 	case wfEventsRefreshMsg:
-		m.wfErrors.refreshing = false
-		m.wfErrors.lastRefresh = time.Now()
+		m.wfErrors.recordRefresh(msg.err)
 		if msg.err == nil && msg.steps != nil {
 			if m.debugData.PlanDAG != nil {
 				if m.debugData.PlanDAG.WorkflowStepsByKey == nil {
 					m.debugData.PlanDAG.WorkflowStepsByKey = make(map[string]*ResourceWorkflowSteps)
 				}
+				enrichBootstrapSteps(msg.steps, m.node.Key, m.debugData.PlanDAG)
+				m.wfErrors.keepSelectedStep(m.debugData.PlanDAG.WorkflowStepsByKey[m.node.Key], msg.steps)
 				m.debugData.PlanDAG.WorkflowStepsByKey[m.node.Key] = msg.steps
 			}
 		}
 		if isWorkflowInProgress(m.getTfWfEvents()) {
-			return m, tea.Batch(scheduleWfEventsRefresh(), scheduleWfCountdownTick())
+			return m, tea.Batch(scheduleWfEventsRefresh(m.wfErrors.nextRefreshInterval()), scheduleWfCountdownTick())
 		}
 	case wfCountdownTickMsg:
 		if isWorkflowInProgress(m.getTfWfEvents()) || m.isProgressInFlight() {
@@ -1056,6 +1148,22 @@ func (m terraformDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.workspaceMsg = "Shell session active. Exit the shell to return to the TUI."
 		return m, m.openShell(msg.session)
+	case externalEditorFileMsg:
+		if msg.err != nil {
+			m.workspaceMsg = fmt.Sprintf("Open in editor failed: %v", msg.err)
+			return m, nil
+		}
+		m.workspaceMsg = fmt.Sprintf("Opened a local copy of %s in the editor. Exit the editor to return to the TUI.", filepath.Base(msg.path))
+		return m, openInExternalEditor(msg.path, msg.content)
+	case externalEditorFinishedMsg:
+		switch {
+		case msg.err != nil:
+			m.workspaceMsg = fmt.Sprintf("Editor exited with error: %v", msg.err)
+		case msg.changed:
+			m.workspaceMsg = fmt.Sprintf("Changes to the local copy of %s were discarded. Use e to edit the file on the executor pod.", filepath.Base(msg.path))
+		default:
+			m.workspaceMsg = fmt.Sprintf("Closed %s in the editor.", filepath.Base(msg.path))
+		}
 	case terraformShellFinishedMsg:
 		if msg.err != nil {
 			m.workspaceMsg = fmt.Sprintf("Shell exited with error: %v", msg.err)
@@ -1303,16 +1411,21 @@ func (m terraformDetailModel) patchWorkspace() tea.Cmd {
 	})
 }
 
+// logCodeWidth is the width log lines are wrapped at, after the line number gutter.
+func (m terraformDetailModel) logCodeWidth() int {
+	maxCodeWidth := m.contentWidth() - 9
+	if maxCodeWidth < 20 {
+		maxCodeWidth = 20
+	}
+	return maxCodeWidth
+}
+
 func (m terraformDetailModel) logMaxScroll() int {
 	bodyH := m.bodyHeight() - 4
 	if bodyH < 1 {
 		bodyH = 1
 	}
-	maxCodeWidth := m.contentWidth() - 9
-	if maxCodeWidth < 20 {
-		maxCodeWidth = 20
-	}
-	vlines := expandLinesToVisual(m.logLines, maxCodeWidth)
+	vlines := expandLinesToVisual(m.logLines, m.logCodeWidth())
 	maxScroll := len(vlines) - bodyH
 	if maxScroll < 0 {
 		maxScroll = 0
@@ -1499,6 +1612,8 @@ func (m terraformDetailModel) copyableContent() string {
 		if m.tfOutputJSON != "" {
 			return m.tfOutputJSON
 		}
+	case tabK8sEvents:
+		return k8sEventsCopyText(m.k8sEvents)
 	case tabWfErrors:
 		return workflowEventsCopyText(m.getTfWfEvents())
 	}
@@ -1532,7 +1647,11 @@ func (m terraformDetailModel) View() string {
 func (m terraformDetailModel) renderHeader() string {
 	style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("230")).Background(lipgloss.Color("62")).Padding(0, 1)
 	text := fmt.Sprintf("Resource Detail · %s · %s", m.node.Key, m.node.Type)
-	return lipgloss.Place(m.width, 1, lipgloss.Left, lipgloss.Top, style.Render(text))
+	header := style.Render(text)
+	if badge := renderConnectionBadge(m.connectionState()); badge != "" {
+		header += "  " + badge
+	}
+	return lipgloss.Place(m.width, 1, lipgloss.Left, lipgloss.Top, header)
 }
 
 func tabBorderWithBottom(left, middle, right string) lipgloss.Border {
@@ -1631,6 +1750,9 @@ func (m terraformDetailModel) getTabContent() string {
 	case tabLogs:
 		// Logs tab handles its own scrolling
 		return m.renderLogsTab()
+	case tabK8sEvents:
+		// Events tab handles its own scrolling
+		return m.renderK8sEventsTab()
 	case tabOpHistory:
 		// History tab handles its own scrolling
 		return m.renderOperationHistoryTab()
@@ -1670,17 +1792,23 @@ func (m terraformDetailModel) renderFooter() string {
 	if m.editingFile {
 		text = "ctrl+s: save to pod  esc: cancel edit  q: quit"
 	} else if m.viewingFile {
-		text = "esc: back to files  e: edit  ↑↓/pgup/pgdn: scroll  y: copy  q: quit"
+		text = "esc: back to files  e: edit  o: open in $EDITOR  ↑↓/pgup/pgdn: scroll  y: copy  q: quit"
 	} else if m.activeTab == tabTfFiles && m.fileTree != nil && len(m.fileTree.Flat) > 0 {
-		text = "↑↓: navigate  enter: open/expand  e: edit  s: shell  p: persist  r: refresh  tab: switch  esc: back  q: quit"
+		text = "↑↓: navigate  enter: open/expand  e: edit  o: open in $EDITOR  s: shell  p: persist  r: refresh  tab: switch  esc: back  q: quit"
 	} else if m.activeTab == tabTfOutput && len(m.outputTree) > 0 {
 		text = "↑↓: navigate  enter: expand/collapse  y: copy  tab/shift+tab: switch tabs  esc: back  q: quit"
+	} else if m.activeTab == tabLogs && m.logSearch.editing {
+		text = "type a regular expression  enter: search  esc: cancel"
 	} else if m.activeTab == tabLogs {
-		text = "↑↓/pgup/pgdn: scroll  f: toggle follow  y: copy  tab/shift+tab: switch tabs  esc: back  q: quit"
+		text = "↑↓/pgup/pgdn: scroll  /: search  n/N: next/prev match  f: toggle follow  a: all pods/operation logs  y: copy  tab/shift+tab: switch tabs  esc: back  q: quit"
+	} else if m.activeTab == tabK8sEvents {
+		text = "↑↓/pgup/pgdn: scroll  r: refresh  y: copy  tab/shift+tab: switch tabs  esc: back  q: quit"
 	} else if m.activeTab == tabOpHistory && len(m.historyDates) > 0 {
 		text = "↑↓: navigate  enter: expand/collapse  tab/shift+tab: switch tabs  esc: back  q: quit"
 	} else if m.activeTab == tabWfErrors {
 		text = "↑↓/pgup/pgdn: scroll  y: copy  tab/shift+tab: switch tabs  esc: back  q: quit"
+	} else if m.activeTab == tabProgress && m.tfProgress != nil && firstFailedResourceIndex(m.tfProgress.Resources) >= 0 {
+		text = "tab/shift+tab: switch tabs  ↑↓: scroll  F: jump to failed resource  esc: back  q: quit"
 	} else {
 		text = "tab/shift+tab: switch tabs  ↑↓: scroll  esc: back  q: quit"
 	}
@@ -1706,6 +1834,28 @@ func (m terraformDetailModel) renderProgressTab() string {
 		return fmt.Sprintf("\n  %s\n", subtleStyle.Render("No terraform progress data available for this resource."))
 	}
 
+	var b strings.Builder
+	b.WriteString(m.renderProgressSummary())
+
+	// Table header
+	addrStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	typeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	for _, res := range m.tfProgress.Resources {
+		icon := stateIcon(res.State)
+		sStyle := styleForStatus(res.State)
+		stateStr := sStyle.Render(fmt.Sprintf("%-12s", res.State))
+		addr := addrStyle.Render(res.Address)
+		resType := typeStyle.Render(res.Type)
+		fmt.Fprintf(&b, "  %s %s  %s  %s\n", icon, stateStr, addr, resType)
+	}
+
+	return b.String()
+}
+
+// renderProgressSummary renders the progress tab above the resource rows, so that the rows start at the line
+// after its last newline.
+func (m terraformDetailModel) renderProgressSummary() string {
 	var b strings.Builder
 	p := m.tfProgress
 
@@ -1768,20 +1918,30 @@ func (m terraformDetailModel) renderProgressTab() string {
 	fmt.Fprintf(&b, "  %s\n", headerStyle.Render(fmt.Sprintf("Resources (%d)", len(p.Resources))))
 	b.WriteString("\n")
 
-	// Table header
-	addrStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-	typeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	return b.String()
+}
 
-	for _, res := range p.Resources {
-		icon := stateIcon(res.State)
-		sStyle := styleForStatus(res.State)
-		stateStr := sStyle.Render(fmt.Sprintf("%-12s", res.State))
-		addr := addrStyle.Render(res.Address)
-		resType := typeStyle.Render(res.Type)
-		fmt.Fprintf(&b, "  %s %s  %s  %s\n", icon, stateStr, addr, resType)
+// firstFailedResourceIndex returns the index of the first resource in a failed or error state, or -1.
+func firstFailedResourceIndex(resources []TerraformResourceDetail) int {
+	for i, res := range resources {
+		switch strings.ToLower(res.State) {
+		case "failed", "error":
+			return i
+		}
 	}
+	return -1
+}
 
-	return b.String()
+// jumpToFirstFailedResource scrolls the progress tab to the row of the first failed resource.
+func (m *terraformDetailModel) jumpToFirstFailedResource() {
+	if m.loading || m.loadErr != nil || m.tfProgress == nil {
+		return
+	}
+	index := firstFailedResourceIndex(m.tfProgress.Resources)
+	if index < 0 {
+		return
+	}
+	m.scrollY = min(strings.Count(m.renderProgressSummary(), "\n")+index, m.progressMaxScroll())
 }
 
 type dateSection struct {
@@ -2446,9 +2606,9 @@ func (m terraformDetailModel) renderTerraformFilesTab() string {
 			pos = fmt.Sprintf("%d%%", pct)
 		}
 		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		fmt.Fprintf(&b, "\n  %s\n", dimStyle.Render(fmt.Sprintf("↑↓: navigate  enter: open/expand  e: edit  s: shell  p: persist  r: refresh  [%d/%d %s]", m.fileCursor+1, totalEntries, pos)))
+		fmt.Fprintf(&b, "\n  %s\n", dimStyle.Render(fmt.Sprintf("↑↓: navigate  enter: open/expand  e: edit  o: open in $EDITOR  s: shell  p: persist  r: refresh  [%d/%d %s]", m.fileCursor+1, totalEntries, pos)))
 	} else {
-		fmt.Fprintf(&b, "\n  %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("↑↓: navigate  enter: open/expand  e: edit  s: shell  p: persist  r: refresh"))
+		fmt.Fprintf(&b, "\n  %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("↑↓: navigate  enter: open/expand  e: edit  o: open in $EDITOR  s: shell  p: persist  r: refresh"))
 	}
 
 	return b.String()
@@ -2472,7 +2632,7 @@ func (m terraformDetailModel) renderFileContent() string {
 		entry := m.fileTree.Flat[m.fileCursor]
 		headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("117"))
 		fmt.Fprintf(&b, "  %s\n", headerStyle.Render(entry.RelPath))
-		fmt.Fprintf(&b, "  %s\n\n", lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("esc: back to file list  ↑↓/pgup/pgdn: scroll"))
+		fmt.Fprintf(&b, "  %s\n\n", lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("esc: back to file list  o: open in $EDITOR  ↑↓/pgup/pgdn: scroll"))
 		headerLines = 3
 	}
 
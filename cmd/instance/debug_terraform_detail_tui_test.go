@@ -1,7 +1,10 @@
 package instance
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/require"
@@ -13,6 +16,7 @@ func TestTerraformTabNames(t *testing.T) {
 	require.Equal(t, "Terraform Files", tabNames[tabTfFiles])
 	require.Equal(t, "Terraform Output", tabNames[tabTfOutput])
 	require.Equal(t, "Live Logs", tabNames[tabLogs])
+	require.Equal(t, "K8s Events", tabNames[tabK8sEvents])
 	require.Equal(t, "Operation History", tabNames[tabOpHistory])
 	require.Equal(t, "Workflow Events", tabNames[tabWfErrors])
 }
@@ -110,3 +114,146 @@ func TestIsProgressInFlightUsesExecutionState(t *testing.T) {
 		t.Fatal("expected completed execution state to override stale running progress")
 	}
 }
+
+func TestTerraformLogSearchJumpsToMatches(t *testing.T) {
+	model := newTerraformDetailModel(PlanDAGNode{}, DebugData{})
+	model.loading = false
+	model.width, model.height = 120, 40
+	model.activeTab = tabLogs
+	for i := range 100 {
+		line := fmt.Sprintf("step %d ok", i)
+		if i == 9 || i == 59 {
+			line = fmt.Sprintf("step %d Error: quota exceeded", i)
+		}
+		model.logLines = append(model.logLines, line)
+	}
+
+	press := func(m terraformDetailModel, msg tea.KeyMsg) terraformDetailModel {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		return updated.(terraformDetailModel)
+	}
+
+	model = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	require.True(t, model.logSearch.editing)
+	model = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("error")})
+	model = press(model, tea.KeyMsg{Type: tea.KeyEnter})
+
+	require.False(t, model.logSearch.editing)
+	require.Equal(t, []int{9, 59}, model.logSearch.matches)
+	require.Equal(t, 9-logSearchContextLines, model.logScroll)
+	require.False(t, model.logFollow)
+	require.Contains(t, model.renderLogSearchStatus(), "match 1/2")
+
+	model = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	require.Equal(t, 1, model.logSearch.current)
+	require.Equal(t, min(59-logSearchContextLines, model.logMaxScroll()), model.logScroll)
+
+	// Previous match wraps around past the first one
+	model = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	model = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	require.Equal(t, 1, model.logSearch.current)
+
+	// Esc clears the search before leaving the view
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Nil(t, cmd)
+	model = updated.(terraformDetailModel)
+	require.Nil(t, model.logSearch.pattern)
+	require.Empty(t, model.logSearch.matches)
+}
+
+func TestTerraformLogSearchInvalidPattern(t *testing.T) {
+	model := newTerraformDetailModel(PlanDAGNode{}, DebugData{})
+	model.activeTab = tabLogs
+	model.logLines = []string{"a", "b"}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("(")})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(terraformDetailModel)
+
+	require.Error(t, model.logSearch.err)
+	require.Nil(t, model.logSearch.pattern)
+}
+
+func TestHighlightLogMatches(t *testing.T) {
+	re, err := compileLogSearch("quota")
+	require.NoError(t, err)
+	got := highlightLogMatches("Error: QUOTA exceeded", re, false)
+	require.Contains(t, got, "QUOTA")
+	require.Contains(t, got, " exceeded")
+
+	re, err = compileLogSearch("  ")
+	require.NoError(t, err)
+	require.Nil(t, re)
+}
+
+func TestTerraformDetailConnectionState(t *testing.T) {
+	model := newTerraformDetailModel(PlanDAGNode{}, DebugData{})
+	model.loading = true
+	require.Equal(t, k8sConnUnknown, model.connectionState())
+	require.Empty(t, renderConnectionBadge(model.connectionState()))
+
+	model.loading = false
+	require.Equal(t, k8sConnDisconnected, model.connectionState(), "no kubernetes connection")
+
+	model.k8sConn = &k8sConnections{}
+	require.Equal(t, k8sConnConnected, model.connectionState())
+
+	updatedAny, _ := model.Update(logStreamDoneMsg{err: fmt.Errorf("connection refused")})
+	updated := updatedAny.(terraformDetailModel)
+	require.Equal(t, k8sConnConnected, updated.connectionState(), "the log stream is only restarted on the dataplane")
+
+	updated.logReconnecting = true
+	require.Equal(t, k8sConnReconnecting, updated.connectionState())
+	updatedAny, _ = updated.Update(logLineMsg{})
+	updated = updatedAny.(terraformDetailModel)
+	require.Equal(t, k8sConnConnected, updated.connectionState(), "a fetch from the restarted stream clears reconnecting")
+
+	for i := 0; i < k8sConnDisconnectedAfterErrors; i++ {
+		updatedAny, _ = updated.Update(progressRefreshMsg{err: fmt.Errorf("timeout")})
+		updated = updatedAny.(terraformDetailModel)
+		if i < k8sConnDisconnectedAfterErrors-1 {
+			require.Equal(t, k8sConnReconnecting, updated.connectionState())
+		}
+	}
+	require.Equal(t, k8sConnDisconnected, updated.connectionState())
+	require.Contains(t, renderConnectionBadge(updated.connectionState()), "disconnected")
+
+	updatedAny, _ = updated.Update(progressRefreshMsg{})
+	updated = updatedAny.(terraformDetailModel)
+	require.Equal(t, k8sConnConnected, updated.connectionState())
+}
+
+func TestValidateDebugPollInterval(t *testing.T) {
+	require.NoError(t, validateDebugPollInterval("log-poll-interval", time.Second))
+	require.NoError(t, validateDebugPollInterval("log-poll-interval", defaultLogPollInterval))
+	require.ErrorContains(t, validateDebugPollInterval("progress-refresh-interval", 500*time.Millisecond), "--progress-refresh-interval must be at least 1s")
+	require.Error(t, validateDebugPollInterval("progress-refresh-interval", 0))
+	require.ErrorContains(t, validateDebugPollInterval("refresh-interval", time.Nanosecond), "--refresh-interval must be at least 1s")
+}
+
+func TestTerraformDetailJumpToFirstFailedResource(t *testing.T) {
+	model := newTerraformDetailModel(PlanDAGNode{}, DebugData{})
+	model.loading = false
+	model.width = 120
+	model.height = 20
+	resources := make([]TerraformResourceDetail, 0, 40)
+	for i := 0; i < 40; i++ {
+		resources = append(resources, TerraformResourceDetail{Address: fmt.Sprintf("aws_instance.web[%d]", i), Type: "aws_instance", State: "ready"})
+	}
+	resources[25].State = "failed"
+	resources[30].State = "error"
+	model.tfProgress = &TerraformProgressData{Status: "failed", Resources: resources}
+
+	require.Equal(t, 25, firstFailedResourceIndex(resources))
+	require.Contains(t, model.renderFooter(), "F: jump to failed resource")
+
+	updatedAny, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	updated := updatedAny.(terraformDetailModel)
+	require.Greater(t, updated.scrollY, 0)
+	require.Contains(t, updated.getTabContent(), "aws_instance.web[25]")
+	require.Contains(t, strings.SplitN(updated.getTabContent(), "\n", 2)[0], "aws_instance.web[25]", "the failed resource is the first visible row")
+
+	require.Equal(t, -1, firstFailedResourceIndex(resources[:25]))
+}
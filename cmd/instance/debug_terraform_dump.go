@@ -0,0 +1,183 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+)
+
+// terraformWorkspaceCandidate is a pod and path where the terraform files of a resource may be found
+type terraformWorkspaceCandidate struct {
+	conn     *k8sConnection
+	podName  string
+	basePath string
+}
+
+// defaultTerraformWorkspaceCandidates returns the default executor pod workspaces of every operation, on both the
+// dataplane and control-plane clusters.
+func defaultTerraformWorkspaceCandidates(conn *k8sConnections, progress *TerraformProgressData) []terraformWorkspaceCandidate {
+	if conn == nil || progress == nil || progress.TerraformName == "" {
+		return nil
+	}
+	var candidates []terraformWorkspaceCandidate
+	defaultPodName := terraformExecutorPodName(progress.TerraformName)
+	for _, c := range []*k8sConnection{conn.dataplane, conn.controlPlane} {
+		if c == nil {
+			continue
+		}
+		for _, op := range []string{"apply", "diff", "output"} {
+			candidates = append(candidates, terraformWorkspaceCandidate{
+				conn:     c,
+				podName:  defaultPodName,
+				basePath: terraformFilesBasePath(progress.TerraformName, progress.InstanceID, op),
+			})
+		}
+	}
+	return candidates
+}
+
+// fetchFirstTerraformFileTree returns the file tree of the first candidate workspace that has files, or nil.
+func fetchFirstTerraformFileTree(ctx context.Context, candidates []terraformWorkspaceCandidate) *TerraformFileTree {
+	for _, candidate := range candidates {
+		if candidate.conn == nil || candidate.podName == "" || candidate.basePath == "" {
+			continue
+		}
+		tree, err := fetchTerraformFileTree(ctx, candidate.conn, terraformConfigMapNamespace, candidate.podName, candidate.basePath)
+		if err == nil && tree != nil && len(tree.Flat) > 0 {
+			tree.conn = candidate.conn
+			return tree
+		}
+	}
+	return nil
+}
+
+// terraformWorkspaceCandidatesForResource returns the workspace recorded in the execution state of the resource
+// first, then the default workspaces.
+func terraformWorkspaceCandidatesForResource(ctx context.Context, conn *k8sConnections, instanceID, resourceID string, progress *TerraformProgressData) []terraformWorkspaceCandidate {
+	if conn == nil {
+		return nil
+	}
+	var candidates []terraformWorkspaceCandidate
+	for _, c := range []*k8sConnection{conn.dataplane, conn.controlPlane} {
+		if c == nil {
+			continue
+		}
+		index, err := loadTerraformConfigMapIndex(ctx, c.clientset, instanceID)
+		if err != nil || index == nil {
+			continue
+		}
+		stateData := extractTerraformStateData(index, instanceID, resourceID)
+		if stateData != nil && stateData.ExecutionState.hasWorkspace() {
+			candidates = append(candidates, terraformWorkspaceCandidate{
+				conn:     c,
+				podName:  stateData.ExecutionState.PodName,
+				basePath: stateData.ExecutionState.TerraformFilesPath,
+			})
+		}
+	}
+	return append(candidates, defaultTerraformWorkspaceCandidates(conn, progress)...)
+}
+
+// runDebugDumpTerraformFiles writes every file of the terraform workspace of a resource, as found on the live
+// executor pod, under dir.
+func runDebugDumpTerraformFiles(ctx context.Context, instanceID, token, resourceKey, dir string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	serviceID, environmentID, _, _, err := getInstance(ctx, token, instanceID)
+	if err != nil {
+		return fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	instanceData, err := fetchInstanceDataForResource(ctx, token, serviceID, environmentID, instanceID)
+	if err != nil {
+		return err
+	}
+
+	resourceIndex, err := buildResourceIndex(ctx, token, serviceID, instanceData, false)
+	if err != nil {
+		return fmt.Errorf("failed to build resource indexes: %w", err)
+	}
+	if !resourceIndex.isTerraformKey(resourceKey) {
+		return fmt.Errorf("resource '%s' is not a terraform resource of instance %s", resourceKey, instanceID)
+	}
+	resourceID, ok := resourceIndex.resourceIDForKey(resourceKey)
+	if !ok {
+		return fmt.Errorf("resource '%s' not found in instance %s", resourceKey, instanceID)
+	}
+
+	progress, _, conn, err := fetchTerraformProgress(ctx, token, instanceData, instanceID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	tree := fetchFirstTerraformFileTree(ctx, terraformWorkspaceCandidatesForResource(ctx, conn, instanceID, resourceID, progress))
+	if tree == nil {
+		return fmt.Errorf("no terraform executor pod with files found for resource '%s'", resourceKey)
+	}
+
+	count, err := writeTerraformFileTree(tree.Root, dir, func(filePath string) (string, error) {
+		return fetchFileContentFromPod(ctx, tree.conn, tree.Namespace, tree.PodName, filePath)
+	})
+	if err != nil {
+		return err
+	}
+
+	utils.PrintSuccess(fmt.Sprintf("Saved %d terraform files of resource %s from pod %s to %s", count, resourceKey, tree.PodName, dir))
+	return nil
+}
+
+// writeTerraformFileTree writes the files under entry to dir, preserving the tree, and returns the number of files
+// written. fetch returns the content of a file by its path in the pod.
+func writeTerraformFileTree(entry *TerraformFileEntry, dir string, fetch func(filePath string) (string, error)) (int, error) {
+	if entry == nil {
+		return 0, nil
+	}
+
+	target, err := terraformDumpPath(dir, entry.RelPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if !entry.IsDir {
+		content, err := fetch(entry.Path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read %s: %w", entry.RelPath, err)
+		}
+		if err = os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return 0, fmt.Errorf("failed to create directory for %s: %w", entry.RelPath, err)
+		}
+		if err = os.WriteFile(target, []byte(content), 0600); err != nil {
+			return 0, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		return 1, nil
+	}
+
+	if err = os.MkdirAll(target, 0700); err != nil {
+		return 0, fmt.Errorf("failed to create directory %s: %w", target, err)
+	}
+	count := 0
+	for _, child := range entry.Children {
+		n, err := writeTerraformFileTree(child, dir, fetch)
+		if err != nil {
+			return count, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// terraformDumpPath returns the local path of a workspace file under dir, refusing paths that escape it.
+func terraformDumpPath(dir, relPath string) (string, error) {
+	cleaned := path.Clean(relPath)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("refusing to write %s outside of %s", relPath, dir)
+	}
+	return filepath.Join(dir, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,45 @@
+package instance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTerraformFileTreePreservesTree(t *testing.T) {
+	require := require.New(t)
+
+	root := &TerraformFileEntry{Path: "/tmp/tf", RelPath: ".", IsDir: true, Children: []*TerraformFileEntry{
+		{Path: "/tmp/tf/modules", RelPath: "modules", IsDir: true, Children: []*TerraformFileEntry{
+			{Path: "/tmp/tf/modules/vpc.tf", RelPath: "modules/vpc.tf"},
+		}},
+		{Path: "/tmp/tf/main.tf", RelPath: "main.tf"},
+		{Path: "/tmp/tf/empty", RelPath: "empty", IsDir: true},
+	}}
+
+	dir := t.TempDir()
+	count, err := writeTerraformFileTree(root, dir, func(filePath string) (string, error) {
+		return "# " + filePath, nil
+	})
+	require.NoError(err)
+	require.Equal(2, count)
+
+	content, err := os.ReadFile(filepath.Join(dir, "modules", "vpc.tf"))
+	require.NoError(err)
+	require.Equal("# /tmp/tf/modules/vpc.tf", string(content))
+	require.FileExists(filepath.Join(dir, "main.tf"))
+	require.DirExists(filepath.Join(dir, "empty"))
+}
+
+func TestTerraformDumpPathRefusesEscapes(t *testing.T) {
+	_, err := terraformDumpPath("out", "../etc/passwd")
+	require.Error(t, err)
+	_, err = terraformDumpPath("out", "/etc/passwd")
+	require.Error(t, err)
+
+	p, err := terraformDumpPath("out", "modules/../main.tf")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("out", "main.tf"), p)
+}
@@ -42,6 +42,9 @@ type TerraformFileTree struct {
 	conn      *k8sConnection        // the k8s connection where the executor pod was found
 }
 
+// terraformExecutorContainer is the container of the terraform executor pod that commands run in
+const terraformExecutorContainer = "terraform-executor"
+
 // terraformExecutorPodName builds the pod name for the terraform executor
 func terraformExecutorPodName(terraformName string) string {
 	return "tf-executor-" + terraformName
@@ -73,7 +76,7 @@ func execInPodWithStreams(
 		return "", fmt.Errorf("kubernetes connection is not available")
 	}
 	if container == "" {
-		container = "terraform-executor"
+		container = terraformExecutorContainer
 	}
 	req := conn.clientset.CoreV1().RESTClient().Post().
 		Resource("pods").
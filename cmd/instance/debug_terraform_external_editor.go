@@ -0,0 +1,88 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// externalEditorFileMsg is sent when the content of a file to open in the external editor has been fetched
+type externalEditorFileMsg struct {
+	path    string
+	content string
+	err     error
+}
+
+// externalEditorFinishedMsg is sent when the external editor exits
+type externalEditorFinishedMsg struct {
+	path    string
+	changed bool
+	err     error
+}
+
+// externalEditorCommand returns the command line of the user's editor: $VISUAL, then $EDITOR, falling back to vi.
+func externalEditorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// writeExternalEditorFile writes content to a temp file ending with the file's base name, so editors pick the
+// right syntax highlighting.
+func writeExternalEditorFile(filePath, content string) (string, error) {
+	f, err := os.CreateTemp("", "omnistrate-tf-*-"+filepath.Base(filePath))
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err = f.WriteString(content); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// openInExternalEditor suspends the TUI, opens a local copy of a file in the user's editor and resumes when the
+// editor exits. The editor gets the terminal, so TTY editors like vi work. The copy is removed afterwards.
+func openInExternalEditor(filePath, content string) tea.Cmd {
+	tmpPath, err := writeExternalEditorFile(filePath, content)
+	if err != nil {
+		return func() tea.Msg {
+			return externalEditorFinishedMsg{path: filePath, err: err}
+		}
+	}
+	args := externalEditorCommand()
+	cmd := exec.Command(args[0], append(args[1:], tmpPath)...) //nolint:gosec // the editor is chosen by the user
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(tmpPath)
+		edited, readErr := os.ReadFile(tmpPath)
+		return externalEditorFinishedMsg{
+			path:    filePath,
+			changed: readErr == nil && string(edited) != content,
+			err:     err,
+		}
+	})
+}
+
+func (m terraformDetailModel) fetchFileForExternalEditor(filePath string) tea.Cmd {
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		c := m.k8sConn.dataplane
+		if m.fileTree.conn != nil {
+			c = m.fileTree.conn
+		}
+		content, err := fetchFileContentFromPod(context.Background(), c, m.fileTree.Namespace, m.fileTree.PodName, filePath)
+		return externalEditorFileMsg{path: filePath, content: content, err: err}
+	})
+}
@@ -0,0 +1,35 @@
+package instance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	require.Equal(t, []string{"vi"}, externalEditorCommand())
+
+	t.Setenv("EDITOR", "code --wait")
+	require.Equal(t, []string{"code", "--wait"}, externalEditorCommand())
+
+	t.Setenv("VISUAL", "nvim")
+	require.Equal(t, []string{"nvim"}, externalEditorCommand())
+}
+
+func TestWriteExternalEditorFileKeepsFileName(t *testing.T) {
+	require := require.New(t)
+
+	tmpPath, err := writeExternalEditorFile("/workspace/tf-r-abc/main.tf", "resource \"null_resource\" \"x\" {}\n")
+	require.NoError(err)
+	defer os.Remove(tmpPath)
+
+	require.True(strings.HasSuffix(filepath.Base(tmpPath), "-main.tf"))
+	content, err := os.ReadFile(tmpPath)
+	require.NoError(err)
+	require.Equal("resource \"null_resource\" \"x\" {}\n", string(content))
+}
@@ -33,7 +33,7 @@ type TerraformFilesResource struct {
 }
 
 func runDebugTerraformFiles(cmd *cobra.Command, args []string) error {
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	resourceIDFilter, err := cmd.Flags().GetString("resource-id")
 	if err != nil {
@@ -0,0 +1,199 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// k8sEventsFetchTimeout bounds listing the events of both clusters.
+const k8sEventsFetchTimeout = 15 * time.Second
+
+// k8sEvent is a Kubernetes event shown in the K8s Events tab.
+type k8sEvent struct {
+	Type     string // "Normal" or "Warning"
+	Reason   string
+	Object   string // kind/name of the involved object
+	Message  string
+	Count    int32
+	LastSeen time.Time
+}
+
+// k8sEventsMsg is sent when the events of the resource's namespace have been listed.
+type k8sEventsMsg struct {
+	events []k8sEvent
+	err    error
+}
+
+// listK8sEvents lists the events in namespace on the dataplane and control-plane clusters, newest first.
+// Only events of objects whose name starts with objectPrefix are kept, unless objectPrefix is empty.
+func listK8sEvents(ctx context.Context, conns *k8sConnections, namespace, objectPrefix string) ([]k8sEvent, error) {
+	if conns == nil {
+		return nil, fmt.Errorf("no kubernetes connection available")
+	}
+
+	var events []k8sEvent
+	var errs []string
+	for _, c := range []*k8sConnection{conns.dataplane, conns.controlPlane} {
+		if c == nil {
+			continue
+		}
+		list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		for _, ev := range list.Items {
+			if objectPrefix != "" && !strings.HasPrefix(ev.InvolvedObject.Name, objectPrefix) {
+				continue
+			}
+			events = append(events, newK8sEvent(ev))
+		}
+	}
+	if len(events) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("failed to list events in namespace %s: %s", namespace, strings.Join(errs, "; "))
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.After(events[j].LastSeen)
+	})
+	return events, nil
+}
+
+func newK8sEvent(ev corev1.Event) k8sEvent {
+	lastSeen := ev.LastTimestamp.Time
+	if lastSeen.IsZero() {
+		lastSeen = ev.EventTime.Time
+	}
+	if lastSeen.IsZero() {
+		lastSeen = ev.CreationTimestamp.Time
+	}
+
+	count := ev.Count
+	if count == 0 && ev.Series != nil {
+		count = ev.Series.Count
+	}
+
+	return k8sEvent{
+		Type:     ev.Type,
+		Reason:   ev.Reason,
+		Object:   strings.ToLower(ev.InvolvedObject.Kind) + "/" + ev.InvolvedObject.Name,
+		Message:  strings.TrimSpace(ev.Message),
+		Count:    count,
+		LastSeen: lastSeen,
+	}
+}
+
+// k8sEventsObjectPrefix returns the name of the terraform executor pod, whose events are shown in the K8s Events tab.
+func (m terraformDetailModel) k8sEventsObjectPrefix() string {
+	if m.fileTree != nil && m.fileTree.PodName != "" {
+		return m.fileTree.PodName
+	}
+	if m.tfExecutionState.PodName != "" {
+		return m.tfExecutionState.PodName
+	}
+	if m.tfProgress != nil && m.tfProgress.TerraformName != "" {
+		return terraformExecutorPodName(m.tfProgress.TerraformName)
+	}
+	return ""
+}
+
+func (m terraformDetailModel) fetchK8sEvents() tea.Cmd {
+	conns := m.k8sConn
+	prefix := m.k8sEventsObjectPrefix()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), k8sEventsFetchTimeout)
+		defer cancel()
+
+		events, err := listK8sEvents(ctx, conns, terraformConfigMapNamespace, prefix)
+		return k8sEventsMsg{events: events, err: err}
+	}
+}
+
+func (m terraformDetailModel) k8sEventsMaxScroll() int {
+	maxScroll := len(m.k8sEvents) - (m.bodyHeight() - 4)
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	return maxScroll
+}
+
+func (m terraformDetailModel) renderK8sEventsTab() string {
+	if m.loading || (m.k8sEventsLoading && m.k8sEvents == nil) {
+		return fmt.Sprintf("\n  %s Fetching kubernetes events...", m.spinner.View())
+	}
+	subtleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	if m.k8sConn == nil {
+		return fmt.Sprintf("\n  %s\n", subtleStyle.Render("No kubernetes connection available for this resource."))
+	}
+	if m.k8sEventsErr != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+		return fmt.Sprintf("\n  %s\n", errStyle.Render(fmt.Sprintf("Error: %v", m.k8sEventsErr)))
+	}
+	if len(m.k8sEvents) == 0 {
+		return fmt.Sprintf("\n  %s\n", subtleStyle.Render("No recent kubernetes events for this resource."))
+	}
+
+	var b strings.Builder
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))
+	scope := terraformConfigMapNamespace
+	if prefix := m.k8sEventsObjectPrefix(); prefix != "" {
+		scope += " · " + prefix
+	}
+	fmt.Fprintf(&b, "  %s  %s\n\n",
+		headerStyle.Render(fmt.Sprintf("Kubernetes Events (%d)", len(m.k8sEvents))),
+		subtleStyle.Render(scope),
+	)
+
+	bodyH := m.bodyHeight() - 4
+	if bodyH < 1 {
+		bodyH = 1
+	}
+	scroll := min(m.k8sEventsScroll, m.k8sEventsMaxScroll())
+	end := min(scroll+bodyH, len(m.k8sEvents))
+
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
+	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	maxMsgWidth := m.contentWidth() - 60
+	if maxMsgWidth < 20 {
+		maxMsgWidth = 20
+	}
+
+	for _, ev := range m.k8sEvents[scroll:end] {
+		typeStyle := normalStyle
+		if ev.Type == corev1.EventTypeWarning {
+			typeStyle = warningStyle
+		}
+		message := ev.Message
+		if ev.Count > 1 {
+			message = fmt.Sprintf("%s (x%d)", message, ev.Count)
+		}
+		fmt.Fprintf(&b, "  %s  %s  %-22s %-28s %s\n",
+			timeStyle.Render(fmt.Sprintf("%-8s", formatRelativeTime(ev.LastSeen, debugNow()))),
+			typeStyle.Render(fmt.Sprintf("%-7s", ev.Type)),
+			truncateValue(ev.Reason, 22),
+			truncateValue(ev.Object, 28),
+			truncateValue(message, maxMsgWidth),
+		)
+	}
+
+	return b.String()
+}
+
+// k8sEventsCopyText returns the events as plain text for clipboard copying.
+func k8sEventsCopyText(events []k8sEvent) string {
+	var b strings.Builder
+	for _, ev := range events {
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			ev.LastSeen.UTC().Format(time.RFC3339), ev.Type, ev.Reason, ev.Object, ev.Count, ev.Message)
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package instance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testK8sEvent(name, namespace, object, eventType, reason string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: object},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        reason + " message",
+		Count:          1,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
+
+func TestListK8sEvents(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2026, 6, 8, 15, 0, 0, 0, time.UTC)
+	podName := terraformExecutorPodName("tf-r-abc")
+	conns := &k8sConnections{
+		dataplane: &k8sConnection{clientset: fake.NewClientset(
+			testK8sEvent("e1", terraformConfigMapNamespace, podName, corev1.EventTypeNormal, "Scheduled", now.Add(-5*time.Minute)),
+			testK8sEvent("e2", terraformConfigMapNamespace, podName, corev1.EventTypeWarning, "BackOff", now.Add(-time.Minute)),
+			testK8sEvent("e3", terraformConfigMapNamespace, "other-pod", corev1.EventTypeWarning, "Failed", now),
+			testK8sEvent("e4", "default", podName, corev1.EventTypeNormal, "Pulled", now),
+		)},
+		controlPlane: &k8sConnection{clientset: fake.NewClientset(
+			testK8sEvent("e5", terraformConfigMapNamespace, podName, corev1.EventTypeNormal, "Started", now.Add(-3*time.Minute)),
+		)},
+	}
+
+	events, err := listK8sEvents(context.Background(), conns, terraformConfigMapNamespace, podName)
+	require.NoError(err)
+	require.Len(events, 3)
+	require.Equal([]string{"BackOff", "Started", "Scheduled"}, []string{events[0].Reason, events[1].Reason, events[2].Reason})
+	require.Equal(corev1.EventTypeWarning, events[0].Type)
+	require.Equal("pod/"+podName, events[0].Object)
+
+	// Without an object prefix every event in the namespace is listed
+	events, err = listK8sEvents(context.Background(), conns, terraformConfigMapNamespace, "")
+	require.NoError(err)
+	require.Len(events, 4)
+	require.Equal("Failed", events[0].Reason)
+
+	_, err = listK8sEvents(context.Background(), nil, terraformConfigMapNamespace, "")
+	require.Error(err)
+}
+
+func TestNewK8sEventFallsBackToEventTime(t *testing.T) {
+	eventTime := time.Date(2026, 6, 8, 15, 0, 0, 0, time.UTC)
+	ev := newK8sEvent(corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Kind: "Job", Name: "tf-job"},
+		Type:           corev1.EventTypeNormal,
+		EventTime:      metav1.NewMicroTime(eventTime),
+		Series:         &corev1.EventSeries{Count: 4},
+	})
+
+	require.True(t, ev.LastSeen.Equal(eventTime))
+	require.Equal(t, int32(4), ev.Count)
+	require.Equal(t, "job/tf-job", ev.Object)
+}
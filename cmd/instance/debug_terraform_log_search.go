@@ -0,0 +1,216 @@
+package instance
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logSearchContextLines is the number of lines shown above a match when jumping to it.
+const logSearchContextLines = 3
+
+// logSearchState holds the / search of the Live Logs tab.
+type logSearchState struct {
+	input   textinput.Model
+	editing bool
+	pattern *regexp.Regexp
+	err     error
+	matches []int // indexes of the log lines matching pattern
+	current int   // index into matches of the match jumped to
+}
+
+func newLogSearchState() *logSearchState {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "regular expression"
+	input.CharLimit = 256
+	return &logSearchState{input: input}
+}
+
+// compileLogSearch compiles a search query as a case-insensitive regular expression. An empty query clears the search.
+func compileLogSearch(query string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile("(?i)" + query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search pattern: %w", err)
+	}
+	return re, nil
+}
+
+// findLogMatches returns the indexes of the lines matching re.
+func findLogMatches(lines []string, re *regexp.Regexp) []int {
+	if re == nil {
+		return nil
+	}
+	var matches []int
+	for i, line := range lines {
+		if re.MatchString(line) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// updateLogSearchKey handles keys while the search pattern is being typed.
+func (m terraformDetailModel) updateLogSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	search := m.logSearch
+	switch msg.String() {
+	case "ctrl+c":
+		if m.logCancel != nil {
+			m.logCancel()
+		}
+		return m, tea.Quit
+	case "esc":
+		search.editing = false
+		search.input.Blur()
+		return m, nil
+	case "enter":
+		search.editing = false
+		search.input.Blur()
+		re, err := compileLogSearch(search.input.Value())
+		search.err = err
+		if err != nil {
+			return m, nil
+		}
+		search.pattern = re
+		search.matches = findLogMatches(m.logLines, re)
+		search.current = 0
+		if len(search.matches) > 0 {
+			// Start from the first match at or below the top of the view
+			top := m.logSourceLineAt(m.logScroll)
+			for i, line := range search.matches {
+				if line >= top {
+					search.current = i
+					break
+				}
+			}
+			m.jumpToLogMatch()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	search.input, cmd = search.input.Update(msg)
+	return m, cmd
+}
+
+// stepLogMatch moves to the next (delta 1) or previous (delta -1) match, wrapping around.
+func (m *terraformDetailModel) stepLogMatch(delta int) {
+	search := m.logSearch
+	if len(search.matches) == 0 {
+		return
+	}
+	search.current = (search.current + delta + len(search.matches)) % len(search.matches)
+	m.jumpToLogMatch()
+}
+
+// jumpToLogMatch scrolls the logs so the current match is shown near the top, and stops following.
+func (m *terraformDetailModel) jumpToLogMatch() {
+	search := m.logSearch
+	if search.current < 0 || search.current >= len(search.matches) {
+		return
+	}
+	target := search.matches[search.current] + 1
+	for i, vl := range expandLinesToVisual(m.logLines, m.logCodeWidth()) {
+		if vl.sourceNum == target {
+			m.logFollow = false
+			m.logScroll = max(0, min(i-logSearchContextLines, m.logMaxScroll()))
+			return
+		}
+	}
+}
+
+// logSourceLineAt returns the index of the log line shown at the given visual line.
+func (m terraformDetailModel) logSourceLineAt(visual int) int {
+	source := 0
+	for i, vl := range expandLinesToVisual(m.logLines, m.logCodeWidth()) {
+		if i > visual {
+			break
+		}
+		if vl.sourceNum > 0 {
+			source = vl.sourceNum - 1
+		}
+	}
+	return source
+}
+
+// refreshLogMatches recomputes the matches after log lines were added or replaced, keeping the current match.
+func (m *terraformDetailModel) refreshLogMatches() {
+	search := m.logSearch
+	if search.pattern == nil {
+		return
+	}
+	currentLine := -1
+	if search.current < len(search.matches) {
+		currentLine = search.matches[search.current]
+	}
+	search.matches = findLogMatches(m.logLines, search.pattern)
+	search.current = 0
+	for i, line := range search.matches {
+		if line == currentLine {
+			search.current = i
+			break
+		}
+	}
+}
+
+// clearLogSearch drops the search pattern and its matches.
+func (m *terraformDetailModel) clearLogSearch() {
+	m.logSearch.pattern = nil
+	m.logSearch.matches = nil
+	m.logSearch.current = 0
+	m.logSearch.err = nil
+	m.logSearch.input.SetValue("")
+}
+
+// renderLogSearchStatus renders the search input while typing, or the match position of the current search.
+func (m terraformDetailModel) renderLogSearchStatus() string {
+	search := m.logSearch
+	if search.editing {
+		return "  " + search.input.View()
+	}
+	if search.err != nil {
+		return "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render(search.err.Error())
+	}
+	if search.pattern == nil {
+		return ""
+	}
+	query := search.input.Value()
+	if len(search.matches) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render(fmt.Sprintf("  /%s: no matches", query))
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render(
+		fmt.Sprintf("  /%s: match %d/%d", query, search.current+1, len(search.matches)))
+}
+
+// highlightLogMatches renders a log line with the parts matching re highlighted.
+func highlightLogMatches(line string, re *regexp.Regexp, current bool) string {
+	locs := re.FindAllStringIndex(line, -1)
+	if len(locs) == 0 {
+		return highlightLogLine(line)
+	}
+
+	matchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("220"))
+	if current {
+		matchStyle = matchStyle.Background(lipgloss.Color("208")).Bold(true)
+	}
+
+	var b strings.Builder
+	prev := 0
+	for _, loc := range locs {
+		if loc[0] == loc[1] {
+			continue
+		}
+		b.WriteString(line[prev:loc[0]])
+		b.WriteString(matchStyle.Render(line[loc[0]:loc[1]]))
+		prev = loc[1]
+	}
+	b.WriteString(line[prev:])
+	return b.String()
+}
@@ -16,15 +16,20 @@ import (
 type logLineMsg struct {
 	lines   []string
 	label   string
-	replace bool // if true, replace all existing lines instead of appending
+	replace bool            // if true, replace all existing lines instead of appending
+	ch      chan logLineMsg // set by waitForLogLines to the channel the batch was read from
 }
 
 // logStreamDoneMsg signals the log polling has ended
 type logStreamDoneMsg struct {
 	err error
+	ch  chan logLineMsg // the channel of the ended stream, when known
 }
 
-const logPollInterval = 3 * time.Second
+const defaultLogPollInterval = 3 * time.Second
+
+// logPollInterval is the interval between log polls, set from --log-poll-interval.
+var logPollInterval = defaultLogPollInterval
 
 // findLatestOperationID finds the latest operation ID that has an apply or destroy log.
 func findLatestApplyDestroyOperationID(cmData map[string]string, history []TerraformHistoryEntry) string {
@@ -161,6 +166,7 @@ func watchApplyDestroyLogs(ctx context.Context, conn *k8sConnection, instanceID,
 	return func() tea.Msg {
 		var prevLines []string
 		prevOpID := ""
+		reported := false
 
 		for {
 			lines, label, opID, newHistory, err := fetchLogsFromConfigMap(ctx, conn, instanceID, resourceID, history)
@@ -221,7 +227,16 @@ func watchApplyDestroyLogs(ctx context.Context, conn *k8sConnection, instanceID,
 					prevLines = lines
 				}
 				prevOpID = opID
+			} else if !reported {
+				// Report the first successful fetch even without lines, so the view knows the stream is healthy
+				select {
+				case ch <- logLineMsg{}:
+				case <-ctx.Done():
+					close(ch)
+					return logStreamDoneMsg{}
+				}
 			}
+			reported = true
 
 			select {
 			case <-ctx.Done():
@@ -251,8 +266,9 @@ func waitForLogLines(ch chan logLineMsg) tea.Cmd {
 	return func() tea.Msg {
 		msg, ok := <-ch
 		if !ok {
-			return logStreamDoneMsg{}
+			return logStreamDoneMsg{ch: ch}
 		}
+		msg.ch = ch
 		return msg
 	}
 }
@@ -268,6 +284,9 @@ func (m terraformDetailModel) renderLogsTab() string {
 	}
 	if !m.logStreaming && !m.logDone && len(m.logLines) == 0 {
 		subtleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+		if m.logAllPods {
+			return fmt.Sprintf("\n  %s\n", subtleStyle.Render("No executor pod logs available for this resource."))
+		}
 		return fmt.Sprintf("\n  %s\n", subtleStyle.Render("No operation logs available for this resource."))
 	}
 
@@ -289,11 +308,16 @@ func (m terraformDetailModel) renderLogsTab() string {
 	if m.logLabel != "" {
 		labelText = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("  " + m.logLabel)
 	}
-	fmt.Fprintf(&b, "  %s%s%s%s\n\n",
-		headerStyle.Render(fmt.Sprintf("Operation Logs (%d lines)", len(m.logLines))),
+	title := "Operation Logs"
+	if m.logAllPods {
+		title = "Executor Pod Logs"
+	}
+	fmt.Fprintf(&b, "  %s%s%s%s%s\n\n",
+		headerStyle.Render(fmt.Sprintf("%s (%d lines)", title, len(m.logLines))),
 		statusText,
 		followText,
 		labelText,
+		m.renderLogSearchStatus(),
 	)
 
 	bodyH := m.bodyHeight() - 4
@@ -302,13 +326,9 @@ func (m terraformDetailModel) renderLogsTab() string {
 	}
 
 	lineNumStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	maxCodeWidth := m.contentWidth() - 9
-	if maxCodeWidth < 20 {
-		maxCodeWidth = 20
-	}
 
 	// Expand all source lines into visual lines with wrapping
-	vlines := expandLinesToVisual(m.logLines, maxCodeWidth)
+	vlines := expandLinesToVisual(m.logLines, m.logCodeWidth())
 	totalLines := len(vlines)
 
 	// Scroll position is managed in Update()
@@ -330,11 +350,36 @@ func (m terraformDetailModel) renderLogsTab() string {
 		end = totalLines
 	}
 
+	// Source line of the current search match, and of the first visible line (which may be a wrapped continuation)
+	currentMatch := 0
+	if m.logSearch.pattern != nil && m.logSearch.current < len(m.logSearch.matches) {
+		currentMatch = m.logSearch.matches[m.logSearch.current] + 1
+	}
+	source := 0
+	for i := min(scroll, totalLines-1); i >= 0; i-- {
+		if vlines[i].sourceNum > 0 {
+			source = vlines[i].sourceNum
+			break
+		}
+	}
+	currentMatchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true)
+
 	for i := scroll; i < end; i++ {
 		vl := vlines[i]
-		styled := highlightLogLine(vl.text)
+		if vl.sourceNum > 0 {
+			source = vl.sourceNum
+		}
+		var styled string
+		if m.logSearch.pattern != nil {
+			styled = highlightLogMatches(vl.text, m.logSearch.pattern, source == currentMatch)
+		} else {
+			styled = highlightLogLine(vl.text)
+		}
 		if vl.sourceNum > 0 {
 			lineNum := lineNumStyle.Render(fmt.Sprintf("%4d", vl.sourceNum))
+			if vl.sourceNum == currentMatch {
+				lineNum = currentMatchStyle.Render(fmt.Sprintf("%4d", vl.sourceNum))
+			}
 			fmt.Fprintf(&b, "  %s │ %s\n", lineNum, styled)
 		} else {
 			fmt.Fprintf(&b, "  %s   %s\n", "    ", styled)
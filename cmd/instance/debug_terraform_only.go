@@ -0,0 +1,154 @@
+package instance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// terraformPlanNodes returns the terraform resources of the plan, sorted by key.
+func terraformPlanNodes(plan *PlanDAG) []PlanDAGNode {
+	if plan == nil {
+		return nil
+	}
+	var nodes []PlanDAGNode
+	for _, node := range plan.Nodes {
+		if strings.Contains(strings.ToLower(node.Type), "terraform") {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return terraformNodeLabel(nodes[i]) < terraformNodeLabel(nodes[j])
+	})
+	return nodes
+}
+
+func terraformNodeLabel(node PlanDAGNode) string {
+	if node.Key != "" {
+		return node.Key
+	}
+	return node.ID
+}
+
+// terraformOnlyModel skips the DAG view: it opens the terraform detail view of the only terraform resource, or
+// lists the terraform resources to pick from when there are several.
+type terraformOnlyModel struct {
+	debugData DebugData
+	nodes     []PlanDAGNode
+	cursor    int
+	width     int
+	height    int
+
+	inDetail bool
+	detail   tea.Model
+}
+
+func newTerraformOnlyModel(data DebugData, nodes []PlanDAGNode) terraformOnlyModel {
+	return terraformOnlyModel{debugData: data, nodes: nodes}
+}
+
+// openTerraformDetailMsg opens the detail view of the only terraform resource on start.
+type openTerraformDetailMsg struct{}
+
+func (m terraformOnlyModel) Init() tea.Cmd {
+	if len(m.nodes) == 1 {
+		return func() tea.Msg { return openTerraformDetailMsg{} }
+	}
+	return nil
+}
+
+func (m terraformOnlyModel) openDetail() (tea.Model, tea.Cmd) {
+	detail := newTerraformDetailModel(m.nodes[m.cursor], m.debugData)
+	detail.width = m.width
+	detail.height = m.height
+	detail.progressBar.Width = m.width - 40
+	if detail.progressBar.Width < 20 {
+		detail.progressBar.Width = 20
+	}
+	m.detail = detail
+	m.inDetail = true
+	return m, detail.Init()
+}
+
+func (m terraformOnlyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = size.Width
+		m.height = size.Height
+	}
+
+	if _, ok := msg.(openTerraformDetailMsg); ok && !m.inDetail {
+		return m.openDetail()
+	}
+
+	if m.inDetail && m.detail != nil {
+		if _, ok := msg.(backToDagMsg); ok {
+			if len(m.nodes) == 1 {
+				return m, tea.Quit
+			}
+			m.inDetail = false
+			m.detail = nil
+			return m, tea.ClearScreen
+		}
+		var cmd tea.Cmd
+		m.detail, cmd = m.detail.Update(msg)
+		return m, cmd
+	}
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.nodes)-1 {
+				m.cursor++
+			}
+		case "enter":
+			return m.openDetail()
+		}
+	}
+	return m, nil
+}
+
+func (m terraformOnlyModel) View() string {
+	if m.inDetail && m.detail != nil {
+		return m.detail.View()
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("230")).Background(lipgloss.Color("62")).Padding(0, 1)
+	cursorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	subtleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", headerStyle.Render(fmt.Sprintf("Terraform Resources · %s", m.debugData.InstanceID)))
+	for i, node := range m.nodes {
+		line := fmt.Sprintf("%s  %s", terraformNodeLabel(node), subtleStyle.Render(node.ID))
+		if i == m.cursor {
+			fmt.Fprintf(&b, "%s %s\n", cursorStyle.Render("▸"), line)
+		} else {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+	fmt.Fprintf(&b, "\n%s\n", subtleStyle.Render("↑↓: navigate  enter: open  q: quit"))
+	return b.String()
+}
+
+// launchTerraformOnlyTUI runs the terraform detail view without the DAG view.
+func launchTerraformOnlyTUI(data DebugData) error {
+	nodes := terraformPlanNodes(data.PlanDAG)
+	if len(nodes) == 0 {
+		return fmt.Errorf("instance %s has no terraform resources", data.InstanceID)
+	}
+
+	program := tea.NewProgram(newTerraformOnlyModel(data, nodes), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("failed to run TUI: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package instance
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformPlanNodes(t *testing.T) {
+	plan := &PlanDAG{Nodes: map[string]PlanDAGNode{
+		"r-3": {ID: "r-3", Key: "vpc", Type: "Terraform"},
+		"r-1": {ID: "r-1", Key: "app", Type: "helm"},
+		"r-2": {ID: "r-2", Key: "bucket", Type: "terraform"},
+	}}
+
+	nodes := terraformPlanNodes(plan)
+	require.Len(t, nodes, 2)
+	require.Equal(t, "bucket", nodes[0].Key)
+	require.Equal(t, "vpc", nodes[1].Key)
+
+	require.Empty(t, terraformPlanNodes(nil))
+}
+
+func TestTerraformOnlyModelNavigation(t *testing.T) {
+	nodes := []PlanDAGNode{{ID: "r-1", Key: "bucket"}, {ID: "r-2", Key: "vpc"}}
+	m := newTerraformOnlyModel(DebugData{InstanceID: "inst-1"}, nodes)
+	require.Nil(t, m.Init())
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(terraformOnlyModel)
+	require.Equal(t, 1, m.cursor)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(terraformOnlyModel)
+	require.Equal(t, 1, m.cursor)
+
+	require.Contains(t, m.View(), "vpc")
+}
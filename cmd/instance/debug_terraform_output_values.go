@@ -0,0 +1,136 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// TerraformOutputValues is the --tf-outputs JSON output: the outputs of the latest terraform operation of a resource
+type TerraformOutputValues struct {
+	InstanceID  string                          `json:"instanceId"`
+	ResourceKey string                          `json:"resourceKey"`
+	ResourceID  string                          `json:"resourceId"`
+	Outputs     map[string]TerraformOutputValue `json:"outputs"`
+}
+
+// TerraformOutputValue is one terraform output. The value of a sensitive output is masked unless revealed.
+type TerraformOutputValue struct {
+	Sensitive bool        `json:"sensitive"`
+	Type      interface{} `json:"type,omitempty"`
+	Value     interface{} `json:"value"`
+}
+
+// parseTerraformOutputs parses the terraform output JSON, in the same format as buildOutputTreeFromJSON reads, into
+// output values. Sensitive values are masked unless revealSensitive is set.
+func parseTerraformOutputs(rawJSON string, revealSensitive bool) (map[string]TerraformOutputValue, error) {
+	outputs := make(map[string]TerraformOutputValue)
+	if rawJSON == "" {
+		return outputs, nil
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(rawJSON), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse terraform outputs: %w", err)
+	}
+
+	for key, val := range parsed {
+		// Each output is typically { "sensitive": bool, "type": ..., "value": ... }
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			outputs[key] = TerraformOutputValue{Value: val}
+			continue
+		}
+		sensitive, _ := obj["sensitive"].(bool)
+		output := TerraformOutputValue{Sensitive: sensitive, Type: obj["type"], Value: obj["value"]}
+		if sensitive && !revealSensitive {
+			output.Value = redactedValue
+		}
+		outputs[key] = output
+	}
+	return outputs, nil
+}
+
+// runDebugTerraformOutputValues writes the outputs of the latest terraform operation of a resource as JSON to out,
+// so that automation can read the values terraform exports without opening the interactive view. Revealed
+// sensitive outputs are recorded in the audit log, as in the interactive view.
+func runDebugTerraformOutputValues(ctx context.Context, instanceID, token, resourceKey string, revealSensitive bool, out io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	serviceID, environmentID, _, _, err := getInstance(ctx, token, instanceID)
+	if err != nil {
+		return fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	instanceData, err := fetchInstanceDataForResource(ctx, token, serviceID, environmentID, instanceID)
+	if err != nil {
+		return err
+	}
+
+	resourceIndex, err := buildResourceIndex(ctx, token, serviceID, instanceData, false)
+	if err != nil {
+		return fmt.Errorf("failed to build resource indexes: %w", err)
+	}
+	if !resourceIndex.isTerraformKey(resourceKey) {
+		return fmt.Errorf("resource '%s' is not a terraform resource of instance %s", resourceKey, instanceID)
+	}
+	resourceID, ok := resourceIndex.resourceIDForKey(resourceKey)
+	if !ok {
+		return fmt.Errorf("resource '%s' not found in instance %s", resourceKey, instanceID)
+	}
+
+	_, history, conn, err := fetchTerraformProgress(ctx, token, instanceData, instanceID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	// Find the output log of the latest operation, on the dataplane or control-plane cluster
+	var tfOutputJSON string
+	if conn != nil {
+		for _, c := range []*k8sConnection{conn.dataplane, conn.controlPlane} {
+			if c == nil {
+				continue
+			}
+			index, indexErr := loadTerraformConfigMapIndex(ctx, c.clientset, instanceID)
+			if indexErr != nil || index == nil {
+				continue
+			}
+			if tfData := index.terraformDataForResource(resourceID); tfData != nil && len(tfData.Files) > 0 {
+				if tfOutputJSON = findLatestOutputLog(tfData.Files, history); tfOutputJSON != "" {
+					break
+				}
+			}
+		}
+	}
+
+	outputs, err := parseTerraformOutputs(tfOutputJSON, revealSensitive)
+	if err != nil {
+		return err
+	}
+	if revealSensitive {
+		for key, output := range outputs {
+			if !output.Sensitive {
+				continue
+			}
+			if err = recordSensitiveReveal(debugAuditLogPath, instanceID, resourceKey, key, time.Now()); err != nil {
+				return err
+			}
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(TerraformOutputValues{
+		InstanceID:  instanceID,
+		ResourceKey: resourceKey,
+		ResourceID:  resourceID,
+		Outputs:     outputs,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal terraform outputs to JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(out, string(jsonData))
+	return err
+}
@@ -0,0 +1,39 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTerraformOutputsMasksSensitiveValues(t *testing.T) {
+	require := require.New(t)
+
+	rawJSON := `{
+		"db_endpoint": {"sensitive": false, "type": "string", "value": "db.internal:5432"},
+		"db_password": {"sensitive": true, "type": "string", "value": "hunter2"},
+		"public_ips": {"sensitive": false, "type": ["list", "string"], "value": ["10.0.0.1", "10.0.0.2"]}
+	}`
+
+	outputs, err := parseTerraformOutputs(rawJSON, false)
+	require.NoError(err)
+	require.Len(outputs, 3)
+	require.Equal("db.internal:5432", outputs["db_endpoint"].Value)
+	require.Equal("string", outputs["db_endpoint"].Type)
+	require.True(outputs["db_password"].Sensitive)
+	require.Equal(redactedValue, outputs["db_password"].Value)
+	require.Equal([]interface{}{"10.0.0.1", "10.0.0.2"}, outputs["public_ips"].Value)
+
+	outputs, err = parseTerraformOutputs(rawJSON, true)
+	require.NoError(err)
+	require.Equal("hunter2", outputs["db_password"].Value)
+}
+
+func TestParseTerraformOutputsEmptyAndInvalid(t *testing.T) {
+	outputs, err := parseTerraformOutputs("", false)
+	require.NoError(t, err)
+	require.Empty(t, outputs)
+
+	_, err = parseTerraformOutputs("not json", false)
+	require.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package instance
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podLogFlushInterval is how often the lines read from the executor pods are sent to the Live Logs tab as one batch.
+const podLogFlushInterval = 250 * time.Millisecond
+
+// podLogStream is one container log followed in the all-pods view of the Live Logs tab.
+type podLogStream struct {
+	pod       string
+	container string
+	prefix    string // prepended to every line, e.g. "[tf-executor-abc] "
+}
+
+// listExecutorPodLogStreams returns a stream for every container of the running or finished pods in namespace
+// whose name starts with podPrefix, sorted by pod name. Pending pods have no logs yet and are left out.
+func listExecutorPodLogStreams(ctx context.Context, conn *k8sConnection, namespace, podPrefix string) ([]podLogStream, error) {
+	list, err := conn.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+	}
+
+	var streams []podLogStream
+	for _, pod := range list.Items {
+		if !strings.HasPrefix(pod.Name, podPrefix) || pod.Status.Phase == corev1.PodPending {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			prefix := fmt.Sprintf("[%s] ", pod.Name)
+			if len(pod.Spec.Containers) > 1 {
+				prefix = fmt.Sprintf("[%s/%s] ", pod.Name, container.Name)
+			}
+			streams = append(streams, podLogStream{pod: pod.Name, container: container.Name, prefix: prefix})
+		}
+	}
+	sort.SliceStable(streams, func(i, j int) bool {
+		return streams[i].prefix < streams[j].prefix
+	})
+	return streams, nil
+}
+
+// followPodLog sends every line of the container log to lines, prefixed with the pod name, until the log ends or
+// ctx is cancelled. It reports false when the log could not be opened, so that it is retried on the next poll.
+func followPodLog(ctx context.Context, conn *k8sConnection, namespace string, stream podLogStream, lines chan<- string) bool {
+	req := conn.clientset.CoreV1().Pods(namespace).GetLogs(stream.pod, &corev1.PodLogOptions{Container: stream.container, Follow: true})
+	body, err := req.Stream(ctx)
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		select {
+		case lines <- stream.prefix + scanner.Text():
+		case <-ctx.Done():
+			return true
+		}
+	}
+	return true
+}
+
+// watchExecutorPodLogs multiplexes the logs of every terraform executor pod, i.e. every pod in namespace whose name
+// starts with podPrefix, into the Live Logs tab. The pods are listed again every logPollInterval so that pods
+// started later join the view, and the lines of all pods are merged into one batch every podLogFlushInterval.
+// Every log follows the shared ctx and the polling loop exits when ctx is cancelled.
+func watchExecutorPodLogs(ctx context.Context, conn *k8sConnection, namespace, podPrefix string, ch chan logLineMsg) tea.Cmd {
+	return func() tea.Msg {
+		lines := make(chan string, 100)
+		retry := make(chan string)
+		following := make(map[string]bool)
+
+		// follow starts following the logs that are not followed yet and returns the label of the view
+		follow := func() (string, error) {
+			streams, err := listExecutorPodLogStreams(ctx, conn, namespace, podPrefix)
+			if err != nil {
+				return "", err
+			}
+			for _, stream := range streams {
+				if following[stream.prefix] {
+					continue
+				}
+				following[stream.prefix] = true
+				go func(stream podLogStream) {
+					if !followPodLog(ctx, conn, namespace, stream, lines) {
+						select {
+						case retry <- stream.prefix:
+						case <-ctx.Done():
+						}
+					}
+				}(stream)
+			}
+			return fmt.Sprintf("all pods (%d)", len(following)), nil
+		}
+
+		label, err := follow()
+		if err != nil {
+			close(ch)
+			return logStreamDoneMsg{err: err}
+		}
+		// Report the first listing even without lines, so the view knows the stream is healthy
+		select {
+		case ch <- logLineMsg{label: label}:
+		case <-ctx.Done():
+			close(ch)
+			return logStreamDoneMsg{}
+		}
+
+		poll := time.NewTicker(logPollInterval)
+		defer poll.Stop()
+		flush := time.NewTicker(podLogFlushInterval)
+		defer flush.Stop()
+
+		var batch []string
+		for {
+			select {
+			case <-ctx.Done():
+				close(ch)
+				return logStreamDoneMsg{}
+			case line := <-lines:
+				batch = append(batch, line)
+			case prefix := <-retry:
+				delete(following, prefix)
+			case <-flush.C:
+				if len(batch) == 0 {
+					continue
+				}
+				select {
+				case ch <- logLineMsg{lines: batch, label: label}:
+					batch = nil
+				case <-ctx.Done():
+					close(ch)
+					return logStreamDoneMsg{}
+				}
+			case <-poll.C:
+				if label, err = follow(); err != nil {
+					close(ch)
+					return logStreamDoneMsg{err: err}
+				}
+			}
+		}
+	}
+}
+
+// startLogWatch cancels the current Live Logs stream and starts a new one on conn: the merged executor pod logs
+// in the all-pods view, the operation logs from the tf-state configmap otherwise.
+func (m *terraformDetailModel) startLogWatch(conn *k8sConnection) tea.Cmd {
+	if m.logCancel != nil {
+		m.logCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background()) //nolint:gosec // cancel stored in m.logCancel for later use
+	m.logCancel = cancel
+	m.logChan = make(chan logLineMsg, 50)
+	m.logStreaming = true
+
+	ch := m.logChan
+	var watch tea.Cmd
+	if m.logAllPods {
+		watch = watchExecutorPodLogs(ctx, conn, terraformConfigMapNamespace, m.k8sEventsObjectPrefix(), ch)
+	} else {
+		watch = watchApplyDestroyLogs(ctx, conn, m.debugData.InstanceID, m.node.ID, m.history, ch)
+	}
+	return tea.Batch(
+		func() tea.Msg {
+			msg := watch()
+			if done, ok := msg.(logStreamDoneMsg); ok {
+				done.ch = ch
+				return done
+			}
+			return msg
+		},
+		waitForLogLines(ch),
+	)
+}
+
+// toggleAllPodLogs switches the Live Logs tab between the operation logs and the merged logs of every executor pod.
+func (m terraformDetailModel) toggleAllPodLogs() (tea.Model, tea.Cmd) {
+	if m.logConn == nil {
+		return m, nil
+	}
+	if !m.logAllPods && m.k8sEventsObjectPrefix() == "" {
+		m.clipboardMsg = "✗ No executor pod is known for this resource"
+		return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return clearClipboardMsg{} })
+	}
+
+	m.logAllPods = !m.logAllPods
+	m.logLines = nil
+	m.logLabel = ""
+	m.logScroll = 0
+	m.logFollow = true
+	m.logDone = false
+	m.logErr = nil
+	m.logReconnecting = false
+	m.refreshLogMatches()
+	return m, m.startLogWatch(m.logConn)
+}
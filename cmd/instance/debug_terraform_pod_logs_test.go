@@ -0,0 +1,108 @@
+package instance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testExecutorPod(name string, phase corev1.PodPhase, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: terraformConfigMapNamespace},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+func testExecutorPodConnection() *k8sConnection {
+	podName := terraformExecutorPodName("tf-r-abc")
+	return &k8sConnection{clientset: fake.NewClientset(
+		testExecutorPod(podName+"-2", corev1.PodRunning, "executor", "sidecar"),
+		testExecutorPod(podName+"-1", corev1.PodSucceeded, "executor"),
+		testExecutorPod(podName+"-3", corev1.PodPending, "executor"),
+		testExecutorPod("other-pod", corev1.PodRunning, "app"),
+	)}
+}
+
+func TestListExecutorPodLogStreams(t *testing.T) {
+	require := require.New(t)
+
+	podName := terraformExecutorPodName("tf-r-abc")
+	streams, err := listExecutorPodLogStreams(context.Background(), testExecutorPodConnection(), terraformConfigMapNamespace, podName)
+	require.NoError(err)
+	require.Equal([]podLogStream{
+		{pod: podName + "-1", container: "executor", prefix: "[" + podName + "-1] "},
+		{pod: podName + "-2", container: "executor", prefix: "[" + podName + "-2/executor] "},
+		{pod: podName + "-2", container: "sidecar", prefix: "[" + podName + "-2/sidecar] "},
+	}, streams)
+}
+
+func TestWatchExecutorPodLogsMergesPods(t *testing.T) {
+	require := require.New(t)
+
+	podName := terraformExecutorPodName("tf-r-abc")
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan logLineMsg, 50)
+	done := make(chan interface{}, 1)
+	go func() {
+		done <- watchExecutorPodLogs(ctx, testExecutorPodConnection(), terraformConfigMapNamespace, podName, ch)()
+	}()
+
+	first := <-ch
+	require.Equal("all pods (3)", first.label)
+	require.Empty(first.lines)
+
+	// The fake clientset serves "fake logs" for every container
+	var lines []string
+	deadline := time.After(5 * time.Second)
+	for len(lines) < 3 {
+		select {
+		case msg := <-ch:
+			lines = append(lines, msg.lines...)
+		case <-deadline:
+			require.FailNow("timed out waiting for pod logs", "got %v", lines)
+		}
+	}
+	require.ElementsMatch([]string{
+		"[" + podName + "-1] fake logs",
+		"[" + podName + "-2/executor] fake logs",
+		"[" + podName + "-2/sidecar] fake logs",
+	}, lines)
+
+	cancel()
+	require.Equal(logStreamDoneMsg{}, <-done)
+	_, open := <-ch
+	require.False(open)
+}
+
+func TestTerraformDetailIgnoresReplacedLogStream(t *testing.T) {
+	require := require.New(t)
+
+	model := newTerraformDetailModel(PlanDAGNode{}, DebugData{})
+	model.loading = false
+	stale := model.logChan
+	model.logChan = make(chan logLineMsg, 50)
+	model.logStreaming = true
+
+	updatedAny, cmd := model.Update(logLineMsg{lines: []string{"old"}, ch: stale})
+	updated := updatedAny.(terraformDetailModel)
+	require.Nil(cmd)
+	require.Empty(updated.logLines)
+
+	updatedAny, _ = updated.Update(logStreamDoneMsg{ch: stale})
+	updated = updatedAny.(terraformDetailModel)
+	require.True(updated.logStreaming)
+	require.False(updated.logDone)
+
+	updatedAny, _ = updated.Update(logLineMsg{lines: []string{"new"}, ch: updated.logChan})
+	updated = updatedAny.(terraformDetailModel)
+	require.Equal([]string{"new"}, updated.logLines)
+}
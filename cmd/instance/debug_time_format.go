@@ -0,0 +1,134 @@
+package instance
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// debugTimeFormatUTC shows event timestamps in UTC as returned by the API
+	debugTimeFormatUTC      = "utc"
+	debugTimeFormatLocal    = "local"
+	debugTimeFormatRFC3339  = "rfc3339"
+	debugTimeFormatRelative = "relative"
+)
+
+var debugTimeFormats = []string{debugTimeFormatUTC, debugTimeFormatLocal, debugTimeFormatRFC3339, debugTimeFormatRelative}
+
+// debugTimeFormat is the display format of workflow event timestamps, set from --time-format.
+var debugTimeFormat = debugTimeFormatUTC
+
+// debugNow returns the current time for relative timestamps; tests replace it.
+var debugNow = time.Now
+
+func validateDebugTimeFormat(format string) error {
+	if !slices.Contains(debugTimeFormats, format) {
+		return fmt.Errorf("invalid time-format %q: must be one of %s", format, strings.Join(debugTimeFormats, ", "))
+	}
+	return nil
+}
+
+// formatEventTime formats a workflow event timestamp for event details, copied text and JSON output.
+// Timestamps that cannot be parsed, and all timestamps in the default UTC format, are returned unchanged.
+func formatEventTime(ts string) string {
+	t, ok := parseEventTime(ts)
+	if !ok {
+		return ts
+	}
+
+	switch debugTimeFormat {
+	case debugTimeFormatLocal:
+		return t.Local().Format("2006-01-02 15:04:05 MST")
+	case debugTimeFormatRFC3339:
+		return t.Format(time.RFC3339)
+	case debugTimeFormatRelative:
+		return formatRelativeTime(t, debugNow())
+	default:
+		return ts
+	}
+}
+
+// formatShortTime formats a workflow event timestamp for the compact timeline rows of the TUI.
+func formatShortTime(ts string) string {
+	if t, ok := parseEventTime(ts); ok {
+		switch debugTimeFormat {
+		case debugTimeFormatLocal:
+			return t.Local().Format("15:04:05")
+		case debugTimeFormatRFC3339:
+			return t.Format(time.RFC3339)
+		case debugTimeFormatRelative:
+			return formatRelativeTime(t, debugNow())
+		}
+	}
+
+	if len(ts) >= 19 {
+		return ts[11:19]
+	}
+	if len(ts) > 10 {
+		return ts[11:]
+	}
+	return ts
+}
+
+func parseEventTime(ts string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// formatRelativeTime formats t relative to now using its largest unit, e.g. "2m ago" or "in 5s".
+func formatRelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var amount string
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		amount = fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		amount = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		amount = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+// applyDebugTimeFormat rewrites the workflow step and event timestamps of plan for JSON output. It is applied
+// only once the plan is no longer needed for computing progress, which relies on the raw timestamps.
+func applyDebugTimeFormat(plan *PlanDAG) {
+	if plan == nil || debugTimeFormat == debugTimeFormatUTC {
+		return
+	}
+
+	for _, steps := range plan.WorkflowStepsByKey {
+		if steps == nil {
+			continue
+		}
+		for i := range steps.Steps {
+			step := &steps.Steps[i]
+			step.StartTime = formatEventTime(step.StartTime)
+			step.EndTime = formatEventTime(step.EndTime)
+			for j := range step.Events {
+				step.Events[j].EventTime = formatEventTime(step.Events[j].EventTime)
+			}
+			for j := range step.DepTimelines {
+				step.DepTimelines[j].FinishedAt = formatEventTime(step.DepTimelines[j].FinishedAt)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package instance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/stretchr/testify/require"
+)
+
+func setTestDebugTimeFormat(t *testing.T, format string, now time.Time) {
+	t.Helper()
+
+	prevFormat, prevNow := debugTimeFormat, debugNow
+	t.Cleanup(func() {
+		debugTimeFormat, debugNow = prevFormat, prevNow
+	})
+	debugTimeFormat = format
+	debugNow = func() time.Time { return now }
+}
+
+func TestFormatEventTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	ts := "2026-10-16T11:58:00Z"
+
+	tests := []struct {
+		format    string
+		wantFull  string
+		wantShort string
+	}{
+		{debugTimeFormatUTC, ts, "11:58:00"},
+		{debugTimeFormatRFC3339, "2026-10-16T11:58:00Z", "2026-10-16T11:58:00Z"},
+		{debugTimeFormatRelative, "2m ago", "2m ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			setTestDebugTimeFormat(t, tt.format, now)
+			require.Equal(t, tt.wantFull, formatEventTime(ts))
+			require.Equal(t, tt.wantShort, formatShortTime(ts))
+		})
+	}
+
+	setTestDebugTimeFormat(t, debugTimeFormatLocal, now)
+	parsed, _ := time.Parse(time.RFC3339, ts)
+	require.Equal(t, parsed.Local().Format("2006-01-02 15:04:05 MST"), formatEventTime(ts))
+	require.Equal(t, parsed.Local().Format("15:04:05"), formatShortTime(ts))
+
+	// Timestamps that cannot be parsed are shown as returned by the API
+	require.Equal(t, "yesterday", formatEventTime("yesterday"))
+	require.Equal(t, "", formatShortTime(""))
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, "just now", formatRelativeTime(now, now))
+	require.Equal(t, "45s ago", formatRelativeTime(now.Add(-45*time.Second), now))
+	require.Equal(t, "3h ago", formatRelativeTime(now.Add(-3*time.Hour-20*time.Minute), now))
+	require.Equal(t, "2d ago", formatRelativeTime(now.Add(-50*time.Hour), now))
+	require.Equal(t, "in 5s", formatRelativeTime(now.Add(5*time.Second), now))
+}
+
+func TestApplyDebugTimeFormat(t *testing.T) {
+	newPlan := func() *PlanDAG {
+		return &PlanDAG{
+			WorkflowStepsByKey: map[string]*ResourceWorkflowSteps{
+				"db": {Steps: []WorkflowStepInfo{{
+					Name:         "Deployment",
+					StartTime:    "2026-10-16T11:00:00Z",
+					EndTime:      "2026-10-16T11:30:00Z",
+					Events:       []dataaccess.DebugEvent{{EventTime: "2026-10-16T11:15:00Z"}},
+					DepTimelines: []depTimeline{{Name: "cache", FinishedAt: "2026-10-16T10:00:00Z"}},
+				}}},
+			},
+		}
+	}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	// The default format keeps the timestamps as returned by the API
+	setTestDebugTimeFormat(t, debugTimeFormatUTC, now)
+	plan := newPlan()
+	applyDebugTimeFormat(plan)
+	require.Equal(t, newPlan(), plan)
+
+	setTestDebugTimeFormat(t, debugTimeFormatRelative, now)
+	plan = newPlan()
+	applyDebugTimeFormat(plan)
+	step := plan.WorkflowStepsByKey["db"].Steps[0]
+	require.Equal(t, "1h ago", step.StartTime)
+	require.Equal(t, "30m ago", step.EndTime)
+	require.Equal(t, "45m ago", step.Events[0].EventTime)
+	require.Equal(t, "2h ago", step.DepTimelines[0].FinishedAt)
+
+	applyDebugTimeFormat(nil)
+}
+
+func TestValidateDebugTimeFormat(t *testing.T) {
+	for _, format := range debugTimeFormats {
+		require.NoError(t, validateDebugTimeFormat(format))
+	}
+	require.ErrorContains(t, validateDebugTimeFormat("iso"), "must be one of utc, local, rfc3339, relative")
+}
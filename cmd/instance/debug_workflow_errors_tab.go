@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"strings"
 	"time"
 
@@ -22,6 +23,9 @@ type workflowErrorsState struct {
 	modalScroll int
 	refreshing  bool      // true while fetching fresh workflow events
 	lastRefresh time.Time // when the last successful refresh completed
+
+	consecutiveErrors int           // number of refreshes in a row that failed
+	refreshDelay      time.Duration // delay until the next refresh, grows while refreshes keep failing
 }
 
 // wfEventsRefreshMsg carries refreshed workflow steps for a resource.
@@ -33,7 +37,58 @@ type wfEventsRefreshMsg struct {
 // wfCountdownTickMsg fires every second to update the countdown display.
 type wfCountdownTickMsg struct{}
 
-const wfEventsRefreshInterval = 5 * time.Second
+const (
+	defaultWfEventsRefreshInterval = 5 * time.Second
+	wfEventsRefreshMaxInterval     = 2 * time.Minute
+)
+
+// wfEventsRefreshInterval is the base interval between workflow events refreshes, set from --refresh-interval.
+var wfEventsRefreshInterval = defaultWfEventsRefreshInterval
+
+// recordRefresh marks a refresh as finished and computes the delay until the next one.
+// Consecutive errors back off exponentially with jitter; a successful refresh resets to the base interval.
+func (s *workflowErrorsState) recordRefresh(err error) {
+	s.refreshing = false
+	s.lastRefresh = time.Now()
+	if err != nil {
+		s.consecutiveErrors++
+	} else {
+		s.consecutiveErrors = 0
+	}
+	s.refreshDelay = wfEventsBackoffInterval(wfEventsRefreshInterval, s.consecutiveErrors, rand.Float64()) //nolint:gosec // jitter does not need a secure source
+}
+
+// nextRefreshInterval returns the delay until the next workflow events refresh.
+func (s *workflowErrorsState) nextRefreshInterval() time.Duration {
+	if s.refreshDelay > 0 {
+		return s.refreshDelay
+	}
+	return wfEventsRefreshInterval
+}
+
+// wfEventsBackoffInterval doubles the base interval for every consecutive error, capped at
+// wfEventsRefreshMaxInterval, and spreads the result over its upper half using jitter in [0, 1).
+func wfEventsBackoffInterval(base time.Duration, consecutiveErrors int, jitter float64) time.Duration {
+	if consecutiveErrors <= 0 {
+		return base
+	}
+
+	maxInterval := wfEventsRefreshMaxInterval
+	if base > maxInterval {
+		maxInterval = base
+	}
+
+	backoff := base
+	for i := 0; i < consecutiveErrors && backoff < maxInterval; i++ {
+		backoff *= 2
+	}
+	if backoff > maxInterval {
+		backoff = maxInterval
+	}
+
+	half := backoff / 2
+	return half + time.Duration(jitter*float64(half))
+}
 
 // isWorkflowInProgress returns true if any step is still in-progress or pending.
 func isWorkflowInProgress(steps *ResourceWorkflowSteps) bool {
@@ -77,8 +132,8 @@ func renderLiveIndicator(spinnerView string, refreshing bool, lastRefresh time.T
 	return fmt.Sprintf("  %s  %s", live, dimStyle.Render(fmt.Sprintf("Next refresh in %ds", secs)))
 }
 
-func scheduleWfEventsRefresh() tea.Cmd {
-	return tea.Tick(wfEventsRefreshInterval, func(time.Time) tea.Msg {
+func scheduleWfEventsRefresh(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
 		return wfEventsRefreshTickMsg{}
 	})
 }
@@ -96,13 +151,11 @@ type wfEventsRefreshTickMsg struct{}
 func fetchWfEventsForResource(data DebugData, resourceKey string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		resourcesData, _, err := dataaccess.GetDebugEventsForAllResources(
-			ctx, data.Token, data.ServiceID, data.EnvironmentID, data.InstanceID, true,
-		)
-		if err != nil {
-			return wfEventsRefreshMsg{err: err}
+		events := data.workflowEvents(ctx)
+		if events.err != nil {
+			return wfEventsRefreshMsg{err: events.err}
 		}
-		for _, resource := range resourcesData {
+		for _, resource := range events.resources {
 			if resource.ResourceKey == resourceKey {
 				steps := buildStepsFromRawSteps(resource.RawSteps)
 				return wfEventsRefreshMsg{steps: steps}
@@ -180,6 +233,51 @@ func flattenWfEventItems(steps *ResourceWorkflowSteps) []wfEventItem {
 	return items
 }
 
+// keepSelectedStep moves the cursor so it stays on the same workflow step, and the same row within
+// that step, after a refresh replaces oldSteps with newSteps. Without this the cursor keeps its index
+// and jumps to another step whenever a refresh adds events above it.
+func (s *workflowErrorsState) keepSelectedStep(oldSteps, newSteps *ResourceWorkflowSteps) {
+	oldItems := flattenWfEventItems(oldSteps)
+	newItems := flattenWfEventItems(newSteps)
+	if len(newItems) == 0 {
+		s.cursor = 0
+		return
+	}
+	if s.cursor < 0 || s.cursor >= len(oldItems) {
+		s.cursor = clampInt(s.cursor, 0, len(newItems)-1)
+		return
+	}
+
+	selected := oldItems[s.cursor]
+	stepName := oldSteps.Steps[selected.stepIdx].Name
+	offset := s.cursor - wfStepHeaderIndex(oldItems, oldSteps, stepName)
+
+	header := wfStepHeaderIndex(newItems, newSteps, stepName)
+	if header < 0 {
+		s.cursor = clampInt(s.cursor, 0, len(newItems)-1)
+		return
+	}
+
+	cursor := header
+	for i := header + 1; i < len(newItems) && i <= header+offset; i++ {
+		if newItems[i].isStepHeader {
+			break
+		}
+		cursor = i
+	}
+	s.cursor = cursor
+}
+
+// wfStepHeaderIndex returns the index of the header row for the named step, or -1 if it is not shown.
+func wfStepHeaderIndex(items []wfEventItem, steps *ResourceWorkflowSteps, stepName string) int {
+	for i, item := range items {
+		if item.isStepHeader && steps.Steps[item.stepIdx].Name == stepName {
+			return i
+		}
+	}
+	return -1
+}
+
 // buildStepsFromRawSteps builds step summaries from the raw API step data.
 func buildStepsFromRawSteps(rawSteps []dataaccess.RawWorkflowStep) *ResourceWorkflowSteps {
 	if len(rawSteps) == 0 {
@@ -364,7 +462,7 @@ func renderWorkflowEventsTab(steps *ResourceWorkflowSteps, state *workflowErrors
 	// Prepend live indicator when workflow is in progress
 	liveOffset := 0
 	if isLive {
-		indicator := renderLiveIndicator(spinnerView, state.refreshing, state.lastRefresh)
+		indicator := renderLiveIndicator(spinnerView, state.refreshing, state.lastRefresh, state.nextRefreshInterval())
 		rendered = append([]string{indicator, ""}, rendered...)
 		liveOffset = 2
 		if cursorLine >= 0 {
@@ -930,16 +1028,6 @@ func eventHasAction(raw string) bool {
 	return ok
 }
 
-func formatShortTime(ts string) string {
-	if len(ts) >= 19 {
-		return ts[11:19]
-	}
-	if len(ts) > 10 {
-		return ts[11:]
-	}
-	return ts
-}
-
 // workflowEventsMaxScroll returns the max scroll position for workflow events.
 // renderWfEventModal renders a full-screen modal showing event detail.
 func renderWfEventModal(state *workflowErrorsState, width, height int) string {
@@ -1034,9 +1122,9 @@ func workflowEventsCopyText(steps *ResourceWorkflowSteps) string {
 	}
 	var b strings.Builder
 	for _, step := range steps.Steps {
-		fmt.Fprintf(&b, "\n=== %s [%s] %s → %s ===\n", step.stepDisplayName(), step.Status, step.StartTime, step.EndTime)
+		fmt.Fprintf(&b, "\n=== %s [%s] %s → %s ===\n", step.stepDisplayName(), step.Status, formatEventTime(step.StartTime), formatEventTime(step.EndTime))
 		for _, evt := range step.Events {
-			fmt.Fprintf(&b, "[%s] %s: %s\n", evt.EventTime, evt.EventType, evt.Message)
+			fmt.Fprintf(&b, "[%s] %s: %s\n", formatEventTime(evt.EventTime), evt.EventType, evt.Message)
 		}
 	}
 	return b.String()
@@ -1052,10 +1140,10 @@ func formatEventDetail(evt *dataaccess.DebugEvent) string {
 	if err := json.Unmarshal([]byte(evt.Message), &parsed); err == nil {
 		pretty, err := json.MarshalIndent(parsed, "", "  ")
 		if err == nil {
-			return fmt.Sprintf("Time:  %s\nType:  %s\n\n%s", evt.EventTime, evt.EventType, string(pretty))
+			return fmt.Sprintf("Time:  %s\nType:  %s\n\n%s", formatEventTime(evt.EventTime), evt.EventType, string(pretty))
 		}
 	}
-	return fmt.Sprintf("Time:  %s\nType:  %s\n\n%s", evt.EventTime, evt.EventType, evt.Message)
+	return fmt.Sprintf("Time:  %s\nType:  %s\n\n%s", formatEventTime(evt.EventTime), evt.EventType, evt.Message)
 }
 
 // extractEventAction returns the action name from a JSON event message, or a fallback.
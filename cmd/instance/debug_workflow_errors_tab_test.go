@@ -0,0 +1,106 @@
+package instance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWfEventsBackoffInterval(t *testing.T) {
+	base := 5 * time.Second
+
+	tests := []struct {
+		name              string
+		base              time.Duration
+		consecutiveErrors int
+		jitter            float64
+		expected          time.Duration
+	}{
+		{name: "no errors uses base interval", base: base, consecutiveErrors: 0, jitter: 0.9, expected: base},
+		{name: "first error without jitter", base: base, consecutiveErrors: 1, jitter: 0, expected: 5 * time.Second},
+		{name: "first error with full jitter", base: base, consecutiveErrors: 1, jitter: 1, expected: 10 * time.Second},
+		{name: "third error doubles each time", base: base, consecutiveErrors: 3, jitter: 0.5, expected: 30 * time.Second},
+		{name: "many errors are capped", base: base, consecutiveErrors: 50, jitter: 1, expected: wfEventsRefreshMaxInterval},
+		{name: "base above cap is kept", base: 5 * time.Minute, consecutiveErrors: 2, jitter: 1, expected: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, wfEventsBackoffInterval(tt.base, tt.consecutiveErrors, tt.jitter))
+		})
+	}
+}
+
+func TestWorkflowErrorsStateRecordRefresh(t *testing.T) {
+	state := &workflowErrorsState{refreshing: true}
+	require.Equal(t, wfEventsRefreshInterval, state.nextRefreshInterval())
+
+	state.recordRefresh(errors.New("service unavailable"))
+	require.False(t, state.refreshing)
+	require.Equal(t, 1, state.consecutiveErrors)
+	require.GreaterOrEqual(t, state.nextRefreshInterval(), wfEventsRefreshInterval)
+
+	state.recordRefresh(errors.New("service unavailable"))
+	require.Equal(t, 2, state.consecutiveErrors)
+	require.GreaterOrEqual(t, state.nextRefreshInterval(), 2*wfEventsRefreshInterval)
+
+	state.recordRefresh(nil)
+	require.Equal(t, 0, state.consecutiveErrors)
+	require.Equal(t, wfEventsRefreshInterval, state.nextRefreshInterval())
+}
+
+func wfDebugEvents(messages ...string) []dataaccess.DebugEvent {
+	events := make([]dataaccess.DebugEvent, 0, len(messages))
+	for _, message := range messages {
+		events = append(events, dataaccess.DebugEvent{EventType: string(model.WorkflowStepDebug), Message: message})
+	}
+	return events
+}
+
+func TestWorkflowErrorsStateKeepSelectedStep(t *testing.T) {
+	oldSteps := &ResourceWorkflowSteps{Steps: []WorkflowStepInfo{
+		{Name: "Storage", Events: wfDebugEvents("storage-1")},
+		{Name: "Compute", Events: wfDebugEvents("compute-1", "compute-2")},
+	}}
+	newSteps := &ResourceWorkflowSteps{Steps: []WorkflowStepInfo{
+		{Name: "Storage", Events: wfDebugEvents("storage-1", "storage-2")},
+		{Name: "Network", Events: wfDebugEvents("network-1")},
+		{Name: "Compute", Events: wfDebugEvents("compute-1", "compute-2", "compute-3")},
+	}}
+
+	t.Run("keeps the selected event within its step", func(t *testing.T) {
+		state := &workflowErrorsState{cursor: 3} // first Compute event
+		state.keepSelectedStep(oldSteps, newSteps)
+
+		items := flattenWfEventItems(newSteps)
+		require.Equal(t, 6, state.cursor)
+		require.Equal(t, "compute-1", items[state.cursor].event.Message)
+	})
+
+	t.Run("keeps the selected step header", func(t *testing.T) {
+		state := &workflowErrorsState{cursor: 2} // Compute header
+		state.keepSelectedStep(oldSteps, newSteps)
+
+		items := flattenWfEventItems(newSteps)
+		require.True(t, items[state.cursor].isStepHeader)
+		require.Equal(t, "Compute", newSteps.Steps[items[state.cursor].stepIdx].Name)
+	})
+
+	t.Run("clamps when the selected step is gone", func(t *testing.T) {
+		state := &workflowErrorsState{cursor: 4}
+		state.keepSelectedStep(oldSteps, &ResourceWorkflowSteps{Steps: []WorkflowStepInfo{
+			{Name: "Storage", Events: wfDebugEvents("storage-1")},
+		}})
+		require.Equal(t, 1, state.cursor)
+	})
+
+	t.Run("resets when there are no rows", func(t *testing.T) {
+		state := &workflowErrorsState{cursor: 4}
+		state.keepSelectedStep(oldSteps, nil)
+		require.Equal(t, 0, state.cursor)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
@@ -17,16 +19,90 @@ type ResourceProgress struct {
 	TotalSteps     int    `json:"totalSteps,omitempty"`
 }
 
-func attachWorkflowProgress(ctx context.Context, token, serviceID, environmentID, instanceID string, plan *PlanDAG) {
-	if plan == nil {
-		return
+// debugWorkflowEvents holds the workflow events of all resources of an instance, as returned by a single
+// GetDebugEventsForAllResources call.
+type debugWorkflowEvents struct {
+	resources    []dataaccess.ResourceWorkflowDebugEvents
+	workflowInfo *dataaccess.WorkflowInfo
+	err          error
+}
+
+// debugWorkflowEventsMaxAge is how long fetched workflow events are reused. It is shorter than the refresh
+// intervals, so each refresh still observes new events, but long enough for the DAG and detail view refreshes
+// that fire together to share one API call.
+const debugWorkflowEventsMaxAge = 2 * time.Second
+
+type debugWorkflowEventsEntry struct {
+	events    *debugWorkflowEvents
+	fetchedAt time.Time
+}
+
+// debugWorkflowEventsCache memoizes the workflow events of all resources per instance for a single debug run,
+// so the views that need them share one GetDebugEventsForAllResources call instead of each querying it.
+type debugWorkflowEventsCache struct {
+	mu      sync.Mutex
+	entries map[string]debugWorkflowEventsEntry // instance ID -> last successful fetch
+	fetch   func(ctx context.Context, token, serviceID, environmentID, instanceID string) *debugWorkflowEvents
+	now     func() time.Time
+}
+
+func newDebugWorkflowEventsCache() *debugWorkflowEventsCache {
+	return &debugWorkflowEventsCache{
+		entries: make(map[string]debugWorkflowEventsEntry),
+		fetch:   fetchDebugWorkflowEvents,
+		now:     time.Now,
+	}
+}
+
+// get returns the workflow events of the instance, fetching them unless a fetch younger than
+// debugWorkflowEventsMaxAge is cached. Concurrent callers wait for a single fetch, and failed fetches are not
+// cached. A nil cache always fetches.
+func (c *debugWorkflowEventsCache) get(ctx context.Context, token, serviceID, environmentID, instanceID string) *debugWorkflowEvents {
+	if c == nil {
+		return fetchDebugWorkflowEvents(ctx, token, serviceID, environmentID, instanceID)
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[instanceID]; ok && c.now().Sub(entry.fetchedAt) < debugWorkflowEventsMaxAge {
+		return entry.events
+	}
+
+	events := c.fetch(ctx, token, serviceID, environmentID, instanceID)
+	if events.err == nil {
+		c.entries[instanceID] = debugWorkflowEventsEntry{events: events, fetchedAt: c.now()}
+	}
+	return events
+}
+
+// workflowEvents returns the workflow events of the debugged instance through the cache of the debug run.
+func (data DebugData) workflowEvents(ctx context.Context) *debugWorkflowEvents {
+	return data.WorkflowEventsCache.get(ctx, data.Token, data.ServiceID, data.EnvironmentID, data.InstanceID)
+}
+
+// fetchDebugWorkflowEvents fetches the workflow events of all resources of an instance in one API call.
+func fetchDebugWorkflowEvents(ctx context.Context, token, serviceID, environmentID, instanceID string) *debugWorkflowEvents {
 	resourcesData, workflowInfo, err := dataaccess.GetDebugEventsForAllResources(ctx, token, serviceID, environmentID, instanceID, true)
-	if err != nil {
-		plan.Errors = append(plan.Errors, fmt.Sprintf("workflow progress incomplete: %v", err))
+	return &debugWorkflowEvents{
+		resources:    resourcesData,
+		workflowInfo: workflowInfo,
+		err:          err,
+	}
+}
+
+// attachWorkflowProgress sets the per-resource workflow progress and steps of plan from events fetched with
+// fetchDebugWorkflowEvents.
+func attachWorkflowProgress(plan *PlanDAG, events *debugWorkflowEvents) {
+	if plan == nil || events == nil {
+		return
+	}
+
+	if events.err != nil {
+		plan.Errors = append(plan.Errors, fmt.Sprintf("workflow progress incomplete: %v", events.err))
 	}
 
+	resourcesData, workflowInfo := events.resources, events.workflowInfo
 	if len(resourcesData) == 0 {
 		return
 	}
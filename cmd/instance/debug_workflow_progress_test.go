@@ -0,0 +1,77 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugWorkflowEventsCacheSharesFetches(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	calls := map[string]int{}
+	var failNext bool
+	cache := newDebugWorkflowEventsCache()
+	cache.now = func() time.Time { return now }
+	cache.fetch = func(_ context.Context, _, _, _, instanceID string) *debugWorkflowEvents {
+		calls[instanceID]++
+		if failNext {
+			failNext = false
+			return &debugWorkflowEvents{err: errors.New("unavailable")}
+		}
+		return &debugWorkflowEvents{workflowInfo: &dataaccess.WorkflowInfo{WorkflowID: "wf-1"}}
+	}
+
+	first := cache.get(context.Background(), "token", "s-1", "se-1", "instance-1")
+	second := cache.get(context.Background(), "token", "s-1", "se-1", "instance-1")
+	require.Same(first, second)
+	require.Equal(1, calls["instance-1"])
+
+	// Instances are cached separately
+	cache.get(context.Background(), "token", "s-1", "se-1", "instance-2")
+	require.Equal(1, calls["instance-2"])
+
+	// Events older than the max age are fetched again
+	now = now.Add(debugWorkflowEventsMaxAge)
+	third := cache.get(context.Background(), "token", "s-1", "se-1", "instance-1")
+	require.NotSame(first, third)
+	require.Equal(2, calls["instance-1"])
+
+	// Failed fetches are not cached
+	now = now.Add(debugWorkflowEventsMaxAge)
+	failNext = true
+	require.Error(cache.get(context.Background(), "token", "s-1", "se-1", "instance-1").err)
+	require.NoError(cache.get(context.Background(), "token", "s-1", "se-1", "instance-1").err)
+	require.Equal(4, calls["instance-1"])
+}
+
+func TestDebugWorkflowEventsCacheConcurrentCallersShareOneFetch(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	cache := newDebugWorkflowEventsCache()
+	cache.fetch = func(context.Context, string, string, string, string) *debugWorkflowEvents {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		return &debugWorkflowEvents{}
+	}
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.get(context.Background(), "token", "s-1", "se-1", "instance-1")
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, calls)
+}
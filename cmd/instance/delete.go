@@ -33,7 +33,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, _ := cmd.Flags().GetString("output")
@@ -23,7 +23,10 @@ omnistrate-ctl instance describe instance-abcd1234
 omnistrate-ctl instance describe instance-abcd1234 --deployment-status
 
 # Get deployment status for specific resource only  
-omnistrate-ctl instance describe instance-abcd1234 --deployment-status --resource-key mydb`
+omnistrate-ctl instance describe instance-abcd1234 --deployment-status --resource-key mydb
+
+# Summarize which resources are healthy or failing, with an overall health verdict
+omnistrate-ctl instance describe instance-abcd1234 --health`
 )
 
 type InstanceStatusType string
@@ -79,17 +82,18 @@ var describeCmd = &cobra.Command{
 
 func init() {
 	describeCmd.Args = cobra.ExactArgs(1) // Require exactly one argument
-	describeCmd.Flags().StringP("output", "o", "json", "Output format. Only json is supported")
+	describeCmd.Flags().StringP("output", "o", "json", "Output format. Only json is supported, except with --health which also supports table and defaults to it")
 	describeCmd.Flags().String("resource-id", "", "Filter results by resource ID")
 	describeCmd.Flags().String("resource-key", "", "Filter results by resource key")
 	describeCmd.Flags().Bool("deployment-status", false, "Return compact deployment status information instead of full instance details")
+	describeCmd.Flags().Bool("health", false, "Return a per-resource health rollup derived from the latest workflow events, with an overall instance health verdict")
 }
 
 func runDescribe(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, err := cmd.Flags().GetString("output")
@@ -116,8 +120,29 @@ func runDescribe(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	health, err := cmd.Flags().GetBool("health")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	if health && deploymentStatus {
+		err = errors.New("--health cannot be used with --deployment-status")
+		utils.PrintError(err)
+		return err
+	}
+
 	// Validate output flag
-	if output != "json" {
+	if health {
+		if !cmd.Flags().Changed("output") {
+			output = "table"
+		}
+		if output != "json" && output != "table" {
+			err = errors.New("only json and table output are supported with --health")
+			utils.PrintError(err)
+			return err
+		}
+	} else if output != "json" {
 		err = errors.New("only json output is supported")
 		utils.PrintError(err)
 		return err
@@ -180,6 +205,11 @@ func runDescribe(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// If health flag is set, return the resource health rollup
+	if health {
+		return printInstanceHealth(cmd.Context(), token, output, serviceID, environmentID, instanceID, resourceID, resourceKey)
+	}
+
 	// Apply resource filtering if specified (for full instance response)
 	if resourceID != "" || resourceKey != "" {
 		filteredInstance, err := filterInstanceByResource(cmd.Context(), token, instance, serviceID, resourceID, resourceKey)
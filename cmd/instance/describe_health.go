@@ -0,0 +1,186 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+)
+
+const (
+	ResourceHealthHealthy    = "HEALTHY"
+	ResourceHealthFailing    = "FAILING"
+	ResourceHealthInProgress = "IN_PROGRESS"
+	ResourceHealthPending    = "PENDING"
+	ResourceHealthUnknown    = "UNKNOWN"
+
+	InstanceHealthHealthy     = "HEALTHY"
+	InstanceHealthDegraded    = "DEGRADED"
+	InstanceHealthUnhealthy   = "UNHEALTHY"
+	InstanceHealthProgressing = "PROGRESSING"
+	InstanceHealthUnknown     = "UNKNOWN"
+)
+
+// InstanceHealth is the health rollup of an instance, derived from the events of its latest workflow
+type InstanceHealth struct {
+	InstanceID string           `json:"instanceId"`
+	Status     string           `json:"status"`
+	Health     string           `json:"health"`
+	Summary    string           `json:"summary"`
+	WorkflowID string           `json:"workflowId,omitempty"`
+	Resources  []ResourceHealth `json:"resources"`
+}
+
+// ResourceHealth is the health of a single resource. Fields are never omitted so every table row has the same columns.
+type ResourceHealth struct {
+	ResourceKey string `json:"resourceKey"`
+	ResourceID  string `json:"resourceId"`
+	Health      string `json:"health"`
+	Steps       string `json:"steps"`
+	FailedStep  string `json:"failedStep"`
+	Message     string `json:"message"`
+}
+
+// buildResourceHealth derives the health of a resource from the highest priority event type of each workflow step.
+func buildResourceHealth(resource dataaccess.ResourceWorkflowDebugEvents) ResourceHealth {
+	health := ResourceHealth{
+		ResourceKey: resource.ResourceKey,
+		ResourceID:  resource.ResourceID,
+	}
+	if health.ResourceKey == "" {
+		health.ResourceKey = resource.ResourceName
+	}
+
+	var completed, failed, active int
+	for _, step := range resource.RawSteps {
+		switch model.WorkflowStepEventType(getHighestPriorityEventType(step.Events)) {
+		case model.WorkflowStepFailed:
+			failed++
+			if health.FailedStep == "" {
+				health.FailedStep = step.StepName
+				health.Message = lastEventMessage(step.Events, string(model.WorkflowStepFailed))
+			}
+		case model.WorkflowStepCompleted:
+			completed++
+		case model.WorkflowStepStarted, model.WorkflowStepDebug:
+			active++
+		}
+	}
+	health.Steps = fmt.Sprintf("%d/%d completed", completed, len(resource.RawSteps))
+
+	switch {
+	case failed > 0:
+		health.Health = ResourceHealthFailing
+	case len(resource.RawSteps) > 0 && completed == len(resource.RawSteps):
+		health.Health = ResourceHealthHealthy
+	case active > 0 || completed > 0:
+		health.Health = ResourceHealthInProgress
+	case len(resource.RawSteps) == 0:
+		health.Health = ResourceHealthPending
+	default:
+		health.Health = ResourceHealthUnknown
+	}
+
+	return health
+}
+
+// lastEventMessage returns the message of the last event of the given type, on a single line.
+func lastEventMessage(events []dataaccess.DebugEvent, eventType string) string {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].EventType == eventType {
+			return strings.Join(strings.Fields(events[i].Message), " ")
+		}
+	}
+	return ""
+}
+
+// rollupInstanceHealth gives the overall health verdict of an instance from the health of its resources.
+// A FAILED instance is unhealthy whatever its resources report.
+func rollupInstanceHealth(instanceStatus string, resources []ResourceHealth) (verdict, summary string) {
+	counts := make(map[string]int)
+	for _, resource := range resources {
+		counts[resource.Health]++
+	}
+
+	summary = fmt.Sprintf("%d of %d resource(s) healthy", counts[ResourceHealthHealthy], len(resources))
+	if counts[ResourceHealthFailing] > 0 {
+		summary += fmt.Sprintf(", %d failing", counts[ResourceHealthFailing])
+	}
+	if inFlight := counts[ResourceHealthInProgress] + counts[ResourceHealthPending]; inFlight > 0 {
+		summary += fmt.Sprintf(", %d in progress", inFlight)
+	}
+
+	switch {
+	case InstanceStatusType(instanceStatus) == InstanceStatusFailed:
+		verdict = InstanceHealthUnhealthy
+	case counts[ResourceHealthFailing] > 0 && counts[ResourceHealthFailing] == len(resources):
+		verdict = InstanceHealthUnhealthy
+	case counts[ResourceHealthFailing] > 0:
+		verdict = InstanceHealthDegraded
+	case counts[ResourceHealthInProgress] > 0 || counts[ResourceHealthPending] > 0:
+		verdict = InstanceHealthProgressing
+	case len(resources) > 0 && counts[ResourceHealthHealthy] == len(resources):
+		verdict = InstanceHealthHealthy
+	case len(resources) == 0 && InstanceStatusType(instanceStatus) == InstanceStatusRunning:
+		verdict = InstanceHealthHealthy
+	default:
+		verdict = InstanceHealthUnknown
+	}
+	return verdict, summary
+}
+
+// createInstanceHealth builds the health rollup of an instance, keeping only the resource matching resourceID or
+// resourceKey when either is set.
+func createInstanceHealth(instanceID, instanceStatus string, workflowInfo *dataaccess.WorkflowInfo, resources []dataaccess.ResourceWorkflowDebugEvents, resourceID, resourceKey string) *InstanceHealth {
+	health := &InstanceHealth{
+		InstanceID: instanceID,
+		Status:     instanceStatus,
+		Resources:  make([]ResourceHealth, 0, len(resources)),
+	}
+	if workflowInfo != nil {
+		health.WorkflowID = workflowInfo.WorkflowID
+	}
+
+	for _, resource := range resources {
+		if resourceID != "" && resource.ResourceID != resourceID {
+			continue
+		}
+		if resourceKey != "" && resource.ResourceKey != resourceKey && resource.ResourceName != resourceKey {
+			continue
+		}
+		health.Resources = append(health.Resources, buildResourceHealth(resource))
+	}
+
+	health.Health, health.Summary = rollupInstanceHealth(instanceStatus, health.Resources)
+	return health
+}
+
+// printInstanceHealth fetches the events of the latest workflow of an instance and prints its health rollup.
+func printInstanceHealth(ctx context.Context, token, output, serviceID, environmentID, instanceID, resourceID, resourceKey string) error {
+	resources, workflowInfo, err := dataaccess.GetDebugEventsForAllResources(ctx, token, serviceID, environmentID, instanceID, false)
+	if err != nil {
+		err = fmt.Errorf("failed to get workflow events: %w", err)
+		utils.PrintError(err)
+		return err
+	}
+
+	health := createInstanceHealth(instanceID, string(InstanceStatus), workflowInfo, resources, resourceID, resourceKey)
+
+	if output == "table" {
+		fmt.Printf("Instance %s is %s (%s): %s\n", health.InstanceID, health.Health, health.Status, health.Summary)
+		if len(health.Resources) == 0 {
+			return nil
+		}
+		err = utils.PrintTextTableJsonArrayOutput(output, health.Resources)
+	} else {
+		err = utils.PrintTextTableJsonOutput(output, health)
+	}
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	return nil
+}
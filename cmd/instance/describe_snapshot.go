@@ -38,7 +38,7 @@ func runDescribeSnapshot(cmd *cobra.Command, args []string) error {
 	}
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 	snapshotID := args[1]
 
 	// Retrieve flags
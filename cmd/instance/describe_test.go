@@ -3,6 +3,8 @@ package instance
 import (
 	"testing"
 
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
 	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +22,7 @@ func TestDescribeCommandFlags(t *testing.T) {
 	assert.NotNil(t, describeCmd.Flag("resource-id"))
 	assert.NotNil(t, describeCmd.Flag("resource-key"))
 	assert.NotNil(t, describeCmd.Flag("deployment-status"))
+	assert.NotNil(t, describeCmd.Flag("health"))
 
 	// Test output flag default value
 	outputFlag := describeCmd.Flag("output")
@@ -297,3 +300,84 @@ func filterResourceVersionSummariesLocal(summaries []openapiclientfleet.Resource
 
 	return filteredSummaries, filterInfo, countInfo
 }
+
+func TestBuildResourceHealth(t *testing.T) {
+	completed := []dataaccess.DebugEvent{
+		{EventType: string(model.WorkflowStepStarted)},
+		{EventType: string(model.WorkflowStepCompleted)},
+	}
+	failed := []dataaccess.DebugEvent{
+		{EventType: string(model.WorkflowStepStarted)},
+		{EventType: string(model.WorkflowStepFailed), Message: "pod  crash\nlooping"},
+	}
+	started := []dataaccess.DebugEvent{{EventType: string(model.WorkflowStepStarted)}}
+
+	tests := []struct {
+		name       string
+		steps      []dataaccess.RawWorkflowStep
+		health     string
+		stepsText  string
+		failedStep string
+		message    string
+	}{
+		{"all steps completed", []dataaccess.RawWorkflowStep{{StepName: "Bootstrap", Events: completed}, {StepName: "Deployment", Events: completed}}, ResourceHealthHealthy, "2/2 completed", "", ""},
+		{"failed step", []dataaccess.RawWorkflowStep{{StepName: "Bootstrap", Events: completed}, {StepName: "Deployment", Events: failed}}, ResourceHealthFailing, "1/2 completed", "Deployment", "pod crash looping"},
+		{"step in progress", []dataaccess.RawWorkflowStep{{StepName: "Bootstrap", Events: completed}, {StepName: "Deployment", Events: started}}, ResourceHealthInProgress, "1/2 completed", "", ""},
+		{"no steps", nil, ResourceHealthPending, "0/0 completed", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := buildResourceHealth(dataaccess.ResourceWorkflowDebugEvents{ResourceID: "r-1", ResourceName: "mydb", RawSteps: tt.steps})
+			assert.Equal(t, "mydb", health.ResourceKey)
+			assert.Equal(t, tt.health, health.Health)
+			assert.Equal(t, tt.stepsText, health.Steps)
+			assert.Equal(t, tt.failedStep, health.FailedStep)
+			assert.Equal(t, tt.message, health.Message)
+		})
+	}
+}
+
+func TestRollupInstanceHealth(t *testing.T) {
+	healthy := ResourceHealth{Health: ResourceHealthHealthy}
+	failing := ResourceHealth{Health: ResourceHealthFailing}
+	inProgress := ResourceHealth{Health: ResourceHealthInProgress}
+
+	tests := []struct {
+		name      string
+		status    string
+		resources []ResourceHealth
+		verdict   string
+		summary   string
+	}{
+		{"all healthy", "RUNNING", []ResourceHealth{healthy, healthy}, InstanceHealthHealthy, "2 of 2 resource(s) healthy"},
+		{"some failing", "RUNNING", []ResourceHealth{healthy, failing}, InstanceHealthDegraded, "1 of 2 resource(s) healthy, 1 failing"},
+		{"all failing", "RUNNING", []ResourceHealth{failing}, InstanceHealthUnhealthy, "0 of 1 resource(s) healthy, 1 failing"},
+		{"in progress", "DEPLOYING", []ResourceHealth{healthy, inProgress}, InstanceHealthProgressing, "1 of 2 resource(s) healthy, 1 in progress"},
+		{"failed instance", "FAILED", []ResourceHealth{healthy}, InstanceHealthUnhealthy, "1 of 1 resource(s) healthy"},
+		{"no workflow events", "RUNNING", nil, InstanceHealthHealthy, "0 of 0 resource(s) healthy"},
+		{"no workflow events while stopped", "STOPPED", nil, InstanceHealthUnknown, "0 of 0 resource(s) healthy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, summary := rollupInstanceHealth(tt.status, tt.resources)
+			assert.Equal(t, tt.verdict, verdict)
+			assert.Equal(t, tt.summary, summary)
+		})
+	}
+}
+
+func TestCreateInstanceHealthFiltersResources(t *testing.T) {
+	resources := []dataaccess.ResourceWorkflowDebugEvents{
+		{ResourceID: "r-1", ResourceKey: "mydb"},
+		{ResourceID: "r-2", ResourceKey: "cache"},
+	}
+
+	health := createInstanceHealth("instance-123", "RUNNING", &dataaccess.WorkflowInfo{WorkflowID: "submit-create-1"}, resources, "", "cache")
+	assert.Equal(t, "submit-create-1", health.WorkflowID)
+	assert.Len(t, health.Resources, 1)
+	assert.Equal(t, "r-2", health.Resources[0].ResourceID)
+
+	health = createInstanceHealth("instance-123", "RUNNING", nil, resources, "r-1", "")
+	assert.Len(t, health.Resources, 1)
+	assert.Equal(t, "mydb", health.Resources[0].ResourceKey)
+}
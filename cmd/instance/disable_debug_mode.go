@@ -50,7 +50,7 @@ func runDisableDebug(cmd *cobra.Command, args []string) error {
 	}
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, err := cmd.Flags().GetString("output")
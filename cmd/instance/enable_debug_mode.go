@@ -50,7 +50,7 @@ func runEnableDebug(cmd *cobra.Command, args []string) error {
 	}
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	isForce, err := cmd.Flags().GetBool("force")
 	if err != nil {
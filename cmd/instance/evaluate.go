@@ -43,7 +43,7 @@ func runEvaluate(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 	resourceKey := args[1]
 
 	// Retrieve flags
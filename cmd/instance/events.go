@@ -0,0 +1,256 @@
+package instance
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	eventsExample = `# List the events of the latest workflow of an instance, grouped by workflow step
+omnistrate-ctl instance events instance-abcd1234
+
+# Only list the events of the deployment step, as JSON
+omnistrate-ctl instance events instance-abcd1234 --step deployment --output json
+
+# Print new events as they arrive until the workflow finishes, e.g. in CI logs
+omnistrate-ctl instance events instance-abcd1234 --follow`
+)
+
+// workflowEventSteps are the workflow steps in the order they run.
+var workflowEventSteps = []string{"bootstrap", "storage", "network", "compute", "deployment", "monitoring", "unknown"}
+
+// InstanceEvents is the event stream of the latest workflow of an instance
+type InstanceEvents struct {
+	InstanceID     string           `json:"instanceId"`
+	WorkflowID     string           `json:"workflowId,omitempty"`
+	WorkflowStatus string           `json:"workflowStatus,omitempty"`
+	Resources      []ResourceEvents `json:"resources"`
+}
+
+// ResourceEvents holds the events of a resource grouped by workflow step, in step order
+type ResourceEvents struct {
+	ResourceID  string               `json:"resourceId"`
+	ResourceKey string               `json:"resourceKey"`
+	Steps       []WorkflowStepEvents `json:"steps"`
+}
+
+// WorkflowStepEvents holds the events of a single workflow step
+type WorkflowStepEvents struct {
+	Step   string                  `json:"step"`
+	Status string                  `json:"status"`
+	Events []dataaccess.DebugEvent `json:"events"`
+}
+
+// WorkflowEventRow is a single event in the table output
+type WorkflowEventRow struct {
+	Resource string `json:"resource"`
+	Step     string `json:"step"`
+	Time     string `json:"time"`
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+}
+
+var eventsCmd = &cobra.Command{
+	Use:          "events [instance-id]",
+	Short:        "List the workflow events of an instance",
+	Long:         `This command lists the events of the latest workflow of an instance, grouped by resource and workflow step. It is a lightweight way to follow deployment progress without the interactive debug view. Use --step to only list the events of one workflow step, and --follow to keep printing new events until the workflow succeeds (exit 0) or fails (non-zero exit).`,
+	Example:      eventsExample,
+	RunE:         runEvents,
+	SilenceUsage: true,
+}
+
+func init() {
+	eventsCmd.Args = cobra.ExactArgs(1) // Require exactly one argument (instance ID)
+
+	eventsCmd.Flags().StringP("output", "o", "table", "Output format (table|json)")
+	eventsCmd.Flags().Bool("follow", false, "Print new events as they arrive until the workflow succeeds (exit 0) or fails (non-zero exit). With --output json, events are printed as newline-delimited JSON")
+	eventsCmd.Flags().String("step", "", "Only list the events of this workflow step ("+strings.Join(workflowEventSteps, "|")+")")
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	// Retrieve args
+	instanceID := instanceIDArg(args)
+
+	// Retrieve flags
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	if output != "table" && output != common.OutputTypeJson {
+		err = fmt.Errorf("unsupported output format %q, use table or json", output)
+		utils.PrintError(err)
+		return err
+	}
+	step, err := cmd.Flags().GetString("step")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	step = strings.ToLower(strings.TrimSpace(step))
+	if err = validateWorkflowEventStep(step); err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	// Validate user is currently logged in
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	// Initialize spinner if output is not JSON and events are not followed
+	var sm utils.SpinnerManager
+	var spinner *utils.Spinner
+	if output != common.OutputTypeJson && !follow {
+		sm = utils.NewSpinnerManager()
+		spinner = sm.AddSpinner("Fetching workflow events...")
+		sm.Start()
+	}
+
+	serviceID, environmentID, _, _, err := getInstance(cmd.Context(), token, instanceID)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	if follow {
+		if err = followInstanceEvents(cmd.Context(), token, serviceID, environmentID, instanceID, step, output, os.Stdout); err != nil {
+			utils.PrintError(err)
+			return err
+		}
+		return nil
+	}
+
+	resources, workflowInfo, err := dataaccess.GetDebugEventsForAllResources(cmd.Context(), token, serviceID, environmentID, instanceID, false)
+	if err != nil {
+		err = fmt.Errorf("failed to get workflow events: %w", err)
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	events := createInstanceEvents(instanceID, workflowInfo, resources, step)
+	utils.HandleSpinnerSuccess(spinner, sm, "Successfully retrieved workflow events")
+
+	if output == common.OutputTypeJson {
+		err = utils.PrintTextTableJsonOutput(output, events)
+	} else {
+		err = utils.PrintTextTableJsonArrayOutput(output, workflowEventRows(events))
+	}
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	return nil
+}
+
+func validateWorkflowEventStep(step string) error {
+	if step == "" {
+		return nil
+	}
+	for _, s := range workflowEventSteps {
+		if s == step {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid step %q, valid steps are: %s", step, strings.Join(workflowEventSteps, ", "))
+}
+
+// debugEventsForStep returns the events of a workflow step by its name in workflowEventSteps.
+func debugEventsForStep(events *dataaccess.DebugEventsByWorkflowSteps, step string) []dataaccess.DebugEvent {
+	if events == nil {
+		return nil
+	}
+	switch step {
+	case "bootstrap":
+		return events.Bootstrap
+	case "storage":
+		return events.Storage
+	case "network":
+		return events.Network
+	case "compute":
+		return events.Compute
+	case "deployment":
+		return events.Deployment
+	case "monitoring":
+		return events.Monitoring
+	case "unknown":
+		return events.Unknown
+	}
+	return nil
+}
+
+// createInstanceEvents groups the events of each resource by workflow step, in step order. Steps without events are
+// left out. When step is set, only that step is kept.
+func createInstanceEvents(instanceID string, workflowInfo *dataaccess.WorkflowInfo, resources []dataaccess.ResourceWorkflowDebugEvents, step string) *InstanceEvents {
+	result := &InstanceEvents{
+		InstanceID: instanceID,
+		Resources:  make([]ResourceEvents, 0, len(resources)),
+	}
+	if workflowInfo != nil {
+		result.WorkflowID = workflowInfo.WorkflowID
+		result.WorkflowStatus = workflowInfo.WorkflowStatus
+	}
+
+	for _, resource := range resources {
+		resourceEvents := ResourceEvents{
+			ResourceID:  resource.ResourceID,
+			ResourceKey: resource.ResourceKey,
+			Steps:       []WorkflowStepEvents{},
+		}
+		if resourceEvents.ResourceKey == "" {
+			resourceEvents.ResourceKey = resource.ResourceName
+		}
+		for _, s := range workflowEventSteps {
+			if step != "" && s != step {
+				continue
+			}
+			stepEvents := debugEventsForStep(resource.EventsByWorkflowStep, s)
+			if len(stepEvents) == 0 {
+				continue
+			}
+			resourceEvents.Steps = append(resourceEvents.Steps, WorkflowStepEvents{
+				Step:   s,
+				Status: getHighestPriorityEventType(stepEvents),
+				Events: stepEvents,
+			})
+		}
+		result.Resources = append(result.Resources, resourceEvents)
+	}
+
+	return result
+}
+
+// workflowEventRows flattens the events into one table row per event.
+func workflowEventRows(events *InstanceEvents) []WorkflowEventRow {
+	rows := make([]WorkflowEventRow, 0)
+	for _, resource := range events.Resources {
+		for _, step := range resource.Steps {
+			for _, event := range step.Events {
+				rows = append(rows, WorkflowEventRow{
+					Resource: resource.ResourceKey,
+					Step:     step.Step,
+					Time:     event.EventTime,
+					Type:     event.EventType,
+					Message:  strings.Join(strings.Fields(event.Message), " "),
+				})
+			}
+		}
+	}
+	return rows
+}
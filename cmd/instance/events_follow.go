@@ -0,0 +1,98 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+)
+
+// workflowEventKey identifies an event already printed by --follow.
+type workflowEventKey struct {
+	EventTime string
+	EventType string
+	Message   string
+}
+
+// unseenWorkflowEventRows returns the rows not printed yet, oldest first, and marks them as seen.
+func unseenWorkflowEventRows(rows []WorkflowEventRow, seen map[workflowEventKey]bool) []WorkflowEventRow {
+	var unseen []WorkflowEventRow
+	for _, row := range rows {
+		key := workflowEventKey{EventTime: row.Time, EventType: row.Type, Message: row.Message}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unseen = append(unseen, row)
+	}
+	sort.SliceStable(unseen, func(i, j int) bool {
+		return unseen[i].Time < unseen[j].Time
+	})
+	return unseen
+}
+
+// writeWorkflowEventRow prints a followed event as a JSON line, or as a text line for the table output.
+func writeWorkflowEventRow(out io.Writer, output string, row WorkflowEventRow) error {
+	if output == common.OutputTypeJson {
+		return json.NewEncoder(out).Encode(row)
+	}
+	_, err := fmt.Fprintf(out, "%s  %-20s %-10s %-16s %s\n", row.Time, row.Resource, row.Step, row.Type, row.Message)
+	return err
+}
+
+// followInstanceEvents polls the latest workflow of the instance and prints its new events until the workflow
+// reaches a terminal status. It returns nil once the workflow succeeds and an error if it fails. Failed fetches
+// back off like the debug view's event polling.
+func followInstanceEvents(ctx context.Context, token, serviceID, environmentID, instanceID, step, output string, out io.Writer) error {
+	seen := make(map[workflowEventKey]bool)
+	consecutiveErrors := 0
+
+	for {
+		delay := wfEventsRefreshInterval
+
+		resources, workflowInfo, err := dataaccess.GetDebugEventsForAllResources(ctx, token, serviceID, environmentID, instanceID, false)
+		if err != nil {
+			consecutiveErrors++
+			if consecutiveErrors >= followWorkflowMaxConsecutiveErrors {
+				return fmt.Errorf("failed to get workflow events: %w", err)
+			}
+			delay = wfEventsBackoffInterval(wfEventsRefreshInterval, consecutiveErrors, rand.Float64()) //nolint:gosec // jitter does not need a secure source
+		} else {
+			consecutiveErrors = 0
+			if workflowInfo == nil || workflowInfo.WorkflowID == "" {
+				return fmt.Errorf("no workflow found for instance %s", instanceID)
+			}
+
+			events := createInstanceEvents(instanceID, workflowInfo, resources, step)
+			for _, row := range unseenWorkflowEventRows(workflowEventRows(events), seen) {
+				if err = writeWorkflowEventRow(out, output, row); err != nil {
+					return err
+				}
+			}
+
+			if workflowProgressIsTerminal(workflowInfo.WorkflowStatus) {
+				if workflowProgressIsFailed(workflowInfo.WorkflowStatus) {
+					return fmt.Errorf("workflow %s failed with status: %s", workflowInfo.WorkflowID, workflowInfo.WorkflowStatus)
+				}
+				if output != common.OutputTypeJson {
+					fmt.Fprintf(out, "Workflow %s %s\n", workflowInfo.WorkflowID, workflowProgressNormalizeStatus(workflowInfo.WorkflowStatus))
+				}
+				return nil
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
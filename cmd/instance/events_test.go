@@ -0,0 +1,93 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func testInstanceEventResources() []dataaccess.ResourceWorkflowDebugEvents {
+	return []dataaccess.ResourceWorkflowDebugEvents{
+		{
+			ResourceID:  "r-web",
+			ResourceKey: "web",
+			EventsByWorkflowStep: &dataaccess.DebugEventsByWorkflowSteps{
+				Deployment: []dataaccess.DebugEvent{
+					{EventTime: "2026-06-08T15:02:00Z", EventType: string(model.WorkflowStepStarted), Message: "Deploying\n  web"},
+				},
+				Bootstrap: []dataaccess.DebugEvent{
+					{EventTime: "2026-06-08T15:00:00Z", EventType: string(model.WorkflowStepStarted), Message: "Bootstrapping"},
+					{EventTime: "2026-06-08T15:01:00Z", EventType: string(model.WorkflowStepCompleted), Message: "Bootstrapped"},
+				},
+			},
+		},
+		{
+			ResourceID:   "r-db",
+			ResourceName: "db",
+		},
+	}
+}
+
+func TestCreateInstanceEventsGroupsByStepInOrder(t *testing.T) {
+	require := require.New(t)
+
+	events := createInstanceEvents("instance-1", &dataaccess.WorkflowInfo{WorkflowID: "wf-1", WorkflowStatus: "running"}, testInstanceEventResources(), "")
+	require.Equal("wf-1", events.WorkflowID)
+	require.Equal("running", events.WorkflowStatus)
+	require.Len(events.Resources, 2)
+
+	web := events.Resources[0]
+	require.Len(web.Steps, 2)
+	require.Equal("bootstrap", web.Steps[0].Step)
+	require.Equal(string(model.WorkflowStepCompleted), web.Steps[0].Status)
+	require.Equal("deployment", web.Steps[1].Step)
+	require.Equal(string(model.WorkflowStepStarted), web.Steps[1].Status)
+
+	// Resources without events keep an empty list of steps and fall back to the resource name
+	require.Equal("db", events.Resources[1].ResourceKey)
+	require.Empty(events.Resources[1].Steps)
+
+	rows := workflowEventRows(events)
+	require.Len(rows, 3)
+	require.Equal("Bootstrapping", rows[0].Message)
+	require.Equal("Deploying web", rows[2].Message)
+}
+
+func TestCreateInstanceEventsFiltersStep(t *testing.T) {
+	events := createInstanceEvents("instance-1", nil, testInstanceEventResources(), "deployment")
+	require.Len(t, events.Resources[0].Steps, 1)
+	require.Equal(t, "deployment", events.Resources[0].Steps[0].Step)
+	require.Len(t, workflowEventRows(events), 1)
+}
+
+func TestValidateWorkflowEventStep(t *testing.T) {
+	require.NoError(t, validateWorkflowEventStep(""))
+	require.NoError(t, validateWorkflowEventStep("compute"))
+	require.Error(t, validateWorkflowEventStep("install"))
+}
+
+func TestUnseenWorkflowEventRowsDeduplicates(t *testing.T) {
+	require := require.New(t)
+
+	seen := make(map[workflowEventKey]bool)
+	first := []WorkflowEventRow{
+		{Resource: "web", Step: "deployment", Time: "2026-06-08T15:02:00Z", Type: "WorkflowStepStarted", Message: "Deploying"},
+		{Resource: "web", Step: "bootstrap", Time: "2026-06-08T15:00:00Z", Type: "WorkflowStepStarted", Message: "Bootstrapping"},
+	}
+	rows := unseenWorkflowEventRows(first, seen)
+	require.Len(rows, 2)
+	require.Equal("Bootstrapping", rows[0].Message, "new events are printed oldest first")
+
+	second := []WorkflowEventRow{
+		first[0],
+		first[1],
+		{Resource: "web", Step: "deployment", Time: "2026-06-08T15:03:00Z", Type: "WorkflowStepCompleted", Message: "Deployed"},
+	}
+	rows = unseenWorkflowEventRows(second, seen)
+	require.Len(rows, 1)
+	require.Equal("Deployed", rows[0].Message)
+
+	require.Empty(unseenWorkflowEventRows(second, seen))
+}
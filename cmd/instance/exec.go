@@ -0,0 +1,219 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sexec "k8s.io/client-go/util/exec"
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec [instance-id] --resource=resource-key -- [command...]",
+	Short: "Run a command in a pod of an instance resource",
+	Long: `Run a one-off command in a pod of an instance resource, like kubectl exec, and stream its output.
+For a terraform resource the command runs in its terraform executor pod. For other resources it runs in the first running pod of the resource in the instance namespace, or in the pod given with --pod.
+Use --tty for an interactive command such as a shell. The command exits with the exit code of the remote command.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runExec,
+	Example: `  omnistrate-ctl instance exec <instance-id> --resource=my-db -- ls /data
+  omnistrate-ctl instance exec <instance-id> --resource=my-terraform -- terraform state list
+  omnistrate-ctl instance exec <instance-id> --resource=my-db --pod=my-db-1 --container=postgres --tty -- psql`,
+}
+
+func init() {
+	execCmd.Flags().String("resource", "", "Key of the resource whose pod runs the command")
+	execCmd.Flags().String("pod", "", "Name of the pod to run the command in, instead of the resolved pod of the resource")
+	execCmd.Flags().String("container", "", "Container to run the command in (default: the first container of the pod)")
+	execCmd.Flags().String("namespace", "", "Namespace of the resource pods (default: the instance ID)")
+	execCmd.Flags().BoolP("tty", "t", false, "Attach stdin and allocate a terminal for an interactive command")
+
+	if err := execCmd.MarkFlagRequired("resource"); err != nil {
+		return
+	}
+}
+
+// resourcePod is the pod of an instance resource that commands run in and ports are forwarded to
+type resourcePod struct {
+	conn      *k8sConnection
+	namespace string
+	podName   string
+	container string
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	if cmd.ArgsLenAtDash() != 1 {
+		return utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf("expected the instance ID followed by -- and the command to run"))
+	}
+	instanceID := instanceIDArg(args)
+	command := args[1:]
+
+	resourceKey, err := cmd.Flags().GetString("resource")
+	if err != nil {
+		return fmt.Errorf("failed to get resource flag: %w", err)
+	}
+	podName, err := cmd.Flags().GetString("pod")
+	if err != nil {
+		return fmt.Errorf("failed to get pod flag: %w", err)
+	}
+	container, err := cmd.Flags().GetString("container")
+	if err != nil {
+		return fmt.Errorf("failed to get container flag: %w", err)
+	}
+	namespace, err := cmd.Flags().GetString("namespace")
+	if err != nil {
+		return fmt.Errorf("failed to get namespace flag: %w", err)
+	}
+	tty, err := cmd.Flags().GetBool("tty")
+	if err != nil {
+		return fmt.Errorf("failed to get tty flag: %w", err)
+	}
+
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	target, err := resolveResourcePod(ctx, token, instanceID, resourceKey, namespace, podName)
+	if err != nil {
+		return err
+	}
+	if container != "" {
+		target.container = container
+	}
+
+	if tty {
+		restoreErrorHandlers := suppressInteractiveExecTeardownErrors()
+		defer restoreErrorHandlers()
+		restoreTerminal := makeTerminalRaw(os.Stdin)
+		defer restoreTerminal()
+		stdin, closeStdin := newCancelableInteractiveStdin(os.Stdin)
+		defer closeStdin()
+		_, err = execInPodWithStreams(ctx, target.conn, target.namespace, target.podName, target.container, command, true, stdin, os.Stdout, nil)
+	} else {
+		_, err = execInPodWithStreams(ctx, target.conn, target.namespace, target.podName, target.container, command, false, nil, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	}
+
+	var exitErr k8sexec.ExitError
+	if errors.As(err, &exitErr) {
+		return utils.WithExitCode(exitErr.ExitStatus(), fmt.Errorf("command exited with code %d in pod %s", exitErr.ExitStatus(), target.podName))
+	}
+	return err
+}
+
+// resolveResourcePod finds the pod of the resource to run a command in or forward ports to: podName if set, else the
+// terraform executor pod of a terraform resource, or the first running pod of the resource in namespace
+func resolveResourcePod(ctx context.Context, token, instanceID, resourceKey, namespace, podName string) (*resourcePod, error) {
+	serviceID, environmentID, _, _, err := getInstance(ctx, token, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	instanceData, err := fetchInstanceDataForResource(ctx, token, serviceID, environmentID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceIndex, err := buildResourceIndex(ctx, token, serviceID, instanceData, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build resource indexes: %w", err)
+	}
+	resourceID, ok := resourceIndex.resourceIDForKey(resourceKey)
+	if !ok {
+		return nil, fmt.Errorf("resource '%s' not found in instance %s", resourceKey, instanceID)
+	}
+
+	if resourceIndex.isTerraformKey(resourceKey) {
+		progress, _, conn, err := fetchTerraformProgress(ctx, token, instanceData, instanceID, resourceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range terraformWorkspaceCandidatesForResource(ctx, conn, instanceID, resourceID, progress) {
+			name := candidate.podName
+			if podName != "" {
+				name = podName
+			}
+			if candidate.conn == nil || name == "" {
+				continue
+			}
+			if _, err := candidate.conn.clientset.CoreV1().Pods(terraformConfigMapNamespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				return &resourcePod{
+					conn:      candidate.conn,
+					namespace: terraformConfigMapNamespace,
+					podName:   name,
+					container: terraformExecutorContainer,
+				}, nil
+			}
+		}
+		return nil, fmt.Errorf("no terraform executor pod found for resource '%s'", resourceKey)
+	}
+
+	if instanceData.DeploymentCellID == nil || *instanceData.DeploymentCellID == "" {
+		return nil, fmt.Errorf("deployment cell ID not found for instance %s", instanceID)
+	}
+	conn, err := loadK8sConnectionForCell(ctx, token, *instanceData.DeploymentCellID)
+	if err != nil {
+		return nil, err
+	}
+
+	if namespace == "" {
+		namespace = instanceID
+		if id := instanceData.ConsumptionResourceInstanceResult.GetId(); id != "" {
+			namespace = id
+		}
+	}
+
+	var pod *corev1.Pod
+	if podName != "" {
+		pod, err = conn.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %s in namespace %s: %w", podName, namespace, err)
+		}
+	} else {
+		pods, err := conn.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+		}
+		if pod = selectResourcePod(pods.Items, resourceKey); pod == nil {
+			return nil, fmt.Errorf("no running pod found for resource '%s' in namespace %s: pass --pod to choose one", resourceKey, namespace)
+		}
+	}
+
+	target := &resourcePod{conn: conn, namespace: namespace, podName: pod.Name}
+	if len(pod.Spec.Containers) > 0 {
+		target.container = pod.Spec.Containers[0].Name
+	}
+	return target, nil
+}
+
+// selectResourcePod returns the first running pod, by name, whose name is the resource key or starts with it
+func selectResourcePod(pods []corev1.Pod, resourceKey string) *corev1.Pod {
+	var matches []*corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Name == resourceKey || strings.HasPrefix(pod.Name, resourceKey+"-") {
+			matches = append(matches, pod)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+	return matches[0]
+}
@@ -0,0 +1,39 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectResourcePod(t *testing.T) {
+	require := require.New(t)
+
+	now := metav1.Now()
+	pod := func(name string, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.PodStatus{Phase: phase}}
+	}
+	terminating := pod("db-0", corev1.PodRunning)
+	terminating.DeletionTimestamp = &now
+
+	pods := []corev1.Pod{
+		pod("db-2", corev1.PodRunning),
+		terminating,
+		pod("db-1", corev1.PodPending),
+		pod("dbproxy-0", corev1.PodRunning),
+		pod("db-3", corev1.PodRunning),
+	}
+
+	selected := selectResourcePod(pods, "db")
+	require.NotNil(selected)
+	require.Equal("db-2", selected.Name)
+
+	selected = selectResourcePod(pods, "dbproxy")
+	require.NotNil(selected)
+	require.Equal("dbproxy-0", selected.Name)
+
+	require.Nil(selectResourcePod(pods, "cache"))
+	require.Nil(selectResourcePod([]corev1.Pod{pod("db-0", corev1.PodSucceeded)}, "db"))
+}
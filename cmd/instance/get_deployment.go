@@ -68,7 +68,7 @@ func runGetDeployment(cmd *cobra.Command, args []string) error {
 	}
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, err := cmd.Flags().GetString("output")
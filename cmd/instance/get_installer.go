@@ -42,7 +42,7 @@ func runGetInstaller(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	outputPath, err := cmd.Flags().GetString("output-path")
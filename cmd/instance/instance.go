@@ -19,6 +19,7 @@ func init() {
 	Cmd.AddCommand(deleteCmd)
 	Cmd.AddCommand(listCmd)
 	Cmd.AddCommand(listEndpointsCmd)
+	Cmd.AddCommand(logsCmd)
 	Cmd.AddCommand(startCmd)
 	Cmd.AddCommand(stopCmd)
 	Cmd.AddCommand(restartCmd)
@@ -35,13 +36,20 @@ func init() {
 	Cmd.AddCommand(copySnapshotCmd)
 	Cmd.AddCommand(restoreCmd)
 	Cmd.AddCommand(adoptCmd)
+	Cmd.AddCommand(adoptLocalCmd)
+	Cmd.AddCommand(listAdoptedCmd)
 	Cmd.AddCommand(versionUpgradeCmd)
 	Cmd.AddCommand(debugCmd)
+	Cmd.AddCommand(debugDiffCmd)
+	Cmd.AddCommand(execCmd)
+	Cmd.AddCommand(portForwardCmd)
 	Cmd.AddCommand(breakpointCmd)
 	Cmd.AddCommand(evaluateCmd)
 	Cmd.AddCommand(getInstallerCmd)
 	Cmd.AddCommand(deploymentParametersCmd)
+	Cmd.AddCommand(paramsCmd)
 	Cmd.AddCommand(operationCmd)
+	Cmd.AddCommand(eventsCmd)
 }
 
 func run(cmd *cobra.Command, args []string) {
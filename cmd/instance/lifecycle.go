@@ -0,0 +1,66 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+)
+
+const (
+	instanceLifecycleWaitTimeout  = 30 * time.Minute
+	instanceLifecyclePollInterval = 10 * time.Second
+)
+
+// getInstanceStatus returns the current status of the instance, or UNKNOWN when it is not reported.
+func getInstanceStatus(ctx context.Context, token, serviceID, environmentID, instanceID string) (InstanceStatusType, error) {
+	instance, err := dataaccess.DescribeResourceInstance(ctx, token, serviceID, environmentID, instanceID)
+	if err != nil {
+		return InstanceStatusUnknown, err
+	}
+
+	status := strings.ToUpper(utils.FromPtr(instance.ConsumptionResourceInstanceResult.Status))
+	if status == "" {
+		return InstanceStatusUnknown, nil
+	}
+	return InstanceStatusType(status), nil
+}
+
+// checkInstanceNotInStatus returns an error if the instance is already in the requested status.
+func checkInstanceNotInStatus(instanceID string, current, target InstanceStatusType) error {
+	if current == target {
+		return fmt.Errorf("instance %s is already %s", instanceID, target)
+	}
+	return nil
+}
+
+// waitForInstanceStatus polls the instance until it reaches the target status, fails, or the wait times out.
+func waitForInstanceStatus(ctx context.Context, token, serviceID, environmentID, instanceID string, target InstanceStatusType) error {
+	timeout := time.After(instanceLifecycleWaitTimeout)
+	ticker := time.NewTicker(instanceLifecyclePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return fmt.Errorf("instance %s did not become %s after %s", instanceID, target, instanceLifecycleWaitTimeout)
+		case <-ticker.C:
+			status, err := getInstanceStatus(ctx, token, serviceID, environmentID, instanceID)
+			if err != nil {
+				return err
+			}
+
+			switch status {
+			case target:
+				return nil
+			case InstanceStatusFailed, InstanceStatusCancelled:
+				return fmt.Errorf("instance %s entered %s state while waiting for %s", instanceID, status, target)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleCommandFlags(t *testing.T) {
+	for _, cmd := range []*cobra.Command{startCmd, stopCmd} {
+		t.Run(cmd.Name(), func(t *testing.T) {
+			yesFlag := cmd.Flags().Lookup("yes")
+			require.NotNil(t, yesFlag)
+			require.Equal(t, "y", yesFlag.Shorthand)
+			require.Equal(t, "false", yesFlag.DefValue)
+			require.Equal(t, "bool", yesFlag.Value.Type())
+
+			noWaitFlag := cmd.Flags().Lookup("no-wait")
+			require.NotNil(t, noWaitFlag)
+			require.Equal(t, "false", noWaitFlag.DefValue)
+			require.Equal(t, "bool", noWaitFlag.Value.Type())
+		})
+	}
+}
+
+func TestCheckInstanceNotInStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		current InstanceStatusType
+		target  InstanceStatusType
+		wantErr string
+	}{
+		{name: "stop running instance", current: InstanceStatusRunning, target: InstanceStatusStopped},
+		{name: "start stopped instance", current: InstanceStatusStopped, target: InstanceStatusRunning},
+		{name: "start unknown instance", current: InstanceStatusUnknown, target: InstanceStatusRunning},
+		{name: "stop stopped instance", current: InstanceStatusStopped, target: InstanceStatusStopped, wantErr: "instance instance-1 is already STOPPED"},
+		{name: "start running instance", current: InstanceStatusRunning, target: InstanceStatusRunning, wantErr: "instance instance-1 is already RUNNING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkInstanceNotInStatus("instance-1", tt.current, tt.target)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package instance
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
@@ -22,15 +24,31 @@ omnistrate-ctl instance list -f="service:postgres,environment:Production" -f="se
 omnistrate-ctl instance list --tag env=prod --tag team=backend
 
 # Combine regular filters with tag filters
-omnistrate-ctl instance list -f="service:postgres" --tag env=prod`
+omnistrate-ctl instance list -f="service:postgres" --tag env=prod
+
+# List every instance instead of the first 50
+omnistrate-ctl instance list --all
+
+# List the instances of the service postgres across all its environments, grouped by environment
+omnistrate-ctl instance list -f="service:postgres" --all-environments
+
+# Export the running instances as CSV for a spreadsheet
+omnistrate-ctl instance list -f="status:RUNNING" --output csv > instances.csv
+
+# Print custom columns with a Go template over the instance search records
+omnistrate-ctl instance list --output template --template '{{.Id}} {{.Status}} {{.RegionCode}}'`
 	defaultMaxNameLength = 30 // Maximum length of the name column in the table
+	defaultListLimit     = 50 // Maximum number of instances listed without --all
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list [flags]",
 	Short: "List instance deployments for your service",
 	Long: `This command helps you list instance deployments for your service.
-You can filter for specific instances by using the filter flag.`,
+You can filter for specific instances by using the filter flag.
+Use --all-environments to sort the instances by environment and show the type of each environment.
+Use --output csv to print a header row and one comma-separated row per instance, with the table columns.
+Use --output template with --template to render each instance search record through a Go text/template.`,
 	Example:      listExample,
 	RunE:         runList,
 	SilenceUsage: true,
@@ -41,6 +59,10 @@ func init() {
 	listCmd.Flags().StringArray("tag", []string{}, "Filter instances by tags. Specify tags as key=value pairs. Multiple --tag flags can be used to filter by multiple tags (all tags must match).")
 	listCmd.Flags().Bool("truncate", false, "Truncate long names in the output")
 	listCmd.Flags().BoolP("interactive", "i", false, "Launch interactive list with fuzzy search and selection")
+	listCmd.Flags().Int("limit", defaultListLimit, "Maximum number of instances to list (0 for no limit)")
+	listCmd.Flags().Bool("all", false, "List every instance, ignoring --limit")
+	listCmd.Flags().Bool("all-environments", false, "Sort the instances by environment and show the type of each environment")
+	listCmd.Flags().String(common.TemplateFlag, "", "Go template applied to each instance search record when --output=template, e.g. '{{.Id}} {{.Status}}'")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -72,6 +94,46 @@ func runList(cmd *cobra.Command, args []string) error {
 		utils.PrintError(err)
 		return err
 	}
+	templateText, err := cmd.Flags().GetString(common.TemplateFlag)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	allEnvironments, err := cmd.Flags().GetBool("all-environments")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	if all && cmd.Flags().Changed("limit") {
+		err = fmt.Errorf("--limit cannot be used with --all")
+		utils.PrintError(err)
+		return err
+	}
+	if limit < 0 {
+		err = fmt.Errorf("--limit must not be negative")
+		utils.PrintError(err)
+		return err
+	}
+	if all {
+		limit = 0
+	}
+
+	// Parse the output template before fetching anything so a bad field fails fast
+	tmpl, err := common.ParseOutputTemplate[openapiclientfleet.ResourceInstanceSearchRecord](output, templateText)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
 
 	// Parse filters into a map
 	filterMaps, err := utils.ParseFilters(filters, utils.GetSupportedFilterKeys(model.Instance{}))
@@ -94,10 +156,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Initialize spinner if output is not JSON and not interactive
+	// Initialize spinner if output is not JSON, CSV or a template and not interactive
 	var sm utils.SpinnerManager
 	var spinner *utils.Spinner
-	if output != common.OutputTypeJson && !interactive {
+	if output != common.OutputTypeJson && output != common.OutputTypeCsv && output != common.OutputTypeTemplate && !interactive {
 		sm = utils.NewSpinnerManager()
 		spinner = sm.AddSpinner("Listing instance deployments...")
 		sm.Start()
@@ -117,7 +179,12 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if allEnvironments {
+		sortInstancesByEnvironment(searchRes.ResourceInstanceResults)
+	}
+
 	formattedInstances := make([]model.Instance, 0)
+	matchedRecords := make([]openapiclientfleet.ResourceInstanceSearchRecord, 0)
 	for i := range searchRes.ResourceInstanceResults {
 		instance := searchRes.ResourceInstanceResults[i]
 		if instance.Id == "" {
@@ -126,6 +193,7 @@ func runList(cmd *cobra.Command, args []string) error {
 
 		// Format instance
 		formattedInstance := formatInstance(&instance, truncateNames)
+		formattedInstance.EnvironmentType = instance.GetServiceEnvironmentType()
 
 		// Check if the instance matches the filters
 		ok, err := utils.MatchesFilters(formattedInstance, filterMaps)
@@ -142,13 +210,31 @@ func runList(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
+		// The environment type can be filtered on, but is only shown with --all-environments
+		if !allEnvironments {
+			formattedInstance.EnvironmentType = ""
+		}
 		formattedInstances = append(formattedInstances, formattedInstance)
+		matchedRecords = append(matchedRecords, instance)
 	}
 
-	if len(formattedInstances) == 0 {
+	total := len(formattedInstances)
+	formattedInstances, truncated := limitListedInstances(formattedInstances, limit)
+	matchedRecords, _ = limitListedInstances(matchedRecords, limit)
+
+	switch {
+	case total == 0:
 		utils.HandleSpinnerSuccess(spinner, sm, "No instances found.")
-	} else {
-		utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Found %d instance(s).", len(formattedInstances)))
+	case truncated:
+		hint := fmt.Sprintf("Showing %d of %d instance(s); use --all to list every instance.", len(formattedInstances), total)
+		if spinner != nil {
+			utils.HandleSpinnerSuccess(spinner, sm, hint)
+		} else {
+			// Keep JSON, CSV and template output parseable
+			fmt.Fprintln(os.Stderr, hint)
+		}
+	default:
+		utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Found %d instance(s).", total))
 	}
 
 	// Interactive mode: launch TUI list
@@ -156,6 +242,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		return runInteractiveInstanceList(formattedInstances)
 	}
 
+	if tmpl != nil {
+		return utils.PrintTemplateOutput(tmpl, matchedRecords)
+	}
+
 	// Print output
 	err = utils.PrintTextTableJsonArrayOutput(output, formattedInstances)
 	if err != nil {
@@ -165,6 +255,25 @@ func runList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// limitListedInstances returns the first limit items, and whether any were left out. A limit of zero keeps every item.
+func limitListedInstances[T any](items []T, limit int) ([]T, bool) {
+	if limit <= 0 || len(items) <= limit {
+		return items, false
+	}
+	return items[:limit], true
+}
+
+// sortInstancesByEnvironment orders instance search records by environment name, then service name, so the instances
+// of each environment are listed together. Records of the same environment and service keep their order.
+func sortInstancesByEnvironment(records []openapiclientfleet.ResourceInstanceSearchRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].ServiceEnvironmentName != records[j].ServiceEnvironmentName {
+			return records[i].ServiceEnvironmentName < records[j].ServiceEnvironmentName
+		}
+		return records[i].ServiceName < records[j].ServiceName
+	})
+}
+
 func hasResourceInstanceFilters(filters openapiclientfleet.SearchInventoryFilters) bool {
 	return filters.ResourceInstance != nil &&
 		(len(filters.ResourceInstance.Predicates) > 0 || len(filters.ResourceInstance.Tags) > 0)
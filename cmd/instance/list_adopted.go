@@ -0,0 +1,54 @@
+package instance
+
+import (
+	"errors"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listAdoptedExample = `# List the instances adopted with 'instance adopt-local <instance-id>'
+omnistrate-ctl instance list-adopted`
+)
+
+var listAdoptedCmd = &cobra.Command{
+	Use:          "list-adopted [flags]",
+	Short:        "List instances adopted into the local config",
+	Long:         `This command lists the existing instances recorded in the local config with 'instance adopt-local <instance-id>', together with their aliases.`,
+	Example:      listAdoptedExample,
+	Args:         cobra.NoArgs,
+	RunE:         runListAdopted,
+	SilenceUsage: true,
+}
+
+func runListAdopted(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	output, _ := cmd.Flags().GetString("output")
+
+	adopted, err := config.ListAdoptedInstances()
+	if err != nil && !errors.Is(err, config.ErrConfigFileNotFound) {
+		utils.PrintError(err)
+		return err
+	}
+
+	if len(adopted) == 0 {
+		utils.PrintInfo("No adopted instances found. Run 'omnistrate-ctl instance adopt-local <instance-id>' to adopt one.")
+		return nil
+	}
+
+	instances := make([]model.AdoptedInstance, 0, len(adopted))
+	for _, instance := range adopted {
+		instances = append(instances, formatAdoptedInstance(instance))
+	}
+
+	if err = utils.PrintTextTableJsonArrayOutput(output, instances); err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	return nil
+}
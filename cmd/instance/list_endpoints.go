@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
@@ -17,7 +18,16 @@ import (
 
 const (
 	listEndpointsExample = `# List endpoints for a specific instance
-omnistrate-ctl instance list-endpoints instance-abcd1234`
+omnistrate-ctl instance list-endpoints instance-abcd1234
+
+# List the endpoints of a single resource
+omnistrate-ctl instance list-endpoints instance-abcd1234 --resource web
+
+# Print resource.endpoint-name=url lines for use in scripts or an env file
+omnistrate-ctl instance list-endpoints instance-abcd1234 --flat > endpoints.env
+
+# Check that the endpoints of a freshly deployed instance are reachable
+omnistrate-ctl instance list-endpoints instance-abcd1234 --check`
 )
 
 // ResourceEndpoints represents the endpoints for a resource
@@ -25,6 +35,7 @@ type ResourceEndpoints struct {
 	ClusterEndpoint     string                                        `json:"cluster_endpoint"`
 	ClusterPorts        []int64                                       `json:"cluster_ports,omitempty"`
 	AdditionalEndpoints map[string]openapiclientfleet.ClusterEndpoint `json:"additional_endpoints"`
+	Checks              map[string]EndpointCheck                      `json:"checks,omitempty"` // probe results keyed by URL, set by --check
 }
 
 // EndpointTableRow represents a single row in the table output
@@ -36,6 +47,8 @@ type EndpointTableRow struct {
 	Status       string `json:"status,omitempty"`
 	NetworkType  string `json:"network_type,omitempty"`
 	Ports        string `json:"ports,omitempty"`
+	Reachable    string `json:"reachable,omitempty"`
+	Latency      string `json:"latency,omitempty"`
 }
 
 var (
@@ -55,7 +68,7 @@ var (
 var listEndpointsCmd = &cobra.Command{
 	Use:          "list-endpoints [instance-id]",
 	Short:        "List endpoints for a specific instance",
-	Long:         `This command lists all additional endpoints and cluster endpoint for a specific instance by instance ID.`,
+	Long:         `This command lists all additional endpoints and cluster endpoint for a specific instance by instance ID. Use --resource to only list the endpoints of one resource, --flat to print sorted resource.endpoint-name=url lines for automation, and --check to probe each endpoint and report whether it is reachable.`,
 	Example:      listEndpointsExample,
 	RunE:         runListEndpoints,
 	SilenceUsage: true,
@@ -63,13 +76,18 @@ var listEndpointsCmd = &cobra.Command{
 
 func init() {
 	listEndpointsCmd.Args = cobra.ExactArgs(1) // Require exactly one argument (instance ID)
+
+	listEndpointsCmd.Flags().String("resource", "", "Only list the endpoints of the resource with this name")
+	listEndpointsCmd.Flags().Bool("flat", false, "Print one sorted resource.endpoint-name=url line per endpoint, skipping resources without endpoints")
+	listEndpointsCmd.Flags().Bool("check", false, "Probe each endpoint (HTTP for web ports, TCP otherwise) and report reachability and latency")
+	listEndpointsCmd.Flags().Duration("check-timeout", defaultEndpointCheckTimeout, "Timeout for each endpoint probe when --check is set")
 }
 
 func runListEndpoints(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, err := cmd.Flags().GetString("output")
@@ -77,6 +95,31 @@ func runListEndpoints(cmd *cobra.Command, args []string) error {
 		utils.PrintError(err)
 		return err
 	}
+	resourceName, err := cmd.Flags().GetString("resource")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	flat, err := cmd.Flags().GetBool("flat")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	check, err := cmd.Flags().GetBool("check")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	checkTimeout, err := cmd.Flags().GetDuration("check-timeout")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	if check && checkTimeout <= 0 {
+		err = fmt.Errorf("--check-timeout must be greater than zero")
+		utils.PrintError(err)
+		return err
+	}
 
 	// Validate user is currently logged in
 	token, err := common.GetTokenWithLogin()
@@ -85,10 +128,10 @@ func runListEndpoints(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Initialize spinner if output is not JSON
+	// Initialize spinner if output is not JSON or flat
 	var sm utils.SpinnerManager
 	var spinner *utils.Spinner
-	if output != common.OutputTypeJson {
+	if output != common.OutputTypeJson && !flat {
 		sm = utils.NewSpinnerManager()
 		spinner = sm.AddSpinner("Fetching endpoint information...")
 		sm.Start()
@@ -108,6 +151,21 @@ func runListEndpoints(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if resourceName != "" {
+		resourceEndpoints, err = filterResourceEndpoints(resourceEndpoints, resourceName)
+		if err != nil {
+			utils.HandleSpinnerError(spinner, sm, err)
+			return err
+		}
+	}
+
+	if flat {
+		for _, line := range flatEndpointLines(resourceEndpoints) {
+			fmt.Println(line)
+		}
+		return nil
+	}
+
 	if len(resourceEndpoints) == 0 {
 		utils.HandleSpinnerSuccess(spinner, sm, "No endpoint information found for this instance.")
 		// Print empty result for consistency
@@ -123,6 +181,13 @@ func runListEndpoints(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if check {
+		if spinner != nil {
+			spinner.UpdateMessage("Checking endpoint reachability...")
+		}
+		attachEndpointChecks(cmd.Context(), resourceEndpoints, checkTimeout, probeEndpoint)
+	}
+
 	utils.HandleSpinnerSuccess(spinner, sm, "Successfully retrieved endpoint information")
 
 	// Print output
@@ -131,6 +196,9 @@ func runListEndpoints(cmd *cobra.Command, args []string) error {
 	} else {
 		// Convert to table format for better readability
 		tableRows := convertToTableRows(resourceEndpoints)
+		if check {
+			annotateRowChecks(tableRows, resourceEndpoints)
+		}
 		err = utils.PrintTextTableJsonArrayOutput(output, tableRows)
 	}
 	if err != nil {
@@ -141,6 +209,67 @@ func runListEndpoints(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// filterResourceEndpoints keeps only the endpoints of the named resource. Names are matched case-insensitively.
+func filterResourceEndpoints(resourceEndpoints map[string]ResourceEndpoints, resourceName string) (map[string]ResourceEndpoints, error) {
+	names := make([]string, 0, len(resourceEndpoints))
+	for name, endpoints := range resourceEndpoints {
+		if strings.EqualFold(name, strings.TrimSpace(resourceName)) {
+			return map[string]ResourceEndpoints{name: endpoints}, nil
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return resourceEndpoints, nil
+	}
+	return nil, fmt.Errorf("resource %s has no endpoints. Resources with endpoints: %s", resourceName, strings.Join(names, ", "))
+}
+
+// flatEndpointLines renders endpoints as resource.endpoint-name=url lines sorted by key. Endpoints that
+// listen on several ports get one line per port with the port appended to the key.
+func flatEndpointLines(resourceEndpoints map[string]ResourceEndpoints) []string {
+	var lines []string
+	add := func(key, endpoint string, ports []int64) {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			return
+		}
+		if len(ports) <= 1 {
+			lines = append(lines, fmt.Sprintf("%s=%s", key, formatEndpointURLs(endpoint, ports)[0]))
+			return
+		}
+		sortedPorts := append([]int64(nil), ports...)
+		sort.Slice(sortedPorts, func(i, j int) bool {
+			return sortedPorts[i] < sortedPorts[j]
+		})
+		for _, port := range sortedPorts {
+			lines = append(lines, fmt.Sprintf("%s.%d=%s", key, port, formatEndpointURL(endpoint, port)))
+		}
+	}
+
+	for resourceName, endpoints := range resourceEndpoints {
+		resourceKey := flatEndpointKey(resourceName)
+		add(resourceKey+".cluster_endpoint", endpoints.ClusterEndpoint, endpoints.ClusterPorts)
+		for endpointName, endpoint := range endpoints.AdditionalEndpoints {
+			add(resourceKey+"."+flatEndpointKey(endpointName), utils.FromPtr(endpoint.Endpoint), endpoint.OpenPorts)
+		}
+	}
+
+	sort.Strings(lines)
+	return lines
+}
+
+// flatEndpointKey replaces whitespace and "=" so names can be used as keys in flat output.
+func flatEndpointKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '=' || unicode.IsSpace(r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+}
+
 // getInstanceWithResourceName gets instance details including resource name
 func getInstanceWithResourceName(ctx context.Context, token, instanceID string) (serviceID, environmentID string, err error) {
 	searchRes, err := dataaccess.SearchInventory(ctx, token, fmt.Sprintf("resourceinstance:%s", instanceID))
@@ -0,0 +1,175 @@
+package instance
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultEndpointCheckTimeout = 5 * time.Second
+	// endpointCheckConcurrency bounds the number of probes in flight at once
+	endpointCheckConcurrency = 8
+	// defaultEndpointCheckPort is dialed for endpoints that carry neither a scheme nor a port
+	defaultEndpointCheckPort = "443"
+)
+
+// EndpointCheck is the result of probing a single endpoint URL.
+type EndpointCheck struct {
+	Reachable bool   `json:"reachable"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type endpointProber func(ctx context.Context, target string, timeout time.Duration) error
+
+// probeEndpoint issues an HTTP GET for http(s) URLs and a TCP dial otherwise. Any HTTP response,
+// whatever its status, counts as reachable.
+func probeEndpoint(ctx context.Context, target string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			return err
+		}
+		client := &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return res.Body.Close()
+	}
+
+	address := target
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, defaultEndpointCheckPort)
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// checkEndpoints probes every target concurrently, with at most endpointCheckConcurrency probes in flight.
+func checkEndpoints(ctx context.Context, targets []string, timeout time.Duration, probe endpointProber) map[string]EndpointCheck {
+	results := make(map[string]EndpointCheck, len(targets))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, endpointCheckConcurrency)
+
+	for _, target := range targets {
+		mu.Lock()
+		_, seen := results[target]
+		if !seen {
+			results[target] = EndpointCheck{}
+		}
+		mu.Unlock()
+		if seen {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(target string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			err := probe(ctx, target, timeout)
+			check := EndpointCheck{Reachable: err == nil, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				check.Error = err.Error()
+			}
+
+			mu.Lock()
+			results[target] = check
+			mu.Unlock()
+		}(target)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// endpointCheckTargets returns the URLs probed for each resource: the cluster endpoint and the additional
+// endpoints, one URL per open port.
+func endpointCheckTargets(resourceEndpoints map[string]ResourceEndpoints) map[string][]string {
+	targets := make(map[string][]string, len(resourceEndpoints))
+	for resourceName, endpoints := range resourceEndpoints {
+		urls := formatEndpointURLs(endpoints.ClusterEndpoint, endpoints.ClusterPorts)
+		for _, endpoint := range endpoints.AdditionalEndpoints {
+			if endpoint.Endpoint == nil {
+				continue
+			}
+			urls = append(urls, formatEndpointURLs(*endpoint.Endpoint, endpoint.OpenPorts)...)
+		}
+		sort.Strings(urls)
+		targets[resourceName] = urls
+	}
+	return targets
+}
+
+// attachEndpointChecks probes all endpoints and records the results on each resource.
+func attachEndpointChecks(ctx context.Context, resourceEndpoints map[string]ResourceEndpoints, timeout time.Duration, probe endpointProber) {
+	targetsByResource := endpointCheckTargets(resourceEndpoints)
+
+	var allTargets []string
+	for _, targets := range targetsByResource {
+		allTargets = append(allTargets, targets...)
+	}
+	results := checkEndpoints(ctx, allTargets, timeout, probe)
+
+	for resourceName, targets := range targetsByResource {
+		endpoints := resourceEndpoints[resourceName]
+		endpoints.Checks = make(map[string]EndpointCheck, len(targets))
+		for _, target := range targets {
+			endpoints.Checks[target] = results[target]
+		}
+		resourceEndpoints[resourceName] = endpoints
+	}
+}
+
+// annotateRowChecks fills the reachability columns of table rows from the resource check results.
+// A row is reachable when every URL it stands for is reachable; the latency shown is the slowest probe.
+func annotateRowChecks(rows []EndpointTableRow, resourceEndpoints map[string]ResourceEndpoints) {
+	for i := range rows {
+		row := &rows[i]
+		endpoints := resourceEndpoints[row.ResourceName]
+
+		targets := []string{row.URL}
+		if row.EndpointType == "additional" {
+			targets = formatEndpointURLs(row.URL, endpoints.AdditionalEndpoints[row.EndpointName].OpenPorts)
+		}
+
+		reachable := len(targets) > 0
+		var latency int64
+		for _, target := range targets {
+			check, ok := endpoints.Checks[target]
+			if !ok || !check.Reachable {
+				reachable = false
+			}
+			if check.LatencyMs > latency {
+				latency = check.LatencyMs
+			}
+		}
+
+		row.Reachable = "unreachable"
+		if reachable {
+			row.Reachable = "reachable"
+		}
+		row.Latency = (time.Duration(latency) * time.Millisecond).String()
+	}
+}
@@ -0,0 +1,105 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEndpointsBoundsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	probe := func(ctx context.Context, target string, timeout time.Duration) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if target == "down.example.com:5432" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	targets := []string{"down.example.com:5432", "down.example.com:5432"}
+	for i := 0; i < 20; i++ {
+		targets = append(targets, fmt.Sprintf("https://svc%d.example.com:443", i))
+	}
+
+	results := checkEndpoints(context.Background(), targets, time.Second, probe)
+	assert.Len(t, results, 21)
+	assert.LessOrEqual(t, maxInFlight, endpointCheckConcurrency)
+	assert.True(t, results["https://svc0.example.com:443"].Reachable)
+	assert.False(t, results["down.example.com:5432"].Reachable)
+	assert.Equal(t, "connection refused", results["down.example.com:5432"].Error)
+}
+
+func TestAttachEndpointChecksAndAnnotateRows(t *testing.T) {
+	resourceEndpoints := map[string]ResourceEndpoints{
+		"web": {
+			ClusterEndpoint: "web.example.com",
+			ClusterPorts:    []int64{443},
+			AdditionalEndpoints: map[string]openapiclientfleet.ClusterEndpoint{
+				"db": {Endpoint: utils.ToPtr("db.example.com"), OpenPorts: []int64{5432, 5433}},
+			},
+		},
+	}
+	probe := func(ctx context.Context, target string, timeout time.Duration) error {
+		if target == "db.example.com:5433" {
+			return errors.New("timeout")
+		}
+		return nil
+	}
+
+	attachEndpointChecks(context.Background(), resourceEndpoints, time.Second, probe)
+	checks := resourceEndpoints["web"].Checks
+	assert.Len(t, checks, 3)
+	assert.True(t, checks["https://web.example.com:443"].Reachable)
+	assert.True(t, checks["db.example.com:5432"].Reachable)
+	assert.False(t, checks["db.example.com:5433"].Reachable)
+
+	rows := convertToTableRows(resourceEndpoints)
+	annotateRowChecks(rows, resourceEndpoints)
+	for _, row := range rows {
+		if row.EndpointType == "cluster" {
+			assert.Equal(t, "reachable", row.Reachable)
+		} else {
+			assert.Equal(t, "unreachable", row.Reachable)
+		}
+		assert.NotEmpty(t, row.Latency)
+	}
+}
+
+func TestProbeEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	require.NoError(t, probeEndpoint(context.Background(), server.URL, time.Second))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, probeEndpoint(context.Background(), address, time.Second))
+	require.NoError(t, listener.Close())
+
+	assert.Error(t, probeEndpoint(context.Background(), address, time.Second))
+}
@@ -168,3 +168,47 @@ func TestExtractEndpointsHidesObservabilityResource(t *testing.T) {
 	assert.NotContains(t, endpoints, "Omnistrate Observability")
 	assert.Equal(t, []int64{443}, endpoints["Application"].ClusterPorts)
 }
+
+func TestFilterResourceEndpoints(t *testing.T) {
+	resourceEndpoints := map[string]ResourceEndpoints{
+		"Web":    {ClusterEndpoint: "web.example.com"},
+		"Worker": {ClusterEndpoint: "worker.example.com"},
+	}
+
+	filtered, err := filterResourceEndpoints(resourceEndpoints, "web")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]ResourceEndpoints{"Web": {ClusterEndpoint: "web.example.com"}}, filtered)
+
+	_, err = filterResourceEndpoints(resourceEndpoints, "cache")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Web, Worker")
+
+	filtered, err = filterResourceEndpoints(map[string]ResourceEndpoints{}, "web")
+	assert.NoError(t, err)
+	assert.Empty(t, filtered)
+}
+
+func TestFlatEndpointLines(t *testing.T) {
+	resourceEndpoints := map[string]ResourceEndpoints{
+		"web": {
+			ClusterEndpoint: "web.example.com",
+			ClusterPorts:    []int64{443, 80},
+			AdditionalEndpoints: map[string]openapiclientfleet.ClusterEndpoint{
+				"admin ui": {Endpoint: utils.ToPtr("admin.example.com"), OpenPorts: []int64{8443}},
+				"empty":    {},
+			},
+		},
+		"db": {
+			ClusterEndpoint: "db.example.com",
+		},
+	}
+
+	assert.Equal(t, []string{
+		"db.cluster_endpoint=db.example.com",
+		"web.admin-ui=admin.example.com:8443",
+		"web.cluster_endpoint.443=https://web.example.com:443",
+		"web.cluster_endpoint.80=http://web.example.com:80",
+	}, flatEndpointLines(resourceEndpoints))
+
+	assert.Empty(t, flatEndpointLines(nil))
+}
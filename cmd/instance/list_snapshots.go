@@ -40,7 +40,7 @@ func runListSnapshots(cmd *cobra.Command, args []string) error {
 	}
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, err := cmd.Flags().GetString("output")
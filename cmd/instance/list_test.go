@@ -58,3 +58,37 @@ func TestBuildResourceInstanceSearchFiltersReturnsEmptyForUnsupportedOnly(t *tes
 
 	assert.False(t, hasResourceInstanceFilters(filters))
 }
+
+func TestLimitListedInstances(t *testing.T) {
+	items := []string{"instance-1", "instance-2", "instance-3"}
+
+	limited, truncated := limitListedInstances(items, 2)
+	assert.Equal(t, []string{"instance-1", "instance-2"}, limited)
+	assert.True(t, truncated)
+
+	limited, truncated = limitListedInstances(items, 3)
+	assert.Equal(t, items, limited)
+	assert.False(t, truncated)
+
+	// A limit of zero keeps every item, as with --all
+	limited, truncated = limitListedInstances(items, 0)
+	assert.Equal(t, items, limited)
+	assert.False(t, truncated)
+}
+
+func TestSortInstancesByEnvironment(t *testing.T) {
+	records := []openapiclientfleet.ResourceInstanceSearchRecord{
+		{Id: "instance-1", ServiceEnvironmentName: "prod", ServiceName: "postgres"},
+		{Id: "instance-2", ServiceEnvironmentName: "dev", ServiceName: "redis"},
+		{Id: "instance-3", ServiceEnvironmentName: "prod", ServiceName: "mysql"},
+		{Id: "instance-4", ServiceEnvironmentName: "dev", ServiceName: "redis"},
+	}
+
+	sortInstancesByEnvironment(records)
+
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.Id
+	}
+	assert.Equal(t, []string{"instance-2", "instance-4", "instance-3", "instance-1"}, ids)
+}
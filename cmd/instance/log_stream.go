@@ -0,0 +1,312 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+)
+
+const (
+	// watchLogsReconnectDelay is how long to wait before the first reconnect of a log stream that ended; the
+	// delay doubles with every reconnect that delivers no messages, up to watchLogsMaxReconnectDelay
+	watchLogsReconnectDelay    = 2 * time.Second
+	watchLogsMaxReconnectDelay = time.Minute
+	// watchLogsTailLines is how many printed lines of a stream are remembered to skip the ones a reconnect replays
+	watchLogsTailLines         = 200
+	watchLogsReconnectedMarker = "--- reconnected, some lines may be missing ---"
+	observabilityResourceKey   = "omnistrateobserv"
+)
+
+var ansiEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// stripANSI removes ANSI escape sequences such as color codes from s.
+func stripANSI(s string) string {
+	return ansiEscapeRegex.ReplaceAllString(s, "")
+}
+
+// WatchLogsNoColor reports whether streamed logs should have ANSI codes stripped.
+func WatchLogsNoColor(noColorFlag bool) bool {
+	return noColorFlag || os.Getenv("NO_COLOR") != ""
+}
+
+// primaryLogResourceKey picks the resource whose logs are tailed: the deployed resource when known,
+// otherwise the main resource, otherwise the first resource with nodes.
+func primaryLogResourceKey(topology map[string]openapiclientfleet.ResourceNetworkTopologyResult, resourceID string) string {
+	if entry, ok := topology[resourceID]; ok && resourceID != "" {
+		return entry.ResourceKey
+	}
+
+	ids := make([]string, 0, len(topology))
+	for id := range topology {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if topology[id].Main && topology[id].ResourceKey != observabilityResourceKey {
+			return topology[id].ResourceKey
+		}
+	}
+	for _, id := range ids {
+		if len(topology[id].Nodes) > 0 && topology[id].ResourceKey != observabilityResourceKey {
+			return topology[id].ResourceKey
+		}
+	}
+	return ""
+}
+
+// primaryLogStreams returns the log streams of every pod of the instance's primary resource, and the key of
+// that resource.
+func primaryLogStreams(ctx context.Context, logsService *dataaccess.LogsService, token, serviceID, environmentID, instanceID, resourceID string) (string, []dataaccess.LogsStream, error) {
+	instance, err := dataaccess.DescribeResourceInstance(ctx, token, serviceID, environmentID, instanceID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to describe instance %s: %w", instanceID, err)
+	}
+
+	var topology map[string]openapiclientfleet.ResourceNetworkTopologyResult
+	if instance.ConsumptionResourceInstanceResult.DetailedNetworkTopology != nil {
+		topology = *instance.ConsumptionResourceInstanceResult.DetailedNetworkTopology
+	}
+	resourceKey := primaryLogResourceKey(topology, resourceID)
+	if resourceKey == "" {
+		return "", nil, fmt.Errorf("no resource with logs found in instance %s", instanceID)
+	}
+
+	streams, err := logsService.BuildLogStreams(instance, instanceID, resourceKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return resourceKey, streams, nil
+}
+
+// LogWatchTarget is an instance whose logs are tailed by WatchInstancesLogs, with the resource it was deployed for.
+type LogWatchTarget struct {
+	InstanceID string
+	ResourceID string
+}
+
+// WatchInstancesLogs tails the logs of every pod of the primary resource of each target to out until the user
+// presses Ctrl-C. With several targets every line is prefixed with its resource key; a target whose logs cannot be
+// found is reported on stderr while the others are still streamed.
+func WatchInstancesLogs(ctx context.Context, token, serviceID, environmentID string, targets []LogWatchTarget, noColor bool, out io.Writer) error {
+	type resourceLogStreams struct {
+		resourceKey string
+		streams     []dataaccess.LogsStream
+	}
+
+	logsService := dataaccess.NewLogsService()
+	var found []resourceLogStreams
+	var errs []error
+	for _, target := range targets {
+		resourceKey, streams, err := primaryLogStreams(ctx, logsService, token, serviceID, environmentID, target.InstanceID, target.ResourceID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		found = append(found, resourceLogStreams{resourceKey: resourceKey, streams: streams})
+	}
+	if len(found) == 0 {
+		return errors.Join(errs...)
+	}
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Log streaming unavailable: %s\n", err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	resources := make([]string, 0, len(found))
+	for _, resource := range found {
+		resources = append(resources, fmt.Sprintf("%s (%d pod(s))", resource.resourceKey, len(resource.streams)))
+	}
+	noun := "resource"
+	if len(found) > 1 {
+		noun = "resources"
+	}
+	fmt.Fprintf(out, "Streaming logs for %s %s. Press Ctrl-C to stop.\n", noun, strings.Join(resources, ", "))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, resource := range found {
+		for _, stream := range resource.streams {
+			prefix := watchLogsPrefix(resource.resourceKey, stream.PodName, len(found), len(resource.streams))
+			wg.Add(1)
+			go func(stream dataaccess.LogsStream, prefix string) {
+				defer wg.Done()
+				tailLogStream(ctx, logsService, stream, prefix, noColor, nil, out, &mu)
+			}(stream, prefix)
+		}
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// watchLogsPrefix returns the prefix of the lines of one pod's log stream: the resource key when several resources
+// are streamed, the pod name when the resource has several pods, and nothing for a single pod of a single resource.
+func watchLogsPrefix(resourceKey, podName string, resources, pods int) string {
+	switch {
+	case resources > 1 && pods > 1:
+		return fmt.Sprintf("[%s/%s] ", resourceKey, podName)
+	case resources > 1:
+		return fmt.Sprintf("[%s] ", resourceKey)
+	case pods > 1:
+		return fmt.Sprintf("[%s] ", podName)
+	}
+	return ""
+}
+
+// tailLogStream copies messages from one pod's log stream to out, reconnecting when the stream
+// ends, until ctx is cancelled. Each reconnect is announced on stderr and the delay before it backs off
+// exponentially until a connection delivers messages again; the stream is given up when it rejects the connection
+// as unauthorized or not found. The log stream has no resume offset, so lines a reconnect replays are skipped
+// using the tail of the lines already printed, and a marker shows where the stream reconnected. Only the lines
+// keep accepts are printed; a nil keep prints every line.
+func tailLogStream(ctx context.Context, logsService *dataaccess.LogsService, stream dataaccess.LogsStream, prefix string, noColor bool, keep func(line string) bool, out io.Writer, mu *sync.Mutex) {
+	tail := newLogTail(watchLogsTailLines)
+	connected := false
+	delay := watchLogsReconnectDelay
+	for ctx.Err() == nil {
+		received := false
+		conn, err := logsService.ConnectToLogStream(stream.LogsURL)
+		if err == nil {
+			if connected {
+				tail.resync()
+				writeLogLines(out, mu, prefix, watchLogsReconnectedMarker, noColor, nil)
+			}
+			connected = true
+
+			stopClose := context.AfterFunc(ctx, func() { _ = conn.Close() })
+			for {
+				message, readErr := conn.ReadLogs()
+				if readErr != nil {
+					break
+				}
+				received = true
+				if message = tail.filter(message); message != "" {
+					writeLogLines(out, mu, prefix, message, noColor, keep)
+				}
+			}
+			if stopClose() {
+				_ = conn.Close()
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		if received {
+			delay = watchLogsReconnectDelay
+		}
+		mu.Lock()
+		if isPermanentLogStreamError(err) {
+			fmt.Fprintf(os.Stderr, "Stopped streaming logs of pod %s: %s\n", stream.PodName, err)
+			mu.Unlock()
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Log stream of pod %s is unavailable (%s), reconnecting in %s\n", stream.PodName, err, delay)
+		} else {
+			fmt.Fprintf(os.Stderr, "Log stream of pod %s ended, reconnecting in %s\n", stream.PodName, delay)
+		}
+		mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = nextLogStreamReconnectDelay(delay)
+	}
+}
+
+// nextLogStreamReconnectDelay doubles delay, capped at watchLogsMaxReconnectDelay.
+func nextLogStreamReconnectDelay(delay time.Duration) time.Duration {
+	return min(2*delay, watchLogsMaxReconnectDelay)
+}
+
+// isPermanentLogStreamError reports whether reconnecting cannot help because the log stream rejected the
+// connection as unauthorized or not found.
+func isPermanentLogStreamError(err error) bool {
+	var statusErr *dataaccess.LogStreamStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusGone:
+		return true
+	}
+	return false
+}
+
+// logTail remembers the last lines printed from a log stream.
+type logTail struct {
+	lines     []string
+	counts    map[string]int
+	max       int
+	resyncing bool
+}
+
+func newLogTail(maxLines int) *logTail {
+	return &logTail{counts: make(map[string]int), max: maxLines}
+}
+
+// resync marks the stream as reconnected, so the lines it replays from the start are skipped.
+func (t *logTail) resync() {
+	t.resyncing = true
+}
+
+// filter returns the lines of message that were not printed yet and remembers them. While resyncing,
+// lines in the tail are dropped until the first line that is not, which ends the resync.
+func (t *logTail) filter(message string) string {
+	var unseen []string
+	for _, line := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		if t.resyncing {
+			if t.counts[line] > 0 {
+				continue
+			}
+			t.resyncing = false
+		}
+		unseen = append(unseen, line)
+		t.remember(line)
+	}
+	return strings.Join(unseen, "\n")
+}
+
+func (t *logTail) remember(line string) {
+	t.lines = append(t.lines, line)
+	t.counts[line]++
+	if len(t.lines) > t.max {
+		oldest := t.lines[0]
+		t.lines = t.lines[1:]
+		if t.counts[oldest]--; t.counts[oldest] == 0 {
+			delete(t.counts, oldest)
+		}
+	}
+}
+
+func writeLogLines(out io.Writer, mu *sync.Mutex, prefix, message string, noColor bool, keep func(line string) bool) {
+	if noColor {
+		message = stripANSI(message)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		if keep != nil && !keep(line) {
+			continue
+		}
+		fmt.Fprintf(out, "%s%s\n", prefix, line)
+	}
+}
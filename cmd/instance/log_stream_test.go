@@ -0,0 +1,92 @@
+package instance
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripANSI(t *testing.T) {
+	require.Equal(t, "error: failed", stripANSI("\x1b[31merror:\x1b[0m failed"))
+	require.Equal(t, "plain", stripANSI("plain"))
+}
+
+func TestWatchLogsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	require.False(t, WatchLogsNoColor(false))
+	require.True(t, WatchLogsNoColor(true))
+
+	t.Setenv("NO_COLOR", "1")
+	require.True(t, WatchLogsNoColor(false))
+}
+
+func TestPrimaryLogResourceKey(t *testing.T) {
+	topology := map[string]openapiclientfleet.ResourceNetworkTopologyResult{
+		"r-observ": {ResourceKey: "omnistrateobserv", Main: true},
+		"r-db":     {ResourceKey: "postgres", Nodes: []openapiclientfleet.NodeNetworkTopologyResult{{}}},
+		"r-app":    {ResourceKey: "app", Main: true},
+	}
+
+	require.Equal(t, "postgres", primaryLogResourceKey(topology, "r-db"))
+	require.Equal(t, "app", primaryLogResourceKey(topology, ""))
+	require.Equal(t, "app", primaryLogResourceKey(topology, "r-unknown"))
+
+	delete(topology, "r-app")
+	require.Equal(t, "postgres", primaryLogResourceKey(topology, ""))
+
+	require.Empty(t, primaryLogResourceKey(nil, ""))
+}
+
+func TestWatchLogsPrefix(t *testing.T) {
+	require.Empty(t, watchLogsPrefix("postgres", "postgres-0", 1, 1))
+	require.Equal(t, "[postgres-1] ", watchLogsPrefix("postgres", "postgres-1", 1, 2))
+	require.Equal(t, "[postgres] ", watchLogsPrefix("postgres", "postgres-0", 2, 1))
+	require.Equal(t, "[redis/redis-1] ", watchLogsPrefix("redis", "redis-1", 2, 3))
+}
+
+func TestWriteLogLines(t *testing.T) {
+	var out bytes.Buffer
+	var mu sync.Mutex
+
+	writeLogLines(&out, &mu, "[pod-1] ", "\x1b[32mstarted\x1b[0m\nready\n", true, nil)
+
+	require.Equal(t, "[pod-1] started\n[pod-1] ready\n", out.String())
+}
+
+func TestLogTailSkipsLinesReplayedAfterReconnect(t *testing.T) {
+	require := require.New(t)
+
+	tail := newLogTail(3)
+	require.Equal("a\nb", tail.filter("a\nb\n"))
+	require.Equal("c\nd", tail.filter("c\nd"))
+
+	// Without a reconnect, repeated lines are printed again
+	require.Equal("d", tail.filter("d"))
+
+	// After a reconnect the stream replays from the start; lines still in the tail are skipped until new ones arrive
+	tail.resync()
+	require.Equal("", tail.filter("c\nd"))
+	require.Equal("e\nd", tail.filter("d\ne\nd"))
+}
+
+func TestNextLogStreamReconnectDelay(t *testing.T) {
+	require.Equal(t, 4*time.Second, nextLogStreamReconnectDelay(watchLogsReconnectDelay))
+	require.Equal(t, watchLogsMaxReconnectDelay, nextLogStreamReconnectDelay(45*time.Second))
+	require.Equal(t, watchLogsMaxReconnectDelay, nextLogStreamReconnectDelay(watchLogsMaxReconnectDelay))
+}
+
+func TestIsPermanentLogStreamError(t *testing.T) {
+	require.True(t, isPermanentLogStreamError(fmt.Errorf("pod-1: %w", &dataaccess.LogStreamStatusError{StatusCode: http.StatusUnauthorized, Err: errors.New("bad handshake")})))
+	require.True(t, isPermanentLogStreamError(&dataaccess.LogStreamStatusError{StatusCode: http.StatusNotFound, Err: errors.New("bad handshake")}))
+	require.False(t, isPermanentLogStreamError(&dataaccess.LogStreamStatusError{StatusCode: http.StatusBadGateway, Err: errors.New("bad handshake")}))
+	require.False(t, isPermanentLogStreamError(errors.New("connection reset by peer")))
+	require.False(t, isPermanentLogStreamError(nil))
+}
@@ -0,0 +1,264 @@
+package instance
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+	"github.com/spf13/cobra"
+)
+
+const (
+	logsExample = `# Stream the logs of every running instance of a service
+omnistrate-ctl instance logs --service s-abcd1234 --selector RUNNING
+
+# Only print the error lines of the last 15 minutes, across all instances of the service
+omnistrate-ctl instance logs --service s-abcd1234 --grep '(?i)error' --since 15m
+
+# Stream the logs of up to 50 failed instances
+omnistrate-ctl instance logs --service s-abcd1234 --selector FAILED --max-instances 50`
+	defaultLogsMaxInstances = 10 // Maximum number of instances streamed at once without --max-instances
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs --service=[service-id] [--selector=status] [flags]",
+	Short: "Stream the logs of the instances of a service",
+	Long: `This command streams the logs of the primary resource of every instance of a service, optionally only the
+instances in a given status, and prints the combined output with each line prefixed by its instance ID, and by
+its pod when the resource has several pods. It runs until Ctrl-C.
+Use --grep to only print the lines matching a regular expression, and --since to skip the lines logged before a
+point in time, judged by the timestamp at the start of each line. Lines without a timestamp follow the line
+before them. At most --max-instances instances are streamed at once; the command fails when more match.`,
+	Example:      logsExample,
+	RunE:         runLogs,
+	SilenceUsage: true,
+}
+
+func init() {
+	logsCmd.Flags().String("service", "", "ID or name of the service whose instances are streamed")
+	logsCmd.Flags().String("selector", "", "Only stream the instances in this status, e.g. RUNNING or FAILED")
+	logsCmd.Flags().String("grep", "", "Only print the log lines matching this regular expression")
+	logsCmd.Flags().Duration("since", 0, "Only print the log lines logged within this duration, e.g. 15m")
+	logsCmd.Flags().Int("max-instances", defaultLogsMaxInstances, "Maximum number of instances streamed at once")
+	logsCmd.Flags().Bool("no-color", false, "Strip ANSI color codes from streamed logs (also enabled by the NO_COLOR environment variable)")
+
+	if err := logsCmd.MarkFlagRequired("service"); err != nil {
+		return
+	}
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	service, err := cmd.Flags().GetString("service")
+	if err != nil {
+		return err
+	}
+	selector, err := cmd.Flags().GetString("selector")
+	if err != nil {
+		return err
+	}
+	grepPattern, err := cmd.Flags().GetString("grep")
+	if err != nil {
+		return err
+	}
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		return err
+	}
+	maxInstances, err := cmd.Flags().GetInt("max-instances")
+	if err != nil {
+		return err
+	}
+	noColor, err := cmd.Flags().GetBool("no-color")
+	if err != nil {
+		return err
+	}
+
+	if since < 0 {
+		err = utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf("--since must not be negative"))
+		utils.PrintError(err)
+		return err
+	}
+	if maxInstances < 1 {
+		err = utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf("--max-instances must be at least 1"))
+		utils.PrintError(err)
+		return err
+	}
+	logFilter, err := newDebugLogFilter(grepPattern)
+	if err != nil {
+		err = utils.WithExitCode(utils.ExitCodeValidation, err)
+		utils.PrintError(err)
+		return err
+	}
+
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	searchRes, err := dataaccess.SearchInventory(cmd.Context(), token, "resourceinstance:i")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	instances := selectLogInstances(searchRes.ResourceInstanceResults, service, selector)
+	if len(instances) == 0 {
+		err = utils.WithExitCode(utils.ExitCodeNotFound, fmt.Errorf("no instances of service %s match", describeLogSelection(service, selector)))
+		utils.PrintError(err)
+		return err
+	}
+	if len(instances) > maxInstances {
+		err = utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf("%d instances of service %s match, more than --max-instances=%d; narrow them down with --selector or raise --max-instances",
+			len(instances), describeLogSelection(service, selector), maxInstances))
+		utils.PrintError(err)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	// Log lines go to stdout so that they can be piped; progress and per-instance failures go to stderr
+	fmt.Fprintf(os.Stderr, "Streaming logs of %d instance(s). Press Ctrl-C to stop.\n", len(instances))
+
+	var notBefore time.Time
+	if since > 0 {
+		notBefore = time.Now().Add(-since)
+	}
+
+	logsService := dataaccess.NewLogsService()
+	noColor = WatchLogsNoColor(noColor)
+	out := cmd.OutOrStdout()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var streamed int
+	for _, instance := range instances {
+		wg.Add(1)
+		go func(instance openapiclientfleet.ResourceInstanceSearchRecord) {
+			defer wg.Done()
+
+			resourceID := ""
+			if instance.ResourceId != nil {
+				resourceID = *instance.ResourceId
+			}
+			_, streams, err := primaryLogStreams(ctx, logsService, token, instance.ServiceId, instance.ServiceEnvironmentId, instance.Id, resourceID)
+			if err != nil {
+				mu.Lock()
+				fmt.Fprintf(os.Stderr, "Skipping instance %s: %s\n", instance.Id, err)
+				mu.Unlock()
+				return
+			}
+
+			mu.Lock()
+			streamed++
+			mu.Unlock()
+
+			var streamsWg sync.WaitGroup
+			for _, stream := range streams {
+				prefix := fmt.Sprintf("[%s] ", instance.Id)
+				if len(streams) > 1 {
+					prefix = fmt.Sprintf("[%s/%s] ", instance.Id, stream.PodName)
+				}
+				keep := newLogLineFilter(logFilter, notBefore)
+				streamsWg.Add(1)
+				go func(stream dataaccess.LogsStream, prefix string) {
+					defer streamsWg.Done()
+					tailLogStream(ctx, logsService, stream, prefix, noColor, keep, out, &mu)
+				}(stream, prefix)
+			}
+			streamsWg.Wait()
+		}(instance)
+	}
+	wg.Wait()
+
+	if streamed == 0 && ctx.Err() == nil {
+		err = fmt.Errorf("logs are not available for any of the %d matching instance(s)", len(instances))
+		utils.PrintError(err)
+		return err
+	}
+	return nil
+}
+
+// selectLogInstances returns the instances of service, given by ID or name, in the status selector, sorted by
+// instance ID. An empty selector selects every status.
+func selectLogInstances(records []openapiclientfleet.ResourceInstanceSearchRecord, service, selector string) []openapiclientfleet.ResourceInstanceSearchRecord {
+	var selected []openapiclientfleet.ResourceInstanceSearchRecord
+	for _, record := range records {
+		if record.Id == "" {
+			continue
+		}
+		if record.ServiceId != service && !strings.EqualFold(record.ServiceName, service) {
+			continue
+		}
+		if selector != "" && !strings.EqualFold(record.Status, selector) {
+			continue
+		}
+		selected = append(selected, record)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Id < selected[j].Id
+	})
+	return selected
+}
+
+func describeLogSelection(service, selector string) string {
+	if selector == "" {
+		return service
+	}
+	return fmt.Sprintf("%s in status %s", service, strings.ToUpper(selector))
+}
+
+// logLineTimestampLayouts are the layouts of the timestamps log lines are expected to start with
+var logLineTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+}
+
+// newLogLineFilter returns the filter of the lines of one log stream, keeping the lines matching grep and logged
+// at or after notBefore. A line without a timestamp is kept when the line before it was, so that multi-line
+// entries such as stack traces stay whole. A nil grep and zero notBefore keep every line.
+func newLogLineFilter(grep *regexp.Regexp, notBefore time.Time) func(line string) bool {
+	lastKept := true
+	return func(line string) bool {
+		if !notBefore.IsZero() {
+			if logged, ok := logLineTime(line); ok {
+				lastKept = !logged.Before(notBefore)
+			}
+			if !lastKept {
+				return false
+			}
+		}
+		return grep == nil || grep.MatchString(line)
+	}
+}
+
+// logLineTime parses the timestamp at the start of a log line, optionally in square brackets.
+func logLineTime(line string) (time.Time, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+	candidates := []string{strings.Trim(fields[0], "[]")}
+	if len(fields) > 1 {
+		candidates = append(candidates, strings.Trim(fields[0]+" "+fields[1], "[]"))
+	}
+	for _, candidate := range candidates {
+		for _, layout := range logLineTimestampLayouts {
+			if logged, err := time.Parse(layout, candidate); err == nil {
+				return logged, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,60 @@
+package instance
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectLogInstances(t *testing.T) {
+	require := require.New(t)
+
+	records := []openapiclientfleet.ResourceInstanceSearchRecord{
+		{Id: "instance-3", ServiceId: "s-1", ServiceName: "postgres", Status: "FAILED"},
+		{Id: "instance-1", ServiceId: "s-1", ServiceName: "postgres", Status: "RUNNING"},
+		{Id: "instance-2", ServiceId: "s-2", ServiceName: "redis", Status: "RUNNING"},
+		{Id: "", ServiceId: "s-1", ServiceName: "postgres", Status: "RUNNING"},
+	}
+
+	ids := func(records []openapiclientfleet.ResourceInstanceSearchRecord) []string {
+		var ids []string
+		for _, record := range records {
+			ids = append(ids, record.Id)
+		}
+		return ids
+	}
+
+	require.Equal([]string{"instance-1", "instance-3"}, ids(selectLogInstances(records, "s-1", "")))
+	require.Equal([]string{"instance-1", "instance-3"}, ids(selectLogInstances(records, "Postgres", "")))
+	require.Equal([]string{"instance-1"}, ids(selectLogInstances(records, "s-1", "running")))
+	require.Empty(selectLogInstances(records, "s-3", ""))
+}
+
+func TestLogLineFilterGrepAndSince(t *testing.T) {
+	require := require.New(t)
+
+	notBefore := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	keep := newLogLineFilter(regexp.MustCompile(`(?i)error|at `), notBefore)
+
+	require.False(keep("2026-01-02T09:59:00Z ERROR too old"))
+	require.False(keep("  at main.go:10"), "continuation of a dropped line is dropped")
+	require.True(keep("2026-01-02T10:00:01Z ERROR recent"))
+	require.True(keep("  at main.go:12"), "continuation of a kept line is kept")
+	require.False(keep("[2026-01-02 10:00:02] info: not matching"))
+	require.True(keep("[2026-01-02 10:00:03] error: matching"))
+
+	keepAll := newLogLineFilter(nil, time.Time{})
+	require.True(keepAll("anything"))
+}
+
+func TestLogLineTime(t *testing.T) {
+	logged, ok := logLineTime("2026-01-02T10:00:00.5Z started")
+	require.True(t, ok)
+	require.Equal(t, time.Date(2026, 1, 2, 10, 0, 0, 500000000, time.UTC), logged)
+
+	_, ok = logLineTime("no timestamp here")
+	require.False(t, ok)
+}
@@ -114,7 +114,7 @@ func runOperationList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	operations, err := loadSupportedOperations(cmd, args[0])
+	operations, err := loadSupportedOperations(cmd, instanceIDArg(args))
 	if err != nil {
 		utils.PrintError(err)
 		return err
@@ -132,7 +132,7 @@ func runOperationDescribe(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	operations, err := loadSupportedOperations(cmd, args[0])
+	operations, err := loadSupportedOperations(cmd, instanceIDArg(args))
 	if err != nil {
 		utils.PrintError(err)
 		return err
@@ -182,7 +182,7 @@ func runOperationTrigger(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 	selector := args[1]
 	serviceID, environmentID, _, resourceID, err := getInstance(cmd.Context(), token, instanceID)
 	if err != nil {
@@ -0,0 +1,242 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+)
+
+var paramsCmd = &cobra.Command{
+	Use:   "params --service=[service-id] --plan=[plan-id] [--resource=resource-id] [--version=version]",
+	Short: "List the parameters an instance of a service plan is created with",
+	Long: `This command lists the input parameters of the CREATE API of each resource of a service plan, required ones first, with their types and defaults, so you know what to pass to --param before deploying.
+Parameters set by other flags (cloud provider, region and subscription) are left out. Without --resource, the parameters of every resource of the plan are listed.`,
+	Example: `  omnistrate-ctl instance params --service=s-12345 --plan=pt-12345
+  omnistrate-ctl instance params --service=s-12345 --plan=pt-12345 --resource=r-12345 --output=json`,
+	RunE:         runParams,
+	SilenceUsage: true,
+}
+
+func init() {
+	paramsCmd.Flags().String("service", "", "Service ID")
+	paramsCmd.Flags().String("plan", "", "Service plan ID")
+	paramsCmd.Flags().String("resource", "", "Resource ID (default: every resource of the plan)")
+	paramsCmd.Flags().String("version", "", "Service plan version (default: the latest version)")
+	paramsCmd.Flags().StringP("output", "o", "table", "Output format (table|json)")
+
+	for _, required := range []string{"service", "plan"} {
+		if err := paramsCmd.MarkFlagRequired(required); err != nil {
+			utils.PrintError(err)
+		}
+	}
+}
+
+// ResourceParameters holds the CREATE parameters of a resource of a service plan
+type ResourceParameters struct {
+	ResourceID   string          `json:"resourceId"`
+	ResourceKey  string          `json:"resourceKey"`
+	ResourceName string          `json:"resourceName"`
+	Parameters   []ParameterInfo `json:"parameters"`
+}
+
+// PlanParametersOutput holds the CREATE parameters of the resources of a service plan version
+type PlanParametersOutput struct {
+	ServiceID string               `json:"serviceId"`
+	PlanID    string               `json:"planId"`
+	Version   string               `json:"version"`
+	Resources []ResourceParameters `json:"resources"`
+}
+
+func runParams(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	serviceID, err := cmd.Flags().GetString("service")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	planID, err := cmd.Flags().GetString("plan")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	resourceID, err := cmd.Flags().GetString("resource")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	version, err := cmd.Flags().GetString("version")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	if output != "table" && output != "json" {
+		err = utils.WithExitCode(utils.ExitCodeValidation, fmt.Errorf("unsupported output format: %s. Supported formats are table and json", output))
+		utils.PrintError(err)
+		return err
+	}
+
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	result, err := fetchPlanParameters(cmd.Context(), token, serviceID, planID, resourceID, version)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	if output == "json" {
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal output to JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	printPlanParametersTable(result)
+	return nil
+}
+
+// fetchPlanParameters returns the CREATE parameters of resourceID, or of every non-internal resource of the plan when
+// it is empty, in the given version of the plan or its latest one
+func fetchPlanParameters(ctx context.Context, token, serviceID, planID, resourceID, version string) (PlanParametersOutput, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if version == "" {
+		latest, err := dataaccess.FindLatestVersion(ctx, token, serviceID, planID)
+		if err != nil {
+			return PlanParametersOutput{}, fmt.Errorf("failed to find latest version: %w", err)
+		}
+		version = latest
+	}
+
+	resources, err := dataaccess.ListResources(ctx, token, serviceID, planID, &version)
+	if err != nil {
+		return PlanParametersOutput{}, fmt.Errorf("failed to list the resources of the service plan: %w", err)
+	}
+
+	result := PlanParametersOutput{ServiceID: serviceID, PlanID: planID, Version: version, Resources: []ResourceParameters{}}
+	for _, resource := range resources.Resources {
+		if resource.Internal || (resourceID != "" && resource.Id != resourceID) {
+			continue
+		}
+
+		offeringResource, err := dataaccess.DescribeServiceOfferingResource(ctx, token, serviceID, resource.Id, "none", planID, version)
+		if err != nil {
+			return PlanParametersOutput{}, fmt.Errorf("failed to describe service offering resource %s: %w", resource.Key, err)
+		}
+
+		result.Resources = append(result.Resources, ResourceParameters{
+			ResourceID:   resource.Id,
+			ResourceKey:  resource.Key,
+			ResourceName: resource.Name,
+			Parameters:   createParameterInfos(offeringResource.GetConsumptionDescribeServiceOfferingResourceResult().Apis),
+		})
+	}
+
+	if resourceID != "" && len(result.Resources) == 0 {
+		return PlanParametersOutput{}, utils.WithExitCode(utils.ExitCodeNotFound, fmt.Errorf("resource ID '%s' not found in version %s of service plan %s", resourceID, version, planID))
+	}
+	return result, nil
+}
+
+// createParameterInfos returns the input parameters of the CREATE API, except those set by other flags, required
+// ones first and then sorted by key
+func createParameterInfos(apis []openapiclientfleet.APIEntity) []ParameterInfo {
+	parameters := []ParameterInfo{}
+	for _, api := range apis {
+		if api.Verb != "CREATE" {
+			continue
+		}
+		for _, param := range api.InputParameters {
+			switch param.Key {
+			case "subscriptionId", "cloud_provider", "region":
+				continue
+			}
+			parameters = append(parameters, ParameterInfo{
+				Key:          param.Key,
+				DisplayName:  param.DisplayName,
+				Description:  param.Description,
+				Type:         param.Type,
+				Required:     param.Required,
+				Modifiable:   param.Modifiable,
+				IsList:       param.IsList,
+				DefaultValue: param.DefaultValue,
+				Options:      param.Options,
+				Regex:        param.Regex,
+				Custom:       param.Custom,
+				API:          "create",
+			})
+		}
+		break
+	}
+
+	sort.Slice(parameters, func(i, j int) bool {
+		if parameters[i].Required != parameters[j].Required {
+			return parameters[i].Required
+		}
+		return parameters[i].Key < parameters[j].Key
+	})
+	return parameters
+}
+
+func printPlanParametersTable(output PlanParametersOutput) {
+	fmt.Printf("Parameters for service %s, plan %s (version: %s)\n", output.ServiceID, output.PlanID, output.Version)
+
+	if len(output.Resources) == 0 {
+		fmt.Println("\nNo resources found.")
+		return
+	}
+
+	for _, resource := range output.Resources {
+		fmt.Printf("\nResource %s (%s, ID: %s)\n\n", resource.ResourceName, resource.ResourceKey, resource.ResourceID)
+		if len(resource.Parameters) == 0 {
+			fmt.Println("No parameters.")
+			continue
+		}
+
+		fmt.Printf("%-25s %-12s %-10s %-20s %s\n", "PARAMETER KEY", "TYPE", "REQUIRED", "DEFAULT", "DESCRIPTION")
+		fmt.Printf("%-25s %-12s %-10s %-20s %s\n",
+			strings.Repeat("-", 25), strings.Repeat("-", 12), strings.Repeat("-", 10),
+			strings.Repeat("-", 20), strings.Repeat("-", 20))
+
+		for _, param := range resource.Parameters {
+			defaultValue := "-"
+			if param.DefaultValue != nil {
+				defaultValue = *param.DefaultValue
+				if len(defaultValue) > 20 {
+					defaultValue = defaultValue[:17] + "..."
+				}
+			}
+
+			description := param.Description
+			if len(description) > 50 {
+				description = description[:47] + "..."
+			}
+
+			fmt.Printf("%-25s %-12s %-10t %-20s %s\n", param.Key, param.Type, param.Required, defaultValue, description)
+		}
+	}
+
+	fmt.Println("\nPass required parameters without a default to 'deploy' or 'instance create' with --param.")
+}
@@ -0,0 +1,47 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateParameterInfos(t *testing.T) {
+	require := require.New(t)
+
+	apis := []openapiclientfleet.APIEntity{
+		{
+			Verb: "MODIFY",
+			InputParameters: []openapiclientfleet.InputParameterEntity{
+				{Key: "modifyOnly", Type: "String"},
+			},
+		},
+		{
+			Verb: "CREATE",
+			InputParameters: []openapiclientfleet.InputParameterEntity{
+				{Key: "replicas", Type: "Float64", DefaultValue: utils.ToPtr("1")},
+				{Key: "region", Type: "String", Required: true},
+				{Key: "password", Type: "Password", Required: true},
+				{Key: "cloud_provider", Type: "String", Required: true},
+				{Key: "instanceType", Type: "String", Required: true, DefaultValue: utils.ToPtr("t3.medium")},
+				{Key: "subscriptionId", Type: "String"},
+			},
+		},
+	}
+
+	parameters := createParameterInfos(apis)
+	require.Len(parameters, 3)
+	require.Equal("instanceType", parameters[0].Key)
+	require.True(parameters[0].Required)
+	require.Equal("t3.medium", *parameters[0].DefaultValue)
+	require.Equal("password", parameters[1].Key)
+	require.Nil(parameters[1].DefaultValue)
+	require.Equal("replicas", parameters[2].Key)
+	require.False(parameters[2].Required)
+	require.Equal("Float64", parameters[2].Type)
+	require.Equal("create", parameters[2].API)
+
+	require.Empty(createParameterInfos(nil))
+}
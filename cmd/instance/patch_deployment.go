@@ -58,7 +58,7 @@ func runPatchDeployment(cmd *cobra.Command, args []string) error {
 	}
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	resourceName, err := cmd.Flags().GetString("resource-name")
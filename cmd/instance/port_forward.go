@@ -0,0 +1,145 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+var portForwardCmd = &cobra.Command{
+	Use:   "port-forward [instance-id] [local-port:pod-port] --resource=resource-key",
+	Short: "Forward a local port to a pod of an instance resource",
+	Long: `Forward a local port to a pod of an instance resource, like kubectl port-forward, to connect local tools such as psql or redis-cli to the resource without exposing a public endpoint.
+The pod is resolved as in 'instance exec': the first running pod of the resource in the instance namespace, the terraform executor pod of a terraform resource, or the pod given with --pod.
+A single port forwards the same local port, and a local port of 0 picks a free one. Press Ctrl-C to stop forwarding.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPortForward,
+	Example: `  omnistrate-ctl instance port-forward <instance-id> 5432:5432 --resource=my-db
+  omnistrate-ctl instance port-forward <instance-id> 6379 --resource=my-cache --pod=my-cache-0`,
+}
+
+func init() {
+	portForwardCmd.Flags().String("resource", "", "Key of the resource whose pod the port is forwarded to")
+	portForwardCmd.Flags().String("pod", "", "Name of the pod to forward to, instead of the resolved pod of the resource")
+	portForwardCmd.Flags().String("namespace", "", "Namespace of the resource pods (default: the instance ID)")
+	portForwardCmd.Flags().String("address", "localhost", "Local address to listen on")
+
+	if err := portForwardCmd.MarkFlagRequired("resource"); err != nil {
+		return
+	}
+}
+
+func runPortForward(cmd *cobra.Command, args []string) error {
+	instanceID := instanceIDArg(args)
+	ports, err := parsePortForwardSpec(args[1])
+	if err != nil {
+		return utils.WithExitCode(utils.ExitCodeValidation, err)
+	}
+
+	resourceKey, err := cmd.Flags().GetString("resource")
+	if err != nil {
+		return fmt.Errorf("failed to get resource flag: %w", err)
+	}
+	podName, err := cmd.Flags().GetString("pod")
+	if err != nil {
+		return fmt.Errorf("failed to get pod flag: %w", err)
+	}
+	namespace, err := cmd.Flags().GetString("namespace")
+	if err != nil {
+		return fmt.Errorf("failed to get namespace flag: %w", err)
+	}
+	address, err := cmd.Flags().GetString("address")
+	if err != nil {
+		return fmt.Errorf("failed to get address flag: %w", err)
+	}
+
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	target, err := resolveResourcePod(ctx, token, instanceID, resourceKey, namespace, podName)
+	if err != nil {
+		return err
+	}
+
+	// Stop forwarding on Ctrl-C, closing the listeners and streams before exiting
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case <-signals:
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		close(stopCh)
+	}()
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Forwarding to pod %s in namespace %s, press Ctrl-C to stop\n", target.podName, target.namespace)
+	return forwardPodPorts(target, address, ports, stopCh, cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+// forwardPodPorts forwards ports, in the local:pod form, from address to the pod until stopCh is closed
+func forwardPodPorts(target *resourcePod, address, ports string, stopCh chan struct{}, out, errOut io.Writer) error {
+	transport, upgrader, err := spdy.RoundTripperFor(target.conn.restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create port-forward transport: %w", err)
+	}
+	req := target.conn.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(target.namespace).
+		Name(target.podName).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{address}, []string{ports}, stopCh, nil, out, errOut)
+	if err != nil {
+		return fmt.Errorf("failed to create port forwarder: %w", err)
+	}
+	if err := forwarder.ForwardPorts(); err != nil {
+		return fmt.Errorf("port-forward to pod %s failed: %w", target.podName, err)
+	}
+	return nil
+}
+
+// parsePortForwardSpec validates a local-port:pod-port pair, or a single port forwarded to the same local port, and
+// returns it in the local:pod form
+func parsePortForwardSpec(spec string) (string, error) {
+	local, remote, found := strings.Cut(spec, ":")
+	if !found {
+		local = spec
+		remote = spec
+	}
+
+	localPort, err := strconv.Atoi(local)
+	if err != nil || localPort < 0 || localPort > 65535 {
+		return "", fmt.Errorf("invalid local port %q in %q: expected a number between 0 and 65535", local, spec)
+	}
+	podPort, err := strconv.Atoi(remote)
+	if err != nil || podPort < 1 || podPort > 65535 {
+		return "", fmt.Errorf("invalid pod port %q in %q: expected a number between 1 and 65535", remote, spec)
+	}
+	return fmt.Sprintf("%d:%d", localPort, podPort), nil
+}
@@ -0,0 +1,28 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortForwardSpec(t *testing.T) {
+	require := require.New(t)
+
+	ports, err := parsePortForwardSpec("15432:5432")
+	require.NoError(err)
+	require.Equal("15432:5432", ports)
+
+	ports, err = parsePortForwardSpec("6379")
+	require.NoError(err)
+	require.Equal("6379:6379", ports)
+
+	ports, err = parsePortForwardSpec("0:8080")
+	require.NoError(err)
+	require.Equal("0:8080", ports)
+
+	for _, spec := range []string{"", "abc", "5432:", ":5432", "70000:80", "8080:0", "-1:80", "1:2:3"} {
+		_, err = parsePortForwardSpec(spec)
+		require.Error(err, spec)
+	}
+}
@@ -14,19 +14,28 @@ import (
 
 const (
 	restartExample = `# Restart an instance deployment
-omnistrate-ctl instance restart instance-abcd1234`
+omnistrate-ctl instance restart instance-abcd1234
+
+# Restart a single resource of an instance deployment
+omnistrate-ctl instance restart instance-abcd1234 --resource mysql
+
+# Restart an instance deployment without prompting for confirmation
+omnistrate-ctl instance restart instance-abcd1234 --yes`
 )
 
 var restartCmd = &cobra.Command{
-	Use:          "restart [instance-id]",
-	Short:        "Restart an instance deployment for your service",
-	Long:         `This command helps you restart the instance for your service.`,
+	Use:   "restart [instance-id] [flags]",
+	Short: "Restart an instance deployment for your service",
+	Long: `This command helps you restart the instance for your service.
+By default all resources of the instance are restarted. Use --resource to restart only the resource with the given key.`,
 	Example:      restartExample,
 	RunE:         runRestart,
 	SilenceUsage: true,
 }
 
 func init() {
+	restartCmd.Flags().StringP("resource", "r", "", "Key of the resource to restart. If not specified, all resources are restarted")
+	restartCmd.Flags().BoolP("yes", "y", false, "Pre-approve the restart of the instance without prompting for confirmation")
 
 	restartCmd.Args = cobra.ExactArgs(1) // Require exactly one argument
 }
@@ -35,7 +44,7 @@ func runRestart(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, err := cmd.Flags().GetString("output")
@@ -43,6 +52,16 @@ func runRestart(cmd *cobra.Command, args []string) error {
 		utils.PrintError(err)
 		return err
 	}
+	resourceKey, err := cmd.Flags().GetString("resource")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
 
 	// Validate user login
 	token, err := common.GetTokenWithLogin()
@@ -51,6 +70,19 @@ func runRestart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Confirm restart
+	if !yes {
+		confirmed, err := utils.ConfirmAction(restartConfirmationMessage(instanceID, resourceKey))
+		if err != nil {
+			utils.PrintError(err)
+			return err
+		}
+		if !confirmed {
+			utils.PrintInfo("Operation cancelled")
+			return nil
+		}
+	}
+
 	// Initialize spinner if output is not JSON
 	var sm utils.SpinnerManager
 	var spinner *utils.Spinner
@@ -64,10 +96,24 @@ func runRestart(cmd *cobra.Command, args []string) error {
 	// Check if instance exists
 	serviceID, environmentID, _, resourceID, err := getInstance(cmd.Context(), token, instanceID)
 	if err != nil {
-		utils.PrintError(err)
+		utils.HandleSpinnerError(spinner, sm, err)
 		return err
 	}
 
+	// Resolve the resource key to restart a single resource; otherwise the root resource restarts all of them
+	if resourceKey != "" {
+		resourceID, _, err = getResourceFromInstance(cmd.Context(), token, instanceID, resourceKey)
+		if err != nil {
+			utils.HandleSpinnerError(spinner, sm, err)
+			return err
+		}
+		if resourceID == "" {
+			err = fmt.Errorf("resource %s not found in instance %s", resourceKey, instanceID)
+			utils.HandleSpinnerError(spinner, sm, err)
+			return err
+		}
+	}
+
 	// Restart instance
 	err = dataaccess.RestartResourceInstance(
 		cmd.Context(),
@@ -82,7 +128,11 @@ func runRestart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	utils.HandleSpinnerSuccess(spinner, sm, "Successfully restarted instance")
+	if resourceKey != "" {
+		utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Successfully restarted resource %s", resourceKey))
+	} else {
+		utils.HandleSpinnerSuccess(spinner, sm, "Successfully restarted instance")
+	}
 
 	// Search for the instance
 	searchRes, err := dataaccess.SearchInventory(cmd.Context(), token, fmt.Sprintf("resourceinstance:%s", instanceID))
@@ -107,3 +157,10 @@ func runRestart(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func restartConfirmationMessage(instanceID, resourceKey string) string {
+	if resourceKey != "" {
+		return fmt.Sprintf("Are you sure you want to restart resource %s of instance %s?", resourceKey, instanceID)
+	}
+	return fmt.Sprintf("Are you sure you want to restart all resources of instance %s?", instanceID)
+}
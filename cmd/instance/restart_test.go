@@ -0,0 +1,30 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartCommandFlags(t *testing.T) {
+	resourceFlag := restartCmd.Flags().Lookup("resource")
+	require.NotNil(t, resourceFlag)
+	require.Equal(t, "r", resourceFlag.Shorthand)
+	require.Equal(t, "", resourceFlag.DefValue)
+	require.Equal(t, "string", resourceFlag.Value.Type())
+
+	yesFlag := restartCmd.Flags().Lookup("yes")
+	require.NotNil(t, yesFlag)
+	require.Equal(t, "y", yesFlag.Shorthand)
+	require.Equal(t, "false", yesFlag.DefValue)
+	require.Equal(t, "bool", yesFlag.Value.Type())
+}
+
+func TestRestartConfirmationMessage(t *testing.T) {
+	require.Equal(t,
+		"Are you sure you want to restart all resources of instance instance-1?",
+		restartConfirmationMessage("instance-1", ""))
+	require.Equal(t,
+		"Are you sure you want to restart resource mysql of instance instance-1?",
+		restartConfirmationMessage("instance-1", "mysql"))
+}
@@ -54,7 +54,7 @@ func runRestore(cmd *cobra.Command, args []string) error {
 	}
 
 	// Retrieve args and flags
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 	output, err := cmd.Flags().GetString("output")
 	if err != nil {
 		utils.PrintError(err)
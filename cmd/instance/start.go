@@ -14,11 +14,14 @@ import (
 
 const (
 	startExample = `# Start an instance deployment
-omnistrate-ctl instance start instance-abcd1234`
+omnistrate-ctl instance start instance-abcd1234
+
+# Start an instance deployment without waiting for it to complete
+omnistrate-ctl instance start instance-abcd1234 --no-wait --yes`
 )
 
 var startCmd = &cobra.Command{
-	Use:          "start [instance-id]",
+	Use:          "start [instance-id] [flags]",
 	Short:        "Start an instance deployment for your service",
 	Long:         `This command helps you start the instance for your service.`,
 	Example:      startExample,
@@ -27,6 +30,8 @@ var startCmd = &cobra.Command{
 }
 
 func init() {
+	startCmd.Flags().BoolP("yes", "y", false, "Pre-approve the start of the instance without prompting for confirmation")
+	startCmd.Flags().Bool("no-wait", false, "Return immediately instead of waiting for the instance to be started")
 
 	startCmd.Args = cobra.ExactArgs(1) // Require exactly one argument
 }
@@ -35,7 +40,7 @@ func runStart(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, err := cmd.Flags().GetString("output")
@@ -43,6 +48,16 @@ func runStart(cmd *cobra.Command, args []string) error {
 		utils.PrintError(err)
 		return err
 	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	noWait, err := cmd.Flags().GetBool("no-wait")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
 
 	// Validate user login
 	token, err := common.GetTokenWithLogin()
@@ -51,6 +66,19 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Confirm start
+	if !yes {
+		confirmed, err := utils.ConfirmAction(fmt.Sprintf("Are you sure you want to start instance %s?", instanceID))
+		if err != nil {
+			utils.PrintError(err)
+			return err
+		}
+		if !confirmed {
+			utils.PrintInfo("Operation cancelled")
+			return nil
+		}
+	}
+
 	// Initialize spinner if output is not JSON
 	var sm utils.SpinnerManager
 	var spinner *utils.Spinner
@@ -64,7 +92,18 @@ func runStart(cmd *cobra.Command, args []string) error {
 	// Check if instance exists
 	serviceID, environmentID, _, resourceID, err := getInstance(cmd.Context(), token, instanceID)
 	if err != nil {
-		utils.PrintError(err)
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	// Check the instance is not already started
+	previousStatus, err := getInstanceStatus(cmd.Context(), token, serviceID, environmentID, instanceID)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+	if err = checkInstanceNotInStatus(instanceID, previousStatus, InstanceStatusRunning); err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
 		return err
 	}
 
@@ -75,7 +114,21 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	utils.HandleSpinnerSuccess(spinner, sm, "Successfully started instance")
+	if noWait {
+		utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Successfully requested to start instance (status: %s)", previousStatus))
+	} else {
+		// Wait for the instance to be started
+		if spinner != nil {
+			spinner.UpdateMessage(fmt.Sprintf("Waiting for instance to be %s...", InstanceStatusRunning))
+		}
+		err = waitForInstanceStatus(cmd.Context(), token, serviceID, environmentID, instanceID, InstanceStatusRunning)
+		if err != nil {
+			utils.HandleSpinnerError(spinner, sm, err)
+			return err
+		}
+
+		utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Successfully started instance (%s -> %s)", previousStatus, InstanceStatusRunning))
+	}
 
 	// Search for the instance
 	searchRes, err := dataaccess.SearchInventory(cmd.Context(), token, fmt.Sprintf("resourceinstance:%s", instanceID))
@@ -14,11 +14,14 @@ import (
 
 const (
 	stopExample = `# Stop an instance deployment
-omnistrate-ctl instance stop instance-abcd1234`
+omnistrate-ctl instance stop instance-abcd1234
+
+# Stop an instance deployment without waiting for it to complete
+omnistrate-ctl instance stop instance-abcd1234 --no-wait --yes`
 )
 
 var stopCmd = &cobra.Command{
-	Use:          "stop [instance-id]",
+	Use:          "stop [instance-id] [flags]",
 	Short:        "Stop an instance deployment for your service",
 	Long:         `This command helps you stop the instance for your service.`,
 	Example:      stopExample,
@@ -27,6 +30,8 @@ var stopCmd = &cobra.Command{
 }
 
 func init() {
+	stopCmd.Flags().BoolP("yes", "y", false, "Pre-approve the stop of the instance without prompting for confirmation")
+	stopCmd.Flags().Bool("no-wait", false, "Return immediately instead of waiting for the instance to be stopped")
 
 	stopCmd.Args = cobra.ExactArgs(1) // Require exactly one argument
 }
@@ -35,7 +40,7 @@ func runStop(cmd *cobra.Command, args []string) error {
 	defer config.CleanupArgsAndFlags(cmd, &args)
 
 	// Retrieve args
-	instanceID := args[0]
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	output, err := cmd.Flags().GetString("output")
@@ -43,6 +48,16 @@ func runStop(cmd *cobra.Command, args []string) error {
 		utils.PrintError(err)
 		return err
 	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+	noWait, err := cmd.Flags().GetBool("no-wait")
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
 
 	// Validate user login
 	token, err := common.GetTokenWithLogin()
@@ -51,12 +66,25 @@ func runStop(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Confirm stop
+	if !yes {
+		confirmed, err := utils.ConfirmAction(fmt.Sprintf("Are you sure you want to stop instance %s?", instanceID))
+		if err != nil {
+			utils.PrintError(err)
+			return err
+		}
+		if !confirmed {
+			utils.PrintInfo("Operation cancelled")
+			return nil
+		}
+	}
+
 	// Initialize spinner if output is not JSON
 	var sm utils.SpinnerManager
 	var spinner *utils.Spinner
 	if output != "json" {
 		sm = utils.NewSpinnerManager()
-		msg := "Stoping instance..."
+		msg := "Stopping instance..."
 		spinner = sm.AddSpinner(msg)
 		sm.Start()
 	}
@@ -64,7 +92,18 @@ func runStop(cmd *cobra.Command, args []string) error {
 	// Check if instance exists
 	serviceID, environmentID, _, resourceID, err := getInstance(cmd.Context(), token, instanceID)
 	if err != nil {
-		utils.PrintError(err)
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	// Check the instance is not already stopped
+	previousStatus, err := getInstanceStatus(cmd.Context(), token, serviceID, environmentID, instanceID)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+	if err = checkInstanceNotInStatus(instanceID, previousStatus, InstanceStatusStopped); err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
 		return err
 	}
 
@@ -75,7 +114,21 @@ func runStop(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	utils.HandleSpinnerSuccess(spinner, sm, "Successfully stopped instance")
+	if noWait {
+		utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Successfully requested to stop instance (status: %s)", previousStatus))
+	} else {
+		// Wait for the instance to be stopped
+		if spinner != nil {
+			spinner.UpdateMessage(fmt.Sprintf("Waiting for instance to be %s...", InstanceStatusStopped))
+		}
+		err = waitForInstanceStatus(cmd.Context(), token, serviceID, environmentID, instanceID, InstanceStatusStopped)
+		if err != nil {
+			utils.HandleSpinnerError(spinner, sm, err)
+			return err
+		}
+
+		utils.HandleSpinnerSuccess(spinner, sm, fmt.Sprintf("Successfully stopped instance (%s -> %s)", previousStatus, InstanceStatusStopped))
+	}
 
 	// Search for the instance
 	searchRes, err := dataaccess.SearchInventory(cmd.Context(), token, fmt.Sprintf("resourceinstance:%s", instanceID))
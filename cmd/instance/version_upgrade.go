@@ -41,9 +41,10 @@ omnistrate-ctl instance version-upgrade instance-abcd1234 --existing-configurati
 )
 
 var versionUpgradeCmd = &cobra.Command{
-	Use:          "version-upgrade [instance-id]",
-	Short:        "Issue a version upgrade for a deployment instance",
-	Long:         `This command helps you issue a version upgrade for a deployment instance with the specified upgrade configuration override.`,
+	Use:   "version-upgrade [instance-id]",
+	Short: "Issue a version upgrade for a deployment instance",
+	Long: `This command helps you issue a version upgrade for a deployment instance with the specified upgrade configuration override.
+The instance can also be given by the alias it was adopted under with 'instance adopt-local'.`,
 	Example:      versionUpgradeExample,
 	RunE:         runVersionUpgrade,
 	SilenceUsage: true,
@@ -81,8 +82,8 @@ func runVersionUpgrade(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Retrieve args
-	instanceID := args[0]
+	// Retrieve args, resolving an instance alias
+	instanceID := instanceIDArg(args)
 
 	// Retrieve flags
 	// Generate configuration override if requested
@@ -455,6 +455,12 @@ func buildWorkflowProgressSnapshot(ctx context.Context, token, serviceID, enviro
 		return workflowProgressSnapshot{}, err
 	}
 
+	return summarizeWorkflowProgress(instanceID, actionType, resourcesData, workflowInfo), nil
+}
+
+// summarizeWorkflowProgress builds a progress snapshot from fetched workflow events. A nil workflowInfo
+// means the workflow metadata is not available yet, so the workflow is treated as still in progress.
+func summarizeWorkflowProgress(instanceID, actionType string, resourcesData []dataaccess.ResourceWorkflowDebugEvents, workflowInfo *dataaccess.WorkflowInfo) workflowProgressSnapshot {
 	snapshot := workflowProgressSnapshot{
 		InstanceID: instanceID,
 		ActionType: actionType,
@@ -506,7 +512,7 @@ func buildWorkflowProgressSnapshot(ctx context.Context, token, serviceID, enviro
 		snapshot.FailureMessage = workflowProgressFailureMessage(snapshot)
 	}
 
-	return snapshot, nil
+	return snapshot
 }
 
 func buildWorkflowProgressResource(resourceData dataaccess.ResourceWorkflowDebugEvents, workflowInfo *dataaccess.WorkflowInfo) workflowProgressResource {
@@ -99,6 +99,42 @@ func TestBuildWorkflowProgressResourceCompletesWhenAllSectionsComplete(t *testin
 	require.Equal(t, 100, resource.Percent)
 }
 
+func TestSummarizeWorkflowProgressKeepsPollingWithNilWorkflowInfo(t *testing.T) {
+	resourcesData := []dataaccess.ResourceWorkflowDebugEvents{
+		{
+			ResourceID:   "r-database",
+			ResourceKey:  "database",
+			ResourceName: "database",
+			EventsByWorkflowStep: &dataaccess.DebugEventsByWorkflowSteps{
+				Bootstrap: []dataaccess.DebugEvent{{EventType: string(model.WorkflowStepCompleted)}},
+				Compute:   []dataaccess.DebugEvent{{EventType: string(model.WorkflowStepStarted)}},
+			},
+		},
+	}
+
+	require.NotPanics(t, func() {
+		snapshot := summarizeWorkflowProgress("instance-1", "create", resourcesData, nil)
+
+		require.Empty(t, snapshot.WorkflowID)
+		require.Equal(t, "running", snapshot.WorkflowStatus)
+		require.False(t, snapshot.Done)
+		require.False(t, snapshot.Failed)
+		require.Len(t, snapshot.Resources, 1)
+		require.Equal(t, "running", snapshot.Resources[0].Status)
+	})
+}
+
+func TestSummarizeWorkflowProgressWithoutEventsOrWorkflowInfoIsPending(t *testing.T) {
+	resourcesData := []dataaccess.ResourceWorkflowDebugEvents{
+		{ResourceID: "r-database", ResourceKey: "database"},
+	}
+
+	snapshot := summarizeWorkflowProgress("instance-1", "create", resourcesData, nil)
+
+	require.Equal(t, "pending", snapshot.WorkflowStatus)
+	require.False(t, snapshot.Done)
+}
+
 func TestWorkflowProgressFailureMessagePrefersResourceName(t *testing.T) {
 	snapshot := workflowProgressSnapshot{
 		WorkflowStatus: "failed",
@@ -42,6 +42,7 @@ var excludedCommands = []string{
 	"deployment-cell scale-down-nodepool",
 	"deployment-cell scale-up-nodepool",
 	"instance adopt",
+	"instance adopt-local",
 	"instance get-deployment",
 	"instance continue-deployment",
 	"instance enable-debug-mode",
@@ -16,6 +16,7 @@ import (
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/auth/refresh"
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/auth/revoke"
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/build"
+	configcmd "github.com/omnistrate-oss/omnistrate-ctl/cmd/config"
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/cost"
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/customer"
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/customnetwork"
@@ -110,18 +111,86 @@ func Execute() {
 	utils.ConfigureLoggingFromEnvOnce()
 	err := RootCmd.ExecuteContext(ctx)
 	if err != nil {
-		os.Exit(1)
+		// Cobra does not print errors with --json-errors, so report the ones commands did not print themselves
+		if utils.IsJSONErrors() && !utils.JSONErrorPrinted() {
+			utils.PrintJSONError(err)
+		}
+		os.Exit(utils.ExitCodeFor(err))
+	}
+}
+
+// initProfile applies the global --profile flag before any command runs.
+func initProfile() {
+	profile, err := RootCmd.PersistentFlags().GetString("profile")
+	if err == nil {
+		config.SetProfile(profile)
+	}
+}
+
+// initEndpoint applies the global --endpoint flag and validates the endpoint in effect. An invalid
+// endpoint is a validation error even in dry-run, so no command falls back to the default API host.
+func initEndpoint() error {
+	endpoint, err := RootCmd.PersistentFlags().GetString("endpoint")
+	if err == nil {
+		config.SetEndpoint(endpoint)
+	}
+
+	endpoint, err = config.ResolveEndpoint()
+	if err != nil {
+		return utils.WithExitCode(utils.ExitCodeValidation, err)
+	}
+	if config.IsInsecureEndpoint(endpoint) {
+		fmt.Fprintf(os.Stderr, "Warning: endpoint %s does not use TLS; credentials will be sent unencrypted\n", endpoint)
+	}
+	return nil
+}
+
+// initVerbose applies the global --verbose flag before any command runs.
+func initVerbose() {
+	verbose, err := RootCmd.PersistentFlags().GetBool("verbose")
+	if err == nil {
+		utils.SetVerbose(verbose)
+	}
+}
+
+// initJSONErrors applies the global --json-errors flag before any command runs.
+func initJSONErrors() {
+	jsonErrors, err := RootCmd.PersistentFlags().GetBool("json-errors")
+	if err == nil {
+		utils.SetJSONErrors(jsonErrors)
+		RootCmd.SilenceErrors = jsonErrors
+	}
+}
+
+// initQuiet applies the global --quiet flag before any command runs.
+func initQuiet() {
+	quiet, err := RootCmd.PersistentFlags().GetBool("quiet")
+	if err == nil {
+		utils.SetQuiet(quiet)
 	}
 }
 
 func init() {
 	RootCmd.PersistentFlags().BoolP("version", "v", false, "Print the version number of omnistrate-ctl")
-	RootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (text|table|json)")
+	RootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (text|table|json|csv|template); csv and template are only supported by some list commands")
+	RootCmd.PersistentFlags().String("profile", "", "Credential profile to use (overrides the "+config.ProfileEnvVar+" environment variable)")
+	RootCmd.PersistentFlags().String("endpoint", "", "API endpoint URL, e.g. for staging or a dedicated region (overrides the "+config.EndpointEnvVar+" environment variable)")
+	RootCmd.PersistentFlags().Bool("verbose", false, "Print timestamped diagnostics (API calls, request IDs, timing) to stderr")
+	RootCmd.PersistentFlags().Bool("quiet", false, "Suppress spinners and progress messages, printing only final results and errors")
+	RootCmd.PersistentFlags().Bool("json-errors", false, `Print errors to stderr as JSON objects: {"error": "...", "category": "...", "requestId": "..."}`)
+
+	cobra.OnInitialize(initProfile, initJSONErrors, initVerbose, initQuiet)
+	// Run the root hooks ahead of command-specific ones, such as the legacy service-plan notice.
+	cobra.EnableTraverseRunHooks = true
+	RootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return initEndpoint()
+	}
 
 	RootCmd.AddCommand(login.LoginCmd)
 	RootCmd.AddCommand(logout.LogoutCmd)
 	RootCmd.AddCommand(refresh.RefreshCmd)
 	RootCmd.AddCommand(revoke.RevokeTokenCmd)
+	RootCmd.AddCommand(configcmd.Cmd)
 
 	RootCmd.AddCommand(build.BuildCmd)
 	RootCmd.AddCommand(build.BuildFromRepoCmd)
@@ -17,7 +17,13 @@ import (
 
 const (
 	listExample = `# List services
-omnistrate-ctl service list`
+omnistrate-ctl service list
+
+# Export the services as CSV
+omnistrate-ctl service list --output csv > services.csv
+
+# Print the ID and name of each service with a Go template
+omnistrate-ctl service list --output template --template '{{.Id}} {{.Name}}'`
 	defaultMaxNameLength = 30 // Maximum length of the name column in the table
 )
 
@@ -25,7 +31,9 @@ var listCmd = &cobra.Command{
 	Use:   "list [flags]",
 	Short: "List services for your account",
 	Long: `This command helps you list services for your account.
-You can filter for specific services by using the filter flag.`,
+You can filter for specific services by using the filter flag.
+Use --output csv to print a header row and one comma-separated row per service, with the table columns.
+Use --output template with --template to render each service through a Go text/template.`,
 	Example:      listExample,
 	RunE:         runList,
 	SilenceUsage: true,
@@ -35,6 +43,7 @@ func init() {
 	listCmd.Flags().StringArrayP("filter", "f", []string{}, "Filter to apply to the list of services. E.g.: key1:value1,key2:value2, which filters services where key1 equals value1 and key2 equals value2. Allow use of multiple filters to form the logical OR operation. Supported keys: "+strings.Join(utils.GetSupportedFilterKeys(model.Service{}), ",")+". Check the examples for more details.")
 	listCmd.Flags().Bool("truncate", false, "Truncate long names in the output")
 	listCmd.Flags().BoolP("interactive", "i", false, "Launch interactive list with fuzzy search and selection")
+	listCmd.Flags().String(common.TemplateFlag, "", "Go template applied to each service when --output=template, e.g. '{{.Id}} {{.Name}}'")
 
 	listCmd.Args = cobra.NoArgs
 }
@@ -47,6 +56,14 @@ func runList(cmd *cobra.Command, args []string) error {
 	filters, _ := cmd.Flags().GetStringArray("filter")
 	truncateNames, _ := cmd.Flags().GetBool("truncate")
 	interactive, _ := cmd.Flags().GetBool("interactive")
+	templateText, _ := cmd.Flags().GetString(common.TemplateFlag)
+
+	// Parse the output template before fetching anything so a bad field fails fast
+	tmpl, err := common.ParseOutputTemplate[openapiclient.DescribeServiceResult](output, templateText)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
 
 	// Parse and validate filters
 	filterMaps, err := utils.ParseFilters(filters, utils.GetSupportedFilterKeys(model.Service{}))
@@ -62,10 +79,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Initialize spinner if output is not JSON and not interactive
+	// Initialize spinner if output is not JSON, CSV or a template and not interactive
 	var sm utils.SpinnerManager
 	var spinner *utils.Spinner
-	if output != "json" && !interactive {
+	if output != "json" && output != common.OutputTypeCsv && output != common.OutputTypeTemplate && !interactive {
 		sm = utils.NewSpinnerManager()
 		msg := "Listing services..."
 		spinner = sm.AddSpinner(msg)
@@ -80,6 +97,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	var formattedServices []model.Service
+	var matchedServices []openapiclient.DescribeServiceResult
 
 	// Process and filter services
 	for _, service := range listRes.Services {
@@ -93,6 +111,7 @@ func runList(cmd *cobra.Command, args []string) error {
 
 		if match {
 			formattedServices = append(formattedServices, formattedService)
+			matchedServices = append(matchedServices, service)
 		}
 	}
 
@@ -108,6 +127,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		return runInteractiveServiceList(formattedServices)
 	}
 
+	if tmpl != nil {
+		return utils.PrintTemplateOutput(tmpl, matchedServices)
+	}
+
 	// Format output as requested
 	err = utils.PrintTextTableJsonArrayOutput(output, formattedServices)
 	if err != nil {
@@ -1457,7 +1457,7 @@ func TestFormatServicePlansFromServicesSupportsJSONParityPath(t *testing.T) {
 	filterMaps, err := utils.ParseFilters([]string{"environment:dev"}, utils.GetSupportedFilterKeys(displaymodel.ServicePlanVersion{}))
 	require.NoError(t, err)
 
-	plans, err := formatServicePlansFromServices(testBrowserServices(), filterMaps, false)
+	plans, err := formatServicePlansFromServices(testBrowserServices(), filterMaps, false, false)
 
 	require.NoError(t, err)
 	require.Len(t, plans, 1)
@@ -1465,6 +1465,29 @@ func TestFormatServicePlansFromServicesSupportsJSONParityPath(t *testing.T) {
 	require.Equal(t, "Standard", plans[0].PlanName)
 }
 
+func TestFormatServicePlansFromServicesAllEnvironments(t *testing.T) {
+	services := testBrowserServices()
+	devType, prodType := "DEV", "PROD"
+	services[0].ServiceEnvironments[0].Type = &devType
+	services[0].ServiceEnvironments[1].Type = &prodType
+	// List prod first, so the result has to be sorted by environment
+	services[0].ServiceEnvironments[0], services[0].ServiceEnvironments[1] = services[0].ServiceEnvironments[1], services[0].ServiceEnvironments[0]
+
+	plans, err := formatServicePlansFromServices(services, nil, false, false)
+	require.NoError(t, err)
+	require.Len(t, plans, 2)
+	require.Equal(t, "prod", plans[0].Environment)
+	require.Empty(t, plans[0].EnvironmentType)
+
+	plans, err = formatServicePlansFromServices(services, nil, false, true)
+	require.NoError(t, err)
+	require.Len(t, plans, 2)
+	require.Equal(t, "dev", plans[0].Environment)
+	require.Equal(t, "DEV", plans[0].EnvironmentType)
+	require.Equal(t, "prod", plans[1].Environment)
+	require.Equal(t, "PROD", plans[1].EnvironmentType)
+}
+
 func testBrowserServices() []openapiclient.DescribeServiceResult {
 	return []openapiclient.DescribeServiceResult{
 		{
@@ -3,6 +3,7 @@ package serviceplan
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
@@ -17,7 +18,10 @@ import (
 
 const (
 	listExample = `# List service plans of the service postgres in the prod and dev environments
-omnistrate-ctl service-plan list -f="service_name:postgres,environment:prod" -f="service:postgres,environment:dev"`
+omnistrate-ctl service-plan list -f="service_name:postgres,environment:prod" -f="service:postgres,environment:dev"
+
+# List the service plans of the service postgres across all its environments, grouped by environment
+omnistrate-ctl service-plan list -f="service_name:postgres" --all-environments`
 	defaultMaxNameLength = 30 // Maximum length of the name column in the table
 )
 
@@ -26,7 +30,8 @@ func newListCmd(cfg servicePlanCommandConfig) *cobra.Command {
 		Use:   "list [flags]",
 		Short: "List Service Plans for your service",
 		Long: `This command helps you list Service Plans for your service.
-You can filter for specific service plans by using the filter flag.`,
+You can filter for specific service plans by using the filter flag.
+Use --all-environments to sort the service plans by environment and show the type of each environment.`,
 		Example:      servicePlanExample(cfg.commandPath, listExample),
 		RunE:         runList,
 		SilenceUsage: true,
@@ -38,6 +43,7 @@ You can filter for specific service plans by using the filter flag.`,
 
 	cmd.Flags().StringArrayP("filter", "f", []string{}, "Filter to apply to the list of service plans. E.g.: key1:value1,key2:value2, which filters service plans where key1 equals value1 and key2 equals value2. Allow use of multiple filters to form the logical OR operation. Supported keys: "+strings.Join(utils.GetSupportedFilterKeys(model.ServicePlanVersion{}), ",")+". Check the examples for more details.")
 	cmd.Flags().Bool("truncate", false, "Truncate long names in the output")
+	cmd.Flags().Bool("all-environments", false, "Sort the service plans by environment and show the type of each environment")
 	interactiveDescription := "Launch interactive list with fuzzy search and selection"
 	if cfg.listBrowserDefault {
 		interactiveDescription = "Launch interactive plan browser"
@@ -55,6 +61,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	filters, _ := cmd.Flags().GetStringArray("filter")
 	truncateNames, _ := cmd.Flags().GetBool("truncate")
 	interactive, _ := cmd.Flags().GetBool("interactive")
+	allEnvironments, _ := cmd.Flags().GetBool("all-environments")
 
 	// Parse and validate filters
 	filterMaps, err := utils.ParseFilters(filters, utils.GetSupportedFilterKeys(model.ServicePlanVersion{}))
@@ -86,7 +93,7 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	formattedServicePlans, err := formatServicePlansFromServices(listRes.Services, filterMaps, truncateNames)
+	formattedServicePlans, err := formatServicePlansFromServices(listRes.Services, filterMaps, truncateNames, allEnvironments)
 	if err != nil {
 		utils.HandleSpinnerError(spinner, sm, err)
 		return err
@@ -157,13 +164,18 @@ func shouldRunPlanBrowser(cmd *cobra.Command, output string, interactive bool) b
 	return output != "json" && (interactive || cmd.Annotations["serviceplan-list-browser-default"] == "true")
 }
 
-func formatServicePlansFromServices(services []openapiclient.DescribeServiceResult, filterMaps []map[string]string, truncateNames bool) ([]model.ServicePlan, error) {
+// formatServicePlansFromServices formats the service plans of every environment of services that match filterMaps.
+// With allEnvironments, the plans are sorted by environment and carry the environment type.
+func formatServicePlansFromServices(services []openapiclient.DescribeServiceResult, filterMaps []map[string]string, truncateNames, allEnvironments bool) ([]model.ServicePlan, error) {
 	var formattedServicePlans []model.ServicePlan
 
 	for _, service := range services {
 		for _, env := range service.ServiceEnvironments {
 			for _, servicePlan := range env.ServicePlans {
 				formattedServicePlan := formatServicePlan(service.Id, service.Name, env.Name, servicePlan, truncateNames)
+				if allEnvironments {
+					formattedServicePlan.EnvironmentType = env.GetType()
+				}
 
 				match, err := utils.MatchesFilters(formattedServicePlan, filterMaps)
 				if err != nil {
@@ -177,6 +189,15 @@ func formatServicePlansFromServices(services []openapiclient.DescribeServiceResu
 		}
 	}
 
+	if allEnvironments {
+		sort.SliceStable(formattedServicePlans, func(i, j int) bool {
+			if formattedServicePlans[i].Environment != formattedServicePlans[j].Environment {
+				return formattedServicePlans[i].Environment < formattedServicePlans[j].Environment
+			}
+			return formattedServicePlans[i].ServiceName < formattedServicePlans[j].ServiceName
+		})
+	}
+
 	return formattedServicePlans, nil
 }
 
@@ -18,14 +18,18 @@ import (
 
 const (
 	listVersionsExample = `# List service plan versions of the service postgres in the prod and dev environments
-omnistrate-ctl service-plan list-versions postgres postgres -f="service_name:postgres,environment:prod" -f="service:postgres,environment:dev"`
+omnistrate-ctl service-plan list-versions postgres postgres -f="service_name:postgres,environment:prod" -f="service:postgres,environment:dev"
+
+# Show only the preferred version of a service plan by ID
+omnistrate-ctl service-plan list-versions --service-id=s-12345678 --plan-id=pt-12345678 -f="preferred:true"`
 )
 
 func newListVersionsCmd(commandPath string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list-versions [service-name] [plan-name] [flags]",
 		Short: "List Versions of a specific Service Plan",
-		Long: `This command helps you list Versions of a specific Service Plan.
+		Long: `This command helps you list Versions of a specific Service Plan, newest release first.
+Each version shows its release description, its status, whether it is the preferred (default) version, and when it was released.
 You can filter for specific service plan versions by using the filter flag.`,
 		Example:      servicePlanExample(commandPath, listVersionsExample),
 		RunE:         runListVersions,
@@ -169,6 +173,11 @@ func formatServicePlanVersion(servicePlan openapiclientfleet.ServicePlanSearchRe
 		releaseDescription = *servicePlan.VersionName
 	}
 
+	var releasedAt string
+	if servicePlan.ReleasedAt != nil {
+		releasedAt = *servicePlan.ReleasedAt
+	}
+
 	return model.ServicePlanVersion{
 		PlanID:             servicePlan.Id,
 		PlanName:           planName,
@@ -177,7 +186,10 @@ func formatServicePlanVersion(servicePlan openapiclientfleet.ServicePlanSearchRe
 		Environment:        envName,
 		Version:            servicePlan.Version,
 		ReleaseDescription: releaseDescription,
+		Description:        servicePlan.Description,
 		VersionSetStatus:   servicePlan.VersionSetStatus,
+		Preferred:          servicePlan.VersionSetStatus == "Preferred",
+		ReleasedAt:         releasedAt,
 	}
 }
 
@@ -0,0 +1,238 @@
+package serviceplan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/cmd/common"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/dataaccess"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/model"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	promoteExample = `# Promote the preferred version of a service plan from dev to prod
+omnistrate-ctl service-plan promote [service-name] [plan-name] --from=dev --to=prod
+
+# Show what would be promoted without promoting
+omnistrate-ctl service-plan promote [service-name] [plan-name] --from=dev --to=prod --version=latest --dry-run
+
+# Promote a specific version by ID instead of name, without prompting for confirmation
+omnistrate-ctl service-plan promote --service-id=[service-id] --plan-id=[plan-id] --from=dev --to=prod --version=[version] --yes`
+)
+
+func newPromoteCmd(commandPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote [service-name] [plan-name] --from=[environment] --to=[environment] [flags]",
+		Short: "Promote a Version of a Service Plan to another Environment",
+		Long: `This command helps you promote a Version of a Service Plan from one environment (e.g. dev) to the environment it is promoted to (e.g. prod).
+The target environment must have the source environment as its source. Use --dry-run to show what would be promoted without promoting.`,
+		Example:      servicePlanExample(commandPath, promoteExample),
+		RunE:         runPromote,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("from", "", "Name of the environment to promote from")
+	cmd.Flags().String("to", "", "Name of the environment to promote to")
+	cmd.Flags().String("version", "preferred", "Version to promote. Use 'latest' for the latest version or 'preferred' for the preferred version")
+	cmd.Flags().StringP("service-id", "", "", "Service ID. Required if service name is not provided")
+	cmd.Flags().StringP("plan-id", "", "", "Plan ID in the source environment. Required if plan name is not provided")
+	cmd.Flags().Bool("dry-run", false, "Show what would be promoted without promoting")
+	cmd.Flags().BoolP("yes", "y", false, "Pre-approve the promotion without prompting for confirmation")
+
+	if err := cmd.MarkFlagRequired("from"); err != nil {
+		return cmd
+	}
+	if err := cmd.MarkFlagRequired("to"); err != nil {
+		return cmd
+	}
+	return cmd
+}
+
+func runPromote(cmd *cobra.Command, args []string) error {
+	defer config.CleanupArgsAndFlags(cmd, &args)
+
+	// Retrieve flags
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	version, _ := cmd.Flags().GetString("version")
+	output, _ := cmd.Flags().GetString("output")
+	serviceID, _ := cmd.Flags().GetString("service-id")
+	planID, _ := cmd.Flags().GetString("plan-id")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	// Validate input arguments
+	if err := validatePromoteArguments(args, serviceID, planID, from, to); err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	// Set service and plan names if provided in args
+	var serviceName, planName string
+	if len(args) == 2 {
+		serviceName, planName = args[0], args[1]
+	}
+
+	// Validate user login
+	token, err := common.GetTokenWithLogin()
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	// Check if the service plan exists in the source environment
+	serviceID, _, planID, _, err = getServicePlan(cmd.Context(), token, serviceID, serviceName, planID, planName, from)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	service, err := dataaccess.DescribeService(cmd.Context(), token, serviceID)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	promotion, err := buildServicePlanPromotion(service, serviceID, planID, from, to)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	promotion.Version, err = getTargetVersion(cmd.Context(), token, serviceID, planID, version)
+	if err != nil {
+		utils.PrintError(err)
+		return err
+	}
+
+	if dryRun {
+		promotion.PromoteStatus = "DRY_RUN"
+		if output != "json" {
+			fmt.Printf("Dry run: version %s of service plan %s would be promoted from %s to %s\n",
+				promotion.Version, promotion.PlanName, promotion.SourceEnvironmentName, promotion.TargetEnvironmentName)
+		}
+		return utils.PrintTextTableJsonOutput(output, promotion)
+	}
+
+	// Confirm promotion
+	if !yes {
+		confirmed, err := utils.ConfirmAction(fmt.Sprintf("Promote version %s of service plan %s from %s to %s?",
+			promotion.Version, promotion.PlanName, promotion.SourceEnvironmentName, promotion.TargetEnvironmentName))
+		if err != nil {
+			utils.PrintError(err)
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	// Initialize spinner if output is not JSON
+	var sm utils.SpinnerManager
+	var spinner *utils.Spinner
+	if output != "json" {
+		sm = utils.NewSpinnerManager()
+		spinner = sm.AddSpinner("Promoting service plan...")
+		sm.Start()
+	}
+
+	if err = dataaccess.PromoteServiceEnvironment(cmd.Context(), token, serviceID, promotion.SourceEnvironmentID, planID, promotion.Version); err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+
+	// Get the promote status of the target environment
+	statuses, err := dataaccess.PromoteServiceEnvironmentStatus(cmd.Context(), token, serviceID, promotion.SourceEnvironmentID)
+	if err != nil {
+		utils.HandleSpinnerError(spinner, sm, err)
+		return err
+	}
+	for _, status := range statuses {
+		if status.TargetEnvironmentID == promotion.TargetEnvironmentID {
+			promotion.PromoteStatus = status.Status
+		}
+	}
+
+	utils.HandleSpinnerSuccess(spinner, sm, "Successfully promoted service plan")
+
+	if err = utils.PrintTextTableJsonOutput(output, promotion); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// buildServicePlanPromotion describes the promotion of a plan from the environment named from to the environment
+// named to. The target must be promoted from the source, and be its only target, as promoting the source environment
+// promotes to all its targets.
+func buildServicePlanPromotion(service *openapiclient.DescribeServiceResult, serviceID, planID, from, to string) (*model.ServicePlanPromotion, error) {
+	var source, target *openapiclient.ServiceEnvironment
+	for i := range service.ServiceEnvironments {
+		env := &service.ServiceEnvironments[i]
+		if strings.EqualFold(env.Name, from) {
+			source = env
+		}
+		if strings.EqualFold(env.Name, to) {
+			target = env
+		}
+	}
+	if source == nil {
+		return nil, fmt.Errorf("environment %s not found. Please check input values and try again", from)
+	}
+	if target == nil {
+		return nil, fmt.Errorf("environment %s not found. Please check input values and try again", to)
+	}
+	if target.SourceEnvironmentID == nil || *target.SourceEnvironmentID != source.Id {
+		return nil, fmt.Errorf("environment %s is not promoted from %s", target.Name, source.Name)
+	}
+
+	var otherTargets []string
+	for _, env := range service.ServiceEnvironments {
+		if env.Id != target.Id && env.SourceEnvironmentID != nil && *env.SourceEnvironmentID == source.Id {
+			otherTargets = append(otherTargets, env.Name)
+		}
+	}
+	if len(otherTargets) > 0 {
+		return nil, fmt.Errorf("environment %s is also promoted to %s. Use 'environment promote' to promote to all of them", source.Name, strings.Join(otherTargets, ", "))
+	}
+
+	promotion := &model.ServicePlanPromotion{
+		ServiceID:             serviceID,
+		ServiceName:           service.Name,
+		PlanID:                planID,
+		SourceEnvironmentID:   source.Id,
+		SourceEnvironmentName: source.Name,
+		TargetEnvironmentID:   target.Id,
+		TargetEnvironmentName: target.Name,
+	}
+	for _, plan := range source.ServicePlans {
+		if plan.ProductTierID == planID {
+			promotion.PlanName = plan.Name
+		}
+	}
+	if promotion.PlanName == "" {
+		return nil, fmt.Errorf("service plan not found in environment %s. Please check input values and try again", source.Name)
+	}
+
+	return promotion, nil
+}
+
+func validatePromoteArguments(args []string, serviceID, planID, from, to string) error {
+	if len(args) == 0 && (serviceID == "" || planID == "") {
+		return fmt.Errorf("please provide the service name and plan name or the service ID and plan ID")
+	}
+	if len(args) > 0 && len(args) != 2 {
+		return fmt.Errorf("invalid arguments: %s. Need 2 arguments: [service-name] [plan-name]", strings.Join(args, " "))
+	}
+	if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+		return fmt.Errorf("please provide the environments to promote from and to with --from and --to")
+	}
+	if strings.EqualFold(strings.TrimSpace(from), strings.TrimSpace(to)) {
+		return fmt.Errorf("--from and --to must be different environments")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package serviceplan
+
+import (
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func testPromotionService() *openapiclient.DescribeServiceResult {
+	return &openapiclient.DescribeServiceResult{
+		Name: "postgres",
+		ServiceEnvironments: []openapiclient.ServiceEnvironment{
+			{
+				Id:           "se-dev",
+				Name:         "Dev",
+				ServicePlans: []openapiclient.ServicePlan{{Name: "standard", ProductTierID: "pt-dev"}},
+			},
+			{
+				Id:                  "se-prod",
+				Name:                "Prod",
+				SourceEnvironmentID: utils.ToPtr("se-dev"),
+			},
+			{
+				Id:   "se-qa",
+				Name: "QA",
+			},
+		},
+	}
+}
+
+func TestBuildServicePlanPromotion(t *testing.T) {
+	require := require.New(t)
+
+	promotion, err := buildServicePlanPromotion(testPromotionService(), "s-1", "pt-dev", "dev", "prod")
+	require.NoError(err)
+	require.Equal("standard", promotion.PlanName)
+	require.Equal("se-dev", promotion.SourceEnvironmentID)
+	require.Equal("Prod", promotion.TargetEnvironmentName)
+
+	_, err = buildServicePlanPromotion(testPromotionService(), "s-1", "pt-dev", "dev", "qa")
+	require.ErrorContains(err, "is not promoted from")
+
+	_, err = buildServicePlanPromotion(testPromotionService(), "s-1", "pt-dev", "dev", "staging")
+	require.ErrorContains(err, "staging not found")
+
+	_, err = buildServicePlanPromotion(testPromotionService(), "s-1", "pt-other", "dev", "prod")
+	require.ErrorContains(err, "service plan not found")
+
+	// Promoting the source environment promotes to all its targets, so a second target is refused
+	service := testPromotionService()
+	service.ServiceEnvironments[2].SourceEnvironmentID = utils.ToPtr("se-dev")
+	_, err = buildServicePlanPromotion(service, "s-1", "pt-dev", "dev", "prod")
+	require.ErrorContains(err, "also promoted to QA")
+}
+
+func TestValidatePromoteArguments(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(validatePromoteArguments([]string{"postgres", "standard"}, "", "", "dev", "prod"))
+	require.NoError(validatePromoteArguments(nil, "s-1", "pt-1", "dev", "prod"))
+	require.Error(validatePromoteArguments(nil, "", "", "dev", "prod"))
+	require.Error(validatePromoteArguments([]string{"postgres"}, "", "", "dev", "prod"))
+	require.Error(validatePromoteArguments([]string{"postgres", "standard"}, "", "", "dev", "Dev"))
+}
@@ -54,6 +54,7 @@ func newCommand(cfg servicePlanCommandConfig) *cobra.Command {
 	cmd.AddCommand(newDeleteCmd(cfg.commandPath))
 	cmd.AddCommand(newReleaseCmd(cfg.commandPath))
 	cmd.AddCommand(newSetDefaultCmd(cfg.commandPath))
+	cmd.AddCommand(newPromoteCmd(cfg.commandPath))
 	cmd.AddCommand(newDescribeCmd(cfg.commandPath))
 	cmd.AddCommand(newDescribeVersionCmd(cfg.commandPath))
 	cmd.AddCommand(newListCmd(cfg))
@@ -168,6 +168,32 @@ func TestFilterLatestNVersions(t *testing.T) {
 	}
 }
 
+func TestFormatServicePlanVersionReleaseMetadata(t *testing.T) {
+	require := require.New(t)
+
+	preferred := formatServicePlanVersion(openapiclientfleet.ServicePlanSearchRecord{
+		Id:               "pt-12345678",
+		Version:          "2.0",
+		Description:      "Managed Postgres",
+		VersionName:      utils.ToPtr("Add backups"),
+		VersionSetStatus: "Preferred",
+		ReleasedAt:       utils.ToPtr("2023-08-01T00:00:00Z"),
+	}, false)
+	require.True(preferred.Preferred)
+	require.Equal("Add backups", preferred.ReleaseDescription)
+	require.Equal("Managed Postgres", preferred.Description)
+	require.Equal("2023-08-01T00:00:00Z", preferred.ReleasedAt)
+
+	draft := formatServicePlanVersion(openapiclientfleet.ServicePlanSearchRecord{
+		Id:               "pt-12345678",
+		Version:          "2.1",
+		VersionSetStatus: "Draft",
+	}, false)
+	require.False(draft.Preferred)
+	require.Empty(draft.ReleaseDescription)
+	require.Empty(draft.ReleasedAt)
+}
+
 func TestValidateUpdateVersionNameArguments(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidInstanceAlias = errors.New("instance alias must not be empty or contain whitespace")
+	ErrInstanceAliasInUse   = errors.New("instance alias is already used by another instance")
+)
+
+// AdoptedInstance records an instance created outside omnistrate-ctl, e.g. in the web console, so it can be
+// targeted by its alias. Profile is empty for the default profile, as in AuthConfig.
+type AdoptedInstance struct {
+	Profile         string `yaml:"profile,omitempty"`
+	InstanceID      string `yaml:"instance_id"`
+	Alias           string `yaml:"alias,omitempty"`
+	ServiceID       string `yaml:"service_id"`
+	ServiceName     string `yaml:"service_name,omitempty"`
+	EnvironmentID   string `yaml:"environment_id"`
+	EnvironmentName string `yaml:"environment_name,omitempty"`
+	PlanID          string `yaml:"plan_id"`
+	PlanName        string `yaml:"plan_name,omitempty"`
+	AdoptedAt       string `yaml:"adopted_at,omitempty"`
+}
+
+// SaveAdoptedInstance records the instance for the active profile, replacing any previous record of the same
+// instance ID.
+func SaveAdoptedInstance(instance AdoptedInstance) error {
+	instance.Alias = strings.TrimSpace(instance.Alias)
+	if instance.Alias != "" && strings.ContainsAny(instance.Alias, " \t\n") {
+		return ErrInvalidInstanceAlias
+	}
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return err
+	}
+
+	if err = cfg.load(); err != nil {
+		return err
+	}
+
+	instance.Profile = profileKey(cfg.activeProfile())
+
+	idx := -1
+	for i, adopted := range cfg.AdoptedInstances {
+		if adopted.Profile != instance.Profile {
+			continue
+		}
+		if adopted.InstanceID == instance.InstanceID {
+			idx = i
+			continue
+		}
+		if instance.Alias != "" && adopted.Alias == instance.Alias {
+			return errors.Wrapf(ErrInstanceAliasInUse, "%s is adopted as %s", adopted.InstanceID, adopted.Alias)
+		}
+	}
+
+	if instance.Alias != "" {
+		if aliasIdx := cfg.aliasIndex(instance.Profile, AliasNamespaceInstance, instance.Alias); aliasIdx >= 0 && cfg.Aliases[aliasIdx].ID != instance.InstanceID {
+			return errors.Wrapf(ErrInstanceAliasInUse, "%s is an alias of %s", instance.Alias, cfg.Aliases[aliasIdx].ID)
+		}
+	}
+
+	if idx < 0 {
+		cfg.AdoptedInstances = append(cfg.AdoptedInstances, instance)
+	} else {
+		cfg.AdoptedInstances[idx] = instance
+	}
+
+	return cfg.save()
+}
+
+// ListAdoptedInstances returns the instances adopted under the active profile, sorted by instance ID.
+func ListAdoptedInstances() ([]AdoptedInstance, error) {
+	if !fileExists() {
+		return nil, ErrConfigFileNotFound
+	}
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cfg.load(); err != nil {
+		return nil, err
+	}
+
+	return cfg.adoptedInstances(), nil
+}
+
+// adoptedInstances returns the adopted instances of the active profile, sorted by instance ID.
+func (configFile *ConfigFile) adoptedInstances() []AdoptedInstance {
+	key := profileKey(configFile.activeProfile())
+
+	instances := make([]AdoptedInstance, 0)
+	for _, instance := range configFile.AdoptedInstances {
+		if profileKey(instance.Profile) == key {
+			instances = append(instances, instance)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].InstanceID < instances[j].InstanceID
+	})
+
+	return instances
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdoptedInstances(t *testing.T) {
+	t.Cleanup(func() {
+		SetProfile("")
+		_ = os.Remove(filepath.Join(ConfigDir(), DefaultFile))
+	})
+
+	_ = os.Remove(filepath.Join(ConfigDir(), DefaultFile))
+	assert.Equal(t, "instance-123", ResolveInstanceID("instance-123"))
+
+	err := SaveAdoptedInstance(AdoptedInstance{InstanceID: "instance-123", Alias: "orders-db", ServiceID: "s-1", EnvironmentID: "se-1", PlanID: "pt-1"})
+	assert.NoError(t, err)
+	err = SaveAdoptedInstance(AdoptedInstance{InstanceID: "instance-001", ServiceID: "s-1", EnvironmentID: "se-1", PlanID: "pt-1"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "instance-123", ResolveInstanceID("orders-db"))
+	assert.Equal(t, "instance-999", ResolveInstanceID("instance-999"))
+
+	instances, err := ListAdoptedInstances()
+	assert.NoError(t, err)
+	if assert.Len(t, instances, 2) {
+		assert.Equal(t, "instance-001", instances[0].InstanceID)
+		assert.Equal(t, "instance-123", instances[1].InstanceID)
+	}
+
+	// The alias is unique per profile
+	err = SaveAdoptedInstance(AdoptedInstance{InstanceID: "instance-001", Alias: "orders-db"})
+	assert.ErrorIs(t, err, ErrInstanceAliasInUse)
+	err = SaveAdoptedInstance(AdoptedInstance{InstanceID: "instance-001", Alias: "orders db"})
+	assert.ErrorIs(t, err, ErrInvalidInstanceAlias)
+
+	// Adopting an instance again replaces its record
+	err = SaveAdoptedInstance(AdoptedInstance{InstanceID: "instance-123", Alias: "billing-db"})
+	assert.NoError(t, err)
+	assert.Equal(t, "orders-db", ResolveInstanceID("orders-db"))
+	assert.Equal(t, "instance-123", ResolveInstanceID("billing-db"))
+
+	// Other profiles do not see the adopted instances
+	SetProfile("staging")
+	instances, err = ListAdoptedInstances()
+	assert.NoError(t, err)
+	assert.Empty(t, instances)
+	assert.Equal(t, "billing-db", ResolveInstanceID("billing-db"))
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AliasNamespaceInstance holds aliases of instance IDs.
+	AliasNamespaceInstance = "instance"
+	// AliasNamespaceService holds aliases of service IDs or names.
+	AliasNamespaceService = "service"
+)
+
+// AliasNamespaces lists the supported alias namespaces.
+var AliasNamespaces = []string{AliasNamespaceInstance, AliasNamespaceService}
+
+var (
+	ErrInvalidAlias          = errors.New("alias must not be empty or contain whitespace")
+	ErrInvalidAliasNamespace = fmt.Errorf("alias namespace must be one of: %s", strings.Join(AliasNamespaces, ", "))
+	ErrInvalidAliasTarget    = errors.New("alias target must not be empty")
+)
+
+// Alias maps a friendly name to an ID within a namespace. Profile is empty for the default profile, as in AuthConfig.
+type Alias struct {
+	Profile   string `yaml:"profile,omitempty"`
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+	ID        string `yaml:"id"`
+}
+
+// SetAlias saves name as an alias of id in the namespace for the active profile, replacing any previous target.
+func SetAlias(namespace, name, id string) error {
+	name = strings.TrimSpace(name)
+	id = strings.TrimSpace(id)
+	if !slices.Contains(AliasNamespaces, namespace) {
+		return ErrInvalidAliasNamespace
+	}
+	if name == "" || strings.ContainsAny(name, " \t\n") {
+		return ErrInvalidAlias
+	}
+	if id == "" {
+		return ErrInvalidAliasTarget
+	}
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return err
+	}
+
+	if err = cfg.load(); err != nil {
+		return err
+	}
+
+	profile := profileKey(cfg.activeProfile())
+
+	// Instance aliases share their names with the aliases of adopted instances
+	if namespace == AliasNamespaceInstance {
+		for _, instance := range cfg.adoptedInstances() {
+			if instance.Alias == name && instance.InstanceID != id {
+				return errors.Wrapf(ErrInstanceAliasInUse, "%s is adopted as %s", instance.InstanceID, name)
+			}
+		}
+	}
+
+	alias := Alias{Profile: profile, Namespace: namespace, Name: name, ID: id}
+	if idx := cfg.aliasIndex(profile, namespace, name); idx < 0 {
+		cfg.Aliases = append(cfg.Aliases, alias)
+	} else {
+		cfg.Aliases[idx] = alias
+	}
+
+	return cfg.save()
+}
+
+// ListAliases returns the aliases of the active profile, including those of adopted instances, sorted by
+// namespace and name.
+func ListAliases() ([]Alias, error) {
+	if !fileExists() {
+		return nil, ErrConfigFileNotFound
+	}
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cfg.load(); err != nil {
+		return nil, err
+	}
+
+	return cfg.aliases(), nil
+}
+
+// ResolveAlias returns the ID that value is an alias of in the namespace. Any other value, including a plain ID,
+// is returned unchanged.
+func ResolveAlias(namespace, value string) string {
+	aliases, err := ListAliases()
+	if err != nil {
+		return value
+	}
+
+	for _, alias := range aliases {
+		if alias.Namespace == namespace && alias.Name == value {
+			return alias.ID
+		}
+	}
+	return value
+}
+
+// ResolveInstanceID returns the instance ID that idOrAlias is an alias of, either set with `config alias set` or
+// given when adopting the instance. Any other value, including a plain instance ID, is returned unchanged.
+func ResolveInstanceID(idOrAlias string) string {
+	return ResolveAlias(AliasNamespaceInstance, idOrAlias)
+}
+
+// aliases returns the aliases of the active profile merged with the aliases of its adopted instances, sorted by
+// namespace and name.
+func (configFile *ConfigFile) aliases() []Alias {
+	key := profileKey(configFile.activeProfile())
+
+	aliases := make([]Alias, 0)
+	for _, alias := range configFile.Aliases {
+		if profileKey(alias.Profile) == key {
+			aliases = append(aliases, alias)
+		}
+	}
+	for _, instance := range configFile.adoptedInstances() {
+		if instance.Alias == "" || configFile.aliasIndex(key, AliasNamespaceInstance, instance.Alias) >= 0 {
+			continue
+		}
+		aliases = append(aliases, Alias{Profile: key, Namespace: AliasNamespaceInstance, Name: instance.Alias, ID: instance.InstanceID})
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i].Namespace != aliases[j].Namespace {
+			return aliases[i].Namespace < aliases[j].Namespace
+		}
+		return aliases[i].Name < aliases[j].Name
+	})
+
+	return aliases
+}
+
+// aliasIndex returns the index of the alias saved for profile in the namespace, or -1 if there is none.
+func (configFile *ConfigFile) aliasIndex(profile, namespace, name string) int {
+	key := profileKey(profile)
+	for i, alias := range configFile.Aliases {
+		if profileKey(alias.Profile) == key && alias.Namespace == namespace && alias.Name == name {
+			return i
+		}
+	}
+	return -1
+}
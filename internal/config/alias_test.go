@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliases(t *testing.T) {
+	t.Cleanup(func() {
+		SetProfile("")
+		_ = os.Remove(filepath.Join(ConfigDir(), DefaultFile))
+	})
+
+	_ = os.Remove(filepath.Join(ConfigDir(), DefaultFile))
+	assert.Equal(t, "orders", ResolveAlias(AliasNamespaceService, "orders"))
+
+	assert.ErrorIs(t, SetAlias("cluster", "orders", "s-1"), ErrInvalidAliasNamespace)
+	assert.ErrorIs(t, SetAlias(AliasNamespaceService, "my orders", "s-1"), ErrInvalidAlias)
+	assert.ErrorIs(t, SetAlias(AliasNamespaceService, "orders", " "), ErrInvalidAliasTarget)
+
+	assert.NoError(t, SetAlias(AliasNamespaceService, "orders", "s-1"))
+	assert.NoError(t, SetAlias(AliasNamespaceInstance, "orders", "instance-1"))
+	assert.NoError(t, SaveAdoptedInstance(AdoptedInstance{InstanceID: "instance-2", Alias: "billing"}))
+
+	// The same name resolves per namespace, and adopted instance aliases resolve as instance aliases
+	assert.Equal(t, "s-1", ResolveAlias(AliasNamespaceService, "orders"))
+	assert.Equal(t, "instance-1", ResolveInstanceID("orders"))
+	assert.Equal(t, "instance-2", ResolveInstanceID("billing"))
+	assert.Equal(t, "billing", ResolveAlias(AliasNamespaceService, "billing"))
+
+	// Instance aliases and adopted instance aliases cannot point at different instances
+	assert.ErrorIs(t, SetAlias(AliasNamespaceInstance, "billing", "instance-3"), ErrInstanceAliasInUse)
+	assert.ErrorIs(t, SaveAdoptedInstance(AdoptedInstance{InstanceID: "instance-3", Alias: "orders"}), ErrInstanceAliasInUse)
+
+	// Setting an alias again replaces its target
+	assert.NoError(t, SetAlias(AliasNamespaceService, "orders", "s-2"))
+	assert.Equal(t, "s-2", ResolveAlias(AliasNamespaceService, "orders"))
+
+	aliases, err := ListAliases()
+	assert.NoError(t, err)
+	assert.Equal(t, []Alias{
+		{Namespace: AliasNamespaceInstance, Name: "billing", ID: "instance-2"},
+		{Namespace: AliasNamespaceInstance, Name: "orders", ID: "instance-1"},
+		{Namespace: AliasNamespaceService, Name: "orders", ID: "s-2"},
+	}, aliases)
+
+	// Other profiles do not see the aliases
+	SetProfile("staging")
+	aliases, err = ListAliases()
+	assert.NoError(t, err)
+	assert.Empty(t, aliases)
+	assert.Equal(t, "orders", ResolveInstanceID("orders"))
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CACertEnvVar points to a PEM CA bundle to trust for log streams when the --ca-cert flag is not set.
+const CACertEnvVar = "OMCTL_CA_CERT"
+
+// caCertOverride holds the value of the --ca-cert flag.
+var caCertOverride string
+
+// SetCACert selects the PEM CA bundle for the current invocation, taking precedence over
+// OMCTL_CA_CERT. An empty value clears the override.
+func SetCACert(path string) {
+	caCertOverride = strings.TrimSpace(path)
+}
+
+// GetCACert returns the path of the PEM CA bundle in order of precedence: --ca-cert flag and
+// OMCTL_CA_CERT. It returns an empty string when none is set.
+func GetCACert() string {
+	if caCertOverride != "" {
+		return caCertOverride
+	}
+	return strings.TrimSpace(os.Getenv(CACertEnvVar))
+}
+
+// TLSConfig returns the TLS configuration trusting the system roots and the CA bundle from GetCACert.
+// It returns nil when no CA bundle is configured, in which case the defaults apply.
+func TLSConfig() (*tls.Config, error) {
+	path := GetCACert()
+	if path == "" {
+		return nil, nil
+	}
+
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CA bundle")
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("no PEM certificates found in CA bundle %s", path)
+	}
+
+	return &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestCACert(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "omnistrate-ctl test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return path
+}
+
+func TestCACertPrecedence(t *testing.T) {
+	t.Cleanup(func() { SetCACert("") })
+
+	t.Setenv(CACertEnvVar, "")
+	assert.Empty(t, GetCACert())
+
+	t.Setenv(CACertEnvVar, "/etc/ssl/env.pem")
+	assert.Equal(t, "/etc/ssl/env.pem", GetCACert())
+
+	SetCACert(" /etc/ssl/flag.pem ")
+	assert.Equal(t, "/etc/ssl/flag.pem", GetCACert())
+}
+
+func TestTLSConfig(t *testing.T) {
+	t.Cleanup(func() { SetCACert("") })
+	t.Setenv(CACertEnvVar, "")
+
+	// Nothing configured keeps the defaults
+	tlsConfig, err := TLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	SetCACert(writeTestCACert(t))
+	tlsConfig, err = TLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, tlsConfig)
+	assert.NotNil(t, tlsConfig.RootCAs)
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+	SetCACert(notPEM)
+	_, err = TLSConfig()
+	assert.Error(t, err)
+
+	SetCACert(filepath.Join(t.TempDir(), "missing.pem"))
+	_, err = TLSConfig()
+	assert.Error(t, err)
+}
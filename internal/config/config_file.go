@@ -23,13 +23,19 @@ const (
 
 // ConfigFile represents the Omnistrate CTL config file.
 type ConfigFile struct {
-	AuthConfigs               []AuthConfig `yaml:"auths"`
-	GitHubPersonalAccessToken string       `yaml:"github_personal_access_token,omitempty"`
-	FilePath                  string       `yaml:"-"`
+	AuthConfigs               []AuthConfig      `yaml:"auths"`
+	CurrentProfile            string            `yaml:"current_profile,omitempty"`
+	Endpoints                 map[string]string `yaml:"endpoints,omitempty"` // API endpoint per profile name
+	GitHubPersonalAccessToken string            `yaml:"github_personal_access_token,omitempty"`
+	AdoptedInstances          []AdoptedInstance `yaml:"adopted_instances,omitempty"`
+	Aliases                   []Alias           `yaml:"aliases,omitempty"`
+	FilePath                  string            `yaml:"-"`
 }
 
 // AuthConfig represents the authentication configuration.
+// Profile is empty for the default profile so existing config files keep working unchanged.
 type AuthConfig struct {
+	Profile      string `yaml:"profile,omitempty"`
 	Token        string `yaml:"token,omitempty"`
 	RefreshToken string `yaml:"refresh_token,omitempty"` //nolint:gosec
 }
@@ -136,7 +142,7 @@ func (configFile *ConfigFile) load() error {
 	return yaml.Unmarshal(data, configFile)
 }
 
-// CreateOrUpdateAuthConfig creates or updates the authentication configuration.
+// CreateOrUpdateAuthConfig creates or updates the authentication configuration of the active profile.
 func CreateOrUpdateAuthConfig(authConfig AuthConfig) error {
 	configPath, err := EnsureFile()
 	if err != nil {
@@ -162,16 +168,19 @@ func CreateOrUpdateAuthConfig(authConfig AuthConfig) error {
 		}
 	}
 
-	if len(cfg.AuthConfigs) == 0 {
+	profile := cfg.activeProfile()
+	authConfig.Profile = profileKey(profile)
+
+	if idx := cfg.authConfigIndex(profile); idx < 0 {
 		cfg.AuthConfigs = append(cfg.AuthConfigs, authConfig)
 	} else {
-		cfg.AuthConfigs[0] = authConfig
+		cfg.AuthConfigs[idx] = authConfig
 	}
 
 	return cfg.save()
 }
 
-// LookupAuthConfig returns the authentication configuration.
+// LookupAuthConfig returns the authentication configuration of the active profile.
 func LookupAuthConfig() (AuthConfig, error) {
 	var authConfig AuthConfig
 
@@ -193,8 +202,8 @@ func LookupAuthConfig() (AuthConfig, error) {
 		return authConfig, err
 	}
 
-	if len(cfg.AuthConfigs) > 0 && cfg.AuthConfigs[0].Token != "" {
-		ac := cfg.AuthConfigs[0]
+	if idx := cfg.authConfigIndex(cfg.activeProfile()); idx >= 0 && cfg.AuthConfigs[idx].Token != "" {
+		ac := cfg.AuthConfigs[idx]
 		// Decrypt the refresh token after reading from disk
 		if ac.RefreshToken != "" {
 			decrypted, decErr := DecryptToken(ac.RefreshToken)
@@ -210,7 +219,7 @@ func LookupAuthConfig() (AuthConfig, error) {
 	return authConfig, ErrAuthConfigNotFound
 }
 
-// RemoveAuthConfig deletes the authentication configuration.
+// RemoveAuthConfig deletes the authentication configuration of the active profile.
 func RemoveAuthConfig() error {
 	if !fileExists() {
 		return ErrConfigFileNotFound
@@ -230,8 +239,8 @@ func RemoveAuthConfig() error {
 		return err
 	}
 
-	if len(cfg.AuthConfigs) > 0 {
-		cfg.AuthConfigs = []AuthConfig{}
+	if idx := cfg.authConfigIndex(cfg.activeProfile()); idx >= 0 {
+		cfg.AuthConfigs = append(cfg.AuthConfigs[:idx], cfg.AuthConfigs[idx+1:]...)
 		return cfg.save()
 	}
 
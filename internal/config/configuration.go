@@ -34,6 +34,7 @@ const (
 	retryWaitMin         = "OMNISTRATE_RETRY_WAIT_MIN_IN_SECONDS"
 	retryWaitMax         = "OMNISTRATE_RETRY_WAIT_MAX_IN_SECONDS"
 	retryMax             = "OMNISTRATE_RETRY_MAX"
+	maxConfigFileSize    = "OMNISTRATE_MAX_CONFIG_FILE_SIZE_IN_KB"
 
 	// OmnistrateAPIKeyEnv is the environment variable for API key authentication.
 	OmnistrateAPIKeyEnv = "OMNISTRATE_API_KEY" //nolint:gosec // G101: env var name, not a credential
@@ -90,25 +91,36 @@ func GetRefreshToken() (string, error) {
 // has expired or will expire within the given margin. This avoids a network
 // round-trip to validate the token when we can tell locally it's stale.
 func IsTokenExpired(token string, margin time.Duration) bool {
+	expiry, ok := TokenExpiry(token)
+	if !ok {
+		return true
+	}
+
+	return !time.Now().Add(margin).Before(expiry)
+}
+
+// TokenExpiry returns the expiry time from the JWT's exp claim. It returns false
+// when the token is not a JWT or has no exp claim.
+func TokenExpiry(token string) (time.Time, bool) {
 	parts := strings.SplitN(token, ".", 3)
 	if len(parts) != 3 {
-		return true
+		return time.Time{}, false
 	}
 
 	// JWT payload is base64url-encoded (no padding)
 	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
 	if err != nil {
-		return true
+		return time.Time{}, false
 	}
 
 	var claims struct {
 		Exp int64 `json:"exp"`
 	}
 	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
-		return true
+		return time.Time{}, false
 	}
 
-	return time.Now().Add(margin).Unix() >= claims.Exp
+	return time.Unix(claims.Exp, 0), true
 }
 
 func GetIndexCacheTTL() time.Duration {
@@ -138,18 +150,38 @@ func GetSearchIndexName() string {
 	return GetEnv("OMNISTRATE_SEARCH_INDEX_NAME", "search_index.bleve")
 }
 
-// GetHost returns the host of the Omnistrate server
+// GetHost returns the host of the Omnistrate server. It is empty when the endpoint in effect is
+// invalid, so requests fail instead of reaching the default API host.
 func GetHost() string {
+	if u, set := endpointURL(); set {
+		if u == nil {
+			return ""
+		}
+		return u.Host
+	}
 	return GetEnv(omnistrateHost, "api"+"."+GetRootDomain())
 }
 
-// GetRootDomain returns the root domain of the Omnistrate server
+// GetRootDomain returns the root domain of the Omnistrate server. With an endpoint set it is the
+// endpoint host name without its port and leading "api." label.
 func GetRootDomain() string {
+	if u, set := endpointURL(); set {
+		if u == nil {
+			return ""
+		}
+		return strings.TrimPrefix(u.Hostname(), "api.")
+	}
 	return GetEnv(omnistrateRootDomain, defaultRootDomain)
 }
 
 // GetHostScheme returns the scheme of the Omnistrate server
 func GetHostScheme() string {
+	if u, set := endpointURL(); set {
+		if u == nil {
+			return ""
+		}
+		return u.Scheme
+	}
 	return GetEnv(omnistrateHostSchema, "https")
 }
 
@@ -203,11 +235,28 @@ func GetRetryWaitMax() time.Duration {
 	return time.Duration(waitInSeconds) * time.Second
 }
 
+// retryMaxOverride holds a retry count set by a command flag; negative means unset.
+var retryMaxOverride = -1
+
+// SetRetryMax overrides OMNISTRATE_RETRY_MAX for the current invocation. A negative value clears the override.
+func SetRetryMax(retries int) {
+	retryMaxOverride = retries
+}
+
 // GetRetryMax returns the maximum number of retries
 func GetRetryMax() int {
+	if retryMaxOverride >= 0 {
+		return retryMaxOverride
+	}
 	return GetEnvAsInteger(retryMax, "5")
 }
 
+// GetMaxConfigFileSize returns the largest mounted file, in bytes, that is converted to a compose config
+func GetMaxConfigFileSize() int64 {
+	sizeInKB := GetEnvAsInteger(maxConfigFileSize, "1024")
+	return int64(sizeInKB) * 1024
+}
+
 // GetUserAgent returns the User-Agent string for HTTP requests
 func GetUserAgent() string {
 	if Version == "" {
@@ -151,6 +151,16 @@ func TestIsTokenExpired_MalformedToken(t *testing.T) {
 	assert.True(t, IsTokenExpired("", 0))
 }
 
+func TestTokenExpiry(t *testing.T) {
+	exp := time.Now().Add(1 * time.Hour).Unix()
+	expiry, ok := TokenExpiry(makeJWT(exp))
+	assert.True(t, ok)
+	assert.Equal(t, exp, expiry.Unix())
+
+	_, ok = TokenExpiry("not-a-jwt")
+	assert.False(t, ok)
+}
+
 func TestIsTokenExpired_InvalidPayload(t *testing.T) {
 	header := base64.RawURLEncoding.EncodeToString([]byte(`{}`))
 	payload := base64.RawURLEncoding.EncodeToString([]byte(`not json`))
@@ -196,6 +206,26 @@ func TestGetRetryMaxCustom(t *testing.T) {
 	assert.Equal(t, 10, n)
 }
 
+func TestSetRetryMaxOverridesEnv(t *testing.T) {
+	t.Setenv(retryMax, "10")
+	t.Cleanup(func() { SetRetryMax(-1) })
+
+	SetRetryMax(0)
+	assert.Equal(t, 0, GetRetryMax())
+
+	SetRetryMax(-1)
+	assert.Equal(t, 10, GetRetryMax())
+}
+
+func TestGetMaxConfigFileSize(t *testing.T) {
+	assert.Equal(t, int64(1024*1024), GetMaxConfigFileSize())
+}
+
+func TestGetMaxConfigFileSizeCustom(t *testing.T) {
+	t.Setenv(maxConfigFileSize, "64")
+	assert.Equal(t, int64(64*1024), GetMaxConfigFileSize())
+}
+
 func TestCleanupArgsAndFlags_StringArray(t *testing.T) {
 	cmd := &cobra.Command{Use: "test", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
 	cmd.Flags().StringArray("platforms", []string{"linux/amd64"}, "platforms")
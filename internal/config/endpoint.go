@@ -0,0 +1,155 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EndpointEnvVar overrides the API endpoint when the --endpoint flag is not set.
+const EndpointEnvVar = "OMCTL_ENDPOINT"
+
+var ErrInvalidEndpoint = errors.New("endpoint must be an absolute http or https URL without a path, query or fragment, e.g. https://api.example.com")
+
+// endpointOverride holds the value of the global --endpoint flag.
+var endpointOverride string
+
+// resolvedEndpoint caches the endpoint in effect once ResolveEndpoint has run, so the config file
+// is not re-read every time an API client is built.
+var resolvedEndpoint struct {
+	done bool
+	raw  string
+	url  *url.URL
+	err  error
+}
+
+// SetEndpoint selects the API endpoint for the current invocation, taking precedence over
+// OMCTL_ENDPOINT and the endpoint saved for the active profile. An empty value clears the override.
+func SetEndpoint(endpoint string) {
+	endpointOverride = strings.TrimSpace(endpoint)
+	resetResolvedEndpoint()
+}
+
+// ResolveEndpoint determines the endpoint in effect (see GetEndpoint) and parses it once for
+// GetHost, GetHostScheme and GetRootDomain. It returns the endpoint, or an error when an endpoint
+// is set but invalid, in which case no request falls back to the default API host.
+func ResolveEndpoint() (string, error) {
+	endpoint := GetEndpoint()
+	resolvedEndpoint.done = true
+	resolvedEndpoint.raw = endpoint
+	resolvedEndpoint.url = nil
+	resolvedEndpoint.err = nil
+	if endpoint == "" {
+		return "", nil
+	}
+	u, err := ParseEndpoint(endpoint)
+	if err != nil {
+		resolvedEndpoint.err = errors.Wrapf(err, "invalid endpoint %q", endpoint)
+		return endpoint, resolvedEndpoint.err
+	}
+	resolvedEndpoint.url = u
+	return endpoint, nil
+}
+
+func resetResolvedEndpoint() {
+	resolvedEndpoint.done = false
+	resolvedEndpoint.raw = ""
+	resolvedEndpoint.url = nil
+	resolvedEndpoint.err = nil
+}
+
+// ParseEndpoint validates an API endpoint URL such as https://api.example.com.
+func ParseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidEndpoint, err.Error())
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil ||
+		(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return nil, ErrInvalidEndpoint
+	}
+	return u, nil
+}
+
+// IsInsecureEndpoint reports whether endpoint does not use TLS.
+func IsInsecureEndpoint(endpoint string) bool {
+	u, err := ParseEndpoint(endpoint)
+	return err == nil && u.Scheme != "https"
+}
+
+// GetEndpoint returns the API endpoint override in order of precedence: --endpoint flag,
+// OMCTL_ENDPOINT, and the endpoint saved for the active profile. It returns an empty string
+// when none is set, in which case OMNISTRATE_HOST and OMNISTRATE_HOST_SCHEME apply.
+func GetEndpoint() string {
+	if endpointOverride != "" {
+		return endpointOverride
+	}
+	if env := strings.TrimSpace(os.Getenv(EndpointEnvVar)); env != "" {
+		return env
+	}
+	if !fileExists() {
+		return ""
+	}
+	configPath, err := EnsureFile()
+	if err != nil {
+		return ""
+	}
+	cfg, err := New(configPath)
+	if err != nil || cfg.load() != nil {
+		return ""
+	}
+	return cfg.Endpoints[cfg.activeProfile()]
+}
+
+// SaveEndpoint stores endpoint for the active profile. An empty endpoint removes the saved value.
+func SaveEndpoint(endpoint string) error {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint != "" {
+		u, err := ParseEndpoint(endpoint)
+		if err != nil {
+			return err
+		}
+		endpoint = u.Scheme + "://" + u.Host
+	}
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return err
+	}
+
+	if err = cfg.load(); err != nil {
+		return err
+	}
+
+	profile := cfg.activeProfile()
+	if endpoint == "" {
+		delete(cfg.Endpoints, profile)
+	} else {
+		if cfg.Endpoints == nil {
+			cfg.Endpoints = make(map[string]string)
+		}
+		cfg.Endpoints[profile] = endpoint
+	}
+
+	if err = cfg.save(); err != nil {
+		return err
+	}
+	resetResolvedEndpoint()
+	return nil
+}
+
+// endpointURL returns the parsed endpoint in effect, resolving it on first use. It returns
+// set=false when no endpoint is set, and a nil URL with set=true when the endpoint is invalid.
+func endpointURL() (u *url.URL, set bool) {
+	if !resolvedEndpoint.done {
+		_, _ = ResolveEndpoint()
+	}
+	return resolvedEndpoint.url, resolvedEndpoint.raw != ""
+}
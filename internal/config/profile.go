@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultProfile is the profile used when none is selected.
+	DefaultProfile = "default"
+	// ProfileEnvVar selects the credential profile when the --profile flag is not set.
+	ProfileEnvVar = "OMCTL_PROFILE"
+)
+
+var ErrInvalidProfileName = errors.New("profile name must not be empty or contain whitespace")
+
+// profileOverride holds the value of the global --profile flag.
+var profileOverride string
+
+// SetProfile selects the credential profile for the current invocation, taking precedence over
+// OMCTL_PROFILE and the profile saved with `config use-profile`. An empty name clears the override.
+func SetProfile(name string) {
+	profileOverride = strings.TrimSpace(name)
+	resetResolvedEndpoint()
+}
+
+// ActiveProfile returns the name of the credential profile used for authentication.
+func ActiveProfile() string {
+	cfg := &ConfigFile{}
+	if fileExists() {
+		if configPath, err := EnsureFile(); err == nil {
+			if loaded, err := New(configPath); err == nil && loaded.load() == nil {
+				cfg = loaded
+			}
+		}
+	}
+	return cfg.activeProfile()
+}
+
+// UseProfile saves name as the profile used when neither --profile nor OMCTL_PROFILE is set.
+func UseProfile(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, " \t\n") {
+		return ErrInvalidProfileName
+	}
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return err
+	}
+
+	if err = cfg.load(); err != nil {
+		return err
+	}
+
+	cfg.CurrentProfile = profileKey(name)
+
+	return cfg.save()
+}
+
+// ListProfiles returns the names of all profiles with saved credentials, sorted by name.
+func ListProfiles() ([]string, error) {
+	if !fileExists() {
+		return nil, ErrConfigFileNotFound
+	}
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cfg.load(); err != nil {
+		return nil, err
+	}
+
+	profiles := make([]string, 0, len(cfg.AuthConfigs))
+	for _, authConfig := range cfg.AuthConfigs {
+		profiles = append(profiles, profileName(authConfig.Profile))
+	}
+	sort.Strings(profiles)
+
+	return profiles, nil
+}
+
+// activeProfile resolves the profile in order of precedence: --profile flag, OMCTL_PROFILE,
+// the saved current profile, and finally the default profile.
+func (configFile *ConfigFile) activeProfile() string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	if env := strings.TrimSpace(os.Getenv(ProfileEnvVar)); env != "" {
+		return env
+	}
+	return profileName(configFile.CurrentProfile)
+}
+
+// authConfigIndex returns the index of the auth config stored for profile, or -1 if there is none.
+func (configFile *ConfigFile) authConfigIndex(profile string) int {
+	key := profileKey(profile)
+	for i, authConfig := range configFile.AuthConfigs {
+		if profileKey(authConfig.Profile) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// profileName maps the stored representation of a profile to its display name.
+func profileName(key string) string {
+	if key == "" {
+		return DefaultProfile
+	}
+	return key
+}
+
+// profileKey maps a profile name to its stored representation. The default profile is stored
+// with an empty name to stay compatible with config files written before profiles existed.
+func profileKey(name string) string {
+	if name == DefaultProfile {
+		return ""
+	}
+	return name
+}
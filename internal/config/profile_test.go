@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileAuthConfigs(t *testing.T) {
+	t.Cleanup(func() {
+		SetProfile("")
+		_ = os.Remove(filepath.Join(ConfigDir(), DefaultFile))
+	})
+
+	defaultConfig := AuthConfig{Token: "default-token"}
+	err := CreateOrUpdateAuthConfig(defaultConfig)
+	assert.NoError(t, err)
+
+	SetProfile("staging")
+	err = CreateOrUpdateAuthConfig(AuthConfig{Token: "staging-token"})
+	assert.NoError(t, err)
+
+	loaded, err := LookupAuthConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "staging-token", loaded.Token)
+	assert.Equal(t, "staging", loaded.Profile)
+
+	SetProfile("")
+	loaded, err = LookupAuthConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, defaultConfig, loaded)
+
+	profiles, err := ListProfiles()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{DefaultProfile, "staging"}, profiles)
+
+	SetProfile("staging")
+	err = RemoveAuthConfig()
+	assert.NoError(t, err)
+	_, err = LookupAuthConfig()
+	assert.ErrorIs(t, err, ErrAuthConfigNotFound)
+
+	SetProfile(DefaultProfile)
+	loaded, err = LookupAuthConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "default-token", loaded.Token)
+}
+
+func TestActiveProfilePrecedence(t *testing.T) {
+	t.Cleanup(func() {
+		SetProfile("")
+		_ = os.Remove(filepath.Join(ConfigDir(), DefaultFile))
+	})
+
+	_ = os.Remove(filepath.Join(ConfigDir(), DefaultFile))
+	assert.Equal(t, DefaultProfile, ActiveProfile())
+
+	err := UseProfile("saved")
+	assert.NoError(t, err)
+	assert.Equal(t, "saved", ActiveProfile())
+
+	t.Setenv(ProfileEnvVar, "from-env")
+	assert.Equal(t, "from-env", ActiveProfile())
+
+	SetProfile("from-flag")
+	assert.Equal(t, "from-flag", ActiveProfile())
+
+	err = UseProfile(DefaultProfile)
+	assert.NoError(t, err)
+	SetProfile("")
+	t.Setenv(ProfileEnvVar, "")
+	assert.Equal(t, DefaultProfile, ActiveProfile())
+}
+
+func TestUseProfileRejectsInvalidName(t *testing.T) {
+	assert.ErrorIs(t, UseProfile(""), ErrInvalidProfileName)
+	assert.ErrorIs(t, UseProfile("my profile"), ErrInvalidProfileName)
+}
@@ -20,7 +20,7 @@ func DescribeAccount(ctx context.Context, token string, id string) (*openapiclie
 		id,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func ListAccounts(ctx context.Context, token string, cloudProvider string) (*ope
 		cloudProvider,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,7 @@ func DeleteAccount(ctx context.Context, token, accountConfigID string) error {
 		accountConfigID,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return err
 	}
@@ -73,7 +73,7 @@ func CreateAccount(ctx context.Context, token string, accountConfig openapiclien
 		ctxWithToken,
 	).CreateAccountConfigRequest2(accountConfig).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return "", err
 	}
@@ -106,7 +106,7 @@ func UpdateAccount(ctx context.Context, token string, params UpdateAccountParams
 		params.AccountConfigID,
 	).UpdateAccountConfigRequest2(request).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return "", err
 	}
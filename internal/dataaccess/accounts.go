@@ -43,7 +43,7 @@ func ListAccountConfigs(ctx context.Context, token, cloudProviderName string) (*
 		FleetListAccountConfigsRequest2(request).
 		Execute()
 
-	err = handleFleetError(err)
+	err = handleFleetError(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +100,7 @@ func ListServicePlans(ctx context.Context, token, serviceID, serviceEnvironmentI
 		SkipHasPendingChangesCheck(false).
 		Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
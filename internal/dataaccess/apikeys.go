@@ -38,7 +38,7 @@ func CreateAPIKey(
 	if r != nil {
 		defer r.Body.Close()
 	}
-	if err = handleV1Error(err); err != nil {
+	if err = handleV1Error(err, r); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -54,7 +54,7 @@ func ListAPIKeys(ctx context.Context, token string) (*openapiclient.ListAPIKeysR
 	if r != nil {
 		defer r.Body.Close()
 	}
-	if err = handleV1Error(err); err != nil {
+	if err = handleV1Error(err, r); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -69,7 +69,7 @@ func DescribeAPIKey(ctx context.Context, token, id string) (*openapiclient.Descr
 	if r != nil {
 		defer r.Body.Close()
 	}
-	if err = handleV1Error(err); err != nil {
+	if err = handleV1Error(err, r); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -102,7 +102,7 @@ func UpdateAPIKeyMetadata(
 	if r != nil {
 		defer r.Body.Close()
 	}
-	if err = handleV1Error(err); err != nil {
+	if err = handleV1Error(err, r); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -119,7 +119,7 @@ func RevokeAPIKey(ctx context.Context, token, id string) error {
 	if r != nil {
 		defer r.Body.Close()
 	}
-	return handleV1Error(err)
+	return handleV1Error(err, r)
 }
 
 // DeleteAPIKey permanently removes the key identified by id and its
@@ -132,5 +132,5 @@ func DeleteAPIKey(ctx context.Context, token, id string) error {
 	if r != nil {
 		defer r.Body.Close()
 	}
-	return handleV1Error(err)
+	return handleV1Error(err, r)
 }
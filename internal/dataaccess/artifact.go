@@ -60,7 +60,7 @@ func UploadArtifact(
 		}).Execute()
 
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	// Clean up the response ID (remove surrounding quotes and newlines)
@@ -87,7 +87,7 @@ func DescribeArtifact(
 
 	resp, r, err := apiClient.DeploymentArtifactApiAPI.DeploymentArtifactApiDescribeDeploymentArtifact(ctxWithToken, artifactID).Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	return &ArtifactDescribeResult{
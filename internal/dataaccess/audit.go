@@ -65,7 +65,7 @@ func ListAuditEvents(ctx context.Context, token string, opts *ListAuditEventsOpt
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
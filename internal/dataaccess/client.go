@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
 	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
 	openapiclientv1 "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
 	"github.com/pkg/errors"
@@ -16,15 +17,16 @@ import (
 
 // Configure registration api client
 func getV1Client() *openapiclientv1.APIClient {
+	host, scheme := config.GetHost(), config.GetHostScheme()
 	configuration := openapiclientv1.NewConfiguration()
-	configuration.Host = config.GetHost()
-	configuration.Scheme = config.GetHostScheme()
+	configuration.Host = host
+	configuration.Scheme = scheme
 	configuration.Debug = false                     // We set logging on the retryablehttp client
 	configuration.UserAgent = config.GetUserAgent() // Set User-Agent header
 
 	var servers openapiclientv1.ServerConfigurations
 	for _, server := range configuration.Servers {
-		server.URL = fmt.Sprintf("%s://%s", config.GetHostScheme(), config.GetHost())
+		server.URL = fmt.Sprintf("%s://%s", scheme, host)
 		servers = append(servers, server)
 	}
 	configuration.Servers = servers
@@ -36,33 +38,34 @@ func getV1Client() *openapiclientv1.APIClient {
 	return apiClient
 }
 
-func handleV1Error(err error) error {
+func handleV1Error(err error, r *http.Response) error {
 	if err != nil {
 		var serviceErr *openapiclientv1.GenericOpenAPIError
 		ok := errors.As(err, &serviceErr)
 		if !ok {
-			return err
+			return withStatusExitCode(withRequestID(err, r), r)
 		}
 		apiError, ok := serviceErr.Model().(openapiclientv1.Error)
 		if !ok {
-			return fmt.Errorf("%s\nDetail: %s", serviceErr.Error(), string(serviceErr.Body()))
+			return withStatusExitCode(withRequestID(fmt.Errorf("%s\nDetail: %s", serviceErr.Error(), string(serviceErr.Body())), r), r)
 		}
-		return fmt.Errorf("%s\nDetail: %s", apiError.Name, apiError.Message)
+		return withStatusExitCode(withRequestID(fmt.Errorf("%s\nDetail: %s", apiError.Name, apiError.Message), r), r)
 	}
 	return err
 }
 
 // Configure fleet api client
 func getFleetClient() *openapiclientfleet.APIClient {
+	host, scheme := config.GetHost(), config.GetHostScheme()
 	configuration := openapiclientfleet.NewConfiguration()
-	configuration.Host = config.GetHost()
-	configuration.Scheme = config.GetHostScheme()
+	configuration.Host = host
+	configuration.Scheme = scheme
 	configuration.Debug = false                     // We set logging on the retryablehttp client
 	configuration.UserAgent = config.GetUserAgent() // Set User-Agent header
 
 	var servers openapiclientfleet.ServerConfigurations
 	for _, server := range configuration.Servers {
-		server.URL = fmt.Sprintf("%s://%s", config.GetHostScheme(), config.GetHost())
+		server.URL = fmt.Sprintf("%s://%s", scheme, host)
 		servers = append(servers, server)
 	}
 	configuration.Servers = servers
@@ -73,22 +76,34 @@ func getFleetClient() *openapiclientfleet.APIClient {
 	return apiClient
 }
 
-func handleFleetError(err error) error {
+func handleFleetError(err error, r *http.Response) error {
 	if err != nil {
 		var serviceErr *openapiclientfleet.GenericOpenAPIError
 		ok := errors.As(err, &serviceErr)
 		if !ok {
-			return err
+			return withStatusExitCode(withRequestID(err, r), r)
 		}
 		apiError, ok := serviceErr.Model().(openapiclientfleet.Error)
 		if !ok {
-			return fmt.Errorf("%s\nDetail: %s", serviceErr.Error(), string(serviceErr.Body()))
+			return withStatusExitCode(withRequestID(fmt.Errorf("%s\nDetail: %s", serviceErr.Error(), string(serviceErr.Body())), r), r)
 		}
-		return fmt.Errorf("%s\nDetail: %s", apiError.Name, apiError.Message)
+		return withStatusExitCode(withRequestID(fmt.Errorf("%s\nDetail: %s", apiError.Name, apiError.Message), r), r)
 	}
 	return err
 }
 
+// withStatusExitCode attaches the exit code matching the status of the failed response r to err.
+func withStatusExitCode(err error, r *http.Response) error {
+	if err == nil || r == nil {
+		return err
+	}
+	code := utils.ExitCodeForHTTPStatus(r.StatusCode)
+	if code == utils.ExitCodeGeneral {
+		return err
+	}
+	return utils.WithExitCode(code, err)
+}
+
 // Configure retryable http client
 // retryablehttp gives us automatic retries with exponential backoff.
 func getRetryableHttpClient() *http.Client {
@@ -121,7 +136,9 @@ func getRetryableHttpClient() *http.Client {
 			log.Debug().Msgf("Response %s\n%s", res.Status, dump)
 		}
 	}
-	return httpClient.StandardClient()
+	standardClient := httpClient.StandardClient()
+	standardClient.Transport = &verboseTransport{next: standardClient.Transport}
+	return standardClient
 }
 
 // Used to transform the retryablehttp logger to a zerolog logger
@@ -151,8 +168,12 @@ func (l *LeveledLogger) Warn(msg string, keysAndValues ...interface{}) {
 
 func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
 	shouldRetry, _ := retryablehttp.ErrorPropagatedRetryPolicy(ctx, resp, err)
-	// Do not retry POST requests unless the response is a known transient gateway/rate-limit failure.
-	if shouldRetry && resp != nil && resp.Request != nil && resp.Request.Method == http.MethodPost {
+	// Client errors other than rate limiting will not succeed on retry, so fail fast.
+	if shouldRetry && resp != nil && resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		shouldRetry = false
+	}
+	// Do not retry non-idempotent requests unless the response is a known transient gateway/rate-limit failure.
+	if shouldRetry && resp != nil && resp.Request != nil && !isIdempotentMethod(resp.Request.Method) {
 		if !isRetriablePostStatus(resp.StatusCode) {
 			shouldRetry = false
 		}
@@ -160,6 +181,19 @@ func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, err
 	return shouldRetry, nil
 }
 
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodPut,
+		http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
 func isRetriablePostStatus(statusCode int) bool {
 	switch statusCode {
 	case http.StatusTooManyRequests,
@@ -73,3 +73,37 @@ func TestRetryPolicyPreservesDefaultRetryBehaviorForGet(t *testing.T) {
 	require.NoError(t, retryErr)
 	require.True(t, shouldRetry)
 }
+
+func TestRetryPolicyDoesNotRetryNonTransientPatchErrors(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, "https://example.com", nil)
+	require.NoError(t, err)
+
+	resp := &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Request:    req,
+	}
+
+	shouldRetry, retryErr := retryPolicy(context.Background(), resp, errors.New(http.StatusText(http.StatusInternalServerError)))
+	require.NoError(t, retryErr)
+	require.False(t, shouldRetry)
+}
+
+func TestRetryPolicyFailsFastOnClientErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, statusCode := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict} {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+		require.NoError(t, err)
+
+		resp := &http.Response{
+			StatusCode: statusCode,
+			Request:    req,
+		}
+
+		shouldRetry, retryErr := retryPolicy(context.Background(), resp, nil)
+		require.NoError(t, retryErr)
+		require.False(t, shouldRetry, http.StatusText(statusCode))
+	}
+}
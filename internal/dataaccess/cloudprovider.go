@@ -15,7 +15,7 @@ func GetCloudProviderByName(ctx context.Context, token string, cloudProvider str
 		cloudProvider,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return "", err
 	}
@@ -24,7 +24,7 @@ func CheckIfContainerImageAccessible(ctx context.Context, token string, imageReg
 	var r *http.Response
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	r.Body.Close()
@@ -38,7 +38,7 @@ func GenerateComposeSpecFromContainerImage(ctx context.Context, token string, re
 	var r *http.Response
 	res, r, err = apiClient.ComposeGenApiAPI.ComposeGenApiGenerateComposeSpecFromContainerImage(ctxWithToken).GenerateComposeSpecFromContainerImageRequest2(request).Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	r.Body.Close()
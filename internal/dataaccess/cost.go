@@ -53,7 +53,7 @@ func DescribeCloudProviderCost(ctx context.Context, token string, opts CostOptio
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -99,7 +99,7 @@ func DescribeDeploymentCellCost(ctx context.Context, token string, opts CostOpti
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -136,7 +136,7 @@ func DescribeRegionCost(ctx context.Context, token string, opts CostOptions) (re
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -172,7 +172,7 @@ func DescribeUserCost(ctx context.Context, token string, opts CostOptions) (res
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
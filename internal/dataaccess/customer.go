@@ -66,7 +66,7 @@ func CreateCustomerUser(ctx context.Context, token string, req CustomerUserCreat
 		}
 	}()
 	if err != nil {
-		return "", handleFleetError(err)
+		return "", handleFleetError(err, r)
 	}
 	return cleanupId(resp), nil
 }
@@ -91,7 +91,7 @@ func ListCustomerUsers(ctx context.Context, token string, opts CustomerUserListO
 		}
 	}()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return resp, nil
 }
@@ -107,7 +107,7 @@ func DescribeCustomerUser(ctx context.Context, token, userID string) (*openapicl
 		}
 	}()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return resp, nil
 }
@@ -130,7 +130,7 @@ func UpdateCustomerUser(ctx context.Context, token, userID string, req CustomerU
 		}
 	}()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return nil
 }
@@ -146,7 +146,7 @@ func DeleteCustomerUser(ctx context.Context, token, userID string) error {
 		}
 	}()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return nil
 }
@@ -162,7 +162,7 @@ func SendCustomerUserVerificationEmail(ctx context.Context, token, userID string
 		}
 	}()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return nil
 }
@@ -178,7 +178,7 @@ func SuspendCustomerUser(ctx context.Context, token, userID string) error {
 		}
 	}()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return nil
 }
@@ -194,7 +194,7 @@ func UnsuspendCustomerUser(ctx context.Context, token, userID string) error {
 		}
 	}()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return nil
 }
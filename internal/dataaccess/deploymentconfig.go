@@ -15,7 +15,7 @@ func GetDefaultDeploymentConfigID(ctx context.Context, token string) (string, er
 		"default",
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return "nil", err
 	}
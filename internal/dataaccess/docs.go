@@ -684,7 +684,7 @@ func GetJSONSchema(ctx context.Context, schemaType string) (interface{}, error)
 	if err != nil {
 		// Log the error but don't fail the entire operation
 		log.Debug().Err(err).Str("type", schemaType).Msg("Failed to fetch JSON schema")
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, httpRes)
 	}
 	defer httpRes.Body.Close()
 
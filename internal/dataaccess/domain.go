@@ -20,7 +20,7 @@ func ListDomains(ctx context.Context, token string) (*openapiclientv1.ListSaaSPo
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return resp, nil
 }
@@ -36,7 +36,7 @@ func DeleteDomain(ctx context.Context, token, environmentType string) error {
 		}
 	}()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 	return nil
 }
@@ -59,7 +59,7 @@ func CreateDomain(ctx context.Context, token, name, description, environmentType
 		}
 	}()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 	return nil
 }
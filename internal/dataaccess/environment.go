@@ -44,7 +44,7 @@ func CreateServiceEnvironment(ctx context.Context,
 		}
 	}()
 	if err != nil {
-		return "", handleV1Error(err)
+		return "", handleV1Error(err, r)
 	}
 	return cleanupId(resp), nil // remove surrounding quotes and newlines
 }
@@ -64,7 +64,7 @@ func DescribeServiceEnvironment(ctx context.Context, token, serviceID, serviceEn
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return resp, nil
 }
@@ -80,7 +80,7 @@ func ListServiceEnvironments(ctx context.Context, token, serviceID string) (*ope
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return resp, nil
 }
@@ -102,7 +102,7 @@ func PromoteServiceEnvironment(ctx context.Context, token, serviceID, serviceEnv
 		}
 	}()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 	return nil
 }
@@ -129,7 +129,7 @@ func PromoteServiceEnvironmentStatus(ctx context.Context, token, serviceID, serv
 			_ = r.Body.Close()
 		}
 	}()
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return
 	}
@@ -147,7 +147,7 @@ func DeleteServiceEnvironment(ctx context.Context, token, serviceID, serviceEnvi
 		}
 	}()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 	return nil
 }
@@ -23,7 +23,7 @@ func FleetDescribeCustomNetwork(
 
 	customNetwork, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return
@@ -51,7 +51,7 @@ func FleetUpdateCustomNetwork(
 
 	customNetwork, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return
@@ -82,7 +82,7 @@ func FleetListCustomNetworks(
 
 	customNetworks, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return
@@ -112,7 +112,7 @@ func FleetCreateCustomNetwork(
 
 	customNetwork, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return
@@ -135,7 +135,7 @@ func FleetDeleteCustomNetwork(
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 
 	return
@@ -41,7 +41,7 @@ func SaveHelmChart(
 		}).Execute()
 
 	if err != nil {
-		return helmPackage, handleV1Error(err)
+		return helmPackage, handleV1Error(err, r)
 	}
 
 	r.Body.Close()
@@ -56,7 +56,7 @@ func ListHelmCharts(ctx context.Context, token string) (helmPackages *openapicli
 	var r *http.Response
 	helmPackages, r, err = apiClient.HelmPackageApiAPI.HelmPackageApiListHelmPackages(ctxWithToken).Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	r.Body.Close()
@@ -71,7 +71,7 @@ func DescribeHelmChart(ctx context.Context, token, chartName, chartVersion strin
 	var r *http.Response
 	helmPackage, r, err = apiClient.HelmPackageApiAPI.HelmPackageApiDescribeHelmPackage(ctxWithToken, chartName, chartVersion).Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	r.Body.Close()
@@ -91,7 +91,7 @@ func ListHelmChartInstallations(ctx context.Context, token string, hostClusterID
 	var r *http.Response
 	helmPackageInstallations, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	r.Body.Close()
@@ -105,7 +105,7 @@ func DeleteHelmChart(ctx context.Context, token, chartName, chartVersion string)
 	var r *http.Response
 	r, err = apiClient.HelmPackageApiAPI.HelmPackageApiDeleteHelmPackage(ctxWithToken, chartName, chartVersion).Execute()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 
 	r.Body.Close()
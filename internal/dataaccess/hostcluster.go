@@ -30,7 +30,7 @@ func DebugHostCluster(ctx context.Context, token string, hostClusterID string) (
 
 	debugRes, r, err := req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return debugRes, nil
@@ -51,7 +51,7 @@ func DescribeHostCluster(ctx context.Context, token string, hostClusterID string
 
 	hostCluster, r, err := req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return hostCluster, nil
@@ -79,7 +79,7 @@ func ListHostClusters(ctx context.Context, token string, accountConfigID *string
 
 	hostClusters, r, err := req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return hostClusters, nil
@@ -100,7 +100,7 @@ func ApplyPendingChangesToHostCluster(ctx context.Context, token string, hostClu
 
 	r, err := req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 
 	return nil
@@ -133,7 +133,7 @@ func UpdateHostCluster(ctx context.Context, token string, hostClusterID string,
 
 	r, err := req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 
 	return nil
@@ -165,7 +165,7 @@ func AdoptHostCluster(ctx context.Context, token string, hostClusterID string, c
 
 	hostCluster, r, err := req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return hostCluster, nil
@@ -186,7 +186,7 @@ func DeleteHostCluster(ctx context.Context, token string, hostClusterID string)
 
 	r, err := req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 
 	return nil
@@ -219,7 +219,7 @@ func GetKubeConfigForHostCluster(
 
 	kubeConfig, r, err := req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return kubeConfig, nil
@@ -378,7 +378,7 @@ func ListNodepools(ctx context.Context, token string, hostClusterID string) ([]m
 
 	result, r, err := req.Execute()
 	if err != nil {
-		return nil, nil, handleFleetError(err)
+		return nil, nil, handleFleetError(err, r)
 	}
 
 	entities := result.GetEntities()
@@ -663,7 +663,7 @@ func DescribeNodepool(ctx context.Context, token string, hostClusterID string, n
 
 	entity, r, err := req.Execute()
 	if err != nil {
-		fleetErr := handleFleetError(err)
+		fleetErr := handleFleetError(err, r)
 		// Simplify not found errors
 		errMsg := fleetErr.Error()
 		if strings.Contains(errMsg, "not_found") || strings.Contains(errMsg, "Not found") || strings.Contains(errMsg, "notFound") || strings.Contains(errMsg, "404") {
@@ -718,7 +718,7 @@ func ConfigureNodepool(ctx context.Context, token string, hostClusterID string,
 
 	r, err := req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 
 	return nil
@@ -755,7 +755,7 @@ func DeleteNodepool(ctx context.Context, token string, hostClusterID string, nod
 
 	r, err = req.Execute()
 	if err != nil {
-		fleetErr := handleFleetError(err)
+		fleetErr := handleFleetError(err, r)
 		// Simplify not found errors
 		errMsg := fleetErr.Error()
 		if strings.Contains(errMsg, "not_found") || strings.Contains(errMsg, "Not found") || strings.Contains(errMsg, "notFound") || strings.Contains(errMsg, "404") {
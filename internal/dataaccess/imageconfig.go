@@ -0,0 +1,32 @@
+package dataaccess
+
+import (
+	"context"
+	"net/http"
+
+	openapiclient "github.com/omnistrate-oss/omnistrate-sdk-go/v1"
+)
+
+func DescribeImageConfig(ctx context.Context, token, serviceID, imageConfigID string) (resp *openapiclient.DescribeImageConfigResult, err error) {
+	ctxWithToken := context.WithValue(ctx, openapiclient.ContextAccessToken, token)
+	apiClient := getV1Client()
+
+	req := apiClient.ImageConfigApiAPI.ImageConfigApiDescribeImageConfig(
+		ctxWithToken,
+		serviceID,
+		imageConfigID,
+	)
+
+	var r *http.Response
+	defer func() {
+		if r != nil {
+			_ = r.Body.Close()
+		}
+	}()
+
+	resp, r, err = req.Execute()
+	if err != nil {
+		return nil, handleV1Error(err, r)
+	}
+	return
+}
@@ -17,7 +17,7 @@ func SearchInventory(ctx context.Context, token, query string, filters ...openap
 		SearchInventoryRequest2(req).
 		Execute()
 
-	err = handleFleetError(err)
+	err = handleFleetError(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -2,10 +2,12 @@ package dataaccess
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
 	openapiclientfleet "github.com/omnistrate-oss/omnistrate-sdk-go/fleet"
 )
 
@@ -143,21 +145,38 @@ type LogStreamConnection struct {
 	done chan struct{}
 }
 
+// LogStreamStatusError is returned by ConnectToLogStream when the log stream rejects the websocket handshake
+// with an HTTP status, e.g. because the credentials are not accepted or the pod no longer exists.
+type LogStreamStatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *LogStreamStatusError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Err, e.StatusCode)
+}
+
+func (e *LogStreamStatusError) Unwrap() error {
+	return e.Err
+}
+
 // ConnectToLogStream establishes a websocket connection to stream logs
 func (ls *LogsService) ConnectToLogStream(logsURL string) (*LogStreamConnection, error) {
 	if logsURL == "" {
 		return nil, fmt.Errorf("logs URL is empty")
 	}
 
-	// Set up websocket dialer with proper headers and timeouts
-	dialer := websocket.Dialer{
-		HandshakeTimeout: 10 * time.Second,
-		ReadBufferSize:   4096,
-		WriteBufferSize:  4096,
+	dialer, err := newLogStreamDialer()
+	if err != nil {
+		return nil, err
 	}
 
 	conn, resp, err := dialer.Dial(logsURL, nil)
 	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+			return nil, &LogStreamStatusError{StatusCode: resp.StatusCode, Err: fmt.Errorf("failed to connect to log stream: %w", err)}
+		}
 		return nil, fmt.Errorf("failed to connect to log stream: %w", err)
 	}
 	if resp != nil {
@@ -176,6 +195,23 @@ func (ls *LogsService) ConnectToLogStream(logsURL string) (*LogStreamConnection,
 	}, nil
 }
 
+// newLogStreamDialer returns a websocket dialer that goes through the HTTPS_PROXY/HTTP_PROXY/NO_PROXY
+// proxy settings and trusts the CA bundle configured with --ca-cert or OMCTL_CA_CERT.
+func newLogStreamDialer() (*websocket.Dialer, error) {
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS for log stream: %w", err)
+	}
+
+	return &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		TLSClientConfig:  tlsConfig,
+		HandshakeTimeout: 10 * time.Second,
+		ReadBufferSize:   4096,
+		WriteBufferSize:  4096,
+	}, nil
+}
+
 // ReadLogs reads log messages from the websocket connection
 func (lsc *LogStreamConnection) ReadLogs() (string, error) {
 	if lsc.conn == nil {
@@ -30,7 +30,7 @@ func GetNotificationChannelEventHistory(ctx context.Context, token, channelID st
 
 	res, r, err = request.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return
@@ -49,7 +49,7 @@ func ReplayNotificationEvent(ctx context.Context, token, eventID string) (err er
 
 	r, err = client.NotificationsApiAPI.NotificationsApiReplayEvent(ctxWithToken, eventID).Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 
 	return nil
@@ -68,7 +68,7 @@ func ListNotificationChannels(ctx context.Context, token string) (res *openapicl
 
 	res, r, err = client.NotificationsApiAPI.NotificationsApiListNotificationChannels(ctxWithToken).Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return
@@ -87,7 +87,7 @@ func GetNotificationChannel(ctx context.Context, token, channelID string) (res *
 
 	res, r, err = client.NotificationsApiAPI.NotificationsApiDescribeNotificationChannel(ctxWithToken, channelID).Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return
@@ -41,7 +41,7 @@ func GetDeploymentCellHealth(ctx context.Context, token string, opts *Deployment
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -63,7 +63,7 @@ func GetServiceHealth(ctx context.Context, token string, serviceID, serviceEnvir
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -129,7 +129,7 @@ func ListOperationsEvents(ctx context.Context, token string, opts *ListEventsOpt
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
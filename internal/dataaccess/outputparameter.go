@@ -32,7 +32,7 @@ func ListOutputParameters(ctx context.Context, token, serviceID, resourceID, pro
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return
 }
@@ -17,7 +17,7 @@ func DeleteProductTier(ctx context.Context, token, serviceID, productTierID stri
 		productTierID,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return err
 	}
@@ -36,7 +36,7 @@ func DescribeProductTier(ctx context.Context, token, serviceID, productTierID st
 		productTierID,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func ReleaseServicePlan(ctx context.Context, token, serviceID, serviceAPIID, pro
 		}
 	}()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 	return nil
 }
@@ -81,7 +81,7 @@ func DescribePendingChanges(ctx context.Context, token, serviceID, serviceAPIID,
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return resp, nil
 }
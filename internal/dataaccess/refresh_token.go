@@ -19,7 +19,7 @@ func RefreshToken(ctx context.Context, refreshToken string) (LoginResult, error)
 		}
 	}()
 	if err != nil {
-		return LoginResult{}, handleV1Error(err)
+		return LoginResult{}, handleV1Error(err, r)
 	}
 
 	return LoginResult{
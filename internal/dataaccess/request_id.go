@@ -0,0 +1,69 @@
+package dataaccess
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// requestIDHeaders are the response headers checked, in order, for a request identifier.
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Amzn-RequestId",
+	"X-Amzn-Trace-Id",
+	"Traceparent",
+}
+
+// RequestIDError is returned by dataaccess calls that fail after reaching the server. It carries the
+// request ID reported in the response headers so users can quote it to support.
+type RequestIDError struct {
+	Err       error
+	RequestID string
+}
+
+func (e *RequestIDError) Error() string {
+	return e.Err.Error() + "\nRequest ID: " + e.RequestID
+}
+
+func (e *RequestIDError) Unwrap() error {
+	return e.Err
+}
+
+// ServerRequestID returns the request ID, letting packages that cannot import dataaccess read it.
+func (e *RequestIDError) ServerRequestID() string {
+	return e.RequestID
+}
+
+// RequestIDFromError returns the server request ID attached to err, or an empty string.
+func RequestIDFromError(err error) string {
+	var requestIDErr *RequestIDError
+	if errors.As(err, &requestIDErr) {
+		return requestIDErr.RequestID
+	}
+	return ""
+}
+
+// withRequestID attaches the request ID from r to err when the server reported one.
+func withRequestID(err error, r *http.Response) error {
+	if err == nil {
+		return nil
+	}
+	requestID := responseRequestID(r)
+	if requestID == "" || RequestIDFromError(err) != "" {
+		return err
+	}
+	return &RequestIDError{Err: err, RequestID: requestID}
+}
+
+// responseRequestID returns the first request identifier header present on the response.
+func responseRequestID(res *http.Response) string {
+	if res == nil {
+		return ""
+	}
+	for _, header := range requestIDHeaders {
+		if value := res.Header.Get(header); value != "" {
+			return value
+		}
+	}
+	return ""
+}
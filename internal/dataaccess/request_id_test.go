@@ -0,0 +1,45 @@
+package dataaccess
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseRequestID(t *testing.T) {
+	require := require.New(t)
+
+	require.Empty(responseRequestID(nil))
+
+	res := &http.Response{Header: http.Header{}}
+	require.Empty(responseRequestID(res))
+
+	res.Header.Set("X-Amzn-Trace-Id", "Root=1-abc")
+	require.Equal("Root=1-abc", responseRequestID(res))
+
+	res.Header.Set("X-Request-Id", "req-123")
+	require.Equal("req-123", responseRequestID(res))
+}
+
+func TestWithRequestID(t *testing.T) {
+	require := require.New(t)
+
+	baseErr := errors.New("NOT_FOUND\nDetail: account not found")
+	require.NoError(withRequestID(nil, nil))
+	require.Equal(baseErr, withRequestID(baseErr, nil))
+	require.Equal(baseErr, withRequestID(baseErr, &http.Response{Header: http.Header{}}))
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("X-Request-Id", "req-123")
+	err := withRequestID(baseErr, res)
+	require.Equal("NOT_FOUND\nDetail: account not found\nRequest ID: req-123", err.Error())
+	require.Equal("req-123", RequestIDFromError(err))
+	require.ErrorIs(err, baseErr)
+
+	wrapped := errors.Wrap(err, "failed to list accounts")
+	require.Equal("req-123", RequestIDFromError(wrapped))
+	require.Equal(wrapped, withRequestID(wrapped, res))
+	require.Empty(RequestIDFromError(baseErr))
+}
@@ -32,7 +32,7 @@ func DescribeResource(ctx context.Context, token, serviceID, resourceID string,
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return
 }
@@ -59,7 +59,7 @@ func ListResources(ctx context.Context, token, serviceID string, productTierID s
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return
 }
@@ -37,7 +37,7 @@ func CreateResourceInstance(ctx context.Context, token string,
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -75,7 +75,7 @@ func RestoreResourceInstanceSnapshot(ctx context.Context, token string, serviceI
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -101,7 +101,7 @@ func DescribeResourceInstanceSnapshot(ctx context.Context, token string, service
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -121,6 +121,8 @@ type ListResourceInstanceOptions struct {
 	PageSize                *int64
 }
 
+// ListResourceInstance returns a single page of instances; use ListAllResourceInstances to follow the
+// NextPageToken until every page is fetched.
 func ListResourceInstance(ctx context.Context, token string, serviceID, environmentID string, options *ListResourceInstanceOptions) (res *openapiclientfleet.ListFleetResourceInstancesResultInternal, err error) {
 	ctxWithToken := context.WithValue(ctx, openapiclientfleet.ContextAccessToken, token)
 	apiClient := getFleetClient()
@@ -177,11 +179,12 @@ func ListResourceInstance(ctx context.Context, token string, serviceID, environm
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
 
+// ListAllResourceInstances returns the instances of every page, following the NextPageToken of each response.
 func ListAllResourceInstances(ctx context.Context, token string, serviceID, environmentID string, options *ListResourceInstanceOptions) (instances []openapiclientfleet.ResourceInstance, err error) {
 	var nextPageToken string
 
@@ -225,7 +228,7 @@ func ListResourceInstanceSnapshots(ctx context.Context, token string, serviceID,
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -250,7 +253,7 @@ func TriggerResourceInstanceAutoBackup(ctx context.Context, token string, servic
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -283,7 +286,7 @@ func ExecuteResourceInstanceCustomWorkflow(ctx context.Context, token, serviceID
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -311,7 +314,7 @@ func AddCapacityToResourceInstance(ctx context.Context, token string, serviceID,
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -339,7 +342,7 @@ func RemoveCapacityFromResourceInstance(ctx context.Context, token string, servi
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -371,7 +374,7 @@ func FailoverResourceInstance(ctx context.Context, token string, serviceID, envi
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -404,7 +407,7 @@ func CopyResourceInstanceSnapshot(ctx context.Context, token string, serviceID,
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -432,7 +435,7 @@ func DeleteResourceInstance(ctx context.Context, token, serviceID, environmentID
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -457,7 +460,7 @@ func DescribeResourceInstance(ctx context.Context, token string, serviceID, envi
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -484,7 +487,7 @@ func UpdateResourceInstanceDebugMode(ctx context.Context, token string, serviceI
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -509,7 +512,7 @@ func DebugResourceInstance(ctx context.Context, token string, serviceID, environ
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -536,7 +539,7 @@ func RestartResourceInstance(ctx context.Context, token string, serviceID, envir
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -563,7 +566,7 @@ func StartResourceInstance(ctx context.Context, token string, serviceID, environ
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -590,7 +593,7 @@ func StopResourceInstance(ctx context.Context, token string, serviceID, environm
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -632,7 +635,7 @@ func UpdateResourceInstance(
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -666,7 +669,7 @@ func AdoptResourceInstance(ctx context.Context, token string, serviceID, service
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -701,7 +704,7 @@ func OneOffPatchResourceInstance(ctx context.Context, token string, serviceID, e
 
 	_, r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -726,7 +729,7 @@ func DescribeResourceInstanceInstaller(ctx context.Context, token string, servic
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -773,7 +776,7 @@ func EvaluateExpression(ctx context.Context, token, serviceID, productTierID, in
 
 	res, r, err := req.Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	// Handle the response
@@ -20,7 +20,7 @@ func RevokeToken(ctx context.Context, refreshToken string) error {
 		}
 	}()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 
 	return nil
@@ -21,7 +21,7 @@ func SetSecret(ctx context.Context, token, environmentType, name, value string)
 		}
 	}()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 	return nil
 }
@@ -38,7 +38,7 @@ func GetSecret(ctx context.Context, token, environmentType, name string) (*opena
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return resp, nil
 }
@@ -55,7 +55,7 @@ func ListSecrets(ctx context.Context, token, environmentType string) (*openapicl
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 	return resp, nil
 }
@@ -72,7 +72,7 @@ func DeleteSecret(ctx context.Context, token, environmentType, name string) erro
 		}
 	}()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 	return nil
 }
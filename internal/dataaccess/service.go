@@ -24,7 +24,7 @@ func ListServices(ctx context.Context, token string) (*openapiclient.ListService
 	apiClient := getV1Client()
 	resp, r, err := apiClient.ServiceApiAPI.ServiceApiListService(ctxWithToken).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func DescribeService(ctx context.Context, token, serviceID string) (*openapiclie
 	apiClient := getV1Client()
 	resp, r, err := apiClient.ServiceApiAPI.ServiceApiDescribeService(ctxWithToken, serviceID).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func DeleteService(ctx context.Context, token, serviceID string) error {
 	apiClient := getV1Client()
 	r, err := apiClient.ServiceApiAPI.ServiceApiDeleteService(ctxWithToken, serviceID).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return err
 	}
@@ -76,7 +76,7 @@ func PrepareServiceFromServicePlanSpec(ctx context.Context, token string, reques
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	return resp, nil
@@ -95,7 +95,7 @@ func BuildServiceFromServicePlanSpec(ctx context.Context, token string, request
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	return resp, nil
@@ -114,7 +114,7 @@ func BuildServiceFromComposeSpec(ctx context.Context, token string, request open
 		}
 	}()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	return resp, nil
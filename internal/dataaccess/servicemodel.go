@@ -19,7 +19,7 @@ func DescribeServiceModel(ctx context.Context, token, serviceID, serviceModelID
 
 	resp, r, err := apiClient.ServiceModelApiAPI.ServiceModelApiDescribeServiceModel(ctxWithToken, serviceID, serviceModelID).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +45,7 @@ func EnableServiceModelFeature(ctx context.Context, token, serviceID, serviceMod
 
 	r, err = req.Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return err
 	}
@@ -69,7 +69,7 @@ func DisableServiceModelFeature(ctx context.Context, token, serviceID, serviceMo
 
 	r, err = req.Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return err
 	}
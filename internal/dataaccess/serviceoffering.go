@@ -23,7 +23,7 @@ func ListServiceOfferings(ctx context.Context, token string) (inventory *openapi
 
 	inventory, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return inventory, nil
@@ -45,7 +45,7 @@ func DescribeServiceOfferingResource(ctx context.Context, token, serviceID, reso
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return res, nil
@@ -71,7 +71,7 @@ func DescribeServiceOffering(ctx context.Context, token, serviceID, productTierI
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return res, nil
@@ -92,7 +92,7 @@ func ExternalDescribeServiceOffering(ctx context.Context, token, serviceID, envi
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	// Filter offerings array after API call
@@ -137,7 +137,7 @@ func DescribeServiceOfferingResourceV1(ctx context.Context, token, serviceID, re
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	return res, nil
@@ -163,7 +163,7 @@ func ListInputParameters(ctx context.Context, token, serviceID, resourceID, prod
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleV1Error(err)
+		return nil, handleV1Error(err, r)
 	}
 
 	return res, nil
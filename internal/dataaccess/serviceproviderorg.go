@@ -22,7 +22,7 @@ func GetServiceProviderOrganization(ctx context.Context, token string) (res *ope
 
 	res, r, err = apiClient.SpOrganizationApiAPI.SpOrganizationApiDescribeServiceProviderOrganization(ctxWithToken).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return
 	}
@@ -92,7 +92,7 @@ func UpdateServiceProviderOrganization(ctx context.Context, token string, deploy
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleV1Error(err)
+		return handleV1Error(err, r)
 	}
 	return
 }
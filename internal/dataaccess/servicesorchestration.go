@@ -36,7 +36,7 @@ func CreateServicesOrchestration(
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return
@@ -60,7 +60,7 @@ func DeleteServicesOrchestration(ctx context.Context, token, id string) (err err
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -83,7 +83,7 @@ func DescribeServicesOrchestration(ctx context.Context, token string, id string)
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -106,7 +106,7 @@ func ListServicesOrchestration(ctx context.Context, token string, environmentTyp
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -140,7 +140,7 @@ func ModifyServicesOrchestration(
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 
 	return
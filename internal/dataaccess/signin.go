@@ -30,7 +30,7 @@ func LoginWithPassword(ctx context.Context, email string, pass string) (LoginRes
 		defer r.Body.Close()
 	}
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return LoginResult{}, err
 	}
@@ -62,7 +62,7 @@ func LoginWithIdentityProvider(ctx context.Context, deviceCode, identityProvider
 		defer r.Body.Close()
 	}
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return LoginResult{}, err
 	}
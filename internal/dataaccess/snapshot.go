@@ -29,7 +29,7 @@ func ListInstanceSnapshots(ctx context.Context, token, serviceID, environmentID,
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -55,7 +55,7 @@ func DescribeInstanceSnapshot(ctx context.Context, token, serviceID, environment
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -80,7 +80,7 @@ func CreateInstanceSnapshot(ctx context.Context, token, serviceID, environmentID
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -117,7 +117,7 @@ func ListAllSnapshots(ctx context.Context, token, serviceID, environmentID strin
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -143,7 +143,7 @@ func DeleteSnapshot(ctx context.Context, token, serviceID, environmentID, snapsh
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -187,7 +187,7 @@ func RestoreSnapshot(ctx context.Context, token, serviceID, environmentID, snaps
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
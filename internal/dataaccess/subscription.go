@@ -29,7 +29,7 @@ func DescribeSubscription(ctx context.Context, token string, serviceID, environm
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -84,7 +84,7 @@ func GetSubscriptionByCustomerEmailInEnvironment(
 
 	listSubscriptionResult, r, err := req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	if r != nil {
 		_ = r.Body.Close()
@@ -171,7 +171,7 @@ func ListSubscriptionsWithOptions(ctx context.Context, token string, serviceID,
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -240,7 +240,7 @@ func ListUsers(ctx context.Context, token string, serviceID, environmentID strin
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -287,7 +287,7 @@ func ListSubscriptionRequests(ctx context.Context, token string, serviceID, envi
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -312,7 +312,7 @@ func DescribeSubscriptionRequest(ctx context.Context, token string, serviceID, e
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -337,7 +337,7 @@ func ApproveSubscriptionRequest(ctx context.Context, token string, serviceID, en
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -362,7 +362,7 @@ func DenySubscriptionRequest(ctx context.Context, token string, serviceID, envir
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -392,7 +392,7 @@ func CreateSubscriptionOnBehalf(ctx context.Context, token string, serviceID, en
 			if r != nil {
 				_ = r.Body.Close()
 			}
-			return nil, handleFleetError(errors.Wrap(err, "failed to list users"))
+			return nil, handleFleetError(errors.Wrap(err, "failed to list users"), r)
 		}
 		if r != nil {
 			_ = r.Body.Close()
@@ -452,7 +452,7 @@ func CreateSubscriptionOnBehalf(ctx context.Context, token string, serviceID, en
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -477,7 +477,7 @@ func SuspendSubscription(ctx context.Context, token string, serviceID, environme
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -502,7 +502,7 @@ func ResumeSubscription(ctx context.Context, token string, serviceID, environmen
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -527,7 +527,7 @@ func TerminateSubscription(ctx context.Context, token string, serviceID, environ
 
 	r, err = req.Execute()
 	if err != nil {
-		return handleFleetError(err)
+		return handleFleetError(err, r)
 	}
 	return
 }
@@ -40,7 +40,7 @@ func CreateUpgradePath(ctx context.Context, token, serviceID, productTierID, sou
 		}
 	}()
 	if err != nil {
-		return "", handleFleetError(err)
+		return "", handleFleetError(err, r)
 	}
 
 	return resp.UpgradePathId, nil
@@ -71,7 +71,7 @@ func ManageLifecycleWithPayload(ctx context.Context, token, serviceID, productTi
 		}
 	}()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return resp, nil
@@ -97,7 +97,7 @@ func ChangeUpgradePathTargetVersion(ctx context.Context, token, serviceID, produ
 		}
 	}()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return resp, nil
@@ -121,7 +121,7 @@ func DescribeUpgradePath(ctx context.Context, token, serviceID, productTierID, u
 		}
 	}()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return resp, nil
@@ -145,7 +145,7 @@ func ListEligibleInstancesPerUpgrade(ctx context.Context, token, serviceID, prod
 		}
 	}()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 
 	return resp.GetInstances(), nil
@@ -200,7 +200,7 @@ func ListUpgradePaths(ctx context.Context, token, serviceID, productTierID strin
 
 	resp, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -12,7 +12,7 @@ func DescribeUser(ctx context.Context, token string) (*openapiclient.DescribeUse
 	apiClient := getV1Client()
 	resp, r, err := apiClient.UsersApiAPI.UsersApiDescribeUser(ctxWithToken).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,36 @@
+package dataaccess
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+)
+
+// verboseTransport reports each API call, its outcome and its duration through utils.Verbosef.
+// Timing covers the whole call, including any retries performed by the wrapped transport.
+type verboseTransport struct {
+	next http.RoundTripper
+}
+
+func (t *verboseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !utils.IsVerbose() {
+		return t.next.RoundTrip(req)
+	}
+
+	start := time.Now()
+	utils.Verbosef("--> %s %s", req.Method, req.URL.Redacted())
+	res, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		utils.Verbosef("<-- %s %s failed after %s: %v", req.Method, req.URL.Redacted(), elapsed, err)
+		return res, err
+	}
+
+	if requestID := responseRequestID(res); requestID != "" {
+		utils.Verbosef("<-- %s %s %s in %s (request id: %s)", req.Method, req.URL.Redacted(), res.Status, elapsed, requestID)
+	} else {
+		utils.Verbosef("<-- %s %s %s in %s", req.Method, req.URL.Redacted(), res.Status, elapsed)
+	}
+	return res, nil
+}
@@ -0,0 +1,30 @@
+package dataaccess
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerboseTransportPassesThrough(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	utils.SetVerbose(true)
+	t.Cleanup(func() { utils.SetVerbose(false) })
+
+	client := &http.Client{Transport: &verboseTransport{next: http.DefaultTransport}}
+	res, err := client.Get(server.URL)
+	require.NoError(err)
+	defer res.Body.Close()
+	require.Equal(http.StatusAccepted, res.StatusCode)
+	require.Equal("req-123", res.Header.Get("X-Request-Id"))
+}
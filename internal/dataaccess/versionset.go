@@ -17,7 +17,7 @@ func ListVersions(ctx context.Context, token, serviceID, productTierID string) (
 		productTierID,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +37,7 @@ func FindLatestVersion(ctx context.Context, token, serviceID, productTierID stri
 		productTierID,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return "", err
 	}
@@ -61,7 +61,7 @@ func FindPreferredVersion(ctx context.Context, token, serviceID, productTierID s
 		productTierID,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return "", err
 	}
@@ -91,7 +91,7 @@ func DescribeLatestVersion(ctx context.Context, token, serviceID, productTierID
 		productTierID,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +116,7 @@ func DescribeVersionSet(ctx context.Context, token, serviceID, productTierID, ve
 		version,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +136,7 @@ func SetDefaultServicePlan(ctx context.Context, token, serviceID, productTierID,
 		version,
 	).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +158,30 @@ func UpdateVersionSetName(ctx context.Context, token, serviceID, productTierID,
 		version,
 	).UpdateTierVersionSetRequest2(*updateRequest).Execute()
 
-	err = handleV1Error(err)
+	err = handleV1Error(err, r)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Body.Close()
+	return res, nil
+}
+
+func UpdateVersionSetReleaseNotes(ctx context.Context, token, serviceID, productTierID, version, releaseNotes string) (*openapiclient.TierVersionSetMetadata, error) {
+	ctxWithToken := context.WithValue(ctx, openapiclient.ContextAccessToken, token)
+
+	apiClient := getV1Client()
+	updateRequest := openapiclient.NewUpdateTierVersionSetMetadataRequest2()
+	updateRequest.SetReleaseNotes(releaseNotes)
+
+	res, r, err := apiClient.TierVersionSetApiAPI.TierVersionSetApiUpdateTierVersionSetMetadata(
+		ctxWithToken,
+		serviceID,
+		productTierID,
+		version,
+	).UpdateTierVersionSetMetadataRequest2(*updateRequest).Execute()
+
+	err = handleV1Error(err, r)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func ListWorkflows(ctx context.Context, token string, serviceID, environmentID s
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -79,7 +79,7 @@ func GetWorkflowEvents(ctx context.Context, token string, serviceID, environment
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -104,7 +104,7 @@ func DescribeWorkflow(ctx context.Context, token string, serviceID, environmentI
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -128,7 +128,7 @@ func DescribeWorkflowSummary(ctx context.Context, token string, serviceID, envir
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -153,7 +153,7 @@ func TerminateWorkflow(ctx context.Context, token string, serviceID, environment
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -182,7 +182,7 @@ func ResumeWorkflow(ctx context.Context, token string, serviceID, environmentID,
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -211,7 +211,7 @@ func RetryWorkflow(ctx context.Context, token string, serviceID, environmentID,
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -498,7 +498,7 @@ func ListDeploymentCellWorkflows(ctx context.Context, token string, hostClusterI
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -523,7 +523,7 @@ func DescribeDeploymentCellWorkflow(ctx context.Context, token string, hostClust
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -548,7 +548,7 @@ func TerminateDeploymentCellWorkflow(ctx context.Context, token string, hostClus
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -577,7 +577,7 @@ func ResumeDeploymentCellWorkflow(ctx context.Context, token string, hostCluster
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -606,7 +606,7 @@ func RetryDeploymentCellWorkflow(ctx context.Context, token string, hostClusterI
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
@@ -631,7 +631,7 @@ func GetDeploymentCellWorkflowEvents(ctx context.Context, token string, hostClus
 
 	res, r, err = req.Execute()
 	if err != nil {
-		return nil, handleFleetError(err)
+		return nil, handleFleetError(err, r)
 	}
 	return
 }
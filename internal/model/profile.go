@@ -0,0 +1,12 @@
+package model
+
+type Profile struct {
+	Name   string `json:"name"`
+	Active string `json:"active"`
+}
+
+type Alias struct {
+	Namespace string `json:"namespace"`
+	Alias     string `json:"alias"`
+	ID        string `json:"id"`
+}
@@ -1,13 +1,14 @@
 package model
 
 type ServicePlan struct {
-	PlanID         string `json:"plan_id,omitempty"`
-	PlanName       string `json:"plan_name,omitempty"`
-	ServiceID      string `json:"service_id,omitempty"`
-	ServiceName    string `json:"service_name,omitempty"`
-	Environment    string `json:"environment,omitempty"`
-	DeploymentType string `json:"deployment_type,omitempty"`
-	TenancyType    string `json:"tenancy_type,omitempty"`
+	PlanID          string `json:"plan_id,omitempty"`
+	PlanName        string `json:"plan_name,omitempty"`
+	ServiceID       string `json:"service_id,omitempty"`
+	ServiceName     string `json:"service_name,omitempty"`
+	Environment     string `json:"environment,omitempty"`
+	EnvironmentType string `json:"environment_type,omitempty"`
+	DeploymentType  string `json:"deployment_type,omitempty"`
+	TenancyType     string `json:"tenancy_type,omitempty"`
 }
 
 type ServicePlanDetails struct {
@@ -39,10 +40,28 @@ type ServicePlanVersion struct {
 	Environment                    string `json:"environment,omitempty"`
 	Version                        string `json:"version,omitempty"`
 	ReleaseDescription             string `json:"release_description,omitempty"`
+	Description                    string `json:"description,omitempty"`
 	VersionSetStatus               string `json:"version_set_status,omitempty"`
+	Preferred                      bool   `json:"preferred"`
+	ReleasedAt                     string `json:"released_at,omitempty"`
 	IsNewServicePlanVersionCreated bool   `json:"is_new_service_plan_version_created,omitempty"`
 }
 
+// ServiceBuildResult is the JSON output of build: the built service plan version, with the environment ID, whether
+// the service was created by the build, and the resources of the plan that the spec does not define
+type ServiceBuildResult struct {
+	ServicePlanVersion
+	EnvironmentID      string              `json:"environment_id,omitempty"`
+	IsNewService       bool                `json:"is_new_service"`
+	UndefinedResources []UndefinedResource `json:"undefined_resources"`
+}
+
+// UndefinedResource is a resource of a service plan that the built spec does not define
+type UndefinedResource struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
 type ServicePlanVersionDetails struct {
 	PlanID             string     `json:"plan_id,omitempty"`
 	PlanName           string     `json:"plan_name,omitempty"`
@@ -77,3 +96,26 @@ type Resource struct {
 	L7LoadBalancerConfiguration any    `json:"l7_load_balancer_configuration,omitempty"`
 	OperatorCRDConfiguration    any    `json:"operator_crd_configuration,omitempty"`
 }
+
+// ServicePlanVersionChange is a single difference between two versions of a service plan.
+type ServicePlanVersionChange struct {
+	Resource string `json:"resource"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name,omitempty"`
+	Change   string `json:"change"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+}
+
+type ServicePlanPromotion struct {
+	ServiceID             string `json:"service_id"`
+	ServiceName           string `json:"service_name"`
+	PlanID                string `json:"plan_id"`
+	PlanName              string `json:"plan_name"`
+	Version               string `json:"version"`
+	SourceEnvironmentID   string `json:"source_environment_id"`
+	SourceEnvironmentName string `json:"source_environment_name"`
+	TargetEnvironmentID   string `json:"target_environment_id"`
+	TargetEnvironmentName string `json:"target_environment_name"`
+	PromoteStatus         string `json:"promote_status"`
+}
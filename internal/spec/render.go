@@ -0,0 +1,164 @@
+// Package spec renders Omnistrate spec files before they are built or deployed.
+//
+// A spec can embed the content of another file with `{{ $file:path }}`, which is the canonical
+// syntax. The older `${{ file:path }}` form is still accepted but deprecated.
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// IgnoreKeyForFileEmbedding is a key prefix that is dropped when a file is embedded in its place,
+// so that a file reference can stand on its own line without a YAML key.
+const IgnoreKeyForFileEmbedding = "x-embed-$"
+
+var (
+	// fileReferenceRegex matches `{{ $file:path }}` and `${{ file:path }}` together with the
+	// indentation and key that precede it on the same line.
+	fileReferenceRegex = regexp.MustCompile(`(?m)^(?P<indent>[ \t]*)(?P<key>[\S\t ]*?)(?:{{[ \t]*\$file:(?P<filepath>[^\s}]+)[ \t]*}}|\${{[ \t]*file:(?P<legacyfilepath>[^\s}]+)[ \t]*}})`)
+
+	// unquotedCpusRegex matches numeric cpus values that are not quoted
+	unquotedCpusRegex = regexp.MustCompile(`(?m)(^\s*cpus:\s*)([0-9.]+)\s*$`)
+
+	// warningOutput receives deprecation warnings; stderr keeps them out of JSON output
+	warningOutput io.Writer = os.Stderr
+	// legacySyntaxWarning makes sure the deprecation warning is printed once per run
+	legacySyntaxWarning sync.Once
+)
+
+// RenderSpec renders a spec the same way for every command: file references are embedded
+// recursively, resolved relative to baseDir. Variable interpolation is opt-in: when rootDir is set
+// and the spec uses env_file, the variables are interpolated with `docker compose config` run on a
+// copy of the spec in rootDir, so relative env_file paths resolve against rootDir. build passes the
+// working directory; deploy passes an empty rootDir and leaves variables as they are.
+func RenderSpec(data []byte, baseDir, rootDir string) ([]byte, error) {
+	rendered, err := RenderFileReferences(data, baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if rootDir != "" && strings.Contains(string(rendered), "env_file:") {
+		return InterpolateEnvVariables(rendered, rootDir)
+	}
+	return rendered, nil
+}
+
+// RenderFileReferences replaces every file reference in data with the content of the referenced
+// file, indented to match the reference. Referenced files are rendered recursively relative to
+// their own directory.
+func RenderFileReferences(data []byte, baseDir string) ([]byte, error) {
+	indentIndex := fileReferenceRegex.SubexpIndex("indent")
+	keyIndex := fileReferenceRegex.SubexpIndex("key")
+	filePathIndex := fileReferenceRegex.SubexpIndex("filepath")
+	legacyFilePathIndex := fileReferenceRegex.SubexpIndex("legacyfilepath")
+
+	var renderErr error
+	rendered := fileReferenceRegex.ReplaceAllStringFunc(string(data), func(match string) string {
+		if renderErr != nil {
+			return match
+		}
+
+		submatches := fileReferenceRegex.FindStringSubmatch(match)
+		indent := submatches[indentIndex]
+		key := submatches[keyIndex]
+		if strings.HasPrefix(key, IgnoreKeyForFileEmbedding) {
+			key = ""
+		}
+
+		filePath := submatches[filePathIndex]
+		if filePath == "" {
+			filePath = submatches[legacyFilePathIndex]
+			if filePath != "" {
+				legacySyntaxWarning.Do(func() {
+					fmt.Fprintf(warningOutput, "Warning: the '${{ file:%s }}' syntax is deprecated, use '{{ $file:%s }}' instead\n", filePath, filePath)
+				})
+			}
+		}
+		if filePath == "" {
+			renderErr = fmt.Errorf("empty file path in reference: %s", match)
+			return match
+		}
+
+		fullPath := filepath.Clean(filePath)
+		if !filepath.IsAbs(fullPath) {
+			fullPath = filepath.Join(baseDir, fullPath)
+		}
+
+		fileContent, err := os.ReadFile(fullPath)
+		if err != nil {
+			renderErr = fmt.Errorf("failed to read file %s: %w", fullPath, err)
+			return match
+		}
+
+		// Render nested file references relative to the referenced file
+		nested, err := RenderFileReferences(fileContent, filepath.Dir(fullPath))
+		if err != nil {
+			renderErr = fmt.Errorf("failed to process templates in %s: %w", fullPath, err)
+			return match
+		}
+
+		lines := strings.Split(string(nested), "\n")
+		for i, line := range lines {
+			if i == 0 {
+				lines[i] = indent + key + line
+			} else if strings.TrimSpace(line) != "" {
+				lines[i] = indent + line
+			}
+		}
+		return strings.Join(lines, "\n")
+	})
+	if renderErr != nil {
+		return nil, renderErr
+	}
+
+	return []byte(rendered), nil
+}
+
+// InterpolateEnvVariables resolves env_file entries and ${VAR} interpolations by running
+// `docker compose config` on a copy of the spec in rootDir. Literal `$` characters are preserved.
+func InterpolateEnvVariables(data []byte, rootDir string) ([]byte, error) {
+	// Escape `$` to avoid interpolation, except for `${...}` which specifies a variable interpolation
+	content := strings.ReplaceAll(string(data), "$", "$$")
+	content = strings.ReplaceAll(content, "$${", "${")
+	content = strings.ReplaceAll(content, "${{ secrets.GitHubPAT }}", "$${{ secrets.GitHubPAT }}")
+
+	// Write the spec to rootDir so relative env_file paths resolve against it
+	tempFile, err := os.CreateTemp(rootDir, ".omnistrate-spec-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err = tempFile.WriteString(content); err != nil {
+		tempFile.Close()
+		return nil, err
+	}
+	if err = tempFile.Close(); err != nil {
+		return nil, err
+	}
+
+	renderCmd := exec.Command("docker", "compose", "-f", tempFile.Name(), "config") //nolint:gosec // temp file created above
+	cmdOut := &bytes.Buffer{}
+	cmdErr := &bytes.Buffer{}
+	renderCmd.Stdout = cmdOut
+	renderCmd.Stderr = cmdErr
+	if err = renderCmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to interpolate variables with docker compose: %w: %s", err, strings.TrimSpace(cmdErr.String()))
+	}
+
+	// docker compose config escapes `$` as `$$`, so unescape it
+	rendered := strings.ReplaceAll(cmdOut.String(), "$$", "$")
+
+	// Quote numeric cpus values in deploy.resources
+	rendered = unquotedCpusRegex.ReplaceAllString(rendered, `$1"$2"`)
+
+	return []byte(rendered), nil
+}
@@ -0,0 +1,113 @@
+package spec
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeSpecFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestRenderFileReferencesIndentsEmbeddedFile(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, filepath.Join(dir, "include.yaml"), "key1: value1\nnested:\n  key2: value2")
+
+	rendered, err := RenderFileReferences([]byte("root:\n  config: {{ $file:include.yaml }}\n"), dir)
+	require.NoError(t, err)
+	require.Equal(t, "root:\n  config: key1: value1\n  nested:\n    key2: value2\n", string(rendered))
+}
+
+func TestRenderFileReferencesNestedRelativeToReferencedFile(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, filepath.Join(dir, "conf", "outer.yaml"), "outer:\n  {{ $file:inner.yaml }}")
+	writeSpecFile(t, filepath.Join(dir, "conf", "inner.yaml"), "inner: true")
+
+	rendered, err := RenderFileReferences([]byte("{{ $file:conf/outer.yaml }}"), dir)
+	require.NoError(t, err)
+	require.Equal(t, "outer:\n  inner: true", string(rendered))
+}
+
+func TestRenderFileReferencesDropsEmbedKey(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, filepath.Join(dir, "services.yaml"), "web:\n  image: nginx")
+
+	rendered, err := RenderFileReferences([]byte("services:\n  x-embed-$: {{ $file:services.yaml }}"), dir)
+	require.NoError(t, err)
+	require.Equal(t, "services:\n  web:\n    image: nginx", string(rendered))
+}
+
+func TestRenderFileReferencesMissingFile(t *testing.T) {
+	_, err := RenderFileReferences([]byte("config: {{ $file:missing.yaml }}"), t.TempDir())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read file")
+}
+
+func TestRenderSpecWithoutRootDirSkipsInterpolation(t *testing.T) {
+	spec := "env_file: .env\ncommand: echo ${HOME}"
+
+	rendered, err := RenderSpec([]byte(spec), t.TempDir(), "")
+	require.NoError(t, err)
+	require.Equal(t, spec, string(rendered))
+}
+
+func TestRenderSpecWithoutEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, filepath.Join(dir, "script.sh"), "echo $HOME")
+
+	rendered, err := RenderSpec([]byte("command: {{ $file:script.sh }}"), dir, dir)
+	require.NoError(t, err)
+	require.Equal(t, "command: echo $HOME", string(rendered))
+}
+
+func TestRenderFileReferencesSyntaxForms(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, filepath.Join(dir, "value.txt"), "hello")
+
+	tests := []struct {
+		name          string
+		input         string
+		expected      string
+		expectWarning bool
+	}{
+		{name: "canonical", input: "a: {{ $file:value.txt }}", expected: "a: hello"},
+		{name: "canonical without spaces", input: "a: {{$file:value.txt}}", expected: "a: hello"},
+		{name: "deprecated", input: "a: ${{ file:value.txt }}", expected: "a: hello", expectWarning: true},
+		{name: "deprecated without spaces", input: "a: ${{file:value.txt}}", expected: "a: hello", expectWarning: true},
+		{
+			name:          "mixed in one file",
+			input:         "a: {{ $file:value.txt }}\nb: ${{ file:value.txt }}\nc: {{ $file:value.txt }}\nd: ${{ file:value.txt }}",
+			expected:      "a: hello\nb: hello\nc: hello\nd: hello",
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings bytes.Buffer
+			previous := warningOutput
+			warningOutput = &warnings
+			legacySyntaxWarning = sync.Once{}
+			t.Cleanup(func() { warningOutput = previous })
+
+			rendered, err := RenderFileReferences([]byte(tt.input), dir)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(rendered))
+
+			if tt.expectWarning {
+				require.Equal(t, 1, strings.Count(warnings.String(), "deprecated"), "the warning is printed once")
+				require.Contains(t, warnings.String(), "'${{ file:value.txt }}' syntax is deprecated, use '{{ $file:value.txt }}'")
+			} else {
+				require.Empty(t, warnings.String())
+			}
+		})
+	}
+}
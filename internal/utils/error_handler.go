@@ -7,6 +7,9 @@ import (
 
 // EnsureCursorRestoration forces cursor restoration - should be called in cleanup
 func EnsureCursorRestoration() {
+	if IsQuiet() {
+		return
+	}
 	fmt.Print("\033[?25h") // Show cursor
 	os.Stdout.Sync()
 }
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+)
+
+// Exit codes returned by omnistrate-ctl so that scripts and CI can branch on the category of a failure.
+// Exit code 2 is reserved for crashes (see HandlePanic).
+const (
+	ExitCodeGeneral    = 1
+	ExitCodeAuth       = 3
+	ExitCodeValidation = 4
+	ExitCodeBackend    = 5
+	ExitCodeTimeout    = 6
+	ExitCodeNotFound   = 7
+)
+
+// ExitCodeError attaches an exit code to an error.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// WithExitCode attaches code to err. It returns nil when err is nil.
+func WithExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitCodeError{Code: code, Err: err}
+}
+
+// ExitCodeFor returns the exit code for err: the code attached with WithExitCode if any, otherwise one derived
+// from well-known errors, otherwise ExitCodeGeneral.
+func ExitCodeFor(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *ExitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	if errors.Is(err, config.ErrAuthConfigNotFound) || errors.Is(err, config.ErrConfigFileNotFound) || errors.Is(err, config.ErrRefreshTokenNotFound) {
+		return ExitCodeAuth
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ExitCodeTimeout
+	}
+	var timeoutErr interface{ Timeout() bool }
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout() {
+		return ExitCodeTimeout
+	}
+
+	return ExitCodeGeneral
+}
+
+// ExitCodeForHTTPStatus returns the exit code for a failed API response with the given status code.
+func ExitCodeForHTTPStatus(statusCode int) int {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ExitCodeAuth
+	case statusCode == http.StatusNotFound:
+		return ExitCodeNotFound
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusGatewayTimeout:
+		return ExitCodeTimeout
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity:
+		return ExitCodeValidation
+	case statusCode >= 500:
+		return ExitCodeBackend
+	}
+	return ExitCodeGeneral
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-ctl/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+func TestExitCodeFor(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(0, ExitCodeFor(nil))
+	require.Equal(ExitCodeGeneral, ExitCodeFor(errors.New("boom")))
+	require.Equal(ExitCodeNotFound, ExitCodeFor(fmt.Errorf("describe: %w", WithExitCode(ExitCodeNotFound, errors.New("missing")))))
+	require.Equal(ExitCodeAuth, ExitCodeFor(fmt.Errorf("login: %w", config.ErrAuthConfigNotFound)))
+	require.Equal(ExitCodeTimeout, ExitCodeFor(context.DeadlineExceeded))
+	require.Equal(ExitCodeTimeout, ExitCodeFor(fmt.Errorf("request: %w", timeoutError{})))
+
+	require.Nil(WithExitCode(ExitCodeBackend, nil))
+	require.Equal("missing", WithExitCode(ExitCodeNotFound, errors.New("missing")).Error())
+}
+
+func TestExitCodeForHTTPStatus(t *testing.T) {
+	tests := map[int]int{
+		http.StatusUnauthorized:        ExitCodeAuth,
+		http.StatusForbidden:           ExitCodeAuth,
+		http.StatusNotFound:            ExitCodeNotFound,
+		http.StatusBadRequest:          ExitCodeValidation,
+		http.StatusGatewayTimeout:      ExitCodeTimeout,
+		http.StatusInternalServerError: ExitCodeBackend,
+		http.StatusConflict:            ExitCodeGeneral,
+	}
+	for status, code := range tests {
+		require.Equal(t, code, ExitCodeForHTTPStatus(status), "status %d", status)
+	}
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Error categories reported with --json-errors, one for each exit code
+const (
+	ErrorCategoryGeneral    = "general"
+	ErrorCategoryAuth       = "auth"
+	ErrorCategoryValidation = "validation"
+	ErrorCategoryBackend    = "backend"
+	ErrorCategoryTimeout    = "timeout"
+	ErrorCategoryNotFound   = "not_found"
+)
+
+var (
+	jsonErrorsMu       sync.Mutex
+	jsonErrors         bool
+	jsonErrorPrinted   bool
+	jsonErrorsOutput   io.Writer = os.Stderr
+	errorCategoryNames           = map[int]string{
+		ExitCodeAuth:       ErrorCategoryAuth,
+		ExitCodeValidation: ErrorCategoryValidation,
+		ExitCodeBackend:    ErrorCategoryBackend,
+		ExitCodeTimeout:    ErrorCategoryTimeout,
+		ExitCodeNotFound:   ErrorCategoryNotFound,
+	}
+)
+
+// ErrorReport is the JSON object written to stderr for a failed command with --json-errors.
+type ErrorReport struct {
+	Error     string `json:"error"`
+	Category  string `json:"category"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// SetJSONErrors enables or disables reporting errors as JSON objects instead of formatted text.
+func SetJSONErrors(enabled bool) {
+	jsonErrorsMu.Lock()
+	defer jsonErrorsMu.Unlock()
+	jsonErrors = enabled
+}
+
+// IsJSONErrors reports whether the global --json-errors flag is set.
+func IsJSONErrors() bool {
+	jsonErrorsMu.Lock()
+	defer jsonErrorsMu.Unlock()
+	return jsonErrors
+}
+
+// JSONErrorPrinted reports whether an error was already written as JSON, so the failure of a command that
+// printed its own error is not reported twice.
+func JSONErrorPrinted() bool {
+	jsonErrorsMu.Lock()
+	defer jsonErrorsMu.Unlock()
+	return jsonErrorPrinted
+}
+
+// ErrorCategory returns the category of err, derived from its exit code.
+func ErrorCategory(err error) string {
+	if category, ok := errorCategoryNames[ExitCodeFor(err)]; ok {
+		return category
+	}
+	return ErrorCategoryGeneral
+}
+
+// NewErrorReport describes err for machine-readable error output. The server request ID, when err carries one, is
+// reported in its own field rather than in the message.
+func NewErrorReport(err error) ErrorReport {
+	report := ErrorReport{
+		Error:    err.Error(),
+		Category: ErrorCategory(err),
+	}
+
+	var withRequestID interface{ ServerRequestID() string }
+	if errors.As(err, &withRequestID) {
+		report.RequestID = withRequestID.ServerRequestID()
+		report.Error = strings.TrimSuffix(report.Error, "\nRequest ID: "+report.RequestID)
+	}
+	return report
+}
+
+// PrintJSONError writes err to stderr as a single-line JSON ErrorReport.
+func PrintJSONError(err error) {
+	data, marshalErr := json.Marshal(NewErrorReport(err))
+	if marshalErr != nil {
+		data = []byte(fmt.Sprintf(`{"error":%q,"category":%q}`, err.Error(), ErrorCategoryGeneral))
+	}
+
+	jsonErrorsMu.Lock()
+	defer jsonErrorsMu.Unlock()
+	fmt.Fprintln(jsonErrorsOutput, string(data))
+	jsonErrorPrinted = true
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requestIDTestError mimics dataaccess.RequestIDError, which cannot be imported here
+type requestIDTestError struct {
+	err       error
+	requestID string
+}
+
+func (e *requestIDTestError) Error() string {
+	return e.err.Error() + "\nRequest ID: " + e.requestID
+}
+
+func (e *requestIDTestError) Unwrap() error {
+	return e.err
+}
+
+func (e *requestIDTestError) ServerRequestID() string {
+	return e.requestID
+}
+
+func TestNewErrorReport(t *testing.T) {
+	require := require.New(t)
+
+	report := NewErrorReport(errors.New("something failed"))
+	require.Equal(ErrorReport{Error: "something failed", Category: ErrorCategoryGeneral}, report)
+
+	report = NewErrorReport(WithExitCode(ExitCodeNotFound, errors.New("instance not found")))
+	require.Equal(ErrorCategoryNotFound, report.Category)
+
+	// The request ID is reported in its own field, also when the error is wrapped
+	apiErr := WithExitCode(ExitCodeBackend, &requestIDTestError{err: errors.New("internal error"), requestID: "req-123"})
+	report = NewErrorReport(fmt.Errorf("failed to describe instance: %w", apiErr))
+	require.Equal(ErrorReport{
+		Error:     "failed to describe instance: internal error",
+		Category:  ErrorCategoryBackend,
+		RequestID: "req-123",
+	}, report)
+}
+
+func TestErrorCategoryCoversExitCodes(t *testing.T) {
+	require := require.New(t)
+
+	for code, category := range map[int]string{
+		ExitCodeGeneral:    ErrorCategoryGeneral,
+		ExitCodeAuth:       ErrorCategoryAuth,
+		ExitCodeValidation: ErrorCategoryValidation,
+		ExitCodeBackend:    ErrorCategoryBackend,
+		ExitCodeTimeout:    ErrorCategoryTimeout,
+		ExitCodeNotFound:   ErrorCategoryNotFound,
+	} {
+		require.Equal(category, ErrorCategory(WithExitCode(code, errors.New("failed"))), "exit code %d", code)
+	}
+}
+
+func TestPrintErrorWithJSONErrors(t *testing.T) {
+	require := require.New(t)
+
+	// Set OMNISTRATE_DRY_RUN so PrintError doesn't call os.Exit
+	t.Setenv("OMNISTRATE_DRY_RUN", "true")
+
+	var out bytes.Buffer
+	originalOutput := jsonErrorsOutput
+	jsonErrorsOutput = &out
+	SetJSONErrors(true)
+	defer func() {
+		jsonErrorsOutput = originalOutput
+		SetJSONErrors(false)
+		jsonErrorPrinted = false
+	}()
+
+	PrintError(WithExitCode(ExitCodeValidation, errors.New("invalid region")))
+
+	var report map[string]string
+	require.NoError(json.Unmarshal(out.Bytes(), &report))
+	require.Equal(map[string]string{"error": "invalid region", "category": "validation"}, report)
+	require.True(JSONErrorPrinted())
+}
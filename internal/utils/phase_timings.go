@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// PhaseTiming is the wall-clock duration of one phase of a command.
+type PhaseTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// PhaseTimings records the wall-clock duration of the consecutive phases of a long-running command, such as the
+// build, push and workflow wait of a deploy. Starting a phase ends the current one. All methods are safe to call on
+// a nil *PhaseTimings, which records nothing, so callers need not check whether timings were requested.
+type PhaseTimings struct {
+	mu      sync.Mutex
+	phases  []PhaseTiming
+	current string
+	started time.Time
+	now     func() time.Time
+}
+
+// NewPhaseTimings returns an empty phase recorder.
+func NewPhaseTimings() *PhaseTimings {
+	return &PhaseTimings{now: time.Now}
+}
+
+// Start ends the current phase, if any, and starts the named one.
+func (t *PhaseTimings) Start(name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endLocked()
+	t.current = name
+	t.started = t.now()
+}
+
+// Stop ends the current phase.
+func (t *PhaseTimings) Stop() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endLocked()
+}
+
+func (t *PhaseTimings) endLocked() {
+	if t.current == "" {
+		return
+	}
+	t.phases = append(t.phases, PhaseTiming{Name: t.current, Duration: t.now().Sub(t.started)})
+	t.current = ""
+}
+
+// Phases returns the completed phases in the order they ran.
+func (t *PhaseTimings) Phases() []PhaseTiming {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]PhaseTiming(nil), t.phases...)
+}
+
+// Print ends the current phase and writes a breakdown of the phase durations, with their share of the total, to w.
+func (t *PhaseTimings) Print(w io.Writer) {
+	if t == nil {
+		return
+	}
+	t.Stop()
+	phases := t.Phases()
+	if len(phases) == 0 {
+		return
+	}
+
+	var total time.Duration
+	width := len("Total")
+	for _, phase := range phases {
+		total += phase.Duration
+		if len(phase.Name) > width {
+			width = len(phase.Name)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Timings:")
+	for _, phase := range phases {
+		share := 0.0
+		if total > 0 {
+			share = float64(phase.Duration) / float64(total) * 100
+		}
+		fmt.Fprintf(w, "  %-*s  %10s  %5.1f%%\n", width, phase.Name, phase.Duration.Round(time.Millisecond), share)
+	}
+	fmt.Fprintf(w, "  %-*s  %10s\n", width, "Total", total.Round(time.Millisecond))
+}
+
+type phaseTimingsContextKey struct{}
+
+// WithPhaseTimings returns a copy of ctx carrying timings, so that helpers shared between commands, such as the
+// repo build of deploy, can record their phases.
+func WithPhaseTimings(ctx context.Context, timings *PhaseTimings) context.Context {
+	if timings == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, phaseTimingsContextKey{}, timings)
+}
+
+// PhaseTimingsFromContext returns the timings carried by ctx, or nil when timings were not requested.
+func PhaseTimingsFromContext(ctx context.Context) *PhaseTimings {
+	if ctx == nil {
+		return nil
+	}
+	timings, _ := ctx.Value(phaseTimingsContextKey{}).(*PhaseTimings)
+	return timings
+}
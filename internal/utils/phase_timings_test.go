@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseTimings(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timings := NewPhaseTimings()
+	timings.now = func() time.Time { return now }
+
+	timings.Start("Docker build and push")
+	now = now.Add(3 * time.Second)
+	timings.Start("Service build")
+	now = now.Add(time.Second)
+	timings.Stop()
+	now = now.Add(time.Hour) // time between phases is not recorded
+	timings.Stop()
+
+	require.Equal(t, []PhaseTiming{
+		{Name: "Docker build and push", Duration: 3 * time.Second},
+		{Name: "Service build", Duration: time.Second},
+	}, timings.Phases())
+
+	var out bytes.Buffer
+	timings.Print(&out)
+	require.Equal(t, "\nTimings:\n"+
+		"  Docker build and push          3s   75.0%\n"+
+		"  Service build                  1s   25.0%\n"+
+		"  Total                          4s\n", out.String())
+}
+
+func TestPhaseTimingsNilAndContext(t *testing.T) {
+	var timings *PhaseTimings
+	timings.Start("ignored")
+	timings.Stop()
+	require.Nil(t, timings.Phases())
+	var out bytes.Buffer
+	timings.Print(&out)
+	require.Empty(t, out.String())
+
+	ctx := context.Background()
+	require.Nil(t, PhaseTimingsFromContext(ctx))
+	require.Equal(t, ctx, WithPhaseTimings(ctx, nil))
+
+	timings = NewPhaseTimings()
+	require.Same(t, timings, PhaseTimingsFromContext(WithPhaseTimings(ctx, timings)))
+}
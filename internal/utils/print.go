@@ -18,11 +18,15 @@ var (
 )
 
 func PrintError(err error) {
-	errorMsg := color.New(color.FgRed, color.Bold).SprintFunc()
-	msg := fmt.Sprintf("%s %s", errorMsg("Error: "), err.Error())
-	fmt.Fprintln(os.Stderr, msg)
+	if IsJSONErrors() {
+		PrintJSONError(err)
+	} else {
+		errorMsg := color.New(color.FgRed, color.Bold).SprintFunc()
+		msg := fmt.Sprintf("%s %s", errorMsg("Error: "), err.Error())
+		fmt.Fprintln(os.Stderr, msg)
+	}
 	if !config.IsDryRun() {
-		os.Exit(1)
+		os.Exit(ExitCodeFor(err))
 	}
 }
 
@@ -90,6 +94,32 @@ func PrintTextTableJsonArrayOutput[T any](output string, objects []T) error {
 			LastPrintedString = fmt.Sprintf("%v", dataArray)
 		}
 		return err
+	case "csv":
+		dataArray := make([]string, 0)
+		for _, obj := range objects {
+			data, err := json.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			dataArray = append(dataArray, string(data))
+		}
+		// Take the columns from the zero value when there are no objects, so the header is still printed
+		var columnsData []byte
+		if len(dataArray) > 0 {
+			columnsData = []byte(dataArray[0])
+		} else {
+			var zero T
+			data, err := json.Marshal(zero)
+			if err != nil {
+				return err
+			}
+			columnsData = data
+		}
+		err := PrintCSV(columnsData, dataArray)
+		if err == nil {
+			LastPrintedString = fmt.Sprintf("%v", dataArray)
+		}
+		return err
 	case "json":
 		data, err := json.MarshalIndent(objects, "", "    ")
 		if err != nil {
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrNoPromptInput is returned when the input of a prompt ends, e.g. when stdin is not a terminal and is exhausted.
+var ErrNoPromptInput = errors.New("no input available to answer the prompt")
+
+// LinePrompter asks line-based questions: numbered choices and free text. Each answer is a whole line, so
+// multi-word answers are read as one, and the end of the input is reported as ErrNoPromptInput instead of
+// prompting again forever.
+type LinePrompter struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+// NewLinePrompter returns a prompter that reads answers from in and writes prompts to out.
+func NewLinePrompter(in io.Reader, out io.Writer) *LinePrompter {
+	return &LinePrompter{scanner: bufio.NewScanner(in), out: out}
+}
+
+var (
+	stdinPrompter     *LinePrompter
+	stdinPrompterOnce sync.Once
+)
+
+// StdinPrompter returns the prompter reading stdin. It is shared, as a scanner buffers input that a second
+// scanner over stdin would not see.
+func StdinPrompter() *LinePrompter {
+	stdinPrompterOnce.Do(func() {
+		stdinPrompter = NewLinePrompter(os.Stdin, os.Stdout)
+	})
+	return stdinPrompter
+}
+
+// ReadLine prints prompt and returns the next line of input with surrounding whitespace removed.
+func (p *LinePrompter) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(p.out, prompt)
+	if !p.scanner.Scan() {
+		// Finish the prompt line, as no newline was echoed
+		fmt.Fprintln(p.out)
+		if err := p.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", ErrNoPromptInput
+	}
+	return strings.TrimSpace(p.scanner.Text()), nil
+}
+
+// PromptText asks for free text until validate accepts it, printing the validation error after each rejected
+// answer. A nil validate accepts any answer, including an empty one.
+func (p *LinePrompter) PromptText(prompt string, validate func(string) error) (string, error) {
+	for {
+		value, err := p.ReadLine(prompt)
+		if err != nil {
+			return "", err
+		}
+		if validate == nil {
+			return value, nil
+		}
+		if err = validate(value); err != nil {
+			fmt.Fprintf(p.out, "Invalid input: %v\n", err)
+			continue
+		}
+		return value, nil
+	}
+}
+
+// PromptChoice asks for a number between lowest and highest, inclusive, until one is given.
+func (p *LinePrompter) PromptChoice(prompt string, lowest, highest int) (int, error) {
+	var choice int
+	_, err := p.PromptText(prompt, func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < lowest || n > highest {
+			return fmt.Errorf("expected a number from %d to %d", lowest, highest)
+		}
+		choice = n
+		return nil
+	})
+	return choice, err
+}
+
+// NonEmpty is a PromptText validation that rejects empty answers.
+func NonEmpty(value string) error {
+	if value == "" {
+		return errors.New("value cannot be empty")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinePrompterPromptText(t *testing.T) {
+	require := require.New(t)
+
+	var out bytes.Buffer
+	prompter := NewLinePrompter(strings.NewReader("\n  my service name  \n"), &out)
+
+	value, err := prompter.PromptText("Name: ", NonEmpty)
+	require.NoError(err)
+	require.Equal("my service name", value)
+	require.Equal("Name: Invalid input: value cannot be empty\nName: ", out.String())
+
+	_, err = prompter.PromptText("Name: ", nil)
+	require.ErrorIs(err, ErrNoPromptInput)
+}
+
+func TestLinePrompterPromptChoice(t *testing.T) {
+	require := require.New(t)
+
+	var out bytes.Buffer
+	prompter := NewLinePrompter(strings.NewReader("two\n9\n0\n"), &out)
+
+	choice, err := prompter.PromptChoice("Select (0-3): ", 0, 3)
+	require.NoError(err)
+	require.Equal(0, choice)
+	require.Equal(2, strings.Count(out.String(), "Invalid input: expected a number from 0 to 3"))
+
+	_, err = prompter.PromptChoice("Select (0-3): ", 0, 3)
+	require.ErrorIs(err, ErrNoPromptInput)
+}
+
+func TestLinePrompterOptionalText(t *testing.T) {
+	prompter := NewLinePrompter(strings.NewReader("\r\n"), &bytes.Buffer{})
+
+	value, err := prompter.PromptText("Role ARN (optional): ", nil)
+	require.NoError(t, err)
+	require.Empty(t, value)
+}
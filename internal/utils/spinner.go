@@ -99,7 +99,8 @@ func (s *Spinner) Error() {
 // NewSpinnerManager creates a new SpinnerManager backed by a bubbletea program.
 func NewSpinnerManager() SpinnerManager {
 	return &spinnerMgr{
-		done: make(chan struct{}),
+		done:  make(chan struct{}),
+		quiet: IsQuiet(),
 	}
 }
 
@@ -110,6 +111,7 @@ type spinnerMgr struct {
 	startOffset  int
 	width        int
 	useAltScreen bool
+	quiet        bool // Nothing is rendered, see SetQuiet
 	mu           sync.RWMutex
 }
 
@@ -129,7 +131,7 @@ func (sm *spinnerMgr) AddSpinner(msg string) *Spinner {
 
 // Start begins rendering the spinners. Entries added before and after Start() are rendered.
 func (sm *spinnerMgr) Start() {
-	if sm.program != nil {
+	if sm.program != nil || sm.quiet {
 		return
 	}
 
@@ -174,3 +174,18 @@ func TestGroupedDeploymentViewPulsesRegionMarkerOnTick(t *testing.T) {
 	require.Contains(t, view, "●")
 	require.NotContains(t, view, "◉")
 }
+
+func TestQuietSpinnerManagerRendersNothing(t *testing.T) {
+	SetQuiet(true)
+	t.Cleanup(func() { SetQuiet(false) })
+
+	sm := NewSpinnerManager()
+	sm.Start()
+	require.False(t, sm.Running())
+
+	spinner := sm.AddSpinner("Step 1/2: Building service")
+	spinner.UpdateMessage("Step 1/2: Building service: Done")
+	spinner.Complete()
+	sm.Stop()
+	require.False(t, sm.Running())
+}
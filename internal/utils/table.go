@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -147,6 +148,37 @@ func (t Table) PrintToWriter(w io.Writer) {
 	fmt.Fprintln(w, baseStyle.Render(bt.View()))
 }
 
+// PrintCSVToWriter writes the table as CSV: a header row with the columns, then one row per item. Fields that
+// contain commas, quotes or newlines are quoted.
+func (t Table) PrintCSVToWriter(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(t.columns); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// PrintCSV prints JSON objects as CSV with the same columns as PrintTable. The columns are taken from
+// columnsData, so the header row is printed even when there are no objects.
+func PrintCSV(columnsData json.RawMessage, jsonData []string) error {
+	tableWriter, err := NewTableFromJSONTemplate(columnsData)
+	if err != nil {
+		return err
+	}
+	for _, data := range jsonData {
+		if err = tableWriter.AddRowFromJSON(json.RawMessage(data)); err != nil {
+			return err
+		}
+	}
+	return tableWriter.PrintCSVToWriter(os.Stdout)
+}
+
 func PrintTable(jsonData []string) (err error) {
 	if len(jsonData) == 0 {
 		return
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -74,3 +75,22 @@ func TestTableFromAJSON(t *testing.T) {
 	// John Smith  40   UK
 	// Jane Smith  35   Australia
 }
+
+func TestTablePrintCSVToWriter(t *testing.T) {
+	table, err := NewTableFromJSONTemplate([]byte(`{"name": "", "id": "", "environments": ""}`))
+	require.NoError(t, err)
+	require.NoError(t, table.AddRowFromJSON([]byte(`{"id": "s-1", "name": "postgres", "environments": "Dev,Prod"}`)))
+	require.NoError(t, table.AddRowFromJSON([]byte(`{"id": "s-2", "name": "say \"hi\"", "environments": ""}`)))
+
+	var buf bytes.Buffer
+	require.NoError(t, table.PrintCSVToWriter(&buf))
+	require.Equal(t, "name,environments,id\npostgres,\"Dev,Prod\",s-1\n\"say \"\"hi\"\"\",,s-2\n", buf.String())
+}
+
+func TestTablePrintCSVToWriterEmpty(t *testing.T) {
+	table := NewTable([]any{"status", "name"})
+
+	var buf bytes.Buffer
+	require.NoError(t, table.PrintCSVToWriter(&buf))
+	require.Equal(t, "name,status\n", buf.String())
+}
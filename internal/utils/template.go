@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// ParseOutputTemplate parses a Go text/template used to render each result of a list command. The template
+// is checked against the fields of T before any result is printed, so a misspelled field fails up front with
+// the list of fields that are available.
+func ParseOutputTemplate[T any](text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("--template is required when --output=template, e.g. --template '{{.Id}} {{.Status}}'")
+	}
+	tmpl, err := template.New("output").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+
+	var zero T
+	if err = tmpl.Execute(io.Discard, zero); err != nil && strings.Contains(err.Error(), "can't evaluate field") {
+		return nil, fmt.Errorf("invalid --template: %w\nAvailable fields: %s", err, strings.Join(templateFields(zero), ", "))
+	}
+
+	return tmpl, nil
+}
+
+// PrintTemplateOutput renders every object through tmpl on its own line and prints the result.
+func PrintTemplateOutput[T any](tmpl *template.Template, objects []T) error {
+	var buf bytes.Buffer
+	for _, obj := range objects {
+		if err := tmpl.Execute(&buf, obj); err != nil {
+			return fmt.Errorf("failed to execute --template: %w", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+	}
+
+	if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	LastPrintedString = buf.String()
+	return nil
+}
+
+// templateFields lists the exported fields of a struct that a template can address.
+func templateFields(obj any) []string {
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			fields = append(fields, t.Field(i).Name)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type templateRecord struct {
+	Id     string
+	Status string
+	Region *string
+	hidden string
+}
+
+func TestParseOutputTemplate(t *testing.T) {
+	require := require.New(t)
+
+	tmpl, err := ParseOutputTemplate[templateRecord]("{{.Id}} {{.Status}} {{.Region}}")
+	require.NoError(err)
+
+	var buf bytes.Buffer
+	region := "us-east-1"
+	require.NoError(tmpl.Execute(&buf, templateRecord{Id: "instance-1", Status: "RUNNING", Region: &region}))
+	require.Equal("instance-1 RUNNING us-east-1", buf.String())
+
+	_, err = ParseOutputTemplate[templateRecord]("")
+	require.ErrorContains(err, "--template is required")
+
+	_, err = ParseOutputTemplate[templateRecord]("{{.Id")
+	require.ErrorContains(err, "invalid --template")
+
+	_, err = ParseOutputTemplate[templateRecord]("{{.Id}} {{.Stauts}}")
+	require.ErrorContains(err, "Stauts")
+	require.ErrorContains(err, "Available fields: Id, Status, Region")
+	require.NotContains(err.Error(), "hidden")
+}
+
+func TestPrintTemplateOutput(t *testing.T) {
+	require := require.New(t)
+
+	tmpl, err := ParseOutputTemplate[templateRecord]("{{.Id}}\t{{.Status}}")
+	require.NoError(err)
+
+	require.NoError(PrintTemplateOutput(tmpl, []templateRecord{
+		{Id: "instance-1", Status: "RUNNING"},
+		{Id: "instance-2", Status: "STOPPED"},
+	}))
+	require.Equal("instance-1\tRUNNING\ninstance-2\tSTOPPED\n", LastPrintedString)
+}
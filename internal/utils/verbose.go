@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	verboseMu     sync.Mutex
+	verbose       bool
+	quiet         bool
+	verboseOutput io.Writer = os.Stderr
+)
+
+// SetVerbose enables or disables the diagnostic lines written by Verbosef.
+func SetVerbose(enabled bool) {
+	verboseMu.Lock()
+	defer verboseMu.Unlock()
+	verbose = enabled
+}
+
+// IsVerbose reports whether the global --verbose flag is set.
+func IsVerbose() bool {
+	verboseMu.Lock()
+	defer verboseMu.Unlock()
+	return verbose
+}
+
+// SetQuiet enables or disables quiet mode, in which spinner managers render nothing so that only final results
+// and errors are printed.
+func SetQuiet(enabled bool) {
+	verboseMu.Lock()
+	defer verboseMu.Unlock()
+	quiet = enabled
+}
+
+// IsQuiet reports whether the global --quiet flag is set.
+func IsQuiet() bool {
+	verboseMu.Lock()
+	defer verboseMu.Unlock()
+	return quiet
+}
+
+// Verbosef writes a timestamped diagnostic line to stderr when verbose output is enabled.
+// It never writes to stdout so that machine-readable output stays intact.
+func Verbosef(format string, args ...interface{}) {
+	verboseMu.Lock()
+	defer verboseMu.Unlock()
+	if !verbose {
+		return
+	}
+	msg := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+	_, _ = fmt.Fprintf(verboseOutput, "%s [verbose] %s\n", time.Now().UTC().Format(time.RFC3339Nano), msg)
+}
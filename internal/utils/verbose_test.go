@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerbosef(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	origOutput := verboseOutput
+	verboseOutput = &buf
+	t.Cleanup(func() {
+		verboseOutput = origOutput
+		SetVerbose(false)
+	})
+
+	SetVerbose(false)
+	Verbosef("GET %s", "/2022-09-01-00/service")
+	require.Empty(buf.String())
+
+	SetVerbose(true)
+	require.True(IsVerbose())
+	Verbosef("GET %s -> %d (%s)\n", "/2022-09-01-00/service", 200, 15*time.Millisecond)
+
+	line := buf.String()
+	require.True(strings.HasSuffix(line, "[verbose] GET /2022-09-01-00/service -> 200 (15ms)\n"), line)
+	timestamp := strings.SplitN(line, " ", 2)[0]
+	_, err := time.Parse(time.RFC3339Nano, timestamp)
+	require.NoError(err)
+}